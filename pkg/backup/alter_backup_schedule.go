@@ -327,10 +327,24 @@ func processScheduleOptions(
 				continue
 			}
 			s.incArgs.UpdatesLastBackupMetric = updatesLastBackupMetric
+		case optCompactionThreshold, optCompactionWindow, optRetention:
+			// The compaction policy is validated as a whole below.
 		default:
 			return errors.Newf("unexpected schedule option: %s = %s", k, v)
 		}
 	}
+
+	evalCtx := &p.ExtendedEvalContext().Context
+	compactionPolicy, err := makeCompactionPolicy(
+		evalCtx, scheduleOptions, s.fullArgs.CompactionPolicy,
+	)
+	if err != nil {
+		return err
+	}
+	s.fullArgs.CompactionPolicy = compactionPolicy
+	if s.incArgs != nil {
+		s.incArgs.CompactionPolicy = compactionPolicy
+	}
 	return nil
 }
 
@@ -484,6 +498,7 @@ func processFullBackupRecurrence(
 			s.fullArgs.UpdatesLastBackupMetric,
 			s.incStmt,
 			s.fullArgs.ChainProtectedTimestampRecords,
+			s.fullArgs.CompactionPolicy,
 		)
 
 		if err != nil {
@@ -727,6 +742,9 @@ var alterBackupScheduleOptions = exprutil.KVOptionValidationMap{
 	optOnExecFailure:           exprutil.KVStringOptAny,
 	optOnPreviousRunning:       exprutil.KVStringOptAny,
 	optUpdatesLastBackupMetric: exprutil.KVStringOptAny,
+	optCompactionThreshold:     exprutil.KVStringOptRequireValue,
+	optCompactionWindow:        exprutil.KVStringOptRequireValue,
+	optRetention:               exprutil.KVStringOptRequireValue,
 }
 
 func alterBackupScheduleTypeCheck(
@@ -0,0 +1,537 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"maps"
+	"net/url"
+	"slices"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupencryption"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+)
+
+// The backup catalog records, for each backup written or compacted by a job in
+// this cluster, or found in one of the collections it was written to, a
+// summary of the backup's manifest: where the backup is, the time range it
+// covers, its size and the tables it contains. It is exposed by the
+// crdb_internal.backup_catalog and crdb_internal.backup_catalog_tables virtual
+// tables, so that the backups of all collections can be listed, filtered and
+// joined without reading their manifests from external storage.
+//
+// The entry describing a backup is stored in system.backup_catalog, keyed by
+// the backup's redacted URI, once the job which wrote it succeeds. Entries are
+// kept independently of the jobs which wrote them, so they outlive the
+// retention of job records. crdb_internal.rescan_backup_catalog() refreshes
+// the catalog from external storage: it lists the backups of the collections
+// written to by the retained backup jobs and of the collections it is given,
+// and reads the manifest of each of them. Backups which are not yet catalogued
+// are added, and backups of a listed collection which can no longer be found
+// are marked as missing. A backup whose manifest cannot be read does not abort
+// the rescan: the error is recorded in the backup's entry and reported to the
+// client as a notice.
+
+// makeBackupCatalogEntry returns the catalog entry describing the backup
+// written by a job with the given details.
+func makeBackupCatalogEntry(
+	details jobspb.BackupDetails,
+	manifest *backuppb.BackupManifest,
+	descs []catalog.Descriptor,
+	fileCount int64,
+	scannedAt hlc.Timestamp,
+) jobspb.BackupCatalogEntry {
+	entry := jobspb.BackupCatalogEntry{
+		Subdir:          details.Destination.Subdir,
+		URI:             backuputils.RedactURIForErrorMessage(details.URI),
+		StartTime:       manifest.StartTime,
+		EndTime:         manifest.EndTime,
+		RevisionHistory: manifest.MVCCFilter == backuppb.MVCCFilter_All,
+		FullCluster:     manifest.DescriptorCoverage == tree.AllDescriptors,
+		Encrypted: details.EncryptionOptions != nil &&
+			details.EncryptionOptions.Mode != jobspb.EncryptionMode_None,
+		DataSize:  manifest.EntryCounts.DataSize,
+		Rows:      manifest.EntryCounts.Rows,
+		FileCount: fileCount,
+		ScannedAt: scannedAt,
+	}
+	if details.CollectionURI != "" {
+		entry.CollectionURI = backuputils.RedactURIForErrorMessage(details.CollectionURI)
+	}
+	switch {
+	case details.Compact:
+		entry.Type = jobspb.BackupCatalogEntry_COMPACTED
+	case manifest.StartTime.IsEmpty():
+		entry.Type = jobspb.BackupCatalogEntry_FULL
+	default:
+		entry.Type = jobspb.BackupCatalogEntry_INCREMENTAL
+	}
+
+	dbNames := make(map[descpb.ID]string)
+	schemaNames := map[descpb.ID]string{keys.PublicSchemaIDForBackup: catconstants.PublicSchemaName}
+	for _, desc := range descs {
+		switch desc := desc.(type) {
+		case catalog.DatabaseDescriptor:
+			dbNames[desc.GetID()] = desc.GetName()
+		case catalog.SchemaDescriptor:
+			schemaNames[desc.GetID()] = desc.GetName()
+		}
+	}
+	for _, desc := range descs {
+		if table, ok := desc.(catalog.TableDescriptor); ok && table.Public() {
+			entry.Tables = append(entry.Tables, jobspb.BackupCatalogEntry_Table{
+				ID:           table.GetID(),
+				DatabaseName: dbNames[table.GetParentID()],
+				SchemaName:   schemaNames[table.GetParentSchemaID()],
+				Name:         table.GetName(),
+			})
+		}
+	}
+	return entry
+}
+
+// writeBackupCatalogEntry stores the catalog entry describing a backup,
+// replacing any previous entry for the same backup. jobID is the ID of the job
+// which wrote the backup, or zero if the backup was found by listing its
+// collection.
+func writeBackupCatalogEntry(
+	ctx context.Context, txn isql.Txn, jobID jobspb.JobID, entry jobspb.BackupCatalogEntry,
+) error {
+	entryBytes, err := protoutil.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+	jobIDDatum := tree.DNull
+	if jobID != jobspb.InvalidJobID {
+		jobIDDatum = tree.NewDInt(tree.DInt(jobID))
+	}
+	_, err = txn.ExecEx(
+		ctx, "write-backup-catalog-entry", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`UPSERT INTO system.backup_catalog (uri, job_id, entry) VALUES ($1, $2, $3)`,
+		entry.URI, jobIDDatum, tree.NewDBytes(tree.DBytes(entryBytes)),
+	)
+	return err
+}
+
+// readBackupCatalog returns the entries of the backup catalog, keyed by the
+// redacted URI of the backups they describe, along with the IDs of the jobs
+// which wrote them.
+func readBackupCatalog(
+	ctx context.Context, txn isql.Txn,
+) (map[string]catalogedBackup, error) {
+	rows, err := txn.QueryBufferedEx(
+		ctx, "read-backup-catalog", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`SELECT job_id, entry FROM system.backup_catalog`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	backups := make(map[string]catalogedBackup, len(rows))
+	for _, row := range rows {
+		var b catalogedBackup
+		if row[0] != tree.DNull {
+			b.jobID = jobspb.JobID(tree.MustBeDInt(row[0]))
+		}
+		if err := protoutil.Unmarshal([]byte(tree.MustBeDBytes(row[1])), &b.entry); err != nil {
+			return nil, err
+		}
+		backups[b.entry.URI] = b
+	}
+	return backups, nil
+}
+
+// catalogedBackup is an entry of the backup catalog along with the ID of the
+// job which wrote the backup it describes, if any.
+type catalogedBackup struct {
+	jobID jobspb.JobID
+	entry jobspb.BackupCatalogEntry
+}
+
+// recordBackupInCatalog adds the backup written by the job to the backup
+// catalog. Failing to do so does not fail the job, since the entry can be
+// added later by rescanning the catalog.
+func (b *backupResumer) recordBackupInCatalog(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	details jobspb.BackupDetails,
+	manifest *backuppb.BackupManifest,
+) {
+	if !execCfg.Settings.Version.IsActive(ctx, clusterversion.V25_2_BackupCatalogTable) {
+		return
+	}
+	descs := make([]catalog.Descriptor, 0, len(manifest.Descriptors))
+	for i := range manifest.Descriptors {
+		if desc := backupinfo.NewDescriptorForManifest(&manifest.Descriptors[i]); desc != nil {
+			descs = append(descs, desc)
+		}
+	}
+	entry := makeBackupCatalogEntry(
+		details, manifest, descs, int64(len(manifest.Files)), execCfg.Clock.Now(),
+	)
+	if err := execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return writeBackupCatalogEntry(ctx, txn, b.job.ID(), entry)
+	}); err != nil {
+		log.Warningf(ctx, "failed to record backup in the backup catalog: %v", err)
+	}
+}
+
+// rescanTarget is a backup to be read by a rescan of the backup catalog.
+type rescanTarget struct {
+	// jobID is the ID of the job which wrote the backup, or zero if the backup
+	// was found by listing its collection.
+	jobID   jobspb.JobID
+	user    username.SQLUsername
+	details jobspb.BackupDetails
+}
+
+// rescanCollection is a collection whose backups are listed by a rescan of
+// the backup catalog.
+type rescanCollection struct {
+	user username.SQLUsername
+	// encryption are the encryption options of a backup job which wrote to the
+	// collection, which are used to read the backups found in it.
+	encryption *jobspb.BackupEncryptionOptions
+}
+
+// RescanBackupCatalog refreshes the backup catalog from the manifests of the
+// backups written by the retained backup jobs of the cluster and of the
+// backups found in their collections and in the given ones, and returns the
+// number of backups scanned. Backups which could not be listed or read are
+// reported as notices, and are not counted.
+//
+// Note that planner should be a sql.PlanHookState. Due to import cycles with
+// the sql and builtins package, the interface{} type is used.
+func RescanBackupCatalog(
+	ctx context.Context, planner interface{}, collectionURIs []string,
+) (int, error) {
+	p, ok := planner.(sql.PlanHookState)
+	if !ok {
+		return 0, errors.New("missing job execution context")
+	}
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !hasAdmin {
+		if err := p.CheckPrivilegeForUser(
+			ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.BACKUP, p.User(),
+		); err != nil {
+			return 0, pgerror.Wrapf(err, pgcode.InsufficientPrivilege,
+				"only users with the admin role or the BACKUP system privilege are allowed to rescan the backup catalog")
+		}
+	}
+	execCfg := p.ExecCfg()
+	if !execCfg.Settings.Version.IsActive(ctx, clusterversion.V25_2_BackupCatalogTable) {
+		return 0, pgerror.New(pgcode.FeatureNotSupported,
+			"the backup catalog is not available until the cluster version is finalized")
+	}
+
+	targets := make(map[string]rescanTarget)
+	collections := make(map[string]rescanCollection)
+	for _, uri := range collectionURIs {
+		collections[uri] = rescanCollection{user: p.User()}
+	}
+	if err := collectBackupJobTargets(ctx, execCfg, targets, collections); err != nil {
+		return 0, err
+	}
+
+	var existing map[string]catalogedBackup
+	if err := execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) (err error) {
+		existing, err = readBackupCatalog(ctx, txn)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	// Add the backups found in the collections to the targets, and mark the
+	// backups of the listed collections which are no longer there as missing.
+	listed := make(map[string]struct{})
+	for _, collectionURI := range slices.Sorted(maps.Keys(collections)) {
+		c := collections[collectionURI]
+		found, err := listCollectionBackups(ctx, execCfg, c.user, collectionURI)
+		if err != nil {
+			p.BufferClientNotice(ctx, pgnotice.Newf("failed to list the backups in %s: %v",
+				backuputils.RedactURIForErrorMessage(collectionURI), err))
+			continue
+		}
+		listed[backuputils.RedactURIForErrorMessage(collectionURI)] = struct{}{}
+		for _, details := range found {
+			key := backuputils.RedactURIForErrorMessage(details.URI)
+			if _, ok := targets[key]; ok {
+				continue
+			}
+			details.EncryptionOptions = c.encryption
+			targets[key] = rescanTarget{
+				jobID:   existing[key].jobID,
+				user:    c.user,
+				details: details,
+			}
+		}
+	}
+	for uri, b := range existing {
+		if _, ok := targets[uri]; ok {
+			continue
+		}
+		if _, ok := listed[b.entry.CollectionURI]; !ok || b.entry.Missing {
+			continue
+		}
+		b.entry.Missing = true
+		if err := execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+			return writeBackupCatalogEntry(ctx, txn, b.jobID, b.entry)
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	mem := execCfg.RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+	var scanned int
+	for _, uri := range slices.Sorted(maps.Keys(targets)) {
+		target := targets[uri]
+		kmsEnv := backupencryption.MakeBackupKMSEnv(
+			execCfg.Settings,
+			&execCfg.ExternalIODirConfig,
+			execCfg.InternalDB,
+			target.user,
+		)
+		entry, scanErr := scanBackupCatalogEntry(
+			ctx, execCfg, &mem, target.user, &kmsEnv, target.details,
+		)
+		prev, hasPrev := existing[uri]
+		switch {
+		case scanErr != nil:
+			// Keep what is known of a backup which cannot be read, and record why.
+			log.Warningf(ctx, "failed to scan backup %s for the backup catalog: %v", uri, scanErr)
+			p.BufferClientNotice(ctx, pgnotice.Newf("failed to scan backup %s: %v", uri, scanErr))
+			if hasPrev {
+				entry = prev.entry
+			} else {
+				entry = makeBackupCatalogEntry(target.details, &backuppb.BackupManifest{
+					StartTime: target.details.StartTime,
+					EndTime:   target.details.EndTime,
+				}, nil /* descs */, 0 /* fileCount */, execCfg.Clock.Now())
+			}
+			entry.ScanError = scanErr.Error()
+		case entry.Missing && hasPrev:
+			// Keep what is known of a backup which can no longer be found.
+			scannedAt := entry.ScannedAt
+			entry = prev.entry
+			entry.Missing = true
+			entry.ScanError = ""
+			entry.ScannedAt = scannedAt
+		}
+		if target.jobID == jobspb.InvalidJobID && hasPrev &&
+			prev.entry.Type == jobspb.BackupCatalogEntry_COMPACTED {
+			// Whether a backup was compacted is only known from the job which
+			// compacted it.
+			entry.Type = jobspb.BackupCatalogEntry_COMPACTED
+		}
+		if err := execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+			return writeBackupCatalogEntry(ctx, txn, target.jobID, entry)
+		}); err != nil {
+			return 0, err
+		}
+		if scanErr == nil {
+			scanned++
+		}
+	}
+	return scanned, nil
+}
+
+// collectBackupJobTargets adds the backups written by the retained succeeded
+// backup jobs to the targets of a rescan, and their collections to the
+// collections it lists.
+func collectBackupJobTargets(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	targets map[string]rescanTarget,
+	collections map[string]rescanCollection,
+) error {
+	rows, err := execCfg.InternalDB.Executor().QueryBufferedEx(
+		ctx, "backup-catalog-jobs", nil /* txn */, sessiondata.NodeUserSessionDataOverride,
+		`SELECT id FROM system.jobs WHERE job_type = $1 AND status = $2 ORDER BY id`,
+		jobspb.TypeBackup.String(), string(jobs.StateSucceeded),
+	)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		jobID := jobspb.JobID(tree.MustBeDInt(row[0]))
+		job, err := execCfg.JobRegistry.LoadJob(ctx, jobID)
+		if err != nil {
+			if jobs.HasJobNotFoundError(err) {
+				continue
+			}
+			return err
+		}
+		details, ok := job.Details().(jobspb.BackupDetails)
+		if !ok || details.URI == "" {
+			continue
+		}
+		user := job.Payload().UsernameProto.Decode()
+		targets[backuputils.RedactURIForErrorMessage(details.URI)] = rescanTarget{
+			jobID:   jobID,
+			user:    user,
+			details: details,
+		}
+		if details.CollectionURI == "" {
+			continue
+		}
+		if c, ok := collections[details.CollectionURI]; !ok || c.encryption == nil {
+			collections[details.CollectionURI] = rescanCollection{
+				user:       user,
+				encryption: details.EncryptionOptions,
+			}
+		}
+	}
+	return nil
+}
+
+// listCollectionBackups lists the full and incremental backups of every chain
+// in the given collection, returning the details a backup job writing each of
+// them would have had.
+func listCollectionBackups(
+	ctx context.Context, execCfg *sql.ExecutorConfig, user username.SQLUsername, collectionURI string,
+) ([]jobspb.BackupDetails, error) {
+	store, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, collectionURI, user)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open backup storage location")
+	}
+	defer store.Close()
+	subdirs, err := backupdest.ListFullBackupsInCollection(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []jobspb.BackupDetails
+	for _, subdir := range subdirs {
+		fullURI, _, err := backupdest.GetURIsByLocalityKV([]string{collectionURI}, subdir)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, jobspb.BackupDetails{
+			URI:           fullURI,
+			CollectionURI: collectionURI,
+			Destination:   jobspb.BackupDetails_Destination{Subdir: subdir},
+		})
+
+		incLocations, err := backupdest.ResolveIncrementalsBackupLocation(
+			ctx, user, execCfg, nil /* explicitIncrementalCollections */, []string{collectionURI}, subdir,
+		)
+		if err != nil {
+			return nil, err
+		}
+		incStore, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, incLocations[0], user)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open backup storage location")
+		}
+		priors, err := backupdest.FindPriorBackups(ctx, incStore, backupdest.OmitManifest)
+		incStore.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, prior := range priors {
+			incURI, err := url.Parse(incLocations[0])
+			if err != nil {
+				return nil, err
+			}
+			incURI.Path = backuputils.JoinURLPath(incURI.Path, prior)
+			found = append(found, jobspb.BackupDetails{
+				URI:           incURI.String(),
+				CollectionURI: collectionURI,
+				Destination:   jobspb.BackupDetails_Destination{Subdir: subdir},
+			})
+		}
+	}
+	return found, nil
+}
+
+// scanBackupCatalogEntry reads the manifest of the backup written by a job
+// with the given details and returns the catalog entry describing it. If the
+// manifest cannot be found, the returned entry is marked as missing.
+func scanBackupCatalogEntry(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	mem *mon.BoundAccount,
+	user username.SQLUsername,
+	kmsEnv cloud.KMSEnv,
+	details jobspb.BackupDetails,
+) (jobspb.BackupCatalogEntry, error) {
+	manifest, memSize, err := backupinfo.ReadBackupManifestFromURI(
+		ctx, mem, details.URI, user, execCfg.DistSQLSrv.ExternalStorageFromURI,
+		details.EncryptionOptions, kmsEnv,
+	)
+	if err != nil {
+		if !errors.Is(err, cloud.ErrFileDoesNotExist) {
+			return jobspb.BackupCatalogEntry{}, err
+		}
+		entry := makeBackupCatalogEntry(details, &backuppb.BackupManifest{
+			StartTime: details.StartTime,
+			EndTime:   details.EndTime,
+		}, nil /* descs */, 0 /* fileCount */, execCfg.Clock.Now())
+		entry.Missing = true
+		return entry, nil
+	}
+	defer mem.Shrink(ctx, memSize)
+
+	iterFactories, err := backupinfo.GetBackupManifestIterFactories(
+		ctx, execCfg.DistSQLSrv.ExternalStorage, []backuppb.BackupManifest{manifest},
+		details.EncryptionOptions, kmsEnv,
+	)
+	if err != nil {
+		return jobspb.BackupCatalogEntry{}, err
+	}
+	descs, err := backupinfo.BackupManifestDescriptors(ctx, iterFactories[0], manifest.EndTime)
+	if err != nil {
+		return jobspb.BackupCatalogEntry{}, err
+	}
+	fileIt, err := iterFactories[0].NewFileIter(ctx)
+	if err != nil {
+		return jobspb.BackupCatalogEntry{}, err
+	}
+	defer fileIt.Close()
+	var fileCount int64
+	for ; ; fileIt.Next() {
+		if ok, err := fileIt.Valid(); err != nil {
+			return jobspb.BackupCatalogEntry{}, err
+		} else if !ok {
+			break
+		}
+		fileCount++
+	}
+	return makeBackupCatalogEntry(details, &manifest, descs, fileCount, execCfg.Clock.Now()), nil
+}
+
+func init() {
+	builtins.RescanBackupCatalog = RescanBackupCatalog
+}
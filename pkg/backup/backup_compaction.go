@@ -64,17 +64,17 @@ var (
 )
 
 // maybeStartCompactionJob will initiate a compaction job off of a triggering
-// incremental job if the backup chain length exceeds the threshold.
-// backupStmt should be the original backup statement that triggered the job.
+// incremental job if the backup chain length exceeds the threshold. The
+// threshold and the number of backups to compact are taken from the compaction
+// policy of the triggering job's schedule, falling back to the cluster
+// settings.
 func maybeStartCompactionJob(
 	ctx context.Context,
 	execCfg *sql.ExecutorConfig,
 	user username.SQLUsername,
 	triggerJob jobspb.BackupDetails,
 ) (jobspb.JobID, error) {
-	threshold := backupCompactionThreshold.Get(&execCfg.Settings.SV)
-	if threshold == 0 || triggerJob.RevisionHistory ||
-		triggerJob.StartTime.IsEmpty() || triggerJob.ScheduleID == 0 {
+	if triggerJob.RevisionHistory || triggerJob.StartTime.IsEmpty() || triggerJob.ScheduleID == 0 {
 		return 0, nil
 	}
 	env := scheduledjobs.ProdJobSchedulerEnv
@@ -83,6 +83,7 @@ func maybeStartCompactionJob(
 		env = knobs.JobSchedulerEnv
 	}
 	var backupStmt string
+	var policy backuppb.CompactionPolicy
 	if err := execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
 		_, args, err := getScheduledBackupExecutionArgsFromSchedule(
 			ctx, env, jobs.ScheduledJobTxn(txn), triggerJob.ScheduleID,
@@ -93,10 +94,17 @@ func maybeStartCompactionJob(
 			)
 		}
 		backupStmt = args.BackupStatement
+		if args.CompactionPolicy != nil {
+			policy = *args.CompactionPolicy
+		}
 		return nil
 	}); err != nil {
 		return 0, err
 	}
+	threshold, windowSize := compactionThresholdAndWindow(&execCfg.Settings.SV, policy)
+	if threshold == 0 {
+		return 0, nil
+	}
 	kmsEnv := backupencryption.MakeBackupKMSEnv(
 		execCfg.Settings,
 		&execCfg.ExternalIODirConfig,
@@ -110,7 +118,7 @@ func maybeStartCompactionJob(
 	if int64(len(chain)) < threshold {
 		return 0, nil
 	}
-	start, end, err := minSizeDeltaHeuristic(ctx, execCfg, chain)
+	start, end, err := minSizeDeltaHeuristic(ctx, windowSize, chain)
 	if err != nil {
 		return 0, err
 	}
@@ -137,7 +145,22 @@ func maybeStartCompactionJob(
 			return errors.Newf("expected job ID: unexpected result type %T", datums[0])
 		}
 		jobID = jobspb.JobID(idDatum)
-		return nil
+		// Record the schedule on the compaction job so that it applies the
+		// schedule's retention once it completes.
+		return execCfg.JobRegistry.UpdateJobWithTxn(
+			ctx, jobID, txn, func(txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater) error {
+				details, ok := md.Payload.UnwrapDetails().(jobspb.BackupDetails)
+				if !ok {
+					return errors.AssertionFailedf(
+						"unexpected details type %T for compaction job %d", md.Payload.UnwrapDetails(), jobID,
+					)
+				}
+				details.ScheduleID = triggerJob.ScheduleID
+				md.Payload.Details = jobspb.WrapPayloadDetails(details)
+				ju.UpdatePayload(md.Payload)
+				return nil
+			},
+		)
 	})
 	return jobID, err
 }
@@ -382,6 +405,9 @@ func (b *backupResumer) ResumeCompaction(
 		return errors.Wrap(err, "exhausted retries")
 	}
 
+	b.recordBackupInCatalog(ctx, execCtx.ExecCfg(), updatedDetails, backupManifest)
+	maybeGCExpiredBackups(ctx, execCtx, updatedDetails)
+
 	return b.processScheduledBackupCompletion(ctx, jobs.StateSucceeded, execCtx, updatedDetails)
 }
 
@@ -11,7 +11,6 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
-	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/errors"
 )
@@ -27,21 +26,44 @@ var (
 	)
 )
 
+// compactionThresholdAndWindow returns the backup chain length at which a
+// compaction is triggered and the number of backups to compact, as configured
+// by the given schedule policy or, where it is unset, by the cluster settings.
+// A threshold of 0 indicates that compactions are disabled.
+func compactionThresholdAndWindow(
+	sv *settings.Values, policy backuppb.CompactionPolicy,
+) (threshold int64, windowSize int64) {
+	threshold = policy.Threshold
+	if threshold == 0 {
+		threshold = backupCompactionThreshold.Get(sv)
+	}
+	windowSize = policy.Window
+	if windowSize == 0 {
+		windowSize = backupCompactionWindow.Get(sv)
+		// A schedule that only sets its threshold may have a threshold lower than
+		// the default window size, in which case compact as many backups as the
+		// threshold allows.
+		if policy.Threshold != 0 && windowSize >= policy.Threshold {
+			windowSize = policy.Threshold - 1
+		}
+	}
+	return threshold, windowSize
+}
+
 // minSizeDeltaHeuristic is a heuristic that selects a window of backups with the
 // smallest delta in data size between each backup.
 func minSizeDeltaHeuristic(
-	_ context.Context, execCfg *sql.ExecutorConfig, backupChain []backuppb.BackupManifest,
+	_ context.Context, windowSize int64, backupChain []backuppb.BackupManifest,
 ) (int, int, error) {
-	windowSize := int(backupCompactionWindow.Get(&execCfg.Settings.SV))
 	// Compaction does not compact the full backup, so windowSize must be < len(backupChain).
-	if windowSize >= len(backupChain) {
+	if windowSize >= int64(len(backupChain)) {
 		return 0, 0, errors.New("window size must be less than backup chain length")
 	}
 	dataSizes := make([]int64, len(backupChain))
 	for i := range len(backupChain) {
 		dataSizes[i] = backupChain[i].EntryCounts.DataSize
 	}
-	start, end := minDeltaWindow(dataSizes, windowSize)
+	start, end := minDeltaWindow(dataSizes, int(windowSize))
 	return start, end, nil
 }
 
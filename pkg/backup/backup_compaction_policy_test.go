@@ -11,7 +11,6 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
-	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/stretchr/testify/require"
@@ -61,13 +60,64 @@ func TestBackupCompactionHeuristic(t *testing.T) {
 		})
 	}
 
-	st := cluster.MakeTestingClusterSettings()
 	t.Run("too large window", func(t *testing.T) {
 		var windowSize int64 = 5
 		chain := make([]backuppb.BackupManifest, 5)
-		backupCompactionWindow.Override(ctx, &st.SV, windowSize)
-		execCfg := &sql.ExecutorConfig{Settings: st}
-		_, _, err := minSizeDeltaHeuristic(ctx, execCfg, chain)
+		_, _, err := minSizeDeltaHeuristic(ctx, windowSize, chain)
 		require.Error(t, err)
 	})
 }
+
+func TestCompactionThresholdAndWindow(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	backupCompactionThreshold.Override(ctx, &st.SV, 5)
+	backupCompactionWindow.Override(ctx, &st.SV, 3)
+
+	testcases := []struct {
+		name              string
+		policy            backuppb.CompactionPolicy
+		expectedThreshold int64
+		expectedWindow    int64
+	}{
+		{
+			name:              "cluster settings",
+			expectedThreshold: 5,
+			expectedWindow:    3,
+		},
+		{
+			name:              "schedule policy",
+			policy:            backuppb.CompactionPolicy{Threshold: 10, Window: 4},
+			expectedThreshold: 10,
+			expectedWindow:    4,
+		},
+		{
+			name:              "schedule threshold only",
+			policy:            backuppb.CompactionPolicy{Threshold: 8},
+			expectedThreshold: 8,
+			expectedWindow:    3,
+		},
+		{
+			name:              "schedule threshold below window setting",
+			policy:            backuppb.CompactionPolicy{Threshold: 3},
+			expectedThreshold: 3,
+			expectedWindow:    2,
+		},
+		{
+			name:              "schedule window only",
+			policy:            backuppb.CompactionPolicy{Window: 2},
+			expectedThreshold: 5,
+			expectedWindow:    2,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			threshold, window := compactionThresholdAndWindow(&st.SV, tc.policy)
+			require.Equal(t, tc.expectedThreshold, threshold)
+			require.Equal(t, tc.expectedWindow, window)
+		})
+	}
+}
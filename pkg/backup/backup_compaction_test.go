@@ -434,67 +434,105 @@ func TestScheduledBackupCompaction(t *testing.T) {
 
 	th.setOverrideAsOfClauseKnob(t)
 
-	th.sqlDB.Exec(t, "SET CLUSTER SETTING backup.compaction.threshold = 3")
-	th.sqlDB.Exec(t, "SET CLUSTER SETTING backup.compaction.window_size = 2")
-	schedules, err := th.createBackupSchedule(
-		t, "CREATE SCHEDULE FOR BACKUP INTO $1 RECURRING '@hourly'", "nodelocal://1/backup",
-	)
-	require.NoError(t, err)
-	require.Equal(t, 2, len(schedules))
-
-	full, inc := schedules[0], schedules[1]
-	if full.IsPaused() {
-		full, inc = inc, full
+	testCases := []struct {
+		name       string
+		collection string
+		setup      func(t *testing.T)
+		cleanup    func(t *testing.T)
+		options    string
+	}{
+		{
+			name:       "cluster settings",
+			collection: "nodelocal://1/backup",
+			setup: func(t *testing.T) {
+				th.sqlDB.Exec(t, "SET CLUSTER SETTING backup.compaction.threshold = 3")
+				th.sqlDB.Exec(t, "SET CLUSTER SETTING backup.compaction.window_size = 2")
+			},
+			cleanup: func(t *testing.T) {
+				th.sqlDB.Exec(t, "RESET CLUSTER SETTING backup.compaction.threshold")
+				th.sqlDB.Exec(t, "RESET CLUSTER SETTING backup.compaction.window_size")
+			},
+		},
+		{
+			name:       "schedule options",
+			collection: "nodelocal://1/backup-policy",
+			options:    " WITH SCHEDULE OPTIONS compaction_threshold = '3', compaction_window = '2'",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer th.clearSchedules(t)
+			if tc.setup != nil {
+				tc.setup(t)
+			}
+			if tc.cleanup != nil {
+				defer tc.cleanup(t)
+			}
+
+			schedules, err := th.createBackupSchedule(
+				t, "CREATE SCHEDULE FOR BACKUP INTO $1 RECURRING '@hourly'"+tc.options, tc.collection,
+			)
+			require.NoError(t, err)
+			require.Equal(t, 2, len(schedules))
+
+			full, inc := schedules[0], schedules[1]
+			if full.IsPaused() {
+				full, inc = inc, full
+			}
+
+			th.env.SetTime(full.NextRun().Add(time.Second))
+			require.NoError(t, th.executeSchedules())
+			th.waitForSuccessfulScheduledJob(t, full.ScheduleID())
+
+			inc, err = jobs.ScheduledJobDB(th.internalDB()).
+				Load(context.Background(), th.env, inc.ScheduleID())
+			require.NoError(t, err)
+
+			th.env.SetTime(inc.NextRun().Add(time.Second))
+			require.NoError(t, th.executeSchedules())
+			th.waitForSuccessfulScheduledJob(t, inc.ScheduleID())
+
+			inc, err = jobs.ScheduledJobDB(th.internalDB()).
+				Load(context.Background(), th.env, inc.ScheduleID())
+			require.NoError(t, err)
+
+			th.env.SetTime(inc.NextRun().Add(time.Second))
+			require.NoError(t, th.executeSchedules())
+			th.waitForSuccessfulScheduledJob(t, inc.ScheduleID())
+
+			var jobID jobspb.JobID
+			// The scheduler is notified of the backup job completion and then the
+			// compaction job is created in a separate transaction. As such, we need to
+			// poll for the compaction job to be created.
+			testutils.SucceedsSoon(t, func() error {
+				return th.sqlDB.DB.QueryRowContext(
+					ctx,
+					`SELECT job_id FROM [SHOW JOBS] WHERE description ILIKE 'COMPACT%' `+
+						`AND description ILIKE '%' || $1 || '%' AND job_type = 'BACKUP'`,
+					tc.collection,
+				).Scan(&jobID)
+			})
+
+			testutils.SucceedsSoon(t, func() error {
+				th.server.JobRegistry().(*jobs.Registry).TestingNudgeAdoptionQueue()
+				var unused int64
+				return th.sqlDB.DB.QueryRowContext(
+					ctx,
+					"SELECT job_id FROM [SHOW JOBS] WHERE job_id = $1 AND status = $2",
+					jobID, jobs.StateSucceeded,
+				).Scan(&unused)
+			})
+
+			var numBackups int
+			th.sqlDB.QueryRow(
+				t,
+				"SELECT count(DISTINCT (start_time, end_time)) FROM "+
+					"[SHOW BACKUP FROM LATEST IN $1]",
+				tc.collection,
+			).Scan(&numBackups)
+			require.Equal(t, 4, numBackups)
+		})
 	}
-
-	th.env.SetTime(full.NextRun().Add(time.Second))
-	require.NoError(t, th.executeSchedules())
-	th.waitForSuccessfulScheduledJob(t, full.ScheduleID())
-
-	inc, err = jobs.ScheduledJobDB(th.internalDB()).
-		Load(context.Background(), th.env, inc.ScheduleID())
-	require.NoError(t, err)
-
-	th.env.SetTime(inc.NextRun().Add(time.Second))
-	require.NoError(t, th.executeSchedules())
-	th.waitForSuccessfulScheduledJob(t, inc.ScheduleID())
-
-	inc, err = jobs.ScheduledJobDB(th.internalDB()).
-		Load(context.Background(), th.env, inc.ScheduleID())
-	require.NoError(t, err)
-
-	th.env.SetTime(inc.NextRun().Add(time.Second))
-	require.NoError(t, th.executeSchedules())
-	th.waitForSuccessfulScheduledJob(t, inc.ScheduleID())
-
-	var jobID jobspb.JobID
-	// The scheduler is notified of the backup job completion and then the
-	// compaction job is created in a separate transaction. As such, we need to
-	// poll for the compaction job to be created.
-	testutils.SucceedsSoon(t, func() error {
-		return th.sqlDB.DB.QueryRowContext(
-			ctx,
-			`SELECT job_id FROM [SHOW JOBS] WHERE description ILIKE 'COMPACT%' AND job_type = 'BACKUP'`,
-		).Scan(&jobID)
-	})
-
-	testutils.SucceedsSoon(t, func() error {
-		th.server.JobRegistry().(*jobs.Registry).TestingNudgeAdoptionQueue()
-		var unused int64
-		return th.sqlDB.DB.QueryRowContext(
-			ctx,
-			"SELECT job_id FROM [SHOW JOBS] WHERE job_id = $1 AND status = $2",
-			jobID, jobs.StateSucceeded,
-		).Scan(&unused)
-	})
-
-	var numBackups int
-	th.sqlDB.QueryRow(
-		t,
-		"SELECT count(DISTINCT (start_time, end_time)) FROM "+
-			"[SHOW BACKUP FROM LATEST IN 'nodelocal://1/backup']",
-	).Scan(&numBackups)
-	require.Equal(t, 4, numBackups)
 }
 
 // Start and end are unix epoch in nanoseconds.
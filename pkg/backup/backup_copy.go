@@ -0,0 +1,460 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupbase"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/featureflag"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+// BACKUP ... WITH copy_to and BACKUP COPY copy a backup chain from one
+// collection to another, e.g. to keep a second copy of every backup in another
+// region or provider, or in a bucket which retains objects with Object Lock.
+//
+// The files of the chain -- the subdirectory of its full backup and the layers
+// in the default incrementals directory -- are copied byte for byte, so an
+// encrypted chain stays encrypted with the same keys. Each copied file is read
+// back from the destination and compared against the source. Data files are
+// copied before manifests, so that a layer only becomes visible at the
+// destination once all of its data is there, and the LATEST file of the
+// destination is advanced once the whole chain has been copied, so that SHOW
+// BACKUPS, SHOW BACKUP and RESTORE ... FROM LATEST work against the copy as
+// they do against the source.
+//
+// Files which already exist at the destination with the same size and SHA-256
+// checksum as the source were copied by an earlier run and are skipped. This
+// makes copying the chain after an incremental backup only copy the new layer,
+// and lets an interrupted copy be resumed by running it again.
+
+// backupCopyWorkers is the number of files copied concurrently.
+const backupCopyWorkers = 4
+
+// backupCopyStats counts the files and bytes copied by copyBackupChain.
+type backupCopyStats struct {
+	files int64
+	bytes int64
+}
+
+func (s *backupCopyStats) add(other backupCopyStats) {
+	s.files += other.files
+	s.bytes += other.bytes
+}
+
+// copyBackupChain copies the backup chain in the given subdirectory of the
+// source collection to the destination collection.
+func copyBackupChain(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user username.SQLUsername,
+	from, to, subdir string,
+) (backupCopyStats, error) {
+	ctx, sp := tracing.ChildSpan(ctx, "backup.copyBackupChain")
+	defer sp.Finish()
+
+	var stats backupCopyStats
+	mkStore := execCfg.DistSQLSrv.ExternalStorageFromURI
+	if strings.EqualFold(subdir, backupbase.LatestFileName) {
+		latest, err := backupdest.ReadLatestFile(ctx, from, mkStore, user)
+		if err != nil {
+			return stats, err
+		}
+		subdir = latest
+	}
+
+	for i, dir := range [][]string{
+		{subdir},
+		{backupbase.DefaultIncrementalsSubdir, subdir},
+	} {
+		srcURI, err := backuputils.AppendPaths([]string{from}, dir...)
+		if err != nil {
+			return stats, err
+		}
+		dstURI, err := backuputils.AppendPaths([]string{to}, dir...)
+		if err != nil {
+			return stats, err
+		}
+		dirStats, err := func() (backupCopyStats, error) {
+			src, err := mkStore(ctx, srcURI[0], user)
+			if err != nil {
+				return backupCopyStats{}, err
+			}
+			defer src.Close()
+			dst, err := mkStore(ctx, dstURI[0], user)
+			if err != nil {
+				return backupCopyStats{}, err
+			}
+			defer dst.Close()
+			copied, err := copyBackupFiles(ctx, src, dst, i == 0 /* requireManifest */)
+			if errors.Is(err, errNoBackupManifest) {
+				return backupCopyStats{}, pgerror.Newf(pgcode.UndefinedFile,
+					"%s does not contain a completed backup", subdir)
+			}
+			return copied, err
+		}()
+		if err != nil {
+			return stats, err
+		}
+		stats.add(dirStats)
+	}
+
+	return stats, advanceLatestFile(ctx, execCfg, user, to, subdir)
+}
+
+var errNoBackupManifest = errors.New("no backup manifest found")
+
+// listBackupFiles returns the names of all files in the storage.
+func listBackupFiles(ctx context.Context, store cloud.ExternalStorage) ([]string, error) {
+	var names []string
+	err := store.List(ctx, "", "", func(name string) error {
+		if name = strings.TrimPrefix(name, "/"); name != "" {
+			names = append(names, name)
+		}
+		return nil
+	})
+	return names, err
+}
+
+// copyBackupFiles copies every file of the source storage to the destination
+// storage, data files first. If requireManifest is set, errNoBackupManifest is
+// returned if the source does not contain a backup manifest.
+func copyBackupFiles(
+	ctx context.Context, src, dst cloud.ExternalStorage, requireManifest bool,
+) (backupCopyStats, error) {
+	srcFiles, err := listBackupFiles(ctx, src)
+	if err != nil {
+		return backupCopyStats{}, errors.Wrap(err, "listing backup files")
+	}
+	dstFiles, err := listBackupFiles(ctx, dst)
+	if err != nil {
+		return backupCopyStats{}, errors.Wrap(err, "listing copied backup files")
+	}
+	existing := make(map[string]struct{}, len(dstFiles))
+	for _, name := range dstFiles {
+		existing[name] = struct{}{}
+	}
+
+	var dataFiles, otherFiles []string
+	var hasManifest bool
+	for _, name := range srcFiles {
+		if name == backupbase.BackupManifestName {
+			hasManifest = true
+		}
+		if strings.HasPrefix(name, backupbase.ListingDelimDataSlash) ||
+			strings.Contains(name, "/"+backupbase.ListingDelimDataSlash) {
+			dataFiles = append(dataFiles, name)
+		} else {
+			otherFiles = append(otherFiles, name)
+		}
+	}
+	if requireManifest && !hasManifest {
+		return backupCopyStats{}, errNoBackupManifest
+	}
+	sort.Strings(dataFiles)
+	sort.Strings(otherFiles)
+
+	var files, copied atomic.Int64
+	for _, names := range [][]string{dataFiles, otherFiles} {
+		todo := make(chan string)
+		g := ctxgroup.WithContext(ctx)
+		g.GoCtx(func(ctx context.Context) error {
+			defer close(todo)
+			for _, name := range names {
+				select {
+				case todo <- name:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			return nil
+		})
+		for i := 0; i < backupCopyWorkers; i++ {
+			g.GoCtx(func(ctx context.Context) error {
+				for name := range todo {
+					_, exists := existing[name]
+					n, err := copyBackupFile(ctx, src, dst, name, exists)
+					if err != nil {
+						return errors.Wrapf(err, "copying %s", name)
+					}
+					if n > 0 {
+						files.Add(1)
+						copied.Add(n)
+					}
+				}
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return backupCopyStats{}, err
+		}
+	}
+	return backupCopyStats{files: files.Load(), bytes: copied.Load()}, nil
+}
+
+// copyBackupFile copies the named file from the source storage to the
+// destination storage, and verifies the copy by reading it back. It returns
+// the number of bytes copied, which is zero if the file already exists at the
+// destination with the same contents.
+func copyBackupFile(
+	ctx context.Context, src, dst cloud.ExternalStorage, name string, exists bool,
+) (int64, error) {
+	if exists {
+		same, err := sameBackupFile(ctx, src, dst, name)
+		if err != nil {
+			return 0, err
+		}
+		if same {
+			return 0, nil
+		}
+	}
+
+	r, size, err := src.ReadFile(ctx, name, cloud.ReadOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close(ctx)
+
+	srcHash := sha256.New()
+	if err := cloud.WriteFile(ctx, dst, name, io.TeeReader(ioctx.ReaderCtxAdapter(ctx, r), srcHash)); err != nil {
+		return 0, err
+	}
+
+	dstSum, n, err := hashBackupFile(ctx, dst, name)
+	if err != nil {
+		return 0, errors.Wrap(err, "reading back copy")
+	}
+	if n != size || !bytes.Equal(srcHash.Sum(nil), dstSum) {
+		return 0, errors.Newf("copy of %d bytes does not match the %d byte source", n, size)
+	}
+	return size, nil
+}
+
+// sameBackupFile returns true if the named file has the same size and SHA-256
+// checksum in both storages.
+func sameBackupFile(ctx context.Context, src, dst cloud.ExternalStorage, name string) (bool, error) {
+	srcSize, err := src.Size(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	dstSize, err := dst.Size(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	if srcSize != dstSize {
+		return false, nil
+	}
+	srcSum, _, err := hashBackupFile(ctx, src, name)
+	if err != nil {
+		return false, err
+	}
+	dstSum, _, err := hashBackupFile(ctx, dst, name)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(srcSum, dstSum), nil
+}
+
+// hashBackupFile returns the SHA-256 checksum and the size of the named file.
+func hashBackupFile(
+	ctx context.Context, store cloud.ExternalStorage, name string,
+) ([]byte, int64, error) {
+	r, _, err := store.ReadFile(ctx, name, cloud.ReadOptions{NoFileSize: true})
+	if err != nil {
+		return nil, 0, err
+	}
+	defer r.Close(ctx)
+	h := sha256.New()
+	n, err := io.Copy(h, ioctx.ReaderCtxAdapter(ctx, r))
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.Sum(nil), n, nil
+}
+
+// advanceLatestFile points the LATEST file of the collection to the given
+// subdirectory, unless it already points to the same or a later one.
+func advanceLatestFile(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user username.SQLUsername,
+	collectionURI, subdir string,
+) error {
+	mkStore := execCfg.DistSQLSrv.ExternalStorageFromURI
+	latest, err := backupdest.ReadLatestFile(ctx, collectionURI, mkStore, user)
+	if err != nil && !errors.Is(err, cloud.ErrFileDoesNotExist) {
+		return err
+	}
+	// Subdirectories chosen by BACKUP are named after the time the chain was
+	// started, so a later chain sorts after an earlier one.
+	if err == nil && latest >= subdir {
+		return nil
+	}
+	collection, err := mkStore(ctx, collectionURI, user)
+	if err != nil {
+		return err
+	}
+	defer collection.Close()
+	return backupdest.WriteNewLatestFile(ctx, execCfg.Settings, collection, subdir)
+}
+
+// backupCopyMessageKind is the kind of the job message which records that
+// copying a completed backup to its copy_to collection failed.
+const backupCopyMessageKind = "copy-failed"
+
+// copyCompletedBackup copies the chain of a completed backup to the collection
+// named by its copy_to option.
+//
+// The backup itself has completed, so a failure to copy it does not fail the
+// job. Instead, it is logged and recorded in the messages of the job; the copy
+// can be completed by BACKUP COPY, or by the next backup into the same chain.
+func copyCompletedBackup(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user username.SQLUsername,
+	job *jobs.Job,
+	details jobspb.BackupDetails,
+) {
+	copyTo := backuputils.RedactURIForErrorMessage(details.CopyTo)
+	stats, err := copyBackupChain(
+		ctx, execCfg, user, details.CollectionURI, details.CopyTo, details.Destination.Subdir,
+	)
+	if err != nil {
+		msg := fmt.Sprintf("backup completed but copying it to %s failed: %v", copyTo, err)
+		log.Warningf(ctx, "%s", msg)
+		if err := execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+			return job.Messages().Record(ctx, txn, backupCopyMessageKind, msg)
+		}); err != nil {
+			log.Warningf(ctx, "failed to record backup copy failure: %v", err)
+		}
+		return
+	}
+	log.Infof(ctx, "copied %d files (%d bytes) of backup %s to %s",
+		stats.files, stats.bytes, details.Destination.Subdir, copyTo)
+}
+
+var backupCopyHeader = colinfo.ResultColumns{
+	{Name: "files", Typ: types.Int},
+	{Name: "bytes", Typ: types.Int},
+}
+
+func backupCopyTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (ok bool, _ colinfo.ResultColumns, _ error) {
+	copyStmt, ok := stmt.(*tree.BackupCopy)
+	if !ok {
+		return false, nil, nil
+	}
+	if err := exprutil.TypeCheck(
+		ctx, "BACKUP COPY", p.SemaCtx(),
+		exprutil.Strings{
+			copyStmt.Subdir,
+			copyStmt.From,
+			copyStmt.To,
+		},
+	); err != nil {
+		return false, nil, err
+	}
+	return true, backupCopyHeader, nil
+}
+
+func backupCopyPlanHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	copyStmt, ok := stmt.(*tree.BackupCopy)
+	if !ok {
+		return nil, nil, false, nil
+	}
+
+	if err := featureflag.CheckEnabled(
+		ctx,
+		p.ExecCfg(),
+		featureBackupEnabled,
+		"BACKUP COPY",
+	); err != nil {
+		return nil, nil, false, err
+	}
+
+	exprEval := p.ExprEvaluator("BACKUP COPY")
+	subdir, err := exprEval.String(ctx, copyStmt.Subdir)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	from, err := exprEval.String(ctx, copyStmt.From)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	to, err := exprEval.String(ctx, copyStmt.To)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
+		hasAdmin, err := p.HasAdminRole(ctx)
+		if err != nil {
+			return err
+		}
+		if !hasAdmin {
+			if err := p.CheckPrivilegeForUser(
+				ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.BACKUP, p.User(),
+			); err != nil {
+				return pgerror.Wrapf(err, pgcode.InsufficientPrivilege,
+					"only users with the admin role or the BACKUP system privilege are allowed to copy backups")
+			}
+		}
+		if err := sql.CheckDestinationPrivileges(ctx, p, []string{from, to}); err != nil {
+			return err
+		}
+
+		stats, err := copyBackupChain(ctx, p.ExecCfg(), p.User(), from, to, subdir)
+		if err != nil {
+			return err
+		}
+		resultsCh <- tree.Datums{
+			tree.NewDInt(tree.DInt(stats.files)),
+			tree.NewDInt(tree.DInt(stats.bytes)),
+		}
+		return nil
+	}
+
+	return fn, backupCopyHeader, false, nil
+}
+
+func init() {
+	sql.AddPlanHook(
+		"backup copy",
+		backupCopyPlanHook,
+		backupCopyTypeCheck,
+	)
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupbase"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/cloud/cloudpb"
+	"github.com/cockroachdb/cockroach/pkg/cloud/nodelocal"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyBackupFiles(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	src := nodelocal.TestingMakeNodelocalStorage(t.TempDir(), st, cloudpb.ExternalStorage{})
+	defer src.Close()
+	dst := nodelocal.TestingMakeNodelocalStorage(t.TempDir(), st, cloudpb.ExternalStorage{})
+	defer dst.Close()
+
+	write := func(store cloud.ExternalStorage, name, content string) {
+		require.NoError(t, cloud.WriteFile(ctx, store, name, bytes.NewReader([]byte(content))))
+	}
+	read := func(store cloud.ExternalStorage, name string) string {
+		r, _, err := store.ReadFile(ctx, name, cloud.ReadOptions{NoFileSize: true})
+		require.NoError(t, err)
+		defer r.Close(ctx)
+		content, err := ioctx.ReadAll(ctx, r)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	write(src, "data/1.sst", "aaaa")
+	write(src, "data/2.sst", "bbbb")
+	write(src, backupbase.BackupManifestName, "manifest")
+
+	stats, err := copyBackupFiles(ctx, src, dst, true /* requireManifest */)
+	require.NoError(t, err)
+	require.Equal(t, backupCopyStats{files: 3, bytes: 16}, stats)
+
+	// Copying the files again skips the files which were already copied.
+	stats, err = copyBackupFiles(ctx, src, dst, true /* requireManifest */)
+	require.NoError(t, err)
+	require.Equal(t, backupCopyStats{}, stats)
+
+	// A file at the destination with the same size as the source, but other
+	// contents, is copied again.
+	write(dst, "data/2.sst", "cccc")
+	stats, err = copyBackupFiles(ctx, src, dst, true /* requireManifest */)
+	require.NoError(t, err)
+	require.Equal(t, backupCopyStats{files: 1, bytes: 4}, stats)
+	require.Equal(t, "bbbb", read(dst, "data/2.sst"))
+	require.Equal(t, "manifest", read(dst, backupbase.BackupManifestName))
+}
@@ -243,12 +243,29 @@ func backup(
 		}
 	}
 
+	// The size of the data to back up is not known up front, so the estimator
+	// extrapolates it from the fraction of spans that have been exported.
+	estimator := newProgressEstimator(0 /* totalBytes */, numTotalSpans, timeutil.Now())
+	// progressDoneCh is closed once all the progress from the processors has
+	// been ingested.
+	progressDoneCh := make(chan struct{})
+	progressEstimateLoop := func(ctx context.Context) error {
+		timings := func(ctx context.Context) phaseTimings {
+			resumer.mu.Lock()
+			defer resumer.mu.Unlock()
+			return phaseTimingsFromAggregatorStats(ctx, resumer.mu.perNodeAggregatorStats)
+		}
+		return runProgressEstimateLoop(ctx, execCtx.ExecCfg().InternalDB,
+			&execCtx.ExecCfg().Settings.SV, job.ID(), estimator, timings, progressDoneCh)
+	}
+
 	progCh := make(chan *execinfrapb.RemoteProducerMetadata_BulkProcessorProgress)
 	checkpointLoop := func(ctx context.Context) error {
 		// When a processor is done exporting a span, it will send a progress update
 		// to progCh.
 		defer close(requestFinishedCh)
 		defer close(perNodeProgressCh)
+		defer close(progressDoneCh)
 		var numBackedUpFiles int64
 		for progress := range progCh {
 			var progDetails backuppb.BackupManifest_Progress
@@ -258,11 +275,14 @@ func backup(
 			if backupManifest.RevisionStartTime.Less(progDetails.RevStartTime) {
 				backupManifest.RevisionStartTime = progDetails.RevStartTime
 			}
+			var exportedBytes int64
 			for _, file := range progDetails.Files {
 				backupManifest.Files = append(backupManifest.Files, file)
 				backupManifest.EntryCounts.Add(file.EntryCounts)
+				exportedBytes += file.EntryCounts.DataSize
 				numBackedUpFiles++
 			}
+			estimator.record(progress.NodeID, exportedBytes, int(progDetails.CompletedSpans))
 
 			// Signal that an ExportRequest finished to update job progress.
 			for i := int32(0); i < progDetails.CompletedSpans; i++ {
@@ -362,6 +382,7 @@ func backup(
 		jobProgressLoop,
 		checkpointLoop,
 		storePerNodeProgressLoop,
+		progressEstimateLoop,
 		tracingAggLoop,
 		runBackup,
 	); err != nil {
@@ -841,6 +862,14 @@ func (b *backupResumer) Resume(ctx context.Context, execCtx interface{}) error {
 		}
 	}
 
+	// If the backup should be copied to a second collection, copy it, and any
+	// earlier layers of its chain not yet copied, now that it is complete.
+	if details.CopyTo != "" {
+		copyCompletedBackup(ctx, p.ExecCfg(), p.User(), b.job, details)
+	}
+
+	b.recordBackupInCatalog(ctx, p.ExecCfg(), details, backupManifest)
+
 	b.backupStats = res
 
 	// Collect telemetry.
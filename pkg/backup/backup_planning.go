@@ -76,6 +76,7 @@ func resolveOptionsForBackupJobDescription(
 		Detached:                        opts.Detached,
 		ExecutionLocality:               opts.ExecutionLocality,
 		UpdatesClusterMonitoringMetrics: opts.UpdatesClusterMonitoringMetrics,
+		CopyTo:                          opts.CopyTo,
 	}
 
 	if opts.EncryptionPassphrase != nil {
@@ -94,6 +95,14 @@ func resolveOptionsForBackupJobDescription(
 		return tree.BackupOptions{}, err
 	}
 
+	if copyTo, ok := opts.CopyTo.(*tree.StrVal); ok {
+		sanitizedCopyTo, err := sanitizeURIList([]string{copyTo.RawString()})
+		if err != nil {
+			return tree.BackupOptions{}, err
+		}
+		newOpts.CopyTo = sanitizedCopyTo[0]
+	}
+
 	return newOpts, nil
 }
 
@@ -259,7 +268,8 @@ func checkPrivilegesForBackup(
 	{
 		// Cluster and tenant backups require the `BACKUP` system privilege.
 		requiresBackupSystemPrivilege := backupStmt.Coverage() == tree.AllDescriptors ||
-			(backupStmt.Targets != nil && backupStmt.Targets.TenantID.IsSet())
+			(backupStmt.Targets != nil && backupStmt.Targets.TenantID.IsSet()) ||
+			(backupStmt.Targets != nil && backupStmt.Targets.SystemConfig)
 
 		if requiresBackupSystemPrivilege {
 			if err := p.CheckPrivilegeForUser(
@@ -383,6 +393,7 @@ func backupTypeCheck(
 			backupStmt.Subdir,
 			backupStmt.Options.EncryptionPassphrase,
 			backupStmt.Options.ExecutionLocality,
+			backupStmt.Options.CopyTo,
 		},
 		exprutil.StringArrays{
 			tree.Exprs(backupStmt.To),
@@ -515,6 +526,14 @@ func backupPlanHook(
 		}
 	}
 
+	var copyTo string
+	if backupStmt.Options.CopyTo != nil {
+		copyTo, err = exprEval.String(ctx, backupStmt.Options.CopyTo)
+		if err != nil {
+			return nil, nil, false, err
+		}
+	}
+
 	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
 		// TODO(dan): Move this span into sql.
 		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
@@ -533,6 +552,15 @@ func backupPlanHook(
 			return errors.New("the include_all_virtual_clusters option is only supported for full cluster backups")
 		}
 
+		if copyTo != "" {
+			if len(to) > 1 {
+				return errors.New("the copy_to option is not supported for locality-aware backups")
+			}
+			if len(incrementalStorage) > 0 {
+				return errors.New("the copy_to option cannot be used with the incremental_location option")
+			}
+		}
+
 		var asOfInterval int64
 		endTime := p.ExecCfg().Clock.Now()
 		if backupStmt.AsOf.Expr != nil {
@@ -558,8 +586,16 @@ func backupPlanHook(
 
 		switch backupStmt.Coverage() {
 		case tree.RequestedDescriptors:
+			targets := backupStmt.Targets
 			var err error
-			targetDescs, completeDBs, requestedDBs, descsByTablePattern, err = backupresolver.ResolveTargetsToDescriptors(ctx, p, endTime, backupStmt.Targets)
+			if targets.SystemConfig {
+				// The SYSTEM CONFIG target is backed up as the system tables holding
+				// the cluster configuration.
+				if targets, err = systemConfigBackupTargets(*targets); err != nil {
+					return err
+				}
+			}
+			targetDescs, completeDBs, requestedDBs, descsByTablePattern, err = backupresolver.ResolveTargetsToDescriptors(ctx, p, endTime, targets)
 			if err != nil {
 				return errors.Wrap(err, "failed to resolve targets specified in the BACKUP stmt")
 			}
@@ -583,7 +619,11 @@ func backupPlanHook(
 		}
 
 		// Check BACKUP privileges.
-		err = checkPrivilegesForBackup(ctx, backupStmt, p, targetDescs, to)
+		destinations := to
+		if copyTo != "" {
+			destinations = append(append([]string(nil), to...), copyTo)
+		}
+		err = checkPrivilegesForBackup(ctx, backupStmt, p, targetDescs, destinations)
 		if err != nil {
 			return err
 		}
@@ -608,6 +648,7 @@ func backupPlanHook(
 			ApplicationName:                 p.SessionData().ApplicationName,
 			ExecutionLocality:               executionLocality,
 			UpdatesClusterMonitoringMetrics: updatesClusterMonitoringMetrics,
+			CopyTo:                          copyTo,
 		}
 		if backupStmt.CreatedByInfo != nil {
 			initialDetails.ScheduleID = backupStmt.CreatedByInfo.ScheduleID()
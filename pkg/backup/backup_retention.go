@@ -0,0 +1,199 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupbase"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/scheduledjobs"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// maybeGCExpiredBackups garbage collects the backup chains that have outlived
+// the retention of the schedule that triggered the given compaction. Failures
+// are only logged, as the compacted backup has already been written by then.
+func maybeGCExpiredBackups(
+	ctx context.Context, execCtx sql.JobExecContext, details jobspb.BackupDetails,
+) {
+	if details.ScheduleID == 0 {
+		return
+	}
+	execCfg := execCtx.ExecCfg()
+	env := scheduledjobs.ProdJobSchedulerEnv
+	knobs := execCfg.JobsKnobs()
+	if knobs != nil && knobs.JobSchedulerEnv != nil {
+		env = knobs.JobSchedulerEnv
+	}
+	var retention time.Duration
+	if err := execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		_, args, err := getScheduledBackupExecutionArgsFromSchedule(
+			ctx, env, jobs.ScheduledJobTxn(txn), details.ScheduleID,
+		)
+		if err != nil {
+			return err
+		}
+		if args.CompactionPolicy != nil {
+			retention = args.CompactionPolicy.Retention
+		}
+		return nil
+	}); err != nil {
+		log.Warningf(ctx, "failed to get retention of schedule %d: %v", details.ScheduleID, err)
+		return
+	}
+	if retention == 0 {
+		return
+	}
+	deleted, err := gcExpiredBackupChains(
+		ctx, execCfg, execCtx.User(), details, env.Now().Add(-retention),
+	)
+	if err != nil {
+		log.Warningf(ctx, "failed to garbage collect backups of schedule %d: %v", details.ScheduleID, err)
+		return
+	}
+	if len(deleted) > 0 {
+		log.Infof(ctx, "garbage collected backups %v older than %s", deleted, retention)
+	}
+}
+
+// gcExpiredBackupChains deletes the backup chains in the collection of the
+// given backup whose most recent backup ended before the cutoff, and returns
+// the subdirectories of the chains it deleted. The chain that LATEST points
+// to, and the chain of the given backup, are never deleted.
+//
+// The end time of each backup is read off the name of the directory it was
+// written to, so chains written to custom subdirectories are left alone.
+func gcExpiredBackupChains(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user username.SQLUsername,
+	details jobspb.BackupDetails,
+	cutoff time.Time,
+) ([]string, error) {
+	collections := details.Destination.To
+	if len(collections) == 0 {
+		return nil, nil
+	}
+	defaultCollection, _, err := backupdest.GetURIsByLocalityKV(collections, "")
+	if err != nil {
+		return nil, err
+	}
+	mkStore := execCfg.DistSQLSrv.ExternalStorageFromURI
+	latest, err := backupdest.ReadLatestFile(ctx, defaultCollection, mkStore, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading LATEST file")
+	}
+	store, err := mkStore(ctx, defaultCollection, user)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open backup storage location")
+	}
+	defer store.Close()
+	fullBackups, err := backupdest.ListFullBackupsInCollection(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+
+	normalize := func(subdir string) string {
+		return "/" + strings.Trim(subdir, "/")
+	}
+	var deleted []string
+	for _, subdir := range fullBackups {
+		subdir = normalize(subdir)
+		if subdir == normalize(latest) || subdir == normalize(details.Destination.Subdir) {
+			continue
+		}
+		fullEnd, err := time.Parse(backupbase.DateBasedIntoFolderName, subdir)
+		if err != nil {
+			continue
+		}
+		incDirs, err := backupdest.ResolveIncrementalsBackupLocation(
+			ctx, user, execCfg, details.Destination.IncrementalStorage, collections, subdir,
+		)
+		if err != nil {
+			return deleted, err
+		}
+		lastEnd, err := lastBackupEndTime(ctx, mkStore, user, incDirs[0], fullEnd)
+		if err != nil {
+			return deleted, err
+		}
+		if !lastEnd.Before(cutoff) {
+			continue
+		}
+		fullDirs, err := backuputils.AppendPaths(collections, subdir)
+		if err != nil {
+			return deleted, err
+		}
+		// Delete the incremental backups first, so that the chain remains listed
+		// in the collection if we fail part way through, and is retried by the
+		// next compaction.
+		for _, dir := range append(incDirs, fullDirs...) {
+			if err := deleteBackupDir(ctx, mkStore, user, dir); err != nil {
+				return deleted, err
+			}
+		}
+		deleted = append(deleted, subdir)
+	}
+	return deleted, nil
+}
+
+// lastBackupEndTime returns the end time of the last incremental backup in the
+// given incremental backup directory of a chain, or the end time of the chain's
+// full backup if it has no incremental backups.
+func lastBackupEndTime(
+	ctx context.Context,
+	mkStore cloud.ExternalStorageFromURIFactory,
+	user username.SQLUsername,
+	incDir string,
+	fullEnd time.Time,
+) (time.Time, error) {
+	store, err := mkStore(ctx, incDir, user)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "failed to open backup storage location")
+	}
+	defer store.Close()
+	incs, err := backupdest.FindPriorBackups(ctx, store, backupdest.OmitManifest)
+	if err != nil {
+		return time.Time{}, err
+	}
+	lastEnd := fullEnd
+	for _, inc := range incs {
+		end, err := time.Parse(backupbase.DateBasedIncFolderName, inc)
+		if err != nil {
+			continue
+		}
+		if end.After(lastEnd) {
+			lastEnd = end
+		}
+	}
+	return lastEnd, nil
+}
+
+// deleteBackupDir deletes all the files in the given backup directory.
+func deleteBackupDir(
+	ctx context.Context, mkStore cloud.ExternalStorageFromURIFactory, user username.SQLUsername, dir string,
+) error {
+	store, err := mkStore(ctx, dir, user)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open backup storage location")
+	}
+	defer store.Close()
+	// Delete will not delete a nonempty directory, so we have to go through all
+	// files and delete each file one by one.
+	return store.List(ctx, "", "", func(p string) error {
+		return store.Delete(ctx, p)
+	})
+}
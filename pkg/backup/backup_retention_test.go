@@ -0,0 +1,82 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGCExpiredBackupChains(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tempDir, tempDirCleanup := testutils.TempDir(t)
+	defer tempDirCleanup()
+	tc, db, cleanupDB := backupRestoreTestSetupEmpty(
+		t, singleNode, tempDir, InitManualReplication, base.TestClusterArgs{},
+	)
+	defer cleanupDB()
+
+	const collection = "nodelocal://1/retention"
+	db.Exec(t, "CREATE TABLE foo (a INT)")
+	db.Exec(t, "BACKUP INTO $1", collection)
+	db.Exec(t, "BACKUP INTO LATEST IN $1", collection)
+	db.Exec(t, "INSERT INTO foo VALUES (1)")
+	db.Exec(t, "BACKUP INTO $1", collection)
+	db.Exec(t, "BACKUP INTO LATEST IN $1", collection)
+	db.Exec(t, "INSERT INTO foo VALUES (2)")
+	db.Exec(t, "BACKUP INTO $1", collection)
+
+	listChains := func() []string {
+		var chains []string
+		for _, row := range db.QueryStr(t, "SHOW BACKUPS IN $1", collection) {
+			chains = append(chains, "/"+strings.Trim(row[0], "/"))
+		}
+		return chains
+	}
+	chains := listChains()
+	require.Len(t, chains, 3)
+
+	execCfg := tc.ApplicationLayer(0).ExecutorConfig().(sql.ExecutorConfig)
+	details := jobspb.BackupDetails{
+		Destination: jobspb.BackupDetails_Destination{To: []string{collection}},
+	}
+
+	// None of the chains ended before the cutoff.
+	deleted, err := gcExpiredBackupChains(
+		ctx, &execCfg, username.RootUserName(), details, timeutil.Now().Add(-time.Hour),
+	)
+	require.NoError(t, err)
+	require.Empty(t, deleted)
+	require.Equal(t, chains, listChains())
+
+	// All of the chains ended before the cutoff, but the chain that LATEST
+	// points to is kept.
+	deleted, err = gcExpiredBackupChains(
+		ctx, &execCfg, username.RootUserName(), details, timeutil.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+	require.Equal(t, chains[:2], deleted)
+	require.Equal(t, chains[2:], listChains())
+
+	db.Exec(t, "DROP TABLE foo")
+	db.Exec(t, "RESTORE TABLE foo FROM LATEST IN $1", collection)
+	db.CheckQueryResults(t, "SELECT * FROM foo ORDER BY a", [][]string{{"1"}, {"2"}})
+}
@@ -8,6 +8,7 @@ package backup
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
@@ -45,6 +46,9 @@ const (
 	optOnPreviousRunning       = "on_previous_running"
 	optIgnoreExistingBackups   = "ignore_existing_backups"
 	optUpdatesLastBackupMetric = "updates_cluster_last_backup_time_metric"
+	optCompactionThreshold     = "compaction_threshold"
+	optCompactionWindow        = "compaction_window"
+	optRetention               = "retention"
 )
 
 var scheduledBackupOptionExpectValues = map[string]exprutil.KVStringOptValidate{
@@ -53,6 +57,9 @@ var scheduledBackupOptionExpectValues = map[string]exprutil.KVStringOptValidate{
 	optOnPreviousRunning:       exprutil.KVStringOptRequireValue,
 	optIgnoreExistingBackups:   exprutil.KVStringOptRequireNoValue,
 	optUpdatesLastBackupMetric: exprutil.KVStringOptRequireNoValue,
+	optCompactionThreshold:     exprutil.KVStringOptRequireValue,
+	optCompactionWindow:        exprutil.KVStringOptRequireValue,
+	optRetention:               exprutil.KVStringOptRequireValue,
 }
 
 // scheduledBackupGCProtectionEnabled is used to enable and disable the chaining
@@ -87,6 +94,7 @@ type scheduledBackupSpec struct {
 	includeAllSecondaryTenants *bool
 	execLoc                    *string
 	updatesMetrics             *bool
+	copyTo                     *string
 }
 
 // TODO(msbutler): move this function into scheduleBase and remove duplicate function in scheduled changefeeds.
@@ -121,6 +129,63 @@ func scheduleFirstRun(evalCtx *eval.Context, opts map[string]string) (*time.Time
 	return nil, nil
 }
 
+// makeCompactionPolicy applies the compaction and retention schedule options in
+// opts on top of the given policy. It returns nil if the resulting policy is
+// empty, i.e. if the schedule defers entirely to the cluster settings.
+func makeCompactionPolicy(
+	evalCtx *eval.Context, opts map[string]string, policy *backuppb.CompactionPolicy,
+) (*backuppb.CompactionPolicy, error) {
+	var updated backuppb.CompactionPolicy
+	if policy != nil {
+		updated = *policy
+	}
+	if v, ok := opts[optCompactionThreshold]; ok {
+		threshold, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+				"invalid value for %s: %s", optCompactionThreshold, v)
+		}
+		if threshold != 0 && threshold < 3 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"%s must be 0 or at least 3", optCompactionThreshold)
+		}
+		updated.Threshold = threshold
+	}
+	if v, ok := opts[optCompactionWindow]; ok {
+		window, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+				"invalid value for %s: %s", optCompactionWindow, v)
+		}
+		if window != 0 && window < 2 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"%s must be 0 or at least 2", optCompactionWindow)
+		}
+		updated.Window = window
+	}
+	if v, ok := opts[optRetention]; ok {
+		interval, err := tree.ParseDInterval(evalCtx.SessionData().GetIntervalStyle(), v)
+		if err != nil {
+			return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+				"invalid value for %s: %s", optRetention, v)
+		}
+		secs, ok := interval.Duration.AsInt64()
+		if !ok || secs < 0 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"%s must be a non-negative interval", optRetention)
+		}
+		updated.Retention = time.Duration(secs) * time.Second
+	}
+	if updated.Threshold != 0 && updated.Window >= updated.Threshold {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s must be less than %s", optCompactionWindow, optCompactionThreshold)
+	}
+	if updated.Threshold == 0 && updated.Window == 0 && updated.Retention == 0 {
+		return nil, nil
+	}
+	return &updated, nil
+}
+
 func frequencyFromCron(now time.Time, cronStr string) (time.Duration, error) {
 	expr, err := cron.ParseStandard(cronStr)
 	if err != nil {
@@ -244,6 +309,10 @@ func doCreateBackupSchedules(
 		backupNode.Options.ExecutionLocality = tree.NewStrVal(*eval.execLoc)
 	}
 
+	if eval.copyTo != nil && *eval.copyTo != "" {
+		backupNode.Options.CopyTo = tree.NewStrVal(*eval.copyTo)
+	}
+
 	// Evaluate encryption KMS URIs if set.
 	// Only one of encryption passphrase and KMS URI should be set, but this check
 	// is done during backup planning so we do not need to worry about it here.
@@ -309,6 +378,15 @@ func doCreateBackupSchedules(
 	if err != nil {
 		return err
 	}
+	compactionPolicy, err := makeCompactionPolicy(evalCtx, scheduleOptions, nil /* policy */)
+	if err != nil {
+		return err
+	}
+	if compactionPolicy != nil && incRecurrence == nil {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s, %s and %s require a schedule with incremental backups",
+			optCompactionThreshold, optCompactionWindow, optRetention)
+	}
 
 	unpauseOnSuccessID := jobspb.InvalidScheduleID
 
@@ -346,7 +424,7 @@ func doCreateBackupSchedules(
 		}
 		inc, incScheduledBackupArgs, err = makeBackupSchedule(
 			env, p.User(), scheduleLabel, incRecurrence, incrementalScheduleDetails, unpauseOnSuccessID,
-			updateMetricOnSuccess, backupNode, chainProtectedTimestampRecords)
+			updateMetricOnSuccess, backupNode, chainProtectedTimestampRecords, compactionPolicy)
 		if err != nil {
 			return err
 		}
@@ -369,7 +447,7 @@ func doCreateBackupSchedules(
 	var fullScheduledBackupArgs *backuppb.ScheduledBackupExecutionArgs
 	full, fullScheduledBackupArgs, err := makeBackupSchedule(
 		env, p.User(), scheduleLabel, fullRecurrence, details, unpauseOnSuccessID,
-		updateMetricOnSuccess, backupNode, chainProtectedTimestampRecords)
+		updateMetricOnSuccess, backupNode, chainProtectedTimestampRecords, compactionPolicy)
 	if err != nil {
 		return err
 	}
@@ -475,6 +553,7 @@ func makeBackupSchedule(
 	updateLastMetricOnSuccess bool,
 	backupNode *tree.Backup,
 	chainProtectedTimestampRecords bool,
+	compactionPolicy *backuppb.CompactionPolicy,
 ) (*jobs.ScheduledJob, *backuppb.ScheduledBackupExecutionArgs, error) {
 	sj := jobs.NewScheduledJob(env)
 	sj.SetScheduleLabel(label)
@@ -485,6 +564,7 @@ func makeBackupSchedule(
 		UnpauseOnSuccess:               unpauseOnSuccess,
 		UpdatesLastBackupMetric:        updateLastMetricOnSuccess,
 		ChainProtectedTimestampRecords: chainProtectedTimestampRecords,
+		CompactionPolicy:               compactionPolicy,
 	}
 	if backupNode.AppendToLatest {
 		args.BackupType = backuppb.ScheduledBackupExecutionArgs_INCREMENTAL
@@ -709,6 +789,14 @@ func makeScheduledBackupSpec(
 		spec.updatesMetrics = &updatesMetrics
 	}
 
+	if schedule.BackupOptions.CopyTo != nil {
+		copyTo, err := exprEval.String(ctx, schedule.BackupOptions.CopyTo)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to evaluate backup copy_to")
+		}
+		spec.copyTo = &copyTo
+	}
+
 	return spec, nil
 }
 
@@ -774,6 +862,7 @@ func createBackupScheduleTypeCheck(
 		schedule.Recurrence,
 		schedule.BackupOptions.EncryptionPassphrase,
 		schedule.BackupOptions.ExecutionLocality,
+		schedule.BackupOptions.CopyTo,
 	}
 	if schedule.FullBackup != nil {
 		stringExprs = append(stringExprs, schedule.FullBackup.Recurrence)
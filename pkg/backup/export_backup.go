@@ -0,0 +1,886 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupbase"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupdest"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupencryption"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupresolver"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuputils"
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/featureflag"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+	gogotypes "github.com/gogo/protobuf/types"
+)
+
+// EXPORT BACKUP ... INTO PARQUET writes the rows of the tables in a backup to
+// Parquet files in external storage, without restoring the backup, e.g. to load
+// a backed up table into an analytics system.
+//
+// The backup is resolved as RESTORE would resolve it, as of the end time of the
+// chain or of the AS OF SYSTEM TIME clause. The job partitions the primary
+// indexes of the exported tables into the same spans a restore would use, and
+// assigns them to a processor on every SQL instance, which reads the SSTs of
+// the backup overlapping each span, decodes the latest revision of each row
+// with the backed up descriptor of its table and writes the rows to files
+// under <destination>/<database>/<schema>/<table>/.
+//
+// The parameters of the restore span cover are fixed when the job is created,
+// so every execution of the job builds the same cover. The entries of the cover
+// whose rows have been written are recorded in the progress of the job by
+// their index and skipped when it is resumed. The files written for an entry
+// are named after its index, so an entry which was partially exported before
+// the job was paused is exported again into the same files rather than new
+// ones.
+
+const (
+	exportBackupFormatParquet = "PARQUET"
+
+	exportBackupOptCompression = "compression"
+	exportBackupOptChunkRows   = "chunk_rows"
+	exportBackupOptDetached    = "detached"
+
+	// exportBackupChunkRowsDefault is the default maximum number of rows written
+	// to each file.
+	exportBackupChunkRowsDefault = 100000
+	// exportBackupChunkSize is the target size of each file.
+	exportBackupChunkSize = int64(32 << 20)
+)
+
+var exportBackupOptionExpectValues = map[string]exprutil.KVStringOptValidate{
+	backupencryption.BackupOptEncPassphrase: exprutil.KVStringOptRequireValue,
+	backupencryption.BackupOptEncKMS:        exprutil.KVStringOptRequireValue,
+	exportBackupOptCompression:              exprutil.KVStringOptRequireValue,
+	exportBackupOptChunkRows:                exprutil.KVStringOptRequireValue,
+	exportBackupOptDetached:                 exprutil.KVStringOptRequireNoValue,
+}
+
+// exportBackupOptions are the evaluated options of an EXPORT BACKUP statement.
+type exportBackupOptions struct {
+	passphrase  string
+	kms         string
+	compression roachpb.IOFileFormat_Compression
+	chunkRows   int64
+	detached    bool
+}
+
+func exportBackupIsDetached(opts tree.KVOptions) bool {
+	for _, opt := range opts {
+		if string(opt.Key) == exportBackupOptDetached {
+			return true
+		}
+	}
+	return false
+}
+
+func exportBackupHeader(stmt *tree.ExportBackup) colinfo.ResultColumns {
+	if exportBackupIsDetached(stmt.Options) {
+		return jobs.DetachedJobExecutionResultHeader
+	}
+	return jobs.BackupRestoreJobResultHeader
+}
+
+func exportBackupTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (ok bool, _ colinfo.ResultColumns, _ error) {
+	exportStmt, ok := stmt.(*tree.ExportBackup)
+	if !ok {
+		return false, nil, nil
+	}
+	if err := exprutil.TypeCheck(
+		ctx, "EXPORT BACKUP", p.SemaCtx(),
+		exprutil.Strings{
+			exportStmt.Subdir,
+			exportStmt.From,
+			exportStmt.File,
+		},
+		exprutil.KVOptions{
+			KVOptions:  exportStmt.Options,
+			Validation: exportBackupOptionExpectValues,
+		},
+	); err != nil {
+		return false, nil, err
+	}
+	return true, exportBackupHeader(exportStmt), nil
+}
+
+// evalExportBackupOptions evaluates the options of an EXPORT BACKUP statement.
+func evalExportBackupOptions(
+	ctx context.Context, exprEval *exprutil.Evaluator, stmt *tree.ExportBackup,
+) (exportBackupOptions, error) {
+	optVals, err := exprEval.KVOptions(ctx, stmt.Options, exportBackupOptionExpectValues)
+	if err != nil {
+		return exportBackupOptions{}, err
+	}
+	opts := exportBackupOptions{
+		passphrase: optVals[backupencryption.BackupOptEncPassphrase],
+		kms:        optVals[backupencryption.BackupOptEncKMS],
+		chunkRows:  exportBackupChunkRowsDefault,
+	}
+	if opts.passphrase != "" && opts.kms != "" {
+		return exportBackupOptions{}, errors.Newf("cannot specify both %q and %q",
+			backupencryption.BackupOptEncPassphrase, backupencryption.BackupOptEncKMS)
+	}
+	if name, ok := optVals[exportBackupOptCompression]; ok {
+		switch strings.ToLower(name) {
+		case "gzip":
+			opts.compression = roachpb.IOFileFormat_Gzip
+		case "snappy":
+			opts.compression = roachpb.IOFileFormat_Snappy
+		case "none":
+			opts.compression = roachpb.IOFileFormat_None
+		default:
+			return exportBackupOptions{}, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unsupported compression codec %s for parquet file format", name)
+		}
+	}
+	if override, ok := optVals[exportBackupOptChunkRows]; ok {
+		chunkRows, err := strconv.ParseInt(override, 10, 64)
+		if err != nil {
+			return exportBackupOptions{}, pgerror.WithCandidateCode(err, pgcode.InvalidParameterValue)
+		}
+		if chunkRows < 1 {
+			return exportBackupOptions{}, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid value for %q option: %d", exportBackupOptChunkRows, chunkRows)
+		}
+		opts.chunkRows = chunkRows
+	}
+	_, opts.detached = optVals[exportBackupOptDetached]
+	return opts, nil
+}
+
+func exportBackupPlanHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	exportStmt, ok := stmt.(*tree.ExportBackup)
+	if !ok {
+		return nil, nil, false, nil
+	}
+
+	if err := featureflag.CheckEnabled(
+		ctx,
+		p.ExecCfg(),
+		featureRestoreEnabled,
+		"EXPORT BACKUP",
+	); err != nil {
+		return nil, nil, false, err
+	}
+
+	if exportStmt.FileFormat != exportBackupFormatParquet {
+		return nil, nil, false, pgerror.Newf(pgcode.FeatureNotSupported,
+			"EXPORT BACKUP does not support the %s format, only PARQUET", exportStmt.FileFormat)
+	}
+	var targets *tree.BackupTargetList
+	if exportStmt.Targets != nil {
+		targets = exportStmt.Targets
+		if targets.SystemConfig {
+			return nil, nil, false, pgerror.New(pgcode.FeatureNotSupported,
+				"EXPORT BACKUP cannot export the system configuration")
+		}
+		if targets.TenantID.IsSet() {
+			return nil, nil, false, pgerror.New(pgcode.FeatureNotSupported,
+				"EXPORT BACKUP cannot export tenants")
+		}
+	}
+
+	exprEval := p.ExprEvaluator("EXPORT BACKUP")
+	subdir, err := exprEval.String(ctx, exportStmt.Subdir)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	from, err := exprEval.String(ctx, exportStmt.From)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	destination, err := exprEval.String(ctx, exportStmt.File)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	opts, err := evalExportBackupOptions(ctx, &exprEval, exportStmt)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, resultsCh chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer span.Finish()
+
+		if !(p.ExtendedEvalContext().TxnIsSingleStmt || opts.detached) {
+			return errors.Errorf("EXPORT BACKUP cannot be used inside a multi-statement transaction without DETACHED option")
+		}
+
+		if err := checkPrivilegesForExportBackup(ctx, p, from, destination); err != nil {
+			return err
+		}
+
+		var endTime hlc.Timestamp
+		if exportStmt.AsOf.Expr != nil {
+			asOf, err := p.EvalAsOfTimestamp(ctx, exportStmt.AsOf)
+			if err != nil {
+				return err
+			}
+			endTime = asOf.Timestamp
+		}
+
+		return doExportBackupPlan(
+			ctx, p, exportStmt, targets, subdir, from, destination, endTime, opts, resultsCh,
+		)
+	}
+
+	return fn, exportBackupHeader(exportStmt), false, nil
+}
+
+// checkPrivilegesForExportBackup checks that the user may read the backup and
+// write to the destination. Reading the rows of a backup requires the same
+// privilege as a cluster restore, since it bypasses the privileges of the
+// backed up tables.
+func checkPrivilegesForExportBackup(
+	ctx context.Context, p sql.PlanHookState, from, destination string,
+) error {
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if !hasAdmin {
+		if err := p.CheckPrivilegeForUser(
+			ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.RESTORE, p.User(),
+		); err != nil {
+			return pgerror.Wrapf(err, pgcode.InsufficientPrivilege,
+				"only users with the admin role or the RESTORE system privilege are allowed to export backups")
+		}
+	}
+	return sql.CheckDestinationPrivileges(ctx, p, []string{from, destination})
+}
+
+// doExportBackupPlan resolves the backup and the tables to export, and creates
+// the export job.
+func doExportBackupPlan(
+	ctx context.Context,
+	p sql.PlanHookState,
+	stmt *tree.ExportBackup,
+	targets *tree.BackupTargetList,
+	subdir, from, destination string,
+	endTime hlc.Timestamp,
+	opts exportBackupOptions,
+	resultsCh chan<- tree.Datums,
+) error {
+	mkStore := p.ExecCfg().DistSQLSrv.ExternalStorageFromURI
+	if strings.EqualFold(subdir, backupbase.LatestFileName) {
+		latest, err := backupdest.ReadLatestFile(ctx, from, mkStore, p.User())
+		if err != nil {
+			return err
+		}
+		subdir = latest
+	}
+
+	baseDir, err := backuputils.AppendPaths([]string{from}, subdir)
+	if err != nil {
+		return err
+	}
+	incDir, err := backupdest.ResolveIncrementalsBackupLocation(
+		ctx, p.User(), p.ExecCfg(), nil /* explicitIncrementalCollections */, []string{from}, subdir,
+	)
+	if err != nil {
+		if errors.Is(err, cloud.ErrListingUnsupported) {
+			log.Warningf(ctx, "storage sink %v does not support listing, only resolving the base backup", from)
+		} else {
+			return err
+		}
+	}
+
+	baseStores, cleanupFn, err := backupdest.MakeBackupDestinationStores(ctx, p.User(), mkStore, baseDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanupFn(); err != nil {
+			log.Warningf(ctx, "failed to close base store: %+v", err)
+		}
+	}()
+	incStores, cleanupFn, err := backupdest.MakeBackupDestinationStores(ctx, p.User(), mkStore, incDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanupFn(); err != nil {
+			log.Warningf(ctx, "failed to close incremental store: %+v", err)
+		}
+	}()
+
+	ioConf := baseStores[0].ExternalIOConf()
+	kmsEnv := backupencryption.MakeBackupKMSEnv(
+		p.ExecCfg().Settings, &ioConf, p.ExecCfg().InternalDB, p.User(),
+	)
+	encryption, err := resolveExportBackupEncryption(ctx, baseStores[0], opts, &kmsEnv)
+	if err != nil {
+		return err
+	}
+
+	mem := p.ExecCfg().RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+
+	defaultURIs, manifests, localityInfo, memReserved, err := backupdest.ResolveBackupManifests(
+		ctx, &mem, baseStores, incStores, mkStore, baseDir, incDir, endTime, encryption, &kmsEnv,
+		p.User(), false, /* includeSkipped */
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		mem.Shrink(ctx, memReserved)
+	}()
+
+	if err := checkBackupManifestVersionCompatability(
+		ctx, p.ExecCfg().Settings.Version, manifests, false, /* unsafeRestoreIncompatibleVersion */
+	); err != nil {
+		return err
+	}
+
+	layerToIterFactory, err := backupinfo.GetBackupManifestIterFactories(
+		ctx, p.ExecCfg().DistSQLSrv.ExternalStorage, manifests, encryption, &kmsEnv,
+	)
+	if err != nil {
+		return err
+	}
+	allDescs, _, err := backupinfo.LoadSQLDescsFromBackupsAtTime(ctx, manifests, layerToIterFactory, endTime)
+	if err != nil {
+		return err
+	}
+	tables, typeDescs, err := exportBackupTables(ctx, p, allDescs, targets, endTime)
+	if err != nil {
+		return err
+	}
+	if len(tables) == 0 {
+		return errors.New("no tables to export in the backup")
+	}
+
+	if endTime.IsEmpty() {
+		endTime = manifests[len(manifests)-1].EndTime
+	}
+
+	description, err := exportBackupJobDescription(p, stmt, subdir, from, destination, opts)
+	if err != nil {
+		return err
+	}
+	jr := jobs.Record{
+		Description: description,
+		Username:    p.User(),
+		Details: jobspb.ExportBackupDetails{
+			URIs:               defaultURIs,
+			BackupLocalityInfo: localityInfo,
+			EndTime:            endTime,
+			Encryption:         encryption,
+			Tables:             tables,
+			TypeDescs:          typeDescs,
+			Destination:        destination,
+			Compression:        opts.compression,
+			ChunkRows:          opts.chunkRows,
+			TargetSpanSize:     targetRestoreSpanSize.Get(&p.ExecCfg().Settings.SV),
+			MaxFileCount:       maxFileCount.Get(&p.ExecCfg().Settings.SV),
+		},
+		Progress: jobspb.ExportBackupProgress{},
+	}
+
+	if opts.detached {
+		jobID := p.ExecCfg().JobRegistry.MakeJobID()
+		if _, err := p.ExecCfg().JobRegistry.CreateAdoptableJobWithTxn(
+			ctx, jr, jobID, p.InternalSQLTxn(),
+		); err != nil {
+			return err
+		}
+		resultsCh <- tree.Datums{tree.NewDInt(tree.DInt(jobID))}
+		return nil
+	}
+
+	plannerTxn := p.Txn()
+	var sj *jobs.StartableJob
+	if err := func() (err error) {
+		defer func() {
+			if err == nil || sj == nil {
+				return
+			}
+			if cleanupErr := sj.CleanupOnRollback(ctx); cleanupErr != nil {
+				log.Errorf(ctx, "failed to cleanup job: %v", cleanupErr)
+			}
+		}()
+		jobID := p.ExecCfg().JobRegistry.MakeJobID()
+		if err := p.ExecCfg().JobRegistry.CreateStartableJobWithTxn(ctx, &sj, jobID, p.InternalSQLTxn(), jr); err != nil {
+			return err
+		}
+		return plannerTxn.Commit(ctx)
+	}(); err != nil {
+		return err
+	}
+	// Release the descriptor leases held by the committed transaction before
+	// waiting for the job; see doRestorePlan.
+	p.InternalSQLTxn().Descriptors().ReleaseAll(ctx)
+	if err := sj.Start(ctx); err != nil {
+		return err
+	}
+	if err := sj.AwaitCompletion(ctx); err != nil {
+		return err
+	}
+	return sj.ReportExecutionResults(ctx, resultsCh)
+}
+
+// resolveExportBackupEncryption returns the options to decrypt the backup with
+// the passphrase or KMS URI given to EXPORT BACKUP, if any.
+func resolveExportBackupEncryption(
+	ctx context.Context,
+	store cloud.ExternalStorage,
+	opts exportBackupOptions,
+	kmsEnv cloud.KMSEnv,
+) (*jobspb.BackupEncryptionOptions, error) {
+	switch {
+	case opts.passphrase != "":
+		encOpts, err := backupencryption.ReadEncryptionOptions(ctx, store)
+		if err != nil {
+			return nil, err
+		}
+		return &jobspb.BackupEncryptionOptions{
+			Mode: jobspb.EncryptionMode_Passphrase,
+			Key:  storageccl.GenerateKey([]byte(opts.passphrase), encOpts[0].Salt),
+		}, nil
+	case opts.kms != "":
+		encOpts, err := backupencryption.ReadEncryptionOptions(ctx, store)
+		if err != nil {
+			return nil, err
+		}
+		var kmsInfo *jobspb.BackupEncryptionOptions_KMSInfo
+		for _, encFile := range encOpts {
+			kmsInfo, err = backupencryption.ValidateKMSURIsAgainstFullBackup(ctx, []string{opts.kms},
+				backupencryption.NewEncryptedDataKeyMapFromProtoMap(encFile.EncryptedDataKeyByKMSMasterKeyID),
+				kmsEnv)
+			if err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &jobspb.BackupEncryptionOptions{
+			Mode:    jobspb.EncryptionMode_KMS,
+			KMSInfo: kmsInfo,
+		}, nil
+	}
+	return nil, nil
+}
+
+// exportBackupTables returns the tables of the backup to export, which are
+// those matching the targets or, if there are none, every user table in the
+// backup. It also returns the descriptors needed to hydrate the user-defined
+// types used by the tables: the types, schemas and databases of the databases
+// of the tables.
+func exportBackupTables(
+	ctx context.Context,
+	p sql.PlanHookState,
+	allDescs []catalog.Descriptor,
+	targets *tree.BackupTargetList,
+	endTime hlc.Timestamp,
+) ([]jobspb.ExportBackupDetails_Table, []descpb.Descriptor, error) {
+	candidates := allDescs
+	if targets != nil {
+		matched, err := backupresolver.DescriptorsMatchingTargets(ctx,
+			p.CurrentDatabase(), p.CurrentSearchPath(), allDescs, *targets, endTime)
+		if err != nil {
+			return nil, nil, errors.Wrap(err,
+				"failed to resolve targets in the backup, use SHOW BACKUP to find correct targets")
+		}
+		if len(matched.Descs) == 0 {
+			return nil, nil, errors.Errorf("no tables or databases matched the given targets: %s",
+				tree.ErrString(targets))
+		}
+		candidates = matched.Descs
+	}
+
+	names := make(map[descpb.ID]string)
+	names[keys.PublicSchemaIDForBackup] = catconstants.PublicSchemaName
+	for _, desc := range allDescs {
+		switch desc.(type) {
+		case catalog.DatabaseDescriptor, catalog.SchemaDescriptor:
+			names[desc.GetID()] = desc.GetName()
+		}
+	}
+
+	var tables []jobspb.ExportBackupDetails_Table
+	dbs := make(map[descpb.ID]struct{})
+	for _, desc := range candidates {
+		table, ok := desc.(catalog.TableDescriptor)
+		if !ok || !table.IsTable() || table.IsVirtualTable() || !table.Public() {
+			continue
+		}
+		if targets == nil && table.GetParentID() == keys.SystemDatabaseID {
+			continue
+		}
+		dbs[table.GetParentID()] = struct{}{}
+		tables = append(tables, jobspb.ExportBackupDetails_Table{
+			Desc: table.TableDesc(),
+			Path: strings.Join([]string{
+				names[table.GetParentID()],
+				names[table.GetParentSchemaID()],
+				table.GetName(),
+			}, "/"),
+		})
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Desc.ID < tables[j].Desc.ID })
+
+	var typeDescs []descpb.Descriptor
+	for _, desc := range allDescs {
+		if _, ok := dbs[desc.GetParentID()]; !ok {
+			if _, ok := dbs[desc.GetID()]; !ok {
+				continue
+			}
+		}
+		switch desc.(type) {
+		case catalog.DatabaseDescriptor, catalog.SchemaDescriptor, catalog.TypeDescriptor:
+			typeDescs = append(typeDescs, *desc.DescriptorProto())
+		}
+	}
+	return tables, typeDescs, nil
+}
+
+// exportBackupJobDescription returns the description of the export job, which
+// is the statement with its URIs sanitized and its subdirectory resolved.
+func exportBackupJobDescription(
+	p sql.PlanHookState,
+	stmt *tree.ExportBackup,
+	resolvedSubdir, from, destination string,
+	opts exportBackupOptions,
+) (string, error) {
+	sanitizedFrom, err := cloud.SanitizeExternalStorageURI(from, nil /* extraParams */)
+	if err != nil {
+		return "", err
+	}
+	sanitizedDest, err := cloud.SanitizeExternalStorageURI(destination, nil /* extraParams */)
+	if err != nil {
+		return "", err
+	}
+	e := &tree.ExportBackup{
+		Targets:    stmt.Targets,
+		Subdir:     tree.NewDString("/" + strings.TrimPrefix(resolvedSubdir, "/")),
+		From:       tree.NewDString(sanitizedFrom),
+		FileFormat: stmt.FileFormat,
+		File:       tree.NewDString(sanitizedDest),
+		AsOf:       stmt.AsOf,
+	}
+	for _, opt := range stmt.Options {
+		switch string(opt.Key) {
+		case backupencryption.BackupOptEncPassphrase:
+			opt.Value = tree.NewDString("redacted")
+		case backupencryption.BackupOptEncKMS:
+			redacted, err := cloud.RedactKMSURI(opts.kms)
+			if err != nil {
+				return "", err
+			}
+			opt.Value = tree.NewDString(redacted)
+		}
+		e.Options = append(e.Options, opt)
+	}
+	ann := p.ExtendedEvalContext().Annotations
+	return tree.AsStringWithFlags(
+		e, tree.FmtAlwaysQualifyNames|tree.FmtShowFullURIs, tree.FmtAnnotations(ann),
+	), nil
+}
+
+type exportBackupResumer struct {
+	job *jobs.Job
+
+	mu struct {
+		syncutil.Mutex
+		progress jobspb.ExportBackupProgress
+	}
+}
+
+var _ jobs.Resumer = &exportBackupResumer{}
+var _ jobs.JobResultsReporter = &exportBackupResumer{}
+
+// Resume is part of the jobs.Resumer interface.
+func (r *exportBackupResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	p := execCtx.(sql.JobExecContext)
+	details := r.job.Details().(jobspb.ExportBackupDetails)
+	r.mu.progress = *r.job.Progress().Details.(*jobspb.Progress_ExportBackup).ExportBackup
+
+	kmsEnv := backupencryption.MakeBackupKMSEnv(
+		p.ExecCfg().Settings,
+		&p.ExecCfg().ExternalIODirConfig,
+		p.ExecCfg().InternalDB,
+		p.User(),
+	)
+	mem := p.ExecCfg().RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+	manifests, memSize, err := backupinfo.LoadBackupManifestsAtTime(
+		ctx, &mem, details.URIs, p.User(), p.ExecCfg().DistSQLSrv.ExternalStorageFromURI,
+		details.Encryption, &kmsEnv, details.EndTime,
+	)
+	if err != nil {
+		return err
+	}
+	defer mem.Shrink(ctx, memSize)
+
+	backupCodec, err := backupinfo.MakeBackupCodec(manifests)
+	if err != nil {
+		return err
+	}
+	_, backupTenantID, err := keys.DecodeTenantPrefix(backupCodec.TenantPrefix())
+	if err != nil {
+		return err
+	}
+
+	entries, err := r.exportBackupEntries(ctx, p, manifests, backupCodec, details, &kmsEnv)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	fileEncryption, err := backupFileEncryption(ctx, details.Encryption, &kmsEnv)
+	if err != nil {
+		return err
+	}
+
+	spec := execinfrapb.ExportBackupDataSpec{
+		JobID:          int64(r.job.ID()),
+		EndTime:        details.EndTime,
+		Encryption:     fileEncryption,
+		Tables:         details.Tables,
+		TypeDescs:      details.TypeDescs,
+		Destination:    details.Destination,
+		Compression:    details.Compression,
+		ChunkRows:      details.ChunkRows,
+		ChunkSize:      exportBackupChunkSize,
+		UserProto:      p.User().EncodeProto(),
+		BackupTenantID: backupTenantID,
+	}
+
+	progCh := make(chan *execinfrapb.RemoteProducerMetadata_BulkProcessorProgress)
+	entryDoneCh := make(chan struct{}, len(entries))
+	progressLogger := jobs.NewChunkProgressLoggerForJob(
+		r.job, len(entries), r.job.FractionCompleted(),
+		func(ctx context.Context, details jobspb.ProgressDetails) {
+			d, ok := details.(*jobspb.Progress_ExportBackup)
+			if !ok {
+				log.Errorf(ctx, "job progress had unexpected type %T", details)
+				return
+			}
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			progress := r.mu.progress
+			progress.CompletedEntries = append([]int64(nil), progress.CompletedEntries...)
+			d.ExportBackup = &progress
+		},
+	)
+	progressLoop := func(ctx context.Context) error {
+		return errors.Wrap(progressLogger.Loop(ctx, entryDoneCh), "job progress loop")
+	}
+	ingestProgress := func(ctx context.Context) error {
+		defer close(entryDoneCh)
+		for prog := range progCh {
+			var update jobspb.ExportBackupProgress
+			if err := gogotypes.UnmarshalAny(&prog.ProgressDetails, &update); err != nil {
+				log.Errorf(ctx, "unable to unmarshal export backup progress details: %+v", err)
+				continue
+			}
+			func() {
+				r.mu.Lock()
+				defer r.mu.Unlock()
+				for _, idx := range update.CompletedEntries {
+					i, found := slices.BinarySearch(r.mu.progress.CompletedEntries, idx)
+					if !found {
+						r.mu.progress.CompletedEntries = slices.Insert(r.mu.progress.CompletedEntries, i, idx)
+					}
+				}
+				r.mu.progress.Rows += update.Rows
+				r.mu.progress.Files += update.Files
+			}()
+			entryDoneCh <- struct{}{}
+		}
+		return nil
+	}
+	runFlow := func(ctx context.Context) error {
+		return distExportBackup(ctx, p, r.job.ID(), spec, entries, progCh)
+	}
+	if err := ctxgroup.GoAndWait(ctx, progressLoop, ingestProgress, runFlow); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	log.Infof(ctx, "exported %d rows of backup to %d files", r.mu.progress.Rows, r.mu.progress.Files)
+	return nil
+}
+
+// exportBackupEntries returns the restore span entries covering the primary
+// indexes of the exported tables, except for the entries which have already
+// been exported. The ProgressIdx of each entry is its index in the cover.
+func (r *exportBackupResumer) exportBackupEntries(
+	ctx context.Context,
+	p sql.JobExecContext,
+	manifests []backuppb.BackupManifest,
+	backupCodec keys.SQLCodec,
+	details jobspb.ExportBackupDetails,
+	kmsEnv cloud.KMSEnv,
+) ([]execinfrapb.RestoreSpanEntry, error) {
+	execCfg := p.ExecCfg()
+	spans := make(roachpb.Spans, 0, len(details.Tables))
+	for _, table := range details.Tables {
+		desc := tabledesc.NewBuilder(table.Desc).BuildImmutableTable()
+		spans = append(spans, desc.PrimaryIndexSpan(backupCodec))
+	}
+	sort.Sort(spans)
+	if err := checkCoverage(ctx, spans, manifests); err != nil {
+		return nil, err
+	}
+
+	// Jobs created before the parameters of the cover were recorded in their
+	// details build it with the current settings.
+	targetSize, maxFiles := details.TargetSpanSize, details.MaxFileCount
+	if targetSize == 0 {
+		targetSize = targetRestoreSpanSize.Get(&execCfg.Settings.SV)
+	}
+	if maxFiles == 0 {
+		maxFiles = maxFileCount.Get(&execCfg.Settings.SV)
+	}
+
+	backupLocalityMap, err := makeBackupLocalityMap(details.BackupLocalityInfo, p.User())
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving locality locations")
+	}
+	introducedSpanFrontier, err := createIntroducedSpanFrontier(manifests, details.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	defer introducedSpanFrontier.Release()
+	// The whole cover is generated, rather than only its uncompleted part, so
+	// that the index of each entry does not depend on the progress of the job.
+	filter, err := makeSpanCoveringFilter(
+		spans,
+		nil, /* checkpointedSpans */
+		introducedSpanFrontier,
+		targetSize,
+		maxFiles,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer filter.close()
+	layerToIterFactory, err := backupinfo.GetBackupManifestIterFactories(
+		ctx, execCfg.DistSQLSrv.ExternalStorage, manifests, details.Encryption, kmsEnv,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []execinfrapb.RestoreSpanEntry
+	spanCh := make(chan execinfrapb.RestoreSpanEntry, 1000)
+	genSpans := func(ctx context.Context) error {
+		defer close(spanCh)
+		return errors.Wrap(generateAndSendImportSpans(
+			ctx,
+			spans,
+			manifests,
+			layerToIterFactory,
+			backupLocalityMap,
+			filter,
+			fileSpanComparatorForBackups(manifests),
+			spanCh,
+		), "generate and send import spans")
+	}
+	collectSpans := func(ctx context.Context) error {
+		var idx int64
+		for entry := range spanCh {
+			entry.ProgressIdx = idx
+			idx++
+			if _, found := slices.BinarySearch(r.mu.progress.CompletedEntries, entry.ProgressIdx); found {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	}
+	if err := ctxgroup.GoAndWait(ctx, genSpans, collectSpans); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ReportResults implements the JobResultsReporter interface.
+func (r *exportBackupResumer) ReportResults(
+	ctx context.Context, resultsCh chan<- tree.Datums,
+) error {
+	r.mu.Lock()
+	rows := r.mu.progress.Rows
+	r.mu.Unlock()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case resultsCh <- tree.Datums{
+		tree.NewDInt(tree.DInt(r.job.ID())),
+		tree.NewDString(string(jobs.StateSucceeded)),
+		tree.NewDFloat(tree.DFloat(1.0)),
+		tree.NewDInt(tree.DInt(rows)),
+	}:
+		return nil
+	}
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface. The files which were
+// already written are left in place.
+func (r *exportBackupResumer) OnFailOrCancel(context.Context, interface{}, error) error {
+	return nil
+}
+
+// CollectProfile is part of the jobs.Resumer interface.
+func (r *exportBackupResumer) CollectProfile(context.Context, interface{}) error {
+	return nil
+}
+
+func init() {
+	sql.AddPlanHook(
+		"export backup",
+		exportBackupPlanHook,
+		exportBackupTypeCheck,
+	)
+	jobs.RegisterConstructor(
+		jobspb.TypeExportBackup,
+		func(job *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+			return &exportBackupResumer{job: job}
+		},
+		jobs.UsesTenantCostControl,
+	)
+}
@@ -0,0 +1,600 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupsink"
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobsprofiler"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/nstree"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/importer"
+	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/parquet"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/logtags"
+	gogotypes "github.com/gogo/protobuf/types"
+)
+
+// Progress is streamed to the coordinator through metadata.
+var exportBackupDataOutputTypes = []*types.T{}
+
+// exportBackupBatchRows is the number of rows decoded at a time.
+const exportBackupBatchRows = 128
+
+// distExportBackup plans and runs the flow of an EXPORT BACKUP job: the entries
+// are distributed round-robin to an export backup data processor on every SQL
+// instance. The progress of the processors is streamed back over progCh, which
+// is closed when the flow completes.
+func distExportBackup(
+	ctx context.Context,
+	execCtx sql.JobExecContext,
+	jobID jobspb.JobID,
+	spec execinfrapb.ExportBackupDataSpec,
+	entries []execinfrapb.RestoreSpanEntry,
+	progCh chan *execinfrapb.RemoteProducerMetadata_BulkProcessorProgress,
+) error {
+	defer close(progCh)
+	var noTxn *kv.Txn
+
+	dsp := execCtx.DistSQLPlanner()
+	planCtx, sqlInstanceIDs, err := dsp.SetupAllNodesPlanning(
+		ctx, execCtx.ExtendedEvalContext(), execCtx.ExecCfg(),
+	)
+	if err != nil {
+		return errors.Wrap(err, "making distSQL plan")
+	}
+
+	specs := make([]execinfrapb.ExportBackupDataSpec, len(sqlInstanceIDs))
+	for i := range specs {
+		specs[i] = spec
+	}
+	for i, entry := range entries {
+		specs[i%len(specs)].Entries = append(specs[i%len(specs)].Entries, entry)
+	}
+
+	p := planCtx.NewPhysicalPlan()
+	stageID := p.NewStageOnNodes(sqlInstanceIDs)
+	for i, sqlInstanceID := range sqlInstanceIDs {
+		if len(specs[i].Entries) == 0 {
+			continue
+		}
+		pIdx := p.AddProcessor(physicalplan.Processor{
+			SQLInstanceID: sqlInstanceID,
+			Spec: execinfrapb.ProcessorSpec{
+				Core:        execinfrapb.ProcessorCoreUnion{ExportBackupData: &specs[i]},
+				Post:        execinfrapb.PostProcessSpec{},
+				Output:      []execinfrapb.OutputRouterSpec{{Type: execinfrapb.OutputRouterSpec_PASS_THROUGH}},
+				StageID:     stageID,
+				ResultTypes: exportBackupDataOutputTypes,
+			},
+		})
+		p.ResultRouters = append(p.ResultRouters, pIdx)
+	}
+	sql.FinalizePlan(ctx, planCtx, p)
+
+	metaFn := func(_ context.Context, meta *execinfrapb.ProducerMetadata) error {
+		if meta.BulkProcessorProgress != nil {
+			select {
+			case progCh <- meta.BulkProcessorProgress:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	}
+	rowResultWriter := sql.NewRowResultWriter(nil)
+	recv := sql.MakeDistSQLReceiver(
+		ctx,
+		sql.NewMetadataCallbackWriter(rowResultWriter, metaFn),
+		tree.Rows,
+		nil,   /* rangeCache */
+		noTxn, /* txn - the flow does not read or write the database */
+		nil,   /* clockUpdater */
+		execCtx.ExtendedEvalContext().Tracing,
+	)
+	defer recv.Release()
+
+	execCfg := execCtx.ExecCfg()
+	jobsprofiler.StorePlanDiagram(ctx, execCfg.DistSQLSrv.Stopper, p, execCfg.InternalDB, jobID)
+
+	// Copy the eval.Context, as dsp.Run() might change it.
+	evalCtxCopy := execCtx.ExtendedEvalContext().Context.Copy()
+	dsp.Run(ctx, planCtx, noTxn, p, recv, evalCtxCopy, nil /* finishedSetupFn */)
+	return errors.Wrap(rowResultWriter.Err(), "running distSQL flow")
+}
+
+// exportBackupDataProcessor writes the rows of the restore span entries of its
+// spec to files, and emits a progress update for every entry it completes.
+type exportBackupDataProcessor struct {
+	execinfra.ProcessorBase
+
+	spec execinfrapb.ExportBackupDataSpec
+
+	group         ctxgroup.Group
+	cancelAndWait func()
+
+	progCh chan jobspb.ExportBackupProgress
+}
+
+var (
+	_ execinfra.Processor = &exportBackupDataProcessor{}
+	_ execinfra.RowSource = &exportBackupDataProcessor{}
+)
+
+const exportBackupDataProcName = "exportBackupDataProcessor"
+
+func newExportBackupDataProcessor(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec execinfrapb.ExportBackupDataSpec,
+	post *execinfrapb.PostProcessSpec,
+) (execinfra.Processor, error) {
+	ep := &exportBackupDataProcessor{
+		spec:   spec,
+		progCh: make(chan jobspb.ExportBackupProgress),
+	}
+	if err := ep.Init(ctx, ep, post, exportBackupDataOutputTypes, flowCtx, processorID, nil, /* memMonitor */
+		execinfra.ProcStateOpts{
+			TrailingMetaCallback: func() []execinfrapb.ProducerMetadata {
+				ep.close()
+				return nil
+			},
+		}); err != nil {
+		return nil, err
+	}
+	return ep, nil
+}
+
+// Start is part of the RowSource interface.
+func (ep *exportBackupDataProcessor) Start(ctx context.Context) {
+	ctx = logtags.AddTag(ctx, "job", ep.spec.JobID)
+	ctx = ep.StartInternal(ctx, exportBackupDataProcName)
+	ctx, cancel := context.WithCancel(ctx)
+	ep.cancelAndWait = func() {
+		cancel()
+		_ = ep.group.Wait()
+	}
+	ep.group = ctxgroup.WithContext(ctx)
+	ep.group.GoCtx(func(ctx context.Context) error {
+		defer close(ep.progCh)
+		return errors.Wrap(ep.exportEntries(ctx), "exporting backup")
+	})
+}
+
+// Next is part of the RowSource interface.
+func (ep *exportBackupDataProcessor) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	if ep.State != execinfra.StateRunning {
+		return nil, ep.DrainHelper()
+	}
+
+	select {
+	case progDetails, ok := <-ep.progCh:
+		if !ok {
+			ep.MoveToDraining(ep.group.Wait())
+			return nil, ep.DrainHelper()
+		}
+		details, err := gogotypes.MarshalAny(&progDetails)
+		if err != nil {
+			ep.MoveToDraining(err)
+			return nil, ep.DrainHelper()
+		}
+		return nil, &execinfrapb.ProducerMetadata{
+			BulkProcessorProgress: &execinfrapb.RemoteProducerMetadata_BulkProcessorProgress{
+				ProgressDetails: *details,
+				NodeID:          ep.FlowCtx.NodeID.SQLInstanceID(),
+			},
+		}
+	case <-ep.Ctx().Done():
+		ep.MoveToDraining(ep.Ctx().Err())
+		return nil, ep.DrainHelper()
+	}
+}
+
+// ConsumerClosed is part of the RowSource interface.
+func (ep *exportBackupDataProcessor) ConsumerClosed() {
+	ep.close()
+}
+
+func (ep *exportBackupDataProcessor) close() {
+	if ep.Closed {
+		return
+	}
+	if ep.cancelAndWait != nil {
+		ep.cancelAndWait()
+	}
+	ep.InternalClose()
+}
+
+// exportEntries exports the rows of every entry of the spec.
+func (ep *exportBackupDataProcessor) exportEntries(ctx context.Context) error {
+	codec := keys.SystemSQLCodec
+	if ep.spec.BackupTenantID.IsSet() && !ep.spec.BackupTenantID.IsSystem() {
+		codec = keys.MakeSQLCodec(ep.spec.BackupTenantID)
+	}
+	tables, err := makeExportBackupTables(ctx, codec, ep.spec)
+	if err != nil {
+		return err
+	}
+	compression, err := importer.ParquetCompressionCodec(ep.spec.Compression)
+	if err != nil {
+		return err
+	}
+	dest, err := ep.FlowCtx.Cfg.ExternalStorageFromURI(ctx, ep.spec.Destination, ep.spec.User())
+	if err != nil {
+		return err
+	}
+	defer dest.Close()
+
+	var afterWrite func(ctx context.Context, name string) error
+	if knobs, ok := ep.FlowCtx.TestingKnobs().BackupRestoreTestingKnobs.(*sql.BackupRestoreTestingKnobs); ok {
+		afterWrite = knobs.RunAfterWritingExportBackupFile
+	}
+
+	for _, entry := range ep.spec.Entries {
+		w := &exportBackupFileWriter{
+			dest:        dest,
+			compression: compression,
+			suffix:      importer.ParquetCompressionSuffix(ep.spec.Compression),
+			chunkRows:   ep.spec.ChunkRows,
+			chunkSize:   ep.spec.ChunkSize,
+			prefix:      exportBackupFilePrefix(entry),
+			afterWrite:  afterWrite,
+		}
+		if err := ep.exportEntry(ctx, codec, tables, entry, w); err != nil {
+			return errors.Wrapf(err, "exporting backup span %s", entry.Span)
+		}
+		select {
+		case ep.progCh <- jobspb.ExportBackupProgress{
+			CompletedEntries: []int64{entry.ProgressIdx},
+			Rows:             w.rows,
+			Files:            w.files,
+		}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// exportBackupFilePrefix returns the prefix of the names of the files written
+// for the entry, which is its index in the restore span cover. Every execution
+// of the job builds the same cover and writes the same rows of an entry into
+// the same files, so an entry which is exported again after the job is resumed
+// overwrites the files of the previous attempt.
+func exportBackupFilePrefix(entry execinfrapb.RestoreSpanEntry) string {
+	return fmt.Sprintf("%08d", entry.ProgressIdx)
+}
+
+// exportEntry writes the latest revision, as of the end time of the export, of
+// every row of the exported tables in the span of the entry.
+func (ep *exportBackupDataProcessor) exportEntry(
+	ctx context.Context,
+	codec keys.SQLCodec,
+	tables map[descpb.ID]*exportBackupTable,
+	entry execinfrapb.RestoreSpanEntry,
+	w *exportBackupFileWriter,
+) error {
+	if len(entry.Files) == 0 {
+		return nil
+	}
+	storeFiles := make([]storageccl.StoreFile, 0, len(entry.Files))
+	defer func() {
+		for _, f := range storeFiles {
+			if err := f.Store.Close(); err != nil {
+				log.Warningf(ctx, "close export storage failed %v", err)
+			}
+		}
+	}()
+	for _, file := range entry.Files {
+		dir, err := ep.FlowCtx.Cfg.ExternalStorage(ctx, file.Dir)
+		if err != nil {
+			return err
+		}
+		storeFiles = append(storeFiles, storageccl.StoreFile{Store: dir, FilePath: file.Path})
+	}
+	iterOpts := storage.IterOptions{
+		RangeKeyMaskingBelow: ep.spec.EndTime,
+		KeyTypes:             storage.IterKeyTypePointsAndRanges,
+		LowerBound:           keys.LocalMax,
+		UpperBound:           keys.MaxKey,
+	}
+	sstIter, err := storageccl.ExternalSSTReader(ctx, storeFiles, ep.spec.Encryption, iterOpts)
+	if err != nil {
+		return err
+	}
+	iter := storage.NewReadAsOfIterator(sstIter, ep.spec.EndTime)
+	defer iter.Close()
+
+	elidedPrefix, err := backupsink.ElidedPrefix(entry.Span.Key, entry.ElidedPrefix)
+	if err != nil {
+		return err
+	}
+	startKey := storage.MVCCKey{Key: bytes.TrimPrefix(entry.Span.Key, elidedPrefix)}
+	endKey := storage.MVCCKey{Key: entry.Span.EndKey}
+
+	var keyScratch []byte
+	for iter.SeekGE(startKey); ; iter.NextKey() {
+		if ok, err := iter.Valid(); err != nil {
+			return err
+		} else if !ok {
+			break
+		}
+		key := iter.UnsafeKey()
+		keyScratch = append(append(keyScratch[:0], elidedPrefix...), key.Key...)
+		key.Key = keyScratch
+		if !key.Less(endKey) {
+			break
+		}
+		_, tableID, indexID, err := codec.DecodeIndexPrefix(key.Key)
+		if err != nil {
+			return err
+		}
+		table, ok := tables[descpb.ID(tableID)]
+		if !ok || descpb.IndexID(indexID) != table.indexID {
+			continue
+		}
+		v, err := iter.UnsafeValue()
+		if err != nil {
+			return err
+		}
+		value, err := storage.DecodeValueFromMVCCValue(v)
+		if err != nil {
+			return err
+		}
+		if err := w.add(ctx, table, key.Key, value); err != nil {
+			return err
+		}
+	}
+	return w.finish(ctx)
+}
+
+// exportBackupTable is a table exported by the processor, along with the
+// fetcher decoding its rows.
+type exportBackupTable struct {
+	path    string
+	indexID descpb.IndexID
+	schema  *parquet.SchemaDefinition
+
+	fetchSpec fetchpb.IndexFetchSpec
+	fetcher   row.Fetcher
+	alloc     tree.DatumAlloc
+}
+
+// makeExportBackupTables builds the exported tables of the spec, keyed by ID.
+// The user-defined types used by the tables are hydrated from the descriptors
+// in the spec.
+func makeExportBackupTables(
+	ctx context.Context, codec keys.SQLCodec, spec execinfrapb.ExportBackupDataSpec,
+) (map[descpb.ID]*exportBackupTable, error) {
+	var c nstree.MutableCatalog
+	for i := range spec.TypeDescs {
+		c.UpsertDescriptor(backupinfo.NewDescriptorForManifest(&spec.TypeDescs[i]))
+	}
+	for _, t := range spec.Tables {
+		c.UpsertDescriptor(tabledesc.NewBuilder(t.Desc).BuildImmutableTable())
+	}
+	if err := descs.HydrateCatalog(ctx, c); err != nil {
+		return nil, err
+	}
+
+	tables := make(map[descpb.ID]*exportBackupTable, len(spec.Tables))
+	for _, t := range spec.Tables {
+		desc, ok := c.LookupDescriptor(t.Desc.ID).(catalog.TableDescriptor)
+		if !ok {
+			return nil, errors.AssertionFailedf("table %d not found in exported descriptors", t.Desc.ID)
+		}
+		var colIDs []descpb.ColumnID
+		var colNames []string
+		var colTypes []*types.T
+		for _, col := range desc.VisibleColumns() {
+			// Virtual columns are not stored in the primary index.
+			if col.IsVirtual() {
+				continue
+			}
+			colIDs = append(colIDs, col.GetID())
+			colNames = append(colNames, col.GetName())
+			colTypes = append(colTypes, col.GetType())
+		}
+		schema, err := parquet.NewSchema(colNames, colTypes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "table %q", desc.GetName())
+		}
+		et := &exportBackupTable{
+			path:    t.Path,
+			indexID: desc.GetPrimaryIndexID(),
+			schema:  schema,
+		}
+		if err := rowenc.InitIndexFetchSpec(
+			&et.fetchSpec, codec, desc, desc.GetPrimaryIndex(), colIDs,
+		); err != nil {
+			return nil, err
+		}
+		if err := et.fetcher.Init(ctx, row.FetcherInitArgs{
+			WillUseKVProvider: true,
+			Alloc:             &et.alloc,
+			Spec:              &et.fetchSpec,
+		}); err != nil {
+			return nil, err
+		}
+		tables[desc.GetID()] = et
+	}
+	return tables, nil
+}
+
+// exportBackupFileWriter decodes the keys of the rows of an entry, which are
+// added in key order, and writes the rows to files.
+type exportBackupFileWriter struct {
+	dest        cloud.ExternalStorage
+	compression parquet.CompressionCodec
+	suffix      string
+	chunkRows   int64
+	chunkSize   int64
+	prefix      string
+	afterWrite  func(ctx context.Context, name string) error
+
+	// table is the table of the keys being added, kvs are the keys of the rows
+	// which have not been decoded yet and lastRowPrefix is the prefix of the
+	// keys of the last row in it.
+	table         *exportBackupTable
+	kvs           []roachpb.KeyValue
+	lastRowPrefix roachpb.Key
+	pendingRows   int
+
+	// writer writes the current file of the table into buf.
+	buf      bytes.Buffer
+	writer   *parquet.Writer
+	fileRows int64
+	chunk    int
+	datums   []tree.Datum
+
+	rows  int64
+	files int64
+}
+
+// add adds a key of a row of the table. The keys of a row must be added
+// consecutively.
+func (w *exportBackupFileWriter) add(
+	ctx context.Context, table *exportBackupTable, key roachpb.Key, value roachpb.Value,
+) error {
+	if table != w.table {
+		if err := w.finish(ctx); err != nil {
+			return err
+		}
+		w.table = table
+	}
+	rowPrefix, err := keys.EnsureSafeSplitKey(key)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(rowPrefix, w.lastRowPrefix) {
+		if w.pendingRows == exportBackupBatchRows {
+			if err := w.decodeRows(ctx); err != nil {
+				return err
+			}
+		}
+		w.lastRowPrefix = append(w.lastRowPrefix[:0], rowPrefix...)
+		w.pendingRows++
+	}
+	w.kvs = append(w.kvs, roachpb.KeyValue{
+		Key:   key.Clone(),
+		Value: roachpb.Value{RawBytes: append([]byte(nil), value.RawBytes...)},
+	})
+	return nil
+}
+
+// decodeRows decodes the pending rows and adds them to the current file,
+// writing it out whenever it is full.
+func (w *exportBackupFileWriter) decodeRows(ctx context.Context) error {
+	if len(w.kvs) == 0 {
+		return nil
+	}
+	if err := w.table.fetcher.ConsumeKVProvider(ctx, &row.KVProvider{KVs: w.kvs}); err != nil {
+		return err
+	}
+	for {
+		datums, err := w.table.fetcher.NextRowDecoded(ctx)
+		if err != nil {
+			return err
+		}
+		if datums == nil {
+			break
+		}
+		if w.writer == nil {
+			w.buf.Reset()
+			if w.writer, err = parquet.NewWriter(
+				w.table.schema, &w.buf, parquet.WithCompressionCodec(w.compression),
+			); err != nil {
+				return err
+			}
+		}
+		w.datums = w.datums[:0]
+		for _, d := range datums {
+			// Parquet encodes the wrapped datum of a DOidWrapper.
+			w.datums = append(w.datums, tree.UnwrapDOidWrapper(d))
+		}
+		if err := w.writer.AddRow(w.datums); err != nil {
+			return err
+		}
+		w.fileRows++
+		w.rows++
+		if w.fileRows >= w.chunkRows || int64(w.buf.Len()) >= w.chunkSize {
+			if err := w.writeFile(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	w.kvs = w.kvs[:0]
+	w.pendingRows = 0
+	return nil
+}
+
+// writeFile writes out the current file, if any.
+func (w *exportBackupFileWriter) writeFile(ctx context.Context) error {
+	if w.writer == nil {
+		return nil
+	}
+	if err := w.writer.Close(); err != nil {
+		return errors.Wrap(err, "failed to close parquet writer")
+	}
+	w.writer = nil
+	name := fmt.Sprintf("%s/%s.%d.parquet%s", w.table.path, w.prefix, w.chunk, w.suffix)
+	if err := cloud.WriteFile(ctx, w.dest, name, &w.buf); err != nil {
+		return err
+	}
+	if w.afterWrite != nil {
+		if err := w.afterWrite(ctx, name); err != nil {
+			return err
+		}
+	}
+	w.chunk++
+	w.files++
+	w.fileRows = 0
+	return nil
+}
+
+// finish decodes the pending rows of the current table and writes out its
+// last file.
+func (w *exportBackupFileWriter) finish(ctx context.Context) error {
+	if w.table == nil {
+		return nil
+	}
+	if err := w.decodeRows(ctx); err != nil {
+		return err
+	}
+	w.lastRowPrefix = w.lastRowPrefix[:0]
+	return w.writeFile(ctx)
+}
+
+func init() {
+	rowexec.NewExportBackupDataProcessor = newExportBackupDataProcessor
+}
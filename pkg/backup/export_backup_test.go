@@ -0,0 +1,122 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/jobutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/parquet"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExportBackupResume tests that an EXPORT BACKUP job which is paused while
+// it is writing the files of an entry and resumed after the restore span
+// settings changed writes every row exactly once.
+func TestExportBackupResume(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	defer jobs.TestingSetProgressThresholds()()
+
+	const numTables, rowsPerTable, chunkRows = 5, 100, 10
+	// The job is blocked while it writes the files of the third table.
+	const blockAfterFiles = 2*rowsPerTable/chunkRows + 5
+
+	var filesWritten atomic.Int64
+	var resumed atomic.Bool
+	blocked := make(chan struct{})
+	params := base.TestClusterArgs{}
+	params.ServerArgs.Knobs = base.TestingKnobs{
+		DistSQL: &execinfra.TestingKnobs{
+			BackupRestoreTestingKnobs: &sql.BackupRestoreTestingKnobs{
+				RunAfterWritingExportBackupFile: func(ctx context.Context, _ string) error {
+					if filesWritten.Add(1) != blockAfterFiles || resumed.Load() {
+						return nil
+					}
+					close(blocked)
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			},
+		},
+		JobsTestingKnobs: jobs.NewTestingKnobsWithShortIntervals(),
+	}
+	_, sqlDB, dir, cleanupFn := backupRestoreTestSetupWithParams(
+		t, singleNode, 0 /* numAccounts */, InitManualReplication, params,
+	)
+	defer cleanupFn()
+
+	sqlDB.Exec(t, `CREATE DATABASE d`)
+	for i := 1; i <= numTables; i++ {
+		sqlDB.Exec(t, fmt.Sprintf(`CREATE TABLE d.t%d (id INT PRIMARY KEY, v INT)`, i))
+		sqlDB.Exec(t, fmt.Sprintf(`INSERT INTO d.t%d SELECT g, g * 2 FROM generate_series(1, %d) AS g`, i, rowsPerTable))
+	}
+	sqlDB.Exec(t, `BACKUP DATABASE d INTO $1`, localFoo)
+
+	var jobID jobspb.JobID
+	sqlDB.QueryRow(t, fmt.Sprintf(
+		`EXPORT BACKUP FROM LATEST IN $1 INTO PARQUET 'nodelocal://1/export' WITH chunk_rows = %d, detached`,
+		chunkRows,
+	), localFoo).Scan(&jobID)
+	<-blocked
+
+	// Wait for the entries of the first tables to be recorded as completed.
+	testutils.SucceedsSoon(t, func() error {
+		prog := jobutils.GetJobProgress(t, sqlDB, jobID).GetExportBackup()
+		if len(prog.CompletedEntries) < 2 {
+			return errors.Newf("expected at least 2 completed entries, found %d", len(prog.CompletedEntries))
+		}
+		return nil
+	})
+	sqlDB.Exec(t, `PAUSE JOB $1`, jobID)
+	jobutils.WaitForJobToPause(t, sqlDB, jobID)
+
+	// Changing the restore span settings must not change the files the job
+	// writes for the entries it exports again.
+	sqlDB.Exec(t, `SET CLUSTER SETTING backup.restore_span.target_size = '1B'`)
+	resumed.Store(true)
+	sqlDB.Exec(t, `RESUME JOB $1`, jobID)
+	jobutils.WaitForJobToSucceed(t, sqlDB, jobID)
+
+	seen := make(map[string]struct{})
+	require.NoError(t, filepath.WalkDir(
+		filepath.Join(dir, "export"), func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".parquet") {
+				return err
+			}
+			_, datums, err := parquet.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			table := filepath.Base(filepath.Dir(path))
+			for _, row := range datums {
+				key := fmt.Sprintf("%s/%d", table, tree.MustBeDInt(row[0]))
+				if _, ok := seen[key]; ok {
+					return errors.Newf("row %s exported more than once", key)
+				}
+				seen[key] = struct{}{}
+			}
+			return nil
+		},
+	))
+	require.Len(t, seen, numTables*rowsPerTable)
+}
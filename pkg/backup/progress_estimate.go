@@ -0,0 +1,269 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobsprofiler"
+	"github.com/cockroachdb/cockroach/pkg/kv/bulk/bulkpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+	bulkutil "github.com/cockroachdb/cockroach/pkg/util/bulk"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/gogo/protobuf/proto"
+)
+
+var progressEstimateInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"bulkio.backup_restore.progress_estimate_interval",
+	"the interval at which BACKUP and RESTORE jobs persist an estimate of their remaining work",
+	10*time.Second,
+	settings.PositiveDuration,
+)
+
+// throughputHalfLife is the age at which a throughput sample contributes half
+// as much to a node's moving average as a fresh one.
+const throughputHalfLife = time.Minute
+
+var (
+	ingestionStatsName = proto.MessageName(&bulkpb.IngestionPerformanceStats{})
+	admissionStatsName = proto.MessageName(&admissionpb.AdmissionWorkQueueStats{})
+	exportStatsName    = proto.MessageName(&backuppb.ExportStats{})
+)
+
+// phaseTimings is the cumulative time the processors of a job spent in each of
+// its phases, as reported in their tracing aggregator stats.
+type phaseTimings struct {
+	// download is the time the restore's SST batchers spent waiting for keys
+	// to be read from the backup files.
+	download time.Duration
+	// ingestion is the time the restore's SST batchers spent flushing batches
+	// to KV, including any time spent in admission control.
+	ingestion time.Duration
+	// export is the time the backup spent sending ExportRequests and writing
+	// their responses, including any time spent in admission control.
+	export time.Duration
+	// admission is the time requests spent waiting in admission control.
+	admission time.Duration
+}
+
+func (t phaseTimings) sub(o phaseTimings) phaseTimings {
+	return phaseTimings{
+		download:  t.download - o.download,
+		ingestion: t.ingestion - o.ingestion,
+		export:    t.export - o.export,
+		admission: t.admission - o.admission,
+	}
+}
+
+// bottleneck returns the phase that accounts for most of the timings. The
+// time spent in admission control is attributed to admission control rather
+// than to the ingestion or export that it delayed.
+func (t phaseTimings) bottleneck() jobspb.BulkProgressEstimate_Bottleneck {
+	bottleneck, longest := jobspb.BulkProgressEstimate_UNKNOWN, time.Duration(0)
+	for _, phase := range []struct {
+		bottleneck jobspb.BulkProgressEstimate_Bottleneck
+		duration   time.Duration
+	}{
+		{jobspb.BulkProgressEstimate_DOWNLOAD, t.download},
+		{jobspb.BulkProgressEstimate_INGESTION, t.ingestion - t.admission},
+		{jobspb.BulkProgressEstimate_EXPORT, t.export - t.admission},
+		{jobspb.BulkProgressEstimate_ADMISSION_CONTROL, t.admission},
+	} {
+		if phase.duration > longest {
+			bottleneck, longest = phase.bottleneck, phase.duration
+		}
+	}
+	return bottleneck
+}
+
+// phaseTimingsFromAggregatorStats sums the phase timings across all the
+// components of a job's flows.
+func phaseTimingsFromAggregatorStats(
+	ctx context.Context, stats bulkutil.ComponentAggregatorStats,
+) phaseTimings {
+	var t phaseTimings
+	for _, events := range stats {
+		for name, data := range events {
+			var err error
+			switch name {
+			case ingestionStatsName:
+				var s bulkpb.IngestionPerformanceStats
+				if err = protoutil.Unmarshal(data, &s); err == nil {
+					t.download += s.FillWait
+					t.ingestion += s.BatchWait
+				}
+			case admissionStatsName:
+				var s admissionpb.AdmissionWorkQueueStats
+				if err = protoutil.Unmarshal(data, &s); err == nil {
+					t.admission += s.WaitDurationNanos
+				}
+			case exportStatsName:
+				var s backuppb.ExportStats
+				if err = protoutil.Unmarshal(data, &s); err == nil {
+					t.export += s.Duration
+				}
+			}
+			if err != nil {
+				log.Warningf(ctx, "failed to unmarshal aggregated event %s: %v", name, err)
+			}
+		}
+	}
+	return t
+}
+
+type nodeThroughput struct {
+	completedBytes int64
+	// pendingBytes are the bytes processed since the previous estimate.
+	pendingBytes   int64
+	bytesPerSecond float64
+	sampled        bool
+}
+
+// progressEstimator estimates the remaining work of a BACKUP or RESTORE from
+// the progress reported by its processors. It maintains an exponentially
+// weighted moving average of the throughput of each node, which is updated
+// every time an estimate is produced.
+type progressEstimator struct {
+	// totalBytes is the number of bytes the job expects to process, or zero if
+	// it is not known up front, in which case it is extrapolated from the
+	// fraction of totalSpans that have completed.
+	totalBytes int64
+	totalSpans int
+
+	mu struct {
+		syncutil.Mutex
+		nodes          map[base.SQLInstanceID]*nodeThroughput
+		completedSpans int
+		lastEstimate   time.Time
+		lastTimings    phaseTimings
+		bottleneck     jobspb.BulkProgressEstimate_Bottleneck
+	}
+}
+
+func newProgressEstimator(totalBytes int64, totalSpans int, now time.Time) *progressEstimator {
+	e := &progressEstimator{totalBytes: totalBytes, totalSpans: totalSpans}
+	e.mu.nodes = make(map[base.SQLInstanceID]*nodeThroughput)
+	e.mu.lastEstimate = now
+	return e
+}
+
+// record accounts for the bytes and spans that a processor on the given node
+// reports as completed.
+func (e *progressEstimator) record(instanceID base.SQLInstanceID, bytes int64, spans int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	n, ok := e.mu.nodes[instanceID]
+	if !ok {
+		n = &nodeThroughput{}
+		e.mu.nodes[instanceID] = n
+	}
+	n.completedBytes += bytes
+	n.pendingBytes += bytes
+	e.mu.completedSpans += spans
+}
+
+// estimate folds the bytes recorded since the previous estimate into the
+// moving averages and returns the resulting estimate. timings are the
+// cumulative phase timings of the job, which are used to determine its current
+// bottleneck.
+func (e *progressEstimator) estimate(
+	now time.Time, timings phaseTimings,
+) jobspb.BulkProgressEstimate {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	res := jobspb.BulkProgressEstimate{UpdatedAt: now}
+	if elapsed := now.Sub(e.mu.lastEstimate); elapsed > 0 {
+		// Weigh the new sample by how long it covers, so that the average decays
+		// at the same rate regardless of how often estimates are produced.
+		weight := 1 - math.Exp2(-elapsed.Seconds()/throughputHalfLife.Seconds())
+		for _, n := range e.mu.nodes {
+			rate := float64(n.pendingBytes) / elapsed.Seconds()
+			if n.sampled {
+				n.bytesPerSecond += weight * (rate - n.bytesPerSecond)
+			} else {
+				n.bytesPerSecond, n.sampled = rate, true
+			}
+			n.pendingBytes = 0
+		}
+		e.mu.lastEstimate = now
+	}
+
+	for id, n := range e.mu.nodes {
+		res.Nodes = append(res.Nodes, jobspb.BulkProgressEstimate_NodeThroughput{
+			SQLInstanceID:  id,
+			CompletedBytes: n.completedBytes,
+			BytesPerSecond: n.bytesPerSecond,
+		})
+		res.CompletedBytes += n.completedBytes
+		res.BytesPerSecond += n.bytesPerSecond
+	}
+	sort.Slice(res.Nodes, func(i, j int) bool {
+		return res.Nodes[i].SQLInstanceID < res.Nodes[j].SQLInstanceID
+	})
+
+	res.TotalBytes = e.totalBytes
+	if res.TotalBytes == 0 && e.mu.completedSpans > 0 {
+		res.TotalBytes = int64(float64(res.CompletedBytes) * float64(e.totalSpans) / float64(e.mu.completedSpans))
+	}
+	// The total is only an estimate, so it may be exceeded by the bytes the
+	// processors actually report.
+	res.TotalBytes = max(res.TotalBytes, res.CompletedBytes)
+	res.RemainingBytes = res.TotalBytes - res.CompletedBytes
+	if res.BytesPerSecond > 0 {
+		res.ETA = time.Duration(float64(res.RemainingBytes) / res.BytesPerSecond * float64(time.Second))
+	}
+
+	// Keep reporting the previous bottleneck if no processor reported stats
+	// since the previous estimate.
+	if b := timings.sub(e.mu.lastTimings).bottleneck(); b != jobspb.BulkProgressEstimate_UNKNOWN {
+		e.mu.bottleneck = b
+	}
+	e.mu.lastTimings = timings
+	res.Bottleneck = e.mu.bottleneck
+	return res
+}
+
+// runProgressEstimateLoop periodically persists an estimate of the job's
+// remaining work until doneCh is closed. timings returns the job's current
+// cumulative phase timings.
+func runProgressEstimateLoop(
+	ctx context.Context,
+	db isql.DB,
+	sv *settings.Values,
+	jobID jobspb.JobID,
+	e *progressEstimator,
+	timings func(ctx context.Context) phaseTimings,
+	doneCh <-chan struct{},
+) error {
+	var timer timeutil.Timer
+	defer timer.Stop()
+	for {
+		timer.Reset(progressEstimateInterval.Get(sv))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-doneCh:
+			return nil
+		case <-timer.C:
+			timer.Read = true
+			estimate := e.estimate(timeutil.Now(), timings(ctx))
+			jobsprofiler.StoreProgressEstimate(ctx, db, jobID, &estimate)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv/bulk/bulkpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+	bulkutil "github.com/cockroachdb/cockroach/pkg/util/bulk"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressEstimator(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	start := time.Unix(0, 0)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+
+	t.Run("known total", func(t *testing.T) {
+		e := newProgressEstimator(1000, 10, start)
+
+		// Nothing has been processed yet, so there is no ETA.
+		est := e.estimate(at(time.Second), phaseTimings{})
+		require.Equal(t, int64(1000), est.TotalBytes)
+		require.Equal(t, int64(1000), est.RemainingBytes)
+		require.Zero(t, est.ETA)
+		require.Equal(t, jobspb.BulkProgressEstimate_UNKNOWN, est.Bottleneck)
+
+		// Two nodes each process 100 bytes over 10 seconds.
+		e.record(1, 100, 0)
+		e.record(2, 100, 0)
+		est = e.estimate(at(11*time.Second), phaseTimings{})
+		require.Equal(t, int64(200), est.CompletedBytes)
+		require.Equal(t, int64(800), est.RemainingBytes)
+		require.InDelta(t, 20, est.BytesPerSecond, 0.001)
+		require.Equal(t, 40*time.Second, est.ETA)
+		require.Len(t, est.Nodes, 2)
+		require.EqualValues(t, 1, est.Nodes[0].SQLInstanceID)
+		require.InDelta(t, 10, est.Nodes[0].BytesPerSecond, 0.001)
+
+		// Node 2 stalls. Its moving average decays but does not drop to zero.
+		e.record(1, 100, 0)
+		est = e.estimate(at(21*time.Second), phaseTimings{})
+		require.InDelta(t, 10, est.Nodes[0].BytesPerSecond, 0.001)
+		require.Less(t, est.Nodes[1].BytesPerSecond, 10.0)
+		require.Greater(t, est.Nodes[1].BytesPerSecond, 0.0)
+
+		// The processors may report more bytes than were expected.
+		e.record(1, 1000, 0)
+		est = e.estimate(at(31*time.Second), phaseTimings{})
+		require.Equal(t, est.CompletedBytes, est.TotalBytes)
+		require.Zero(t, est.RemainingBytes)
+		require.Zero(t, est.ETA)
+	})
+
+	t.Run("extrapolated total", func(t *testing.T) {
+		e := newProgressEstimator(0 /* totalBytes */, 4, start)
+		est := e.estimate(at(time.Second), phaseTimings{})
+		require.Zero(t, est.TotalBytes)
+
+		e.record(1, 300, 1)
+		est = e.estimate(at(11*time.Second), phaseTimings{})
+		require.Equal(t, int64(1200), est.TotalBytes)
+		require.Equal(t, int64(900), est.RemainingBytes)
+		require.Equal(t, 30*time.Second, est.ETA)
+	})
+
+	t.Run("bottleneck", func(t *testing.T) {
+		e := newProgressEstimator(1000, 10, start)
+		timings := phaseTimings{download: 10 * time.Second, ingestion: 5 * time.Second}
+		require.Equal(t, jobspb.BulkProgressEstimate_DOWNLOAD, e.estimate(at(time.Second), timings).Bottleneck)
+
+		// Only the time spent since the previous estimate is considered.
+		timings.ingestion += 20 * time.Second
+		require.Equal(t, jobspb.BulkProgressEstimate_INGESTION, e.estimate(at(2*time.Second), timings).Bottleneck)
+
+		// Admission control waits are not attributed to ingestion.
+		timings.ingestion += 20 * time.Second
+		timings.admission += 15 * time.Second
+		require.Equal(t, jobspb.BulkProgressEstimate_ADMISSION_CONTROL, e.estimate(at(3*time.Second), timings).Bottleneck)
+
+		// Without new stats the previous bottleneck is retained.
+		require.Equal(t, jobspb.BulkProgressEstimate_ADMISSION_CONTROL, e.estimate(at(4*time.Second), timings).Bottleneck)
+	})
+}
+
+func TestPhaseTimingsFromAggregatorStats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	marshal := func(msg protoutil.Message) []byte {
+		data, err := protoutil.Marshal(msg)
+		require.NoError(t, err)
+		return data
+	}
+	stats := bulkutil.ComponentAggregatorStats{
+		execinfrapb.ComponentID{SQLInstanceID: 1}: {
+			ingestionStatsName: marshal(&bulkpb.IngestionPerformanceStats{FillWait: time.Second, BatchWait: 2 * time.Second}),
+			admissionStatsName: marshal(&admissionpb.AdmissionWorkQueueStats{WaitDurationNanos: time.Second}),
+		},
+		execinfrapb.ComponentID{SQLInstanceID: 2}: {
+			ingestionStatsName: marshal(&bulkpb.IngestionPerformanceStats{FillWait: 3 * time.Second}),
+			exportStatsName:    marshal(&backuppb.ExportStats{Duration: 4 * time.Second}),
+			"unrelated":        []byte("ignored"),
+		},
+	}
+	require.Equal(t, phaseTimings{
+		download:  4 * time.Second,
+		ingestion: 2 * time.Second,
+		export:    4 * time.Second,
+		admission: time.Second,
+	}, phaseTimingsFromAggregatorStats(context.Background(), stats))
+}
@@ -37,6 +37,8 @@ type restorationData interface {
 	getRekeys() []execinfrapb.TableRekey
 	getTenantRekeys() []execinfrapb.TenantRekey
 	getPKIDs() map[uint64]bool
+	getRowFilter() *execinfrapb.RestoreRowFilter
+	getMerge() *execinfrapb.RestoreMergeSpec
 
 	// isValidateOnly returns ture iff only validation should occur
 	isValidateOnly() bool
@@ -84,6 +86,13 @@ type restorationDataBase struct {
 
 	// validateOnly indicates this data should only get read from external storage, not written
 	validateOnly bool
+
+	// rowFilter, if set, restricts the rows of a table that are restored.
+	rowFilter *execinfrapb.RestoreRowFilter
+
+	// merge, if set, writes the restored rows into an existing table instead of
+	// ingesting them.
+	merge *execinfrapb.RestoreMergeSpec
 }
 
 // restorationDataBase implements restorationData.
@@ -104,6 +113,16 @@ func (b *restorationDataBase) getPKIDs() map[uint64]bool {
 	return b.pkIDs
 }
 
+// getRowFilter implements restorationData.
+func (b *restorationDataBase) getRowFilter() *execinfrapb.RestoreRowFilter {
+	return b.rowFilter
+}
+
+// getMerge implements restorationData.
+func (b *restorationDataBase) getMerge() *execinfrapb.RestoreMergeSpec {
+	return b.merge
+}
+
 // getSpans implements restorationData.
 func (b *restorationDataBase) getSpans() []roachpb.Span {
 	return b.spans
@@ -407,6 +407,24 @@ func (rd *restoreDataProcessor) runRestoreWorkers(
 			return errors.Wrap(err, "creating key rewriter from rekeys")
 		}
 
+		var rowFilter *restoreRowFilter
+		if rd.spec.RowFilter != nil {
+			rowFilter, err = makeRestoreRowFilter(ctx, rd.FlowCtx.Codec(), rd.spec.RowFilter,
+				rd.spec.TableRekeys, rd.FlowCtx.NewEvalCtx())
+			if err != nil {
+				return errors.Wrap(err, "creating row filter")
+			}
+		}
+
+		var merger *restoreMerger
+		if rd.spec.Merge != nil {
+			merger, err = makeRestoreMerger(ctx, rd.FlowCtx.Codec(), rd.spec.Merge,
+				rd.spec.TableRekeys, rd.FlowCtx.Cfg.DB)
+			if err != nil {
+				return errors.Wrap(err, "creating restore merger")
+			}
+		}
+
 		var sstIter mergedSST
 		for {
 			done, err := func() (done bool, _ error) {
@@ -429,7 +447,7 @@ func (rd *restoreDataProcessor) runRestoreWorkers(
 						return done, errors.Wrap(err, "opening SSTs")
 					}
 
-					summary, err := rd.processRestoreSpanEntry(ctx, kr, sstIter)
+					summary, err := rd.processRestoreSpanEntry(ctx, kr, rowFilter, merger, sstIter)
 					if err != nil {
 						return done, errors.Wrap(err, "processing restore span entry")
 					}
@@ -457,7 +475,11 @@ func (rd *restoreDataProcessor) runRestoreWorkers(
 }
 
 func (rd *restoreDataProcessor) processRestoreSpanEntry(
-	ctx context.Context, kr *KeyRewriter, sst mergedSST,
+	ctx context.Context,
+	kr *KeyRewriter,
+	rowFilter *restoreRowFilter,
+	merger *restoreMerger,
+	sst mergedSST,
 ) (kvpb.BulkOpSummary, error) {
 	db := rd.FlowCtx.Cfg.DB
 	var summary kvpb.BulkOpSummary
@@ -472,7 +494,8 @@ func (rd *restoreDataProcessor) processRestoreSpanEntry(
 	}
 
 	var batcher SSTBatcherExecutor
-	if rd.spec.ValidateOnly {
+	if rd.spec.ValidateOnly || merger != nil {
+		// A merge restore writes the restored rows through the merger instead.
 		batcher = &sstBatcherNoop{}
 	} else {
 		writeAtBatchTS := writeAtBatchTS(ctx, entry.Span, kr.fromSystemTenant)
@@ -586,6 +609,13 @@ func (rd *restoreDataProcessor) processRestoreSpanEntry(
 			}
 			continue
 		}
+		if rowFilter != nil {
+			if ok, err := rowFilter.matches(ctx, key.Key); err != nil {
+				return summary, err
+			} else if !ok {
+				continue
+			}
+		}
 
 		// Rewriting the key means the checksum needs to be updated.
 		value.ClearChecksum()
@@ -595,6 +625,13 @@ func (rd *restoreDataProcessor) processRestoreSpanEntry(
 			log.Infof(ctx, "Put %s -> %s", key.Key, value.PrettyPrint())
 		}
 
+		if merger != nil {
+			if err := merger.add(ctx, key.Key, value); err != nil {
+				return summary, errors.Wrapf(err, "merging: %s -> %s", key, value.PrettyPrint())
+			}
+			continue
+		}
+
 		// Using valueScratch here assumes that
 		// DecodeValueFromMVCCValue, ClearChecksum, and
 		// InitChecksum don't copy/reallocate the slice they
@@ -618,6 +655,9 @@ func (rd *restoreDataProcessor) processRestoreSpanEntry(
 		}
 	}
 
+	if merger != nil {
+		return merger.finish(ctx)
+	}
 	return batcher.GetSummary(), nil
 }
 
@@ -661,6 +701,7 @@ func (rd *restoreDataProcessor) Next() (rowenc.EncDatumRow, *execinfrapb.Produce
 			return nil, rd.DrainHelper()
 		}
 		prog.ProgressDetails = *details
+		prog.NodeID = rd.FlowCtx.NodeID.SQLInstanceID()
 		rd.progressMade = true
 		return nil, &execinfrapb.ProducerMetadata{BulkProcessorProgress: &prog}
 	case <-rd.aggTimer.C:
@@ -365,17 +365,7 @@ func restore(
 		return roachpb.RowCount{}, err
 	}
 
-	// If any layer of the backup was produced with revision history before 24.1,
-	// we need to assume inclusive end-keys. If no layers used revision history or
-	// those that did were produced with #118990 in 24.1+, we can assume exclusive
-	// end-keys.
-	var fsc fileSpanComparator = &exclusiveEndKeyComparator{}
-	for _, i := range backupManifests {
-		if i.ClusterVersion.Less(clusterversion.V24_1.Version()) && i.MVCCFilter == backuppb.MVCCFilter_All {
-			fsc = &inclusiveEndKeyComparator{}
-			break
-		}
-	}
+	fsc := fileSpanComparatorForBackups(backupManifests)
 
 	countSpansCh := make(chan execinfrapb.RestoreSpanEntry, 1000)
 	genSpan := func(ctx context.Context, spanCh chan execinfrapb.RestoreSpanEntry) error {
@@ -392,12 +382,26 @@ func restore(
 		), "generate and send import spans")
 	}
 
-	// Count number of import spans.
+	// Count number of import spans, and the number of bytes they cover to
+	// estimate the restore's remaining work. A file that straddles several
+	// import spans is included in each of them, but since the spans are
+	// generated in key order it only needs to be counted the first time it is
+	// seen.
 	var numImportSpans int
+	var importSpanBytes int64
 	var countTasks []func(ctx context.Context) error
 	spanCountTask := func(ctx context.Context) error {
-		for range countSpansCh {
+		var prevFiles map[string]struct{}
+		for entry := range countSpansCh {
 			numImportSpans++
+			files := make(map[string]struct{}, len(entry.Files))
+			for _, f := range entry.Files {
+				files[f.Path] = struct{}{}
+				if _, ok := prevFiles[f.Path]; !ok {
+					importSpanBytes += f.BackupFileEntryCounts.DataSize
+				}
+			}
+			prevFiles = files
 		}
 		return nil
 	}
@@ -434,9 +438,27 @@ func restore(
 
 	progCh := make(chan *execinfrapb.RemoteProducerMetadata_BulkProcessorProgress)
 	if !details.ExperimentalOnline {
+		// progressDoneCh is closed once all the progress from the processors has
+		// been ingested.
+		progressDoneCh := make(chan struct{})
+		if dataToRestore.isMainBundle() {
+			progressTracker.estimator = newProgressEstimator(importSpanBytes, numImportSpans, timeutil.Now())
+			progressEstimateLoop := func(ctx context.Context) error {
+				timings := func(ctx context.Context) phaseTimings {
+					resumer.mu.Lock()
+					defer resumer.mu.Unlock()
+					return phaseTimingsFromAggregatorStats(ctx, resumer.mu.perNodeAggregatorStats)
+				}
+				return runProgressEstimateLoop(ctx, execCtx.ExecCfg().InternalDB,
+					&execCtx.ExecCfg().Settings.SV, job.ID(), progressTracker.estimator, timings, progressDoneCh)
+			}
+			tasks = append(tasks, progressEstimateLoop)
+		}
+
 		// Online restore tracks progress by pinging requestFinishedCh instead
 		generativeCheckpointLoop := func(ctx context.Context) error {
 			defer close(requestFinishedCh)
+			defer close(progressDoneCh)
 			for progress := range progCh {
 				if spanDone, err := progressTracker.ingestUpdate(ctx, progress); err != nil {
 					return err
@@ -1008,6 +1030,11 @@ func createImportingDescriptors(
 		switch desc := desc.(type) {
 		case catalog.TableDescriptor:
 			mut := tabledesc.NewBuilder(desc.TableDesc()).BuildCreatedMutableTable()
+			if details.RowFilter != "" && mut.GetID() == details.RowFilterTableID {
+				if err := prepareRowFilteredTable(ctx, mut); err != nil {
+					return nil, nil, nil, err
+				}
+			}
 			if shouldPreRestore(mut) {
 				preRestoreTables = append(preRestoreTables, mut)
 			} else {
@@ -1056,6 +1083,21 @@ func createImportingDescriptors(
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	var rowFilter *execinfrapb.RestoreRowFilter
+	if details.RowFilter != "" {
+		for _, table := range postRestoreTables {
+			if table.GetID() != details.RowFilterTableID {
+				continue
+			}
+			postRestoreSpans, err = restrictSpansToRowFilter(
+				ctx, backupCodec, postRestoreSpans, table, details.RowFilter, &p.ExtendedEvalContext().Context,
+			)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			rowFilter = &execinfrapb.RestoreRowFilter{TableID: table.GetID(), Expr: details.RowFilter}
+		}
+	}
 	var verifySpans []roachpb.Span
 	if details.VerifyData {
 		// verifySpans contains the spans that should be read and checksum'd during a
@@ -1582,6 +1624,7 @@ func createImportingDescriptors(
 			tableRekeys:  rekeys,
 			tenantRekeys: tenantRekeys,
 			pkIDs:        pkIDs,
+			rowFilter:    rowFilter,
 		},
 	}
 
@@ -1775,6 +1818,9 @@ func (r *restoreResumer) doResume(ctx context.Context, execCtx interface{}) erro
 	if err := p.ExecCfg().JobRegistry.CheckPausepoint("restore.before_load_descriptors_from_backup"); err != nil {
 		return err
 	}
+	if details.MergeInto != nil {
+		return r.doMergeResume(ctx, p, details)
+	}
 
 	kmsEnv := backupencryption.MakeBackupKMSEnv(
 		p.ExecCfg().Settings,
@@ -2028,6 +2074,19 @@ func (r *restoreResumer) doResume(ctx context.Context, execCtx interface{}) erro
 		}
 		details = r.job.Details().(jobspb.RestoreDetails)
 
+		if err := r.cleanupTempSystemTables(ctx); err != nil {
+			return err
+		}
+	} else if details.DescriptorCoverage == tree.SystemConfig {
+		// The zones table is part of the pre-data bundle, and is restored along
+		// with the other system tables of the configuration.
+		systemTables := append(slices.Clip(preData.systemTables), mainData.systemTables...)
+		if err := r.restoreSystemConfig(
+			ctx, p.ExecCfg().InternalDB, systemTables, details.SystemConfig,
+		); err != nil {
+			return err
+		}
+
 		if err := r.cleanupTempSystemTables(ctx); err != nil {
 			return err
 		}
@@ -2209,7 +2268,8 @@ func (r *restoreResumer) notifyStatsRefresherOfNewTables() {
 func tempSystemDatabaseID(
 	details jobspb.RestoreDetails, tables []catalog.TableDescriptor,
 ) descpb.ID {
-	if details.DescriptorCoverage != tree.AllDescriptors && !isSystemUserRestore(details) {
+	if details.DescriptorCoverage != tree.AllDescriptors && !isSystemUserRestore(details) &&
+		details.DescriptorCoverage != tree.SystemConfig {
 		return descpb.InvalidID
 	}
 
@@ -0,0 +1,525 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupencryption"
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logutil"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+)
+
+// A RESTORE TABLE with the into_table option restores the rows of the table
+// into an existing table instead of creating a new table, e.g. to rewind some
+// rows of a live table to their backed up values:
+//
+//   - no descriptors are created; the keys of the restored table are rewritten
+//     to the ID of the existing table, and decoded into rows by the restore data
+//     processor using the backed up descriptor;
+//   - the rows are written to the existing table with INSERT ... ON CONFLICT
+//     statements run as the user running the restore. They are regular
+//     transactional writes at the current time, which maintain the indexes of
+//     the table, including those being added by an in-flight schema change, and
+//     check its constraints and foreign keys;
+//   - rows whose primary key already exists in the table are either overwritten
+//     or skipped, depending on the on_conflict option.
+//
+// The rows of the table which do not exist in the backup are left untouched.
+// If the restore fails or is canceled, the rows which were already written
+// remain.
+
+const (
+	restoreOnConflictOverwrite = "overwrite"
+	restoreOnConflictSkip      = "skip"
+)
+
+// restoreMergeBatchSize is the number of rows written by each statement of a
+// merge restore.
+const restoreMergeBatchSize = 128
+
+// planMergeRestore resolves the existing table named by the into_table option
+// and checks that the rows of the restored table can be written to it.
+func planMergeRestore(
+	ctx context.Context,
+	p sql.PlanHookState,
+	exprEval *exprutil.Evaluator,
+	opts tree.RestoreOptions,
+	tablesByID map[descpb.ID]*tabledesc.Mutable,
+) (*jobspb.RestoreDetails_MergeInto, error) {
+	if len(tablesByID) != 1 {
+		return nil, errors.Errorf("%q option can only be used when restoring a single table", restoreOptIntoTable)
+	}
+	var backupTable *tabledesc.Mutable
+	for _, table := range tablesByID {
+		backupTable = table
+	}
+
+	skipConflicts := false
+	if opts.OnConflict != nil {
+		var err error
+		skipConflicts, err = evalRestoreOnConflict(ctx, exprEval, opts.OnConflict)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	name, err := exprEval.String(ctx, opts.IntoTable)
+	if err != nil {
+		return nil, err
+	}
+	tn, err := parser.ParseQualifiedTableName(name)
+	if err != nil {
+		return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue, "parsing %q option", restoreOptIntoTable)
+	}
+	_, target, err := p.ResolveMutableTableDescriptor(ctx, tn, true /* required */, tree.ResolveRequireTableDesc)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, target, privilege.INSERT); err != nil {
+		return nil, err
+	}
+	if !skipConflicts {
+		if err := p.CheckPrivilege(ctx, target, privilege.UPDATE); err != nil {
+			return nil, err
+		}
+	}
+
+	columnNames, err := mergeRestoreColumns(backupTable, target)
+	if err != nil {
+		return nil, err
+	}
+	return &jobspb.RestoreDetails_MergeInto{
+		TableID:         target.GetID(),
+		BackupTableDesc: backupTable.TableDesc(),
+		ColumnNames:     columnNames,
+		SkipConflicts:   skipConflicts,
+	}, nil
+}
+
+// evalRestoreOnConflict evaluates the on_conflict option, and returns whether
+// the restored rows which conflict with existing rows are skipped rather than
+// overwritten.
+func evalRestoreOnConflict(
+	ctx context.Context, exprEval *exprutil.Evaluator, expr tree.Expr,
+) (bool, error) {
+	onConflict, err := exprEval.String(ctx, expr)
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(onConflict) {
+	case restoreOnConflictOverwrite:
+		return false, nil
+	case restoreOnConflictSkip:
+		return true, nil
+	default:
+		return false, pgerror.Newf(pgcode.InvalidParameterValue,
+			"invalid value for %q option: %q, expected %q or %q",
+			restoreOptOnConflict, onConflict, restoreOnConflictOverwrite, restoreOnConflictSkip)
+	}
+}
+
+// mergeRestoreColumns returns the names of the columns of the backed up table
+// which are written to the existing table. Every such column must exist in the
+// existing table with an equivalent type, and both tables must have the same
+// primary key.
+func mergeRestoreColumns(backupTable, target catalog.TableDescriptor) ([]string, error) {
+	if !target.IsTable() || target.IsVirtualTable() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a table", target.GetName())
+	}
+
+	var columnNames []string
+	for _, col := range backupTable.PublicColumns() {
+		// Computed columns are computed again by the existing table.
+		if col.IsComputed() || col.IsInaccessible() {
+			continue
+		}
+		targetCol := catalog.FindColumnByName(target, col.GetName())
+		if targetCol == nil || !targetCol.Public() {
+			return nil, pgerror.Newf(pgcode.UndefinedColumn,
+				"column %q of the backed up table does not exist in table %q", col.GetName(), target.GetName())
+		}
+		if targetCol.IsComputed() {
+			return nil, pgerror.Newf(pgcode.InvalidColumnDefinition,
+				"column %q of table %q is computed", col.GetName(), target.GetName())
+		}
+		if col.GetType().UserDefined() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot restore column %q of user-defined type %s into an existing table",
+				col.GetName(), col.GetType().SQLString())
+		}
+		if !col.GetType().Equivalent(targetCol.GetType()) {
+			return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+				"column %q has type %s in the backup but type %s in table %q",
+				col.GetName(), col.GetType().SQLString(), targetCol.GetType().SQLString(), target.GetName())
+		}
+		columnNames = append(columnNames, col.GetName())
+	}
+
+	backupPrimary, targetPrimary := backupTable.GetPrimaryIndex(), target.GetPrimaryIndex()
+	samePrimaryKey := backupPrimary.NumKeyColumns() == targetPrimary.NumKeyColumns()
+	for i := 0; samePrimaryKey && i < backupPrimary.NumKeyColumns(); i++ {
+		samePrimaryKey = backupPrimary.GetKeyColumnName(i) == targetPrimary.GetKeyColumnName(i)
+	}
+	if !samePrimaryKey {
+		return nil, pgerror.Newf(pgcode.InvalidTableDefinition,
+			"primary key of table %q does not match the primary key of the backed up table", target.GetName())
+	}
+	return columnNames, nil
+}
+
+// doMergeResume restores the rows of a table of the backup into an existing
+// table.
+func (r *restoreResumer) doMergeResume(
+	ctx context.Context, p sql.JobExecContext, details jobspb.RestoreDetails,
+) error {
+	kmsEnv := backupencryption.MakeBackupKMSEnv(
+		p.ExecCfg().Settings,
+		&p.ExecCfg().ExternalIODirConfig,
+		p.ExecCfg().InternalDB,
+		p.User(),
+	)
+	mem := p.ExecCfg().RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+	backupManifests, _, _, _, err := loadBackupSQLDescs(
+		ctx, &mem, p, details, details.Encryption, &kmsEnv,
+	)
+	if err != nil {
+		return err
+	}
+	if err := r.validateJobIsResumable(ctx, p.ExecCfg(), backupManifests); err != nil {
+		return err
+	}
+	backupCodec, err := backupinfo.MakeBackupCodec(backupManifests)
+	if err != nil {
+		return err
+	}
+	data, err := makeMergeRestorationData(ctx, p, backupCodec, details)
+	if err != nil {
+		return err
+	}
+	if err := p.ExecCfg().JobRegistry.CheckPausepoint("restore.before_flow"); err != nil {
+		return err
+	}
+
+	res, err := restoreWithRetry(
+		ctx,
+		p,
+		backupManifests,
+		details.BackupLocalityInfo,
+		details.EndTime,
+		data,
+		r,
+		details.Encryption,
+		&kmsEnv,
+	)
+	if err != nil {
+		return err
+	}
+
+	r.restoreStats = res
+	emitRestoreJobEvent(ctx, p, jobs.StateSucceeded, r.job)
+	logutil.LogJobCompletion(ctx, restoreJobEventType, r.job.ID(), true, nil, res.Rows)
+	return nil
+}
+
+// makeMergeRestorationData returns the data bundle of a merge restore. The
+// keys of the restored table are rewritten to the ID of the existing table.
+func makeMergeRestorationData(
+	ctx context.Context, p sql.JobExecContext, backupCodec keys.SQLCodec, details jobspb.RestoreDetails,
+) (*mainRestorationData, error) {
+	merge := details.MergeInto
+	backupTable := tabledesc.NewBuilder(merge.BackupTableDesc).BuildImmutableTable()
+
+	spans := roachpb.Spans{backupTable.PrimaryIndexSpan(backupCodec)}
+	var rowFilter *execinfrapb.RestoreRowFilter
+	if details.RowFilter != "" {
+		var err error
+		spans, err = restrictSpansToRowFilter(
+			ctx, backupCodec, spans, backupTable, details.RowFilter, &p.ExtendedEvalContext().Context,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rowFilter = &execinfrapb.RestoreRowFilter{TableID: backupTable.GetID(), Expr: details.RowFilter}
+	}
+
+	rekeyed := tabledesc.NewBuilder(merge.BackupTableDesc).BuildCreatedMutableTable()
+	rekeyed.ID = merge.TableID
+	newDescBytes, err := protoutil.Marshal(rekeyed.DescriptorProto())
+	if err != nil {
+		return nil, errors.NewAssertionErrorWithWrappedErrf(err, "marshaling descriptor")
+	}
+
+	_, backupTenantID, err := keys.DecodeTenantPrefix(backupCodec.TenantPrefix())
+	if err != nil {
+		return nil, err
+	}
+	var tenantRekeys []execinfrapb.TenantRekey
+	if backupTenantID == roachpb.SystemTenantID {
+		tenantRekeys = append(tenantRekeys, isBackupFromSystemTenantRekey)
+	}
+
+	return &mainRestorationData{
+		restorationDataBase{
+			spans: spans,
+			tableRekeys: []execinfrapb.TableRekey{{
+				OldID:   uint32(backupTable.GetID()),
+				NewDesc: newDescBytes,
+			}},
+			tenantRekeys: tenantRekeys,
+			pkIDs: map[uint64]bool{
+				kvpb.BulkOpSummaryID(uint64(merge.TableID), uint64(backupTable.GetPrimaryIndexID())): true,
+			},
+			rowFilter: rowFilter,
+			merge: &execinfrapb.RestoreMergeSpec{
+				TableID:       merge.TableID,
+				ColumnNames:   merge.ColumnNames,
+				SkipConflicts: merge.SkipConflicts,
+				UserProto:     p.User().EncodeProto(),
+			},
+		},
+	}, nil
+}
+
+// restoreMerger writes the rows restored by the restore data processor into an
+// existing table.
+type restoreMerger struct {
+	db      isql.DB
+	user    username.SQLUsername
+	tableID descpb.ID
+	indexID descpb.IndexID
+
+	fetcher row.Fetcher
+	alloc   tree.DatumAlloc
+
+	// kvs are the restored keys of the rows of the current batch, and
+	// lastRowPrefix is the prefix of the keys of the last row in it.
+	kvs           []roachpb.KeyValue
+	lastRowPrefix roachpb.Key
+	pendingRows   int
+
+	columnNames   []string
+	pkNames       []string
+	skipConflicts bool
+	args          []interface{}
+
+	summary kvpb.BulkOpSummary
+}
+
+// makeRestoreMerger makes a merger for the restore data processor. The table
+// the rows are decoded with is found in the table rekeys of the processor.
+func makeRestoreMerger(
+	ctx context.Context,
+	codec keys.SQLCodec,
+	spec *execinfrapb.RestoreMergeSpec,
+	tableRekeys []execinfrapb.TableRekey,
+	db isql.DB,
+) (*restoreMerger, error) {
+	var table catalog.TableDescriptor
+	for _, rekey := range tableRekeys {
+		var desc descpb.Descriptor
+		if err := protoutil.Unmarshal(rekey.NewDesc, &desc); err != nil {
+			return nil, errors.Wrapf(err, "unmarshalling rekey descriptor for old table id %d", rekey.OldID)
+		}
+		tableDesc, _, _, _, _ := descpb.GetDescriptors(&desc)
+		if tableDesc != nil && tableDesc.ID == spec.TableID {
+			table = tabledesc.NewBuilder(tableDesc).BuildImmutableTable()
+		}
+	}
+	if table == nil {
+		return nil, errors.AssertionFailedf("no rekey for merged table %d", spec.TableID)
+	}
+
+	m := &restoreMerger{
+		db:            db,
+		user:          spec.UserProto.Decode(),
+		tableID:       spec.TableID,
+		columnNames:   spec.ColumnNames,
+		skipConflicts: spec.SkipConflicts,
+	}
+	colIDs := make([]descpb.ColumnID, len(spec.ColumnNames))
+	for i, name := range spec.ColumnNames {
+		col, err := catalog.MustFindColumnByName(table, name)
+		if err != nil {
+			return nil, err
+		}
+		colIDs[i] = col.GetID()
+	}
+	var fetchSpec fetchpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(
+		&fetchSpec, codec, table, table.GetPrimaryIndex(), colIDs,
+	); err != nil {
+		return nil, err
+	}
+	if err := m.fetcher.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &m.alloc,
+		Spec:              &fetchSpec,
+	}); err != nil {
+		return nil, err
+	}
+
+	primary := table.GetPrimaryIndex()
+	m.indexID = primary.GetID()
+	m.pkNames = make([]string, primary.NumKeyColumns())
+	for i := range m.pkNames {
+		m.pkNames[i] = primary.GetKeyColumnName(i)
+	}
+	return m, nil
+}
+
+// mergeRestoreStmt returns the statement writing the given number of rows to
+// the table.
+func mergeRestoreStmt(
+	tableID descpb.ID, columnNames []string, pkNames []string, skipConflicts bool, numRows int,
+) string {
+	var b strings.Builder
+	quoted := make([]string, len(columnNames))
+	for i, name := range columnNames {
+		quoted[i] = tree.NameString(name)
+	}
+	fmt.Fprintf(&b, "INSERT INTO [%d AS t] (%s) VALUES ", tableID, strings.Join(quoted, ", "))
+	for i := 0; i < numRows; i++ {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("(")
+		for j := range columnNames {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "$%d", i*len(columnNames)+j+1)
+		}
+		b.WriteString(")")
+	}
+
+	isPK := make(map[string]bool, len(pkNames))
+	quotedPK := make([]string, len(pkNames))
+	for i, name := range pkNames {
+		isPK[name] = true
+		quotedPK[i] = tree.NameString(name)
+	}
+	var set []string
+	for i, name := range columnNames {
+		if !isPK[name] {
+			set = append(set, fmt.Sprintf("%s = excluded.%s", quoted[i], quoted[i]))
+		}
+	}
+	if skipConflicts || len(set) == 0 {
+		b.WriteString(" ON CONFLICT DO NOTHING")
+	} else {
+		fmt.Fprintf(&b, " ON CONFLICT (%s) DO UPDATE SET %s",
+			strings.Join(quotedPK, ", "), strings.Join(set, ", "))
+	}
+	return b.String()
+}
+
+// add adds a restored key of the table to the current batch, writing the
+// batch first if it is full. The keys of a row must be added consecutively.
+func (m *restoreMerger) add(ctx context.Context, key roachpb.Key, value roachpb.Value) error {
+	rowPrefix, err := keys.EnsureSafeSplitKey(key)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(rowPrefix, m.lastRowPrefix) {
+		if m.pendingRows == restoreMergeBatchSize {
+			if err := m.flush(ctx); err != nil {
+				return err
+			}
+		}
+		m.lastRowPrefix = append(m.lastRowPrefix[:0], rowPrefix...)
+		m.pendingRows++
+	}
+	m.kvs = append(m.kvs, roachpb.KeyValue{
+		Key:   key.Clone(),
+		Value: roachpb.Value{RawBytes: append([]byte(nil), value.RawBytes...)},
+	})
+	m.summary.DataSize += int64(len(key) + len(value.RawBytes))
+	return nil
+}
+
+// flush writes the rows of the current batch to the table.
+func (m *restoreMerger) flush(ctx context.Context) error {
+	if len(m.kvs) == 0 {
+		return nil
+	}
+	if err := m.fetcher.ConsumeKVProvider(ctx, &row.KVProvider{KVs: m.kvs}); err != nil {
+		return err
+	}
+	m.args = m.args[:0]
+	numRows := 0
+	for {
+		datums, err := m.fetcher.NextRowDecoded(ctx)
+		if err != nil {
+			return err
+		}
+		if datums == nil {
+			break
+		}
+		for _, d := range datums {
+			m.args = append(m.args, d)
+		}
+		numRows++
+	}
+
+	if numRows > 0 {
+		stmt := mergeRestoreStmt(m.tableID, m.columnNames, m.pkNames, m.skipConflicts, numRows)
+		if _, err := m.db.Executor().ExecEx(ctx, "restore-merge", nil, /* txn */
+			sessiondata.InternalExecutorOverride{User: m.user},
+			stmt, m.args...,
+		); err != nil {
+			return errors.Wrapf(err, "writing restored rows to table %d", m.tableID)
+		}
+	}
+	if m.summary.EntryCounts == nil {
+		m.summary.EntryCounts = make(map[uint64]int64)
+	}
+	m.summary.EntryCounts[kvpb.BulkOpSummaryID(uint64(m.tableID), uint64(m.indexID))] += int64(numRows)
+
+	m.kvs = m.kvs[:0]
+	m.pendingRows = 0
+	return nil
+}
+
+// finish writes the remaining rows and returns the summary of the rows written
+// since the last call.
+func (m *restoreMerger) finish(ctx context.Context) (kvpb.BulkOpSummary, error) {
+	if err := m.flush(ctx); err != nil {
+		return kvpb.BulkOpSummary{}, err
+	}
+	m.lastRowPrefix = m.lastRowPrefix[:0]
+	summary := m.summary
+	m.summary = kvpb.BulkOpSummary{}
+	return summary, nil
+}
@@ -77,6 +77,9 @@ const (
 	restoreOptSkipLocalitiesCheck       = "skip_localities_check"
 	restoreOptAsTenant                  = "virtual_cluster_name"
 	restoreOptForceTenantID             = "virtual_cluster"
+	restoreOptWhere                     = "where"
+	restoreOptIntoTable                 = "into_table"
+	restoreOptOnConflict                = "on_conflict"
 
 	// The temporary database system tables will be restored into for full
 	// cluster backups.
@@ -813,7 +816,8 @@ func allocateDescriptorRewrites(
 		}
 	}
 
-	if descriptorCoverage == tree.AllDescriptors || descriptorCoverage == tree.SystemUsers {
+	if descriptorCoverage == tree.AllDescriptors || descriptorCoverage == tree.SystemUsers ||
+		descriptorCoverage == tree.SystemConfig {
 		// Increment the DescIDSequenceKey so that it is higher than both the max desc ID
 		// in the backup and current max desc ID in the restoring cluster. This generator
 		// keeps produced the next descriptor ID.
@@ -991,6 +995,87 @@ func maybeUpgradeDescriptors(
 	return nil
 }
 
+// discardInProgressSchemaChanges removes the schema changes which were in
+// progress on the restored tables, so that each table is restored as it was
+// seen by readers at the restore time. It is used when the descriptors were
+// reconstructed from the revision history of a backup: the backup only
+// contains the data of public indexes, so resuming a schema change from an
+// arbitrary point, e.g. after a backfill completed, would leave the table with
+// indexes which are missing their data.
+//
+// Declarative schema changes are discarded from every descriptor which took
+// part in them, since the job restored for one would expect the others.
+func discardInProgressSchemaChanges(descs []catalog.Descriptor) {
+	discardedJobs := make(map[catpb.JobID]struct{})
+	for _, desc := range descs {
+		table, ok := desc.(*tabledesc.Mutable)
+		if !ok {
+			continue
+		}
+		if state := table.GetDeclarativeSchemaChangerState(); state != nil {
+			discardedJobs[state.JobID] = struct{}{}
+			table.SetDeclarativeSchemaChangerState(nil)
+		}
+		discardTableMutations(table)
+	}
+	if len(discardedJobs) == 0 {
+		return
+	}
+	for _, desc := range descs {
+		mut, ok := desc.(catalog.MutableDescriptor)
+		if !ok {
+			continue
+		}
+		if state := mut.GetDeclarativeSchemaChangerState(); state != nil {
+			if _, ok := discardedJobs[state.JobID]; ok {
+				mut.SetDeclarativeSchemaChangerState(nil)
+			}
+		}
+	}
+}
+
+// discardTableMutations removes all the mutations of the table, leaving only
+// its public columns, indexes and constraints. Constraints which were being
+// added or dropped are left unvalidated, since their validation was not known
+// to have completed.
+func discardTableMutations(table *tabledesc.Mutable) {
+	for _, m := range table.Mutations {
+		if col := m.GetColumn(); col != nil {
+			table.RemoveColumnFromFamilyAndPrimaryIndex(col.ID)
+		}
+	}
+	table.Mutations = nil
+	table.MutationJobs = nil
+
+	checks := table.Checks[:0]
+	for _, c := range table.Checks {
+		if c.Validity == descpb.ConstraintValidity_Validating ||
+			c.Validity == descpb.ConstraintValidity_Dropping {
+			if c.IsNonNullConstraint {
+				// The dummy check constraint used to validate a NOT NULL constraint.
+				continue
+			}
+			c.Validity = descpb.ConstraintValidity_Unvalidated
+		}
+		checks = append(checks, c)
+	}
+	table.Checks = checks
+	for i := range table.OutboundFKs {
+		fk := &table.OutboundFKs[i]
+		if fk.Validity == descpb.ConstraintValidity_Validating ||
+			fk.Validity == descpb.ConstraintValidity_Dropping {
+			fk.Validity = descpb.ConstraintValidity_Unvalidated
+		}
+	}
+	for i := range table.UniqueWithoutIndexConstraints {
+		uc := &table.UniqueWithoutIndexConstraints[i]
+		if uc.Validity == descpb.ConstraintValidity_Validating ||
+			uc.Validity == descpb.ConstraintValidity_Dropping {
+			uc.Validity = descpb.ConstraintValidity_Unvalidated
+		}
+	}
+}
+
 // maybeUpgradeDescriptorsInBackupManifests updates the descriptors in the
 // manifests. This is done in particular to use the newer 19.2-style foreign
 // key representation, if they are not already upgraded.
@@ -1079,6 +1164,9 @@ func resolveOptionsForRestoreJobDescription(
 		ExecutionLocality:                opts.ExecutionLocality,
 		ExperimentalOnline:               opts.ExperimentalOnline,
 		RemoveRegions:                    opts.RemoveRegions,
+		Where:                            opts.Where,
+		IntoTable:                        opts.IntoTable,
+		OnConflict:                       opts.OnConflict,
 	}
 
 	if opts.EncryptionPassphrase != nil {
@@ -1179,6 +1267,9 @@ func restoreTypeCheck(
 			restoreStmt.Options.ForceTenantID,
 			restoreStmt.Options.AsTenant,
 			restoreStmt.Options.ExecutionLocality,
+			restoreStmt.Options.Where,
+			restoreStmt.Options.IntoTable,
+			restoreStmt.Options.OnConflict,
 		},
 	); err != nil {
 		return false, nil, err
@@ -1259,6 +1350,9 @@ func restorePlanHook(
 		if restoreStmt.DescriptorCoverage == tree.SystemUsers {
 			return nil, nil, false, errors.New("cannot set into_db option when only restoring system users")
 		}
+		if restoreStmt.DescriptorCoverage == tree.SystemConfig {
+			return nil, nil, false, errors.New("cannot set into_db option when only restoring system config")
+		}
 		var err error
 		intoDB, err = exprEval.String(ctx, restoreStmt.Options.IntoDB)
 		if err != nil {
@@ -1328,6 +1422,38 @@ func restorePlanHook(
 		return nil, nil, false, errors.New("cannot run online restore with verify_backup_table_data")
 	}
 
+	if restoreStmt.Options.Where != nil {
+		if restoreStmt.DescriptorCoverage != tree.RequestedDescriptors ||
+			len(restoreStmt.Targets.Databases) > 0 || len(restoreStmt.Targets.Tables.TablePatterns) != 1 {
+			return nil, nil, false, errors.Errorf("%q option can only be used when restoring a single table", restoreOptWhere)
+		}
+		if restoreStmt.Options.ExperimentalOnline {
+			return nil, nil, false, errors.Errorf("cannot run online restore with the %q option", restoreOptWhere)
+		}
+		if restoreStmt.Options.SchemaOnly {
+			return nil, nil, false, errors.Errorf("cannot use the %q option with schema_only", restoreOptWhere)
+		}
+	}
+
+	if restoreStmt.Options.IntoTable != nil {
+		if restoreStmt.DescriptorCoverage != tree.RequestedDescriptors ||
+			len(restoreStmt.Targets.Databases) > 0 || len(restoreStmt.Targets.Tables.TablePatterns) != 1 {
+			return nil, nil, false, errors.Errorf("%q option can only be used when restoring a single table", restoreOptIntoTable)
+		}
+		if restoreStmt.Options.IntoDB != nil || restoreStmt.Options.NewDBName != nil {
+			return nil, nil, false, errors.Errorf("cannot use the %q option with %q", restoreOptIntoTable, restoreOptIntoDB)
+		}
+		if restoreStmt.Options.ExperimentalOnline {
+			return nil, nil, false, errors.Errorf("cannot run online restore with the %q option", restoreOptIntoTable)
+		}
+		if restoreStmt.Options.SchemaOnly || restoreStmt.Options.VerifyData {
+			return nil, nil, false, errors.Errorf("cannot use the %q option with schema_only", restoreOptIntoTable)
+		}
+	} else if restoreStmt.Options.OnConflict != nil && restoreStmt.DescriptorCoverage != tree.SystemConfig {
+		return nil, nil, false, errors.Errorf("%q option can only be used with the %q option or when restoring system config",
+			restoreOptOnConflict, restoreOptIntoTable)
+	}
+
 	var newTenantID *roachpb.TenantID
 	var newTenantName *roachpb.TenantName
 	if restoreStmt.Options.AsTenant != nil || restoreStmt.Options.ForceTenantID != nil {
@@ -1483,6 +1609,7 @@ func checkPrivilegesForRestore(
 		// Cluster and tenant restores require the `RESTORE` system privilege for
 		// non-admin users.
 		requiresRestoreSystemPrivilege := restoreStmt.DescriptorCoverage == tree.AllDescriptors ||
+			restoreStmt.DescriptorCoverage == tree.SystemConfig ||
 			restoreStmt.Targets.TenantID.IsSet()
 
 		if requiresRestoreSystemPrivilege {
@@ -1865,6 +1992,12 @@ func doRestorePlan(
 		return err
 	}
 
+	// When restoring to a time within a backup, rather than to its end time, the
+	// descriptors were reconstructed from the revision history of the backup.
+	if !endTime.IsEmpty() && !endTime.Equal(mainBackupManifests[len(mainBackupManifests)-1].EndTime) {
+		discardInProgressSchemaChanges(sqlDescs)
+	}
+
 	var oldTenantID *roachpb.TenantID
 	if len(tenants) > 0 {
 		if !p.ExecCfg().Codec.ForSystemTenant() {
@@ -1959,6 +2092,50 @@ func doRestorePlan(
 		return err
 	}
 
+	// When restoring a subset of the rows of a table, validate the predicate
+	// against the table in the backup, and remember the ID the table has in the
+	// backup: the restore job restricts the spans it restores using the backed up
+	// table.
+	var rowFilter string
+	var rowFilterTableID descpb.ID
+	if restoreStmt.Options.Where != nil {
+		rowFilter, err = exprEval.String(ctx, restoreStmt.Options.Where)
+		if err != nil {
+			return err
+		}
+		if len(filteredTablesByID) != 1 {
+			return errors.Errorf("%q option can only be used when restoring a single table", restoreOptWhere)
+		}
+		for id, table := range filteredTablesByID {
+			if err := validateRestoreRowFilter(
+				ctx, table, rowFilter, &p.ExtendedEvalContext().Context,
+			); err != nil {
+				return err
+			}
+			rowFilterTableID = id
+		}
+	}
+
+	var mergeInto *jobspb.RestoreDetails_MergeInto
+	if restoreStmt.Options.IntoTable != nil {
+		mergeInto, err = planMergeRestore(ctx, p, exprEval, restoreStmt.Options, filteredTablesByID)
+		if err != nil {
+			return err
+		}
+		// A merge restore does not create any descriptors: the rows of the
+		// restored table are written into the existing table.
+		databasesByID, schemasByID, typesByID, functionsByID = nil, nil, nil, nil
+		filteredTablesByID = nil
+	}
+
+	var systemConfig *jobspb.RestoreDetails_SystemConfig
+	if restoreStmt.DescriptorCoverage == tree.SystemConfig {
+		systemConfig, err = planSystemConfigRestore(ctx, exprEval, restoreStmt.Options)
+		if err != nil {
+			return err
+		}
+	}
+
 	// When running a full cluster restore, we drop the defaultdb and postgres
 	// databases that are present in a new cluster.
 	// This is done so that they can be restored the same way any other user
@@ -2093,6 +2270,10 @@ func doRestorePlan(
 		ExperimentalOnline:               restoreStmt.Options.ExperimentalOnline,
 		RemoveRegions:                    restoreStmt.Options.RemoveRegions,
 		UnsafeRestoreIncompatibleVersion: restoreStmt.Options.UnsafeRestoreIncompatibleVersion,
+		RowFilter:                        rowFilter,
+		RowFilterTableID:                 rowFilterTableID,
+		MergeInto:                        mergeInto,
+		SystemConfig:                     systemConfig,
 	}
 
 	jr := jobs.Record{
@@ -118,6 +118,8 @@ func distRestore(
 			PKIDs:                md.dataToRestore.getPKIDs(),
 			ValidateOnly:         md.dataToRestore.isValidateOnly(),
 			ResumeClusterVersion: md.resumeClusterVersion,
+			RowFilter:            md.dataToRestore.getRowFilter(),
+			Merge:                md.dataToRestore.getMerge(),
 		}
 
 		// Plan SplitAndScatter on the coordinator node.
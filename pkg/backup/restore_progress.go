@@ -53,6 +53,9 @@ type progressTracker struct {
 	// endTime is the restore as of timestamp. This can be empty, and an empty timestamp
 	// indicates a restore of the latest revision.
 	endTime hlc.Timestamp
+
+	// estimator, if set, is updated with the bytes ingested by each processor.
+	estimator *progressEstimator
 }
 
 func makeProgressTracker(
@@ -168,6 +171,10 @@ func (pt *progressTracker) ingestUpdate(
 	if err := pbtypes.UnmarshalAny(&rawProgress.ProgressDetails, &progDetails); err != nil {
 		log.Errorf(ctx, "unable to unmarshal restore progress details: %+v", err)
 	}
+	if pt.estimator != nil {
+		// Partial progress updates still account for ingested bytes.
+		pt.estimator.record(rawProgress.NodeID, progDetails.Summary.DataSize, 0 /* spans */)
+	}
 	pt.mu.Lock()
 	defer pt.mu.Unlock()
 
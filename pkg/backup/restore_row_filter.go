@@ -0,0 +1,478 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/normalize"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+)
+
+// A RESTORE TABLE with the where option only restores the rows of the table
+// matching a predicate on its primary key columns:
+//
+//   - the spans of the primary index restored are restricted to the spans which
+//     may contain matching rows, as derived from the constraints the predicate
+//     places on the first primary key column;
+//   - the restore data processor evaluates the predicate on the primary key of
+//     every restored key in those spans, and skips the keys of rows which do
+//     not match it;
+//   - the secondary indexes of the table are not restored, and are instead
+//     rebuilt by a schema change job once the restore completes.
+
+// restoreRowFilterRow binds the primary key columns of a row to the indexed
+// vars of a row filter. The indexed var with index i refers to the i-th key
+// column of the primary index.
+type restoreRowFilterRow struct {
+	types  []*types.T
+	datums tree.Datums
+}
+
+var _ eval.IndexedVarContainer = &restoreRowFilterRow{}
+
+// IndexedVarEval implements eval.IndexedVarContainer.
+func (r *restoreRowFilterRow) IndexedVarEval(idx int) (tree.Datum, error) {
+	return r.datums[idx], nil
+}
+
+// IndexedVarResolvedType implements tree.IndexedVarContainer.
+func (r *restoreRowFilterRow) IndexedVarResolvedType(idx int) *types.T {
+	return r.types[idx]
+}
+
+// parseRestoreRowFilter parses and type-checks a row filter for the table. The
+// returned expression refers to the primary key columns of the table with
+// indexed vars, along with the number of leading primary key columns which must
+// be decoded to evaluate it.
+func parseRestoreRowFilter(
+	ctx context.Context, table catalog.TableDescriptor, filter string, evalCtx *eval.Context,
+) (_ tree.TypedExpr, _ *restoreRowFilterRow, numKeyCols int, _ error) {
+	if !table.IsPhysicalTable() || table.IsSequence() {
+		return nil, nil, 0, pgerror.Newf(pgcode.WrongObjectType,
+			"cannot restore a subset of the rows of %q: not a table", table.GetName())
+	}
+	expr, err := parser.ParseExpr(filter)
+	if err != nil {
+		return nil, nil, 0, pgerror.Wrap(err, pgcode.Syntax, "parsing where option")
+	}
+
+	primary := table.GetPrimaryIndex()
+	row := &restoreRowFilterRow{
+		types:  make([]*types.T, primary.NumKeyColumns()),
+		datums: make(tree.Datums, primary.NumKeyColumns()),
+	}
+	for i := range row.types {
+		col, err := catalog.MustFindColumnByID(table, primary.GetKeyColumnID(i))
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		row.types[i] = col.GetType()
+	}
+
+	// Replace references to the primary key columns with indexed vars.
+	expr, err = tree.SimpleVisit(expr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		vBase, ok := expr.(tree.VarName)
+		if !ok {
+			return true, expr, nil
+		}
+		v, err := vBase.NormalizeVarName()
+		if err != nil {
+			return false, nil, err
+		}
+		c, ok := v.(*tree.ColumnItem)
+		if !ok {
+			return true, expr, nil
+		}
+		col, err := catalog.MustFindColumnByTreeName(table, c.ColumnName)
+		if err != nil {
+			return false, nil, err
+		}
+		ord := -1
+		for i := 0; i < primary.NumKeyColumns(); i++ {
+			if primary.GetKeyColumnID(i) == col.GetID() {
+				ord = i
+			}
+		}
+		if ord < 0 {
+			return false, nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"where option may only reference primary key columns; %q is not a primary key column",
+				col.GetName())
+		}
+		if typ := col.GetType(); typ.UserDefined() || colinfo.CanHaveCompositeKeyEncoding(typ) {
+			return false, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"where option cannot reference column %q of type %s", col.GetName(), typ.SQLString())
+		}
+		numKeyCols = max(numKeyCols, ord+1)
+		return false, tree.NewOrdinalReference(ord), nil
+	})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	semaCtx := tree.MakeSemaContext(nil /* resolver */)
+	semaCtx.IVarContainer = row
+	semaCtx.Properties.Require("where option",
+		tree.RejectSpecial|tree.RejectSubqueries|tree.RejectStableOperators|tree.RejectVolatileFunctions)
+	typedExpr, err := tree.TypeCheckAndRequire(ctx, expr, &semaCtx, types.Bool, "where option")
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if typedExpr, err = normalize.Expr(ctx, evalCtx, typedExpr); err != nil {
+		return nil, nil, 0, err
+	}
+	return typedExpr, row, numKeyCols, nil
+}
+
+// validateRestoreRowFilter checks that the where option can be used to restore
+// the table.
+func validateRestoreRowFilter(
+	ctx context.Context, table catalog.TableDescriptor, filter string, evalCtx *eval.Context,
+) error {
+	if len(table.AllMutations()) > 0 || table.GetDeclarativeSchemaChangerState() != nil {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot restore a subset of the rows of %q: table was backed up during a schema change",
+			table.GetName())
+	}
+	_, _, _, err := parseRestoreRowFilter(ctx, table, filter, evalCtx)
+	return err
+}
+
+// restrictSpansToRowFilter restricts the spans of the table with the row filter
+// to the spans of its primary index which may contain matching rows.
+func restrictSpansToRowFilter(
+	ctx context.Context,
+	codec keys.SQLCodec,
+	spans roachpb.Spans,
+	table catalog.TableDescriptor,
+	filter string,
+	evalCtx *eval.Context,
+) (roachpb.Spans, error) {
+	expr, _, _, err := parseRestoreRowFilter(ctx, table, filter, evalCtx)
+	if err != nil {
+		return nil, err
+	}
+	primarySpan := table.PrimaryIndexSpan(codec)
+	filterSpans, ok, err := rowFilterKeySpans(expr, table, primarySpan)
+	if err != nil || !ok {
+		return spans, err
+	}
+	var excluded roachpb.SpanGroup
+	excluded.Add(primarySpan)
+	excluded.Sub(filterSpans...)
+
+	var restricted roachpb.SpanGroup
+	restricted.Add(spans...)
+	restricted.Sub(excluded.Slice()...)
+	return restricted.Slice(), nil
+}
+
+// rowFilterKeySpans returns the spans of the primary index of the table which
+// contain the rows matching the predicate, as derived from the constraints the
+// predicate places on the first primary key column. Returns false if the
+// predicate does not constrain the first primary key column.
+func rowFilterKeySpans(
+	expr tree.TypedExpr, table catalog.TableDescriptor, primarySpan roachpb.Span,
+) (roachpb.Spans, bool, error) {
+	primary := table.GetPrimaryIndex()
+	col, err := catalog.MustFindColumnByID(table, primary.GetKeyColumnID(0))
+	if err != nil {
+		return nil, false, err
+	}
+	dir := encoding.Ascending
+	if primary.GetKeyColumnDirection(0) == catenumpb.IndexColumn_DESC {
+		dir = encoding.Descending
+	}
+	c := rowFilterConstrainer{
+		colType:     col.GetType(),
+		dir:         dir,
+		primarySpan: primarySpan,
+	}
+	spans, ok, err := c.constrain(expr)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return spans.Slice(), true, nil
+}
+
+// rowFilterConstrainer derives the key spans of the primary index which contain
+// the rows satisfying a predicate on the first primary key column.
+type rowFilterConstrainer struct {
+	colType     *types.T
+	dir         encoding.Direction
+	primarySpan roachpb.Span
+}
+
+// constrain returns the spans which contain the rows satisfying the
+// expression, or false if the expression does not constrain the first primary
+// key column.
+func (c rowFilterConstrainer) constrain(expr tree.TypedExpr) (*roachpb.SpanGroup, bool, error) {
+	switch t := expr.(type) {
+	case *tree.ParenExpr:
+		return c.constrain(t.TypedInnerExpr())
+
+	case *tree.AndExpr:
+		left, leftOK, err := c.constrain(t.TypedLeft())
+		if err != nil {
+			return nil, false, err
+		}
+		right, rightOK, err := c.constrain(t.TypedRight())
+		if err != nil || !leftOK || !rightOK {
+			if leftOK {
+				return left, true, err
+			}
+			return right, rightOK, err
+		}
+		// A ∩ B = A - (A - B).
+		var excluded roachpb.SpanGroup
+		excluded.Add(left.Slice()...)
+		excluded.Sub(right.Slice()...)
+		left.Sub(excluded.Slice()...)
+		return left, true, nil
+
+	case *tree.OrExpr:
+		left, leftOK, err := c.constrain(t.TypedLeft())
+		if err != nil || !leftOK {
+			return nil, false, err
+		}
+		right, rightOK, err := c.constrain(t.TypedRight())
+		if err != nil || !rightOK {
+			return nil, false, err
+		}
+		left.Add(right.Slice()...)
+		return left, true, nil
+
+	case *tree.ComparisonExpr:
+		v, ok := t.Left.(*tree.IndexedVar)
+		if !ok || v.Idx != 0 {
+			return nil, false, nil
+		}
+		var spans roachpb.SpanGroup
+		switch t.Operator.Symbol {
+		case treecmp.In:
+			tuple, ok := t.Right.(*tree.DTuple)
+			if !ok {
+				return nil, false, nil
+			}
+			for _, d := range tuple.D {
+				span, ok, err := c.spanForComparison(treecmp.EQ, d)
+				if err != nil || !ok {
+					return nil, false, err
+				}
+				spans.Add(span)
+			}
+		default:
+			d, ok := t.Right.(tree.Datum)
+			if !ok {
+				return nil, false, nil
+			}
+			span, ok, err := c.spanForComparison(t.Operator.Symbol, d)
+			if err != nil || !ok {
+				return nil, false, err
+			}
+			spans.Add(span)
+		}
+		return &spans, true, nil
+
+	case tree.Datum:
+		// The predicate was folded into a constant.
+		var spans roachpb.SpanGroup
+		if t != tree.DBoolTrue {
+			return &spans, true, nil
+		}
+		spans.Add(c.primarySpan)
+		return &spans, true, nil
+	}
+	return nil, false, nil
+}
+
+// spanForComparison returns the span which contains the rows for which the
+// comparison of the first primary key column with the datum is true.
+func (c rowFilterConstrainer) spanForComparison(
+	op treecmp.ComparisonOperatorSymbol, d tree.Datum,
+) (roachpb.Span, bool, error) {
+	if d == tree.DNull || !d.ResolvedType().Equivalent(c.colType) {
+		return roachpb.Span{}, false, nil
+	}
+	key, err := keyside.Encode(c.primarySpan.Key.Clone(), d, c.dir)
+	if err != nil {
+		return roachpb.Span{}, false, err
+	}
+	keyEnd := roachpb.Key(key).PrefixEnd()
+	// In a descending column, larger values sort first.
+	if c.dir == encoding.Descending {
+		switch op {
+		case treecmp.LT:
+			op = treecmp.GT
+		case treecmp.LE:
+			op = treecmp.GE
+		case treecmp.GT:
+			op = treecmp.LT
+		case treecmp.GE:
+			op = treecmp.LE
+		}
+	}
+	switch op {
+	case treecmp.EQ:
+		return roachpb.Span{Key: key, EndKey: keyEnd}, true, nil
+	case treecmp.LT:
+		return roachpb.Span{Key: c.primarySpan.Key, EndKey: key}, true, nil
+	case treecmp.LE:
+		return roachpb.Span{Key: c.primarySpan.Key, EndKey: keyEnd}, true, nil
+	case treecmp.GT:
+		return roachpb.Span{Key: keyEnd, EndKey: c.primarySpan.EndKey}, true, nil
+	case treecmp.GE:
+		return roachpb.Span{Key: key, EndKey: c.primarySpan.EndKey}, true, nil
+	}
+	return roachpb.Span{}, false, nil
+}
+
+// prepareRowFilteredTable prepares the table restored with a row filter to be
+// created without the data of its secondary indexes: the secondary indexes
+// are turned into index mutations, to be backfilled by a schema change job once
+// the table is restored. Foreign keys of the table referencing itself are
+// marked as unvalidated, as the referenced rows may not have been restored.
+func prepareRowFilteredTable(ctx context.Context, table *tabledesc.Mutable) error {
+	if len(table.Indexes) > 0 {
+		mutationID := table.NextMutationID
+		table.NextMutationID++
+		for i := range table.Indexes {
+			idx := table.Indexes[i]
+			table.Mutations = append(table.Mutations, descpb.DescriptorMutation{
+				Descriptor_: &descpb.DescriptorMutation_Index{Index: &idx},
+				Direction:   descpb.DescriptorMutation_ADD,
+				State:       descpb.DescriptorMutation_BACKFILLING,
+				MutationID:  mutationID,
+			})
+			// The index backfiller expects a temporary index for each index
+			// being added, following it in the mutations.
+			tempIdx := *protoutil.Clone(&idx).(*descpb.IndexDescriptor)
+			tempIdx.UseDeletePreservingEncoding = true
+			tempIdx.ID = 0
+			tempIdx.Name = ""
+			tempIdx.ConstraintID = 0
+			table.Mutations = append(table.Mutations, descpb.DescriptorMutation{
+				Descriptor_: &descpb.DescriptorMutation_Index{Index: &tempIdx},
+				Direction:   descpb.DescriptorMutation_ADD,
+				State:       descpb.DescriptorMutation_DELETE_ONLY,
+				MutationID:  mutationID,
+			})
+		}
+		table.Indexes = nil
+	}
+	for i := range table.OutboundFKs {
+		if fk := &table.OutboundFKs[i]; fk.ReferencedTableID == table.GetID() {
+			fk.Validity = descpb.ConstraintValidity_Unvalidated
+		}
+	}
+	// Allocate the IDs and names of the temporary indexes.
+	return table.AllocateIDsWithoutValidation(ctx, false /* createMissingPrimaryKey */)
+}
+
+// restoreRowFilter evaluates a row filter on the keys restored by the restore
+// data processor.
+type restoreRowFilter struct {
+	expr    tree.TypedExpr
+	row     *restoreRowFilterRow
+	evalCtx *eval.Context
+
+	// prefix is the prefix of the keys of the primary index of the restored
+	// table, after rewriting.
+	prefix  roachpb.Key
+	colDirs []catenumpb.IndexColumn_Direction
+	vals    []rowenc.EncDatum
+	alloc   tree.DatumAlloc
+}
+
+// makeRestoreRowFilter makes a row filter for the restore data processor. The
+// table the filter applies to is found in the table rekeys of the processor.
+func makeRestoreRowFilter(
+	ctx context.Context,
+	codec keys.SQLCodec,
+	spec *execinfrapb.RestoreRowFilter,
+	tableRekeys []execinfrapb.TableRekey,
+	evalCtx *eval.Context,
+) (*restoreRowFilter, error) {
+	var table catalog.TableDescriptor
+	for _, rekey := range tableRekeys {
+		if descpb.ID(rekey.OldID) != spec.TableID {
+			continue
+		}
+		var desc descpb.Descriptor
+		if err := protoutil.Unmarshal(rekey.NewDesc, &desc); err != nil {
+			return nil, errors.Wrapf(err, "unmarshalling rekey descriptor for old table id %d", rekey.OldID)
+		}
+		tableDesc, _, _, _, _ := descpb.GetDescriptors(&desc)
+		if tableDesc == nil {
+			return nil, errors.New("expected a table descriptor")
+		}
+		table = tabledesc.NewBuilder(tableDesc).BuildImmutableTable()
+	}
+	if table == nil {
+		return nil, errors.AssertionFailedf("no rekey for row filtered table %d", spec.TableID)
+	}
+	expr, row, numKeyCols, err := parseRestoreRowFilter(ctx, table, spec.Expr, evalCtx)
+	if err != nil {
+		return nil, err
+	}
+	primary := table.GetPrimaryIndex()
+	colDirs := make([]catenumpb.IndexColumn_Direction, numKeyCols)
+	for i := range colDirs {
+		colDirs[i] = primary.GetKeyColumnDirection(i)
+	}
+	return &restoreRowFilter{
+		expr:    expr,
+		row:     row,
+		evalCtx: evalCtx,
+		prefix:  rowenc.MakeIndexKeyPrefix(codec, table.GetID(), primary.GetID()),
+		colDirs: colDirs,
+		vals:    make([]rowenc.EncDatum, numKeyCols),
+	}, nil
+}
+
+// matches returns false if the key belongs to a row of the filtered table which
+// does not match the filter.
+func (f *restoreRowFilter) matches(ctx context.Context, key roachpb.Key) (bool, error) {
+	if !bytes.HasPrefix(key, f.prefix) {
+		return true, nil
+	}
+	if _, _, err := rowenc.DecodeKeyVals(f.vals, f.colDirs, key[len(f.prefix):]); err != nil {
+		return false, err
+	}
+	for i := range f.vals {
+		if err := f.vals[i].EnsureDecoded(f.row.types[i], &f.alloc); err != nil {
+			return false, err
+		}
+		f.row.datums[i] = f.vals[i].Datum
+	}
+	f.evalCtx.PushIVarContainer(f.row)
+	defer f.evalCtx.PopIVarContainer()
+	d, err := eval.Expr(ctx, f.evalCtx, f.expr)
+	if err != nil {
+		return false, err
+	}
+	return d == tree.DBoolTrue, nil
+}
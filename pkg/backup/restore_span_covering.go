@@ -11,6 +11,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
 	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
@@ -678,6 +679,21 @@ type fileSpanComparator interface {
 	isExclusive() bool
 }
 
+// fileSpanComparatorForBackups returns the fileSpanComparator to use for the
+// files of the given backup chain.
+func fileSpanComparatorForBackups(backups []backuppb.BackupManifest) fileSpanComparator {
+	// If any layer of the backup was produced with revision history before 24.1,
+	// we need to assume inclusive end-keys. If no layers used revision history or
+	// those that did were produced with #118990 in 24.1+, we can assume exclusive
+	// end-keys.
+	for _, i := range backups {
+		if i.ClusterVersion.Less(clusterversion.V24_1.Version()) && i.MVCCFilter == backuppb.MVCCFilter_All {
+			return &inclusiveEndKeyComparator{}
+		}
+	}
+	return &exclusiveEndKeyComparator{}
+}
+
 // inclusiveEndKeyComparator assumes that file spans have inclusive
 // end keys.
 type inclusiveEndKeyComparator struct{}
@@ -7,6 +7,7 @@ package backup
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
@@ -20,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
@@ -300,6 +302,27 @@ func (e *scheduledBackupExecutor) GetCreateScheduleStatement(
 			Value: tree.NewDString(wait),
 		},
 	}
+	if policy := args.CompactionPolicy; policy != nil {
+		if policy.Threshold != 0 {
+			scheduleOptions = append(scheduleOptions, tree.KVOption{
+				Key:   optCompactionThreshold,
+				Value: tree.NewDString(strconv.FormatInt(policy.Threshold, 10)),
+			})
+		}
+		if policy.Window != 0 {
+			scheduleOptions = append(scheduleOptions, tree.KVOption{
+				Key:   optCompactionWindow,
+				Value: tree.NewDString(strconv.FormatInt(policy.Window, 10)),
+			})
+		}
+		if policy.Retention != 0 {
+			retention := duration.MakeDuration(policy.Retention.Nanoseconds(), 0, 0)
+			scheduleOptions = append(scheduleOptions, tree.KVOption{
+				Key:   optRetention,
+				Value: tree.NewDString(retention.String()),
+			})
+		}
+	}
 
 	var destinations []string
 	for i := range backupNode.To {
@@ -37,10 +37,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/doctor"
 	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/protoreflect"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -192,6 +194,16 @@ func showBackupPlanHook(
 		return nil, nil, false, err
 	}
 
+	if showStmt.Options.CheckFingerprints {
+		if showStmt.Options.AsJson || showStmt.Details != tree.BackupDefaultDetails {
+			return nil, nil, false, errors.New(
+				"check_fingerprints cannot be used with as_json, SCHEMAS, FILES, RANGES, REVISIONS or VALIDATE")
+		}
+		if showStmt.Options.CheckFiles {
+			return nil, nil, false, errors.New("check_fingerprints cannot be used with check_files")
+		}
+	}
+
 	infoReader := getBackupInfoReader(p, showStmt)
 
 	if err != nil {
@@ -205,6 +217,14 @@ func showBackupPlanHook(
 		if err := sql.CheckDestinationPrivileges(ctx, p, dest); err != nil {
 			return err
 		}
+		if showStmt.Options.CheckFingerprints {
+			// Fingerprinting the cluster reads all the data covered by the backup.
+			if err := p.CheckPrivilege(
+				ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.VIEWCLUSTERMETADATA,
+			); err != nil {
+				return err
+			}
+		}
 
 		if strings.EqualFold(subdir, backupbase.LatestFileName) {
 			subdir, err = backupdest.ReadLatestFile(ctx, dest[0],
@@ -424,6 +444,8 @@ func getBackupInfoReader(p sql.PlanHookState, showStmt *tree.ShowBackup) backupI
 	var infoReader backupInfoReader
 	if showStmt.Options.AsJson {
 		infoReader = manifestInfoReader{shower: jsonShower}
+	} else if showStmt.Options.CheckFingerprints {
+		infoReader = manifestInfoReader{shower: backupShowerFingerprints(p)}
 	} else {
 		var shower backupShower
 		switch showStmt.Details {
@@ -433,6 +455,8 @@ func getBackupInfoReader(p sql.PlanHookState, showStmt *tree.ShowBackup) backupI
 			shower = backupShowerFileSetup()
 		case tree.BackupSchemaDetails:
 			shower = backupShowerDefault(p, true, showStmt.Options)
+		case tree.BackupRevisionDetails:
+			shower = backupShowerRevisions
 		case tree.BackupValidateDetails:
 			shower = backupShowerDoctor
 
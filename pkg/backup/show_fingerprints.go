@@ -0,0 +1,299 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupsink"
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// SHOW BACKUP ... WITH check_fingerprints verifies a backup chain without
+// restoring it: the key space of the backup is partitioned into the same spans
+// a restore would use, every SST of the chain overlapping each span is read,
+// and the fingerprint of the latest revision of each key as of the end time of
+// the chain is compared against the fingerprint of the span in the cluster at
+// that time, as computed by crdb_internal.fingerprint. Both fingerprints hash
+// each key/timestamp and value the same way (see storage.fingerprintWriter).
+//
+// The cluster fingerprint can only be computed while the end time of the chain
+// is still within the GC TTL of the span; otherwise the span is reported as
+// unavailable.
+
+const (
+	fingerprintStatusMatch       = "match"
+	fingerprintStatusMismatch    = "mismatch"
+	fingerprintStatusUnavailable = "unavailable"
+)
+
+// backupFingerprintOptions are the options used to fingerprint the point keys of
+// a backup, matching those used by an ExportRequest computing a fingerprint.
+var backupFingerprintOptions = storage.MVCCExportFingerprintOptions{
+	StripTenantPrefix:  true,
+	StripValueChecksum: true,
+}
+
+// backupShowerFingerprints returns a shower which reports, for every span of
+// the backup chain, whether the data in the backup matches the data in the
+// cluster at the end time of the chain.
+func backupShowerFingerprints(p sql.PlanHookState) backupShower {
+	return backupShower{
+		header: colinfo.ResultColumns{
+			{Name: "start_pretty", Typ: types.String},
+			{Name: "end_pretty", Typ: types.String},
+			{Name: "start_key", Typ: types.Bytes},
+			{Name: "end_key", Typ: types.Bytes},
+			{Name: "backup_fingerprint", Typ: types.Int},
+			{Name: "cluster_fingerprint", Typ: types.Int},
+			{Name: "status", Typ: types.String},
+		},
+		fn: func(ctx context.Context, info backupInfo) ([]tree.Datums, error) {
+			return checkBackupFingerprints(ctx, p, info)
+		},
+	}
+}
+
+// checkBackupFingerprints computes the fingerprints of every span of the backup
+// chain and of the same span in the cluster.
+func checkBackupFingerprints(
+	ctx context.Context, p sql.PlanHookState, info backupInfo,
+) ([]tree.Datums, error) {
+	execCfg := p.ExecCfg()
+	manifests := info.manifests
+	lastManifest := manifests[len(manifests)-1]
+	endTime := lastManifest.EndTime
+
+	if !lastManifest.ClusterID.Equal(execCfg.NodeInfo.LogicalClusterID()) {
+		return nil, errors.Newf(
+			"check_fingerprints can only be used in the cluster which took the backup")
+	}
+
+	fileEncryption, err := backupFileEncryption(ctx, info.enc, info.kmsEnv)
+	if err != nil {
+		return nil, err
+	}
+	backupLocalityMap, err := makeBackupLocalityMap(info.localityInfo, p.User())
+	if err != nil {
+		return nil, err
+	}
+	introducedSpanFrontier, err := createIntroducedSpanFrontier(manifests, endTime)
+	if err != nil {
+		return nil, err
+	}
+	defer introducedSpanFrontier.Release()
+
+	filter, err := makeSpanCoveringFilter(
+		lastManifest.Spans,
+		nil, /* checkpointedSpans */
+		introducedSpanFrontier,
+		targetRestoreSpanSize.Get(&execCfg.Settings.SV),
+		maxFileCount.Get(&execCfg.Settings.SV),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer filter.close()
+
+	var rows []tree.Datums
+	spanCh := make(chan execinfrapb.RestoreSpanEntry, 1000)
+	genSpans := func(ctx context.Context) error {
+		defer close(spanCh)
+		return errors.Wrap(generateAndSendImportSpans(
+			ctx,
+			lastManifest.Spans,
+			manifests,
+			info.layerToIterFactory,
+			backupLocalityMap,
+			filter,
+			fileSpanComparatorForBackups(manifests),
+			spanCh,
+		), "generate and send import spans")
+	}
+	checkSpans := func(ctx context.Context) error {
+		for entry := range spanCh {
+			backupFingerprint, err := fingerprintBackupSpanEntry(ctx, execCfg, entry, fileEncryption, endTime)
+			if err != nil {
+				return errors.Wrapf(err, "fingerprinting backup span %s", entry.Span)
+			}
+			clusterFingerprint, ok, err := fingerprintClusterSpan(ctx, execCfg, entry.Span, endTime)
+			if err != nil {
+				return errors.Wrapf(err, "fingerprinting cluster span %s", entry.Span)
+			}
+			status := fingerprintStatusUnavailable
+			clusterDatum := tree.DNull
+			if ok {
+				clusterDatum = tree.NewDInt(tree.DInt(clusterFingerprint))
+				status = fingerprintStatusMismatch
+				if clusterFingerprint == backupFingerprint {
+					status = fingerprintStatusMatch
+				}
+			}
+			rows = append(rows, tree.Datums{
+				tree.NewDString(entry.Span.Key.String()),
+				tree.NewDString(entry.Span.EndKey.String()),
+				tree.NewDBytes(tree.DBytes(entry.Span.Key)),
+				tree.NewDBytes(tree.DBytes(entry.Span.EndKey)),
+				tree.NewDInt(tree.DInt(backupFingerprint)),
+				clusterDatum,
+				tree.NewDString(status),
+			})
+		}
+		return nil
+	}
+	if err := ctxgroup.GoAndWait(ctx, genSpans, checkSpans); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// backupFileEncryption returns the options to decrypt the SSTs of a backup
+// encrypted with the given options.
+func backupFileEncryption(
+	ctx context.Context, encryption *jobspb.BackupEncryptionOptions, kmsEnv cloud.KMSEnv,
+) (*kvpb.FileEncryptionOptions, error) {
+	if encryption == nil {
+		return nil, nil
+	}
+	key := encryption.Key
+	if encryption.Mode == jobspb.EncryptionMode_KMS {
+		kms, err := cloud.KMSFromURI(ctx, encryption.KMSInfo.Uri, kmsEnv)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating KMS")
+		}
+		defer func() {
+			if err := kms.Close(); err != nil {
+				log.Infof(ctx, "failed to close KMS: %+v", err)
+			}
+		}()
+		key, err = kms.Decrypt(ctx, encryption.KMSInfo.EncryptedDataKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt data key")
+		}
+	}
+	return &kvpb.FileEncryptionOptions{Key: key}, nil
+}
+
+// fingerprintBackupSpanEntry fingerprints the latest revision of every key in
+// the span of the entry as of the given time, read from the files of the entry.
+func fingerprintBackupSpanEntry(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	entry execinfrapb.RestoreSpanEntry,
+	encryption *kvpb.FileEncryptionOptions,
+	asOf hlc.Timestamp,
+) (uint64, error) {
+	if len(entry.Files) == 0 {
+		return 0, nil
+	}
+	storeFiles := make([]storageccl.StoreFile, 0, len(entry.Files))
+	defer func() {
+		for _, f := range storeFiles {
+			if err := f.Store.Close(); err != nil {
+				log.Warningf(ctx, "close export storage failed %v", err)
+			}
+		}
+	}()
+	for _, file := range entry.Files {
+		dir, err := execCfg.DistSQLSrv.ExternalStorage(ctx, file.Dir)
+		if err != nil {
+			return 0, err
+		}
+		storeFiles = append(storeFiles, storageccl.StoreFile{Store: dir, FilePath: file.Path})
+	}
+	iterOpts := storage.IterOptions{
+		RangeKeyMaskingBelow: asOf,
+		KeyTypes:             storage.IterKeyTypePointsAndRanges,
+		LowerBound:           keys.LocalMax,
+		UpperBound:           keys.MaxKey,
+	}
+	sstIter, err := storageccl.ExternalSSTReader(ctx, storeFiles, encryption, iterOpts)
+	if err != nil {
+		return 0, err
+	}
+	iter := storage.NewReadAsOfIterator(sstIter, asOf)
+	defer iter.Close()
+
+	elidedPrefix, err := backupsink.ElidedPrefix(entry.Span.Key, entry.ElidedPrefix)
+	if err != nil {
+		return 0, err
+	}
+	startKey := storage.MVCCKey{Key: bytes.TrimPrefix(entry.Span.Key, elidedPrefix)}
+	endKey := storage.MVCCKey{Key: entry.Span.EndKey}
+
+	fingerprinter := storage.MakePointKeyFingerprinter(backupFingerprintOptions)
+	var keyScratch []byte
+	for iter.SeekGE(startKey); ; iter.NextKey() {
+		if ok, err := iter.Valid(); err != nil {
+			return 0, err
+		} else if !ok {
+			break
+		}
+		key := iter.UnsafeKey()
+		keyScratch = append(append(keyScratch[:0], elidedPrefix...), key.Key...)
+		key.Key = keyScratch
+		if !key.Less(endKey) {
+			break
+		}
+		v, err := iter.UnsafeValue()
+		if err != nil {
+			return 0, err
+		}
+		value, err := storage.DecodeValueFromMVCCValue(v)
+		if err != nil {
+			return 0, err
+		}
+		if err := fingerprinter.Add(key, value.RawBytes); err != nil {
+			return 0, err
+		}
+	}
+	return fingerprinter.Fingerprint(), nil
+}
+
+// fingerprintClusterSpan fingerprints the latest revision of every key in the
+// span in the cluster as of the given time. It returns false if the data as of
+// that time has been garbage collected.
+func fingerprintClusterSpan(
+	ctx context.Context, execCfg *sql.ExecutorConfig, span roachpb.Span, asOf hlc.Timestamp,
+) (uint64, bool, error) {
+	row, err := execCfg.InternalDB.Executor().QueryRowEx(
+		ctx, "backup-check-fingerprints", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		fmt.Sprintf(
+			"SELECT crdb_internal.fingerprint(ARRAY[$1::BYTES, $2::BYTES], false) AS OF SYSTEM TIME %s",
+			asOf.AsOfSystemTime(),
+		),
+		[]byte(span.Key), []byte(span.EndKey),
+	)
+	if err != nil {
+		if errors.HasType(err, (*kvpb.BatchTimestampBeforeGCError)(nil)) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	if row == nil {
+		return 0, false, errors.AssertionFailedf("no fingerprint returned for span %s", span)
+	}
+	return uint64(tree.MustBeDInt(row[0])), true, nil
+}
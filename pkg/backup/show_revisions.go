@@ -0,0 +1,210 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backupinfo"
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// SHOW BACKUP REVISIONS lists, for every table in a backup chain, the ranges
+// of time to which the table can be restored and the version of its
+// descriptor which a RESTORE ... AS OF SYSTEM TIME within each range restores.
+//
+// A backup taken without revision_history can only be restored to its end
+// time, while one taken with revision_history can be restored to any time
+// after the start of its revision history and up to its end time. Within such
+// a backup, each revision of a table's descriptor is restored from the time it
+// was written until the time of the next revision.
+//
+// A descriptor version may have had a schema change in progress. Restoring to
+// a time within a backup restores the table without that schema change, as it
+// was seen by readers at that time, while restoring to the end time of the
+// backup resumes the schema change.
+
+// tableRevisionRange is a range of time to which a table can be restored with
+// the given descriptor.
+type tableRevisionRange struct {
+	table      catalog.TableDescriptor
+	start, end hlc.Timestamp
+}
+
+var backupShowerRevisions = backupShower{
+	header: colinfo.ResultColumns{
+		{Name: "database_name", Typ: types.String},
+		{Name: "parent_schema_name", Typ: types.String},
+		{Name: "object_name", Typ: types.String},
+		{Name: "object_id", Typ: types.Int},
+		{Name: "descriptor_version", Typ: types.Int},
+		{Name: "start_time", Typ: types.TimestampTZ},
+		{Name: "end_time", Typ: types.TimestampTZ},
+		{Name: "schema_change_in_progress", Typ: types.Bool},
+	},
+
+	fn: func(ctx context.Context, info backupInfo) ([]tree.Datums, error) {
+		dbIDToName := make(map[descpb.ID]string)
+		schemaIDToName := make(map[descpb.ID]string)
+		schemaIDToName[keys.PublicSchemaIDForBackup] = catconstants.PublicSchemaName
+		var ranges []tableRevisionRange
+		for layer, manifest := range info.manifests {
+			descriptors, err := backupinfo.BackupManifestDescriptors(ctx, info.layerToIterFactory[layer], manifest.EndTime)
+			if err != nil {
+				return nil, err
+			}
+			for _, desc := range descriptors {
+				switch desc := desc.(type) {
+				case catalog.DatabaseDescriptor:
+					dbIDToName[desc.GetID()] = desc.GetName()
+				case catalog.SchemaDescriptor:
+					schemaIDToName[desc.GetID()] = desc.GetName()
+				}
+			}
+			layerRanges, err := tableRevisionRangesForLayer(ctx, info.layerToIterFactory[layer], manifest, descriptors)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, layerRanges...)
+		}
+
+		slices.SortStableFunc(ranges, func(a, b tableRevisionRange) int {
+			if a.table.GetID() != b.table.GetID() {
+				return int(a.table.GetID()) - int(b.table.GetID())
+			}
+			return a.start.Compare(b.start)
+		})
+
+		// Merge the ranges of the same descriptor version which are adjacent, such
+		// as those of a table which did not change across backups.
+		var merged []tableRevisionRange
+		for _, r := range ranges {
+			if n := len(merged); n > 0 {
+				last := &merged[n-1]
+				if last.table.GetID() == r.table.GetID() &&
+					last.table.GetVersion() == r.table.GetVersion() &&
+					hasInProgressSchemaChange(last.table) == hasInProgressSchemaChange(r.table) &&
+					r.start.LessEq(last.end) {
+					last.end.Forward(r.end)
+					continue
+				}
+			}
+			merged = append(merged, r)
+		}
+
+		rows := make([]tree.Datums, 0, len(merged))
+		for _, r := range merged {
+			start, err := tree.MakeDTimestampTZ(timeutil.Unix(0, r.start.WallTime), time.Nanosecond)
+			if err != nil {
+				return nil, err
+			}
+			end, err := tree.MakeDTimestampTZ(timeutil.Unix(0, r.end.WallTime), time.Nanosecond)
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, tree.Datums{
+				nullIfEmpty(dbIDToName[r.table.GetParentID()]),
+				nullIfEmpty(schemaIDToName[r.table.GetParentSchemaID()]),
+				tree.NewDString(r.table.GetName()),
+				tree.NewDInt(tree.DInt(r.table.GetID())),
+				tree.NewDInt(tree.DInt(r.table.GetVersion())),
+				start,
+				end,
+				tree.MakeDBool(tree.DBool(hasInProgressSchemaChange(r.table))),
+			})
+		}
+		return rows, nil
+	},
+}
+
+// tableRevisionRangesForLayer returns the ranges of time to which the tables in
+// the given backup layer can be restored.
+func tableRevisionRangesForLayer(
+	ctx context.Context,
+	iterFactory *backupinfo.IterFactory,
+	manifest backuppb.BackupManifest,
+	descriptors []catalog.Descriptor,
+) ([]tableRevisionRange, error) {
+	var ranges []tableRevisionRange
+	if manifest.MVCCFilter != backuppb.MVCCFilter_All {
+		for _, desc := range descriptors {
+			if table, ok := desc.(catalog.TableDescriptor); ok && table.Public() {
+				ranges = append(ranges, tableRevisionRange{table: table, start: manifest.EndTime, end: manifest.EndTime})
+			}
+		}
+		return ranges, nil
+	}
+
+	// The revisions of each descriptor, ordered by time. A nil descriptor
+	// represents a deletion.
+	revisions := make(map[descpb.ID][]backuppb.BackupManifest_DescriptorRevision)
+	it := iterFactory.NewDescriptorChangesIter(ctx)
+	defer it.Close()
+	for ; ; it.Next() {
+		if ok, err := it.Valid(); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+		rev := it.Value()
+		revisions[rev.ID] = append(revisions[rev.ID], *rev)
+	}
+	// Descriptors which did not change during the backup keep the version they
+	// had at its start.
+	for _, desc := range descriptors {
+		if _, ok := revisions[desc.GetID()]; !ok {
+			revisions[desc.GetID()] = []backuppb.BackupManifest_DescriptorRevision{{
+				ID:   desc.GetID(),
+				Time: manifest.StartTime,
+				Desc: desc.DescriptorProto(),
+			}}
+		}
+	}
+
+	revisionStart := manifest.StartTime
+	revisionStart.Forward(manifest.RevisionStartTime)
+	for _, revs := range revisions {
+		slices.SortFunc(revs, func(a, b backuppb.BackupManifest_DescriptorRevision) int {
+			return a.Time.Compare(b.Time)
+		})
+		for i, rev := range revs {
+			if rev.Desc == nil {
+				continue
+			}
+			table, ok := backupinfo.NewDescriptorForManifest(rev.Desc).(catalog.TableDescriptor)
+			if !ok || !table.Public() {
+				continue
+			}
+			start, end := rev.Time, manifest.EndTime
+			if i+1 < len(revs) {
+				end = revs[i+1].Time
+			}
+			start.Forward(revisionStart)
+			if end.LessEq(start) {
+				continue
+			}
+			ranges = append(ranges, tableRevisionRange{table: table, start: start, end: end})
+		}
+	}
+	return ranges, nil
+}
+
+// hasInProgressSchemaChange returns whether the table has a schema change in
+// progress, which is discarded when it is restored to a time within a backup.
+func hasInProgressSchemaChange(table catalog.TableDescriptor) bool {
+	return len(table.AllMutations()) > 0 || table.GetDeclarativeSchemaChangerState() != nil
+}
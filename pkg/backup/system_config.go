@@ -0,0 +1,518 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/backup/backuppb"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descidgen"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
+	pbtypes "github.com/gogo/protobuf/types"
+)
+
+// A BACKUP SYSTEM CONFIG backs up the system tables holding the configuration
+// of the cluster, and a RESTORE SYSTEM CONFIG applies the configuration held in
+// such a backup, or in a cluster backup, to the restoring cluster, e.g. to
+// bootstrap a staging cluster with the settings and roles of production. The
+// configuration is split into categories, which can be selected in both
+// statements:
+//
+//   - settings: the cluster settings, except for the cluster version;
+//   - zones: the zone configurations of the named ranges and of the system
+//     database and its tables. The zone configurations of user databases and
+//     tables are keyed on descriptor IDs specific to the backed up cluster, and
+//     are not restored;
+//   - roles: the users and roles, their memberships and options, their system
+//     privileges and their default session settings for all databases;
+//   - scheduled_jobs: the backup and changefeed schedules, which are restored
+//     paused and with new IDs.
+//
+// The system tables are restored into the temporary system database, as in a
+// cluster restore, and their rows are then merged into the real system tables
+// in a single transaction. Entries which already exist in the restoring
+// cluster are either skipped, the default, or overwritten, depending on the
+// on_conflict option. Entries of the restoring cluster which do not exist in
+// the backup are left untouched.
+
+const (
+	systemConfigSettings      = "settings"
+	systemConfigZones         = "zones"
+	systemConfigRoles         = "roles"
+	systemConfigScheduledJobs = "scheduled_jobs"
+)
+
+// systemConfigCategories are the categories of configuration covered by the
+// SYSTEM CONFIG target when none are named.
+var systemConfigCategories = []string{
+	systemConfigSettings,
+	systemConfigZones,
+	systemConfigRoles,
+	systemConfigScheduledJobs,
+}
+
+// resolveSystemConfigCategories returns the categories of configuration named
+// by the SYSTEM CONFIG target, or all of them if none are named.
+func resolveSystemConfigCategories(targets tree.BackupTargetList) ([]string, error) {
+	if targets.ConfigCategories == nil {
+		return systemConfigCategories, nil
+	}
+	categories := make([]string, 0, len(targets.ConfigCategories))
+	for _, name := range targets.ConfigCategories {
+		category := string(name)
+		if !slices.Contains(systemConfigCategories, category) {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unknown system config category %q, expected one of: %s",
+				category, strings.Join(systemConfigCategories, ", "))
+		}
+		if !slices.Contains(categories, category) {
+			categories = append(categories, category)
+		}
+	}
+	return categories, nil
+}
+
+// systemConfigTableNames returns the sorted names of the system tables holding
+// the given categories of configuration.
+func systemConfigTableNames(categories []string) []string {
+	var names []string
+	for name, config := range systemTableBackupConfiguration {
+		if config.systemConfigCategory != "" && slices.Contains(categories, config.systemConfigCategory) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// systemConfigBackupTargets returns the tables backed up by a BACKUP of the
+// given SYSTEM CONFIG target.
+func systemConfigBackupTargets(targets tree.BackupTargetList) (*tree.BackupTargetList, error) {
+	categories, err := resolveSystemConfigCategories(targets)
+	if err != nil {
+		return nil, err
+	}
+	var patterns tree.TablePatterns
+	for _, name := range systemConfigTableNames(categories) {
+		tn := tree.MakeTableNameWithSchema(
+			catconstants.SystemDatabaseName, catconstants.PublicSchemaName, tree.Name(name),
+		)
+		patterns = append(patterns, &tn)
+	}
+	return &tree.BackupTargetList{Tables: tree.TableAttrs{TablePatterns: patterns}}, nil
+}
+
+// selectSystemConfigTargets returns the system tables in the backup holding the
+// categories of configuration named by the SYSTEM CONFIG target.
+func selectSystemConfigTargets(
+	targets tree.BackupTargetList, allDescs []catalog.Descriptor,
+) ([]catalog.Descriptor, error) {
+	categories, err := resolveSystemConfigCategories(targets)
+	if err != nil {
+		return nil, err
+	}
+	names := systemConfigTableNames(categories)
+	var systemTables []catalog.Descriptor
+	for _, desc := range allDescs {
+		if _, ok := desc.(catalog.TableDescriptor); !ok {
+			continue
+		}
+		if desc.GetParentID() == keys.SystemDatabaseID && slices.Contains(names, desc.GetName()) {
+			systemTables = append(systemTables, desc)
+		}
+	}
+	if len(systemTables) == 0 {
+		return nil, errors.Errorf(
+			"cannot restore system config as the backup contains none of the system tables %s",
+			strings.Join(names, ", "))
+	}
+	return systemTables, nil
+}
+
+// planSystemConfigRestore returns the details of a RESTORE SYSTEM CONFIG.
+func planSystemConfigRestore(
+	ctx context.Context, exprEval *exprutil.Evaluator, opts tree.RestoreOptions,
+) (*jobspb.RestoreDetails_SystemConfig, error) {
+	skipConflicts := true
+	if opts.OnConflict != nil {
+		var err error
+		skipConflicts, err = evalRestoreOnConflict(ctx, exprEval, opts.OnConflict)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &jobspb.RestoreDetails_SystemConfig{SkipConflicts: skipConflicts}, nil
+}
+
+// systemConfigRestoreFunc merges the rows of a system table restored into the
+// temporary system database into the real system table.
+type systemConfigRestoreFunc func(
+	ctx context.Context, deps customRestoreFuncDeps, txn isql.Txn, skipConflicts bool,
+) error
+
+// systemConfigRestoreOrder lists the system tables restored by a RESTORE SYSTEM
+// CONFIG, in the order they are restored in. The tables referencing roles are
+// restored after system.users, so that the IDs of the roles restored from the
+// backup can be looked up, and the cluster settings are restored last.
+var systemConfigRestoreOrder = []struct {
+	table   string
+	restore systemConfigRestoreFunc
+}{
+	{table: systemschema.UsersTable.GetName(), restore: restoreSystemConfigUsers},
+	{table: systemschema.RoleMembersTable.GetName(), restore: restoreSystemConfigRoleMembers},
+	{table: systemschema.RoleOptionsTable.GetName(), restore: restoreSystemConfigRoleOptions},
+	{table: systemschema.SystemPrivilegeTable.GetName(), restore: restoreSystemConfigPrivileges},
+	{table: systemschema.DatabaseRoleSettingsTable.GetName(), restore: restoreSystemConfigDatabaseRoleSettings},
+	{table: systemschema.ZonesTable.GetName(), restore: restoreSystemConfigZones},
+	{table: systemschema.ScheduledJobsTable.GetName(), restore: restoreSystemConfigScheduledJobs},
+	{table: systemschema.SettingsTable.GetName(), restore: restoreSystemConfigSettings},
+}
+
+// restoreSystemConfig merges the rows of the system tables holding the cluster
+// configuration, which were restored into the temporary system database, into
+// the real system tables.
+func (r *restoreResumer) restoreSystemConfig(
+	ctx context.Context,
+	db isql.DB,
+	systemTables []catalog.TableDescriptor,
+	config *jobspb.RestoreDetails_SystemConfig,
+) error {
+	if config == nil {
+		return errors.AssertionFailedf("system config restore without system config details")
+	}
+	deps := customRestoreFuncDeps{
+		settings:  r.execCfg.Settings,
+		codec:     r.execCfg.Codec,
+		clusterID: r.execCfg.NodeInfo.LogicalClusterID(),
+	}
+	return db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		for _, t := range systemConfigRestoreOrder {
+			if !hasSystemTableByName(t.table, systemTables) {
+				continue
+			}
+			if err := t.restore(ctx, deps, txn, config.SkipConflicts); err != nil {
+				return errors.Wrapf(err, "restoring system.%s", t.table)
+			}
+		}
+		return nil
+	})
+}
+
+// mergeSystemConfigRows writes the rows returned by the given query into the
+// named columns of a system table, either skipping or overwriting the rows that
+// conflict with existing rows.
+func mergeSystemConfigRows(
+	ctx context.Context,
+	txn isql.Txn,
+	systemTableName string,
+	skipConflicts bool,
+	columns string,
+	query string,
+	qargs ...interface{},
+) error {
+	stmt := fmt.Sprintf("UPSERT INTO system.%s (%s) %s", systemTableName, columns, query)
+	if skipConflicts {
+		stmt = fmt.Sprintf("INSERT INTO system.%s (%s) %s ON CONFLICT DO NOTHING",
+			systemTableName, columns, query)
+	}
+	n, err := txn.ExecEx(ctx, redact.Sprintf("%s-system-config-merge", systemTableName),
+		txn.KV(), sessiondata.NodeUserSessionDataOverride, stmt, qargs...)
+	if err != nil {
+		return err
+	}
+	log.Infof(ctx, "restored %d rows of system.%s", n, systemTableName)
+	return nil
+}
+
+// restoreSystemConfigUsers creates the users and roles of the backup which do
+// not exist in the restoring cluster, with new IDs. Existing users keep their
+// IDs, and their passwords are only overwritten if requested. The password of
+// the root user is never overwritten, so that the restore cannot lock the
+// operator out of the cluster.
+func restoreSystemConfigUsers(
+	ctx context.Context, deps customRestoreFuncDeps, txn isql.Txn, skipConflicts bool,
+) error {
+	users, err := txn.QueryBufferedEx(ctx, "get-new-users", txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		fmt.Sprintf(`SELECT temp.username, temp."hashedPassword", temp."isRole" FROM %s.users AS temp
+WHERE NOT EXISTS (SELECT * FROM system.users AS u WHERE u.username = temp.username)`, restoreTempSystemDB),
+	)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		id, err := descidgen.GenerateUniqueRoleIDInTxn(ctx, txn.KV(), deps.codec)
+		if err != nil {
+			return err
+		}
+		if _, err := txn.ExecEx(ctx, "insert-new-user", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`INSERT INTO system.users ("username", "hashedPassword", "isRole", "user_id") VALUES ($1, $2, $3, $4)`,
+			user[0], user[1], user[2], id,
+		); err != nil {
+			return err
+		}
+	}
+	log.Infof(ctx, "restored %d new users", len(users))
+	if skipConflicts {
+		return nil
+	}
+	n, err := txn.ExecEx(ctx, "overwrite-existing-users", txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		fmt.Sprintf(`UPDATE system.users AS u
+SET "hashedPassword" = temp."hashedPassword", "isRole" = temp."isRole"
+FROM %s.users AS temp
+WHERE u.username = temp.username AND u.username <> $1`, restoreTempSystemDB),
+		username.RootUser,
+	)
+	if err != nil {
+		return err
+	}
+	log.Infof(ctx, "overwrote %d existing users", n)
+	return nil
+}
+
+func restoreSystemConfigRoleMembers(
+	ctx context.Context, _ customRestoreFuncDeps, txn isql.Txn, skipConflicts bool,
+) error {
+	return mergeSystemConfigRows(ctx, txn, "role_members", skipConflicts,
+		`"role", "member", "isAdmin", role_id, member_id`,
+		fmt.Sprintf(`SELECT temp."role", temp."member", temp."isAdmin", r.user_id, m.user_id
+FROM %s.role_members AS temp
+JOIN system.users AS r ON r.username = temp."role"
+JOIN system.users AS m ON m.username = temp."member"`, restoreTempSystemDB),
+	)
+}
+
+func restoreSystemConfigRoleOptions(
+	ctx context.Context, _ customRestoreFuncDeps, txn isql.Txn, skipConflicts bool,
+) error {
+	return mergeSystemConfigRows(ctx, txn, "role_options", skipConflicts,
+		`username, option, value, user_id`,
+		fmt.Sprintf(`SELECT temp.username, temp.option, temp.value, u.user_id
+FROM %s.role_options AS temp
+JOIN system.users AS u ON u.username = temp.username`, restoreTempSystemDB),
+	)
+}
+
+func restoreSystemConfigPrivileges(
+	ctx context.Context, _ customRestoreFuncDeps, txn isql.Txn, skipConflicts bool,
+) error {
+	return mergeSystemConfigRows(ctx, txn, "privileges", skipConflicts,
+		`username, path, privileges, grant_options, user_id`,
+		fmt.Sprintf(`SELECT temp.username, temp.path, temp.privileges, temp.grant_options,
+	CASE temp.username WHEN '%[2]s' THEN %[3]d ELSE u.user_id END
+FROM %[1]s.privileges AS temp
+LEFT JOIN system.users AS u ON u.username = temp.username
+WHERE temp.username = '%[2]s' OR u.user_id IS NOT NULL`,
+			restoreTempSystemDB, username.PublicRole, username.PublicRoleID),
+	)
+}
+
+// restoreSystemConfigDatabaseRoleSettings only restores the default session
+// settings which apply to all databases, as the others are keyed on database
+// IDs specific to the backed up cluster.
+func restoreSystemConfigDatabaseRoleSettings(
+	ctx context.Context, _ customRestoreFuncDeps, txn isql.Txn, skipConflicts bool,
+) error {
+	return mergeSystemConfigRows(ctx, txn, "database_role_settings", skipConflicts,
+		`database_id, role_name, settings, role_id`,
+		fmt.Sprintf(`SELECT temp.database_id, temp.role_name, temp.settings,
+	CASE temp.role_name WHEN '%[2]s' THEN %[3]d ELSE u.user_id END
+FROM %[1]s.database_role_settings AS temp
+LEFT JOIN system.users AS u ON u.username = temp.role_name
+WHERE temp.database_id = 0 AND (temp.role_name = '%[2]s' OR u.user_id IS NOT NULL)`,
+			restoreTempSystemDB, username.EmptyRole, username.EmptyRoleID),
+	)
+}
+
+// restoreSystemConfigZones only restores the zone configurations of the named
+// ranges and of the system database and its tables, whose IDs are the same in
+// every cluster.
+func restoreSystemConfigZones(
+	ctx context.Context, _ customRestoreFuncDeps, txn isql.Txn, skipConflicts bool,
+) error {
+	return mergeSystemConfigRows(ctx, txn, "zones", skipConflicts,
+		`id, config`,
+		fmt.Sprintf(`SELECT id, config FROM %s.zones WHERE id <= $1`, restoreTempSystemDB),
+		keys.MaxReservedDescID,
+	)
+}
+
+// restoreSystemConfigScheduledJobs only restores the backup and changefeed
+// schedules. The other schedules are either created by the restoring cluster
+// itself, or reference descriptors of the backed up cluster.
+//
+// The schedules are restored paused and with new IDs, so that they cannot
+// collide with the schedules of the restoring cluster, and do not start
+// running, e.g. writing to the collections of the backed up cluster, until
+// they are resumed. A schedule already exists in the restoring cluster if a
+// schedule with the same label and executor does; overwriting it replaces it
+// with the restored one.
+func restoreSystemConfigScheduledJobs(
+	ctx context.Context, deps customRestoreFuncDeps, txn isql.Txn, skipConflicts bool,
+) error {
+	backupExecutor := tree.ScheduledBackupExecutor.InternalName()
+	changefeedExecutor := tree.ScheduledChangefeedExecutor.InternalName()
+	rows, err := txn.QueryBufferedEx(ctx, "get-restored-schedules", txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		fmt.Sprintf(`SELECT temp.schedule_id, temp.schedule_name, temp.owner, temp.schedule_expr,
+	temp.schedule_details, temp.executor_type, temp.execution_args,
+	EXISTS (SELECT * FROM system.scheduled_jobs AS s
+	  WHERE s.schedule_name = temp.schedule_name AND s.executor_type = temp.executor_type)
+FROM %s.scheduled_jobs AS temp WHERE temp.executor_type IN ($1, $2)
+ORDER BY temp.schedule_id`, restoreTempSystemDB),
+		backupExecutor, changefeedExecutor,
+	)
+	if err != nil {
+		return err
+	}
+	if !skipConflicts {
+		if _, err := txn.ExecEx(ctx, "delete-overwritten-schedules", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			fmt.Sprintf(`DELETE FROM system.scheduled_jobs AS s WHERE EXISTS (
+	SELECT * FROM %s.scheduled_jobs AS temp
+	WHERE temp.schedule_name = s.schedule_name AND temp.executor_type = s.executor_type
+	  AND temp.executor_type IN ($1, $2))`, restoreTempSystemDB),
+			backupExecutor, changefeedExecutor,
+		); err != nil {
+			return err
+		}
+	}
+
+	state, err := protoutil.Marshal(&jobspb.ScheduleState{Status: restoredScheduleStatus})
+	if err != nil {
+		return err
+	}
+	newIDs := make(map[jobspb.ScheduleID]jobspb.ScheduleID, len(rows))
+	var backupSchedules []jobspb.ScheduleID
+	for _, row := range rows {
+		if skipConflicts && tree.MustBeDBool(row[7]) {
+			continue
+		}
+		details := row[4]
+		if details != tree.DNull {
+			// The schedule now belongs to the restoring cluster; otherwise it
+			// would pause itself again on its first run once resumed.
+			var sd jobspb.ScheduleDetails
+			if err := protoutil.Unmarshal([]byte(tree.MustBeDBytes(details)), &sd); err != nil {
+				return errors.Wrap(err, "decoding schedule details")
+			}
+			sd.ClusterID = deps.clusterID
+			detailsBytes, err := protoutil.Marshal(&sd)
+			if err != nil {
+				return err
+			}
+			details = tree.NewDBytes(tree.DBytes(detailsBytes))
+		}
+		newID, err := txn.QueryRowEx(ctx, "insert-restored-schedule", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`INSERT INTO system.scheduled_jobs (schedule_name, owner, next_run, schedule_state,
+	schedule_expr, schedule_details, executor_type, execution_args)
+VALUES ($1, $2, NULL, $3, $4, $5, $6, $7) RETURNING schedule_id`,
+			row[1], row[2], tree.NewDBytes(tree.DBytes(state)), row[3], details, row[5], row[6],
+		)
+		if err != nil {
+			return err
+		}
+		oldID := jobspb.ScheduleID(tree.MustBeDInt(row[0]))
+		newIDs[oldID] = jobspb.ScheduleID(tree.MustBeDInt(newID[0]))
+		if string(tree.MustBeDString(row[5])) == backupExecutor {
+			backupSchedules = append(backupSchedules, oldID)
+		}
+	}
+	log.Infof(ctx, "restored %d schedules", len(newIDs))
+
+	// The paired full and incremental backup schedules reference each other by
+	// ID, and the protected timestamp record chained by a schedule belongs to
+	// the backed up cluster.
+	for _, oldID := range backupSchedules {
+		if err := remapRestoredBackupSchedule(ctx, txn, newIDs[oldID], newIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoredScheduleStatus is the status of the schedules restored by a RESTORE
+// SYSTEM CONFIG.
+const restoredScheduleStatus = "restored from a backup: paused until resumed"
+
+// remapRestoredBackupSchedule updates the references of a restored backup
+// schedule to other schedules to their new IDs, dropping those which were not
+// restored, and clears its protected timestamp record.
+func remapRestoredBackupSchedule(
+	ctx context.Context,
+	txn isql.Txn,
+	id jobspb.ScheduleID,
+	newIDs map[jobspb.ScheduleID]jobspb.ScheduleID,
+) error {
+	row, err := txn.QueryRowEx(ctx, "get-restored-backup-schedule", txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT execution_args FROM system.scheduled_jobs WHERE schedule_id = $1`, id,
+	)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		return errors.AssertionFailedf("restored schedule %d not found", id)
+	}
+	var execArgs jobspb.ExecutionArguments
+	if err := protoutil.Unmarshal([]byte(tree.MustBeDBytes(row[0])), &execArgs); err != nil {
+		return errors.Wrap(err, "decoding schedule execution arguments")
+	}
+	args := &backuppb.ScheduledBackupExecutionArgs{}
+	if err := pbtypes.UnmarshalAny(execArgs.Args, args); err != nil {
+		return errors.Wrap(err, "decoding backup schedule arguments")
+	}
+	args.DependentScheduleID = newIDs[args.DependentScheduleID]
+	args.UnpauseOnSuccess = newIDs[args.UnpauseOnSuccess]
+	args.ProtectedTimestampRecord = nil
+	if execArgs.Args, err = pbtypes.MarshalAny(args); err != nil {
+		return err
+	}
+	argsBytes, err := protoutil.Marshal(&execArgs)
+	if err != nil {
+		return err
+	}
+	_, err = txn.ExecEx(ctx, "update-restored-backup-schedule", txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`UPDATE system.scheduled_jobs SET execution_args = $1 WHERE schedule_id = $2`,
+		tree.NewDBytes(tree.DBytes(argsBytes)), id,
+	)
+	return err
+}
+
+// restoreSystemConfigSettings restores the cluster settings, except for the
+// cluster version.
+func restoreSystemConfigSettings(
+	ctx context.Context, _ customRestoreFuncDeps, txn isql.Txn, skipConflicts bool,
+) error {
+	return mergeSystemConfigRows(ctx, txn, "settings", skipConflicts,
+		`name, value, "lastUpdated", "valueType"`,
+		fmt.Sprintf(`SELECT name, value, "lastUpdated", "valueType" FROM %s.settings
+WHERE name <> 'version'`, restoreTempSystemDB),
+	)
+}
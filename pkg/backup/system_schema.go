@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 )
@@ -83,6 +84,10 @@ type systemBackupConfiguration struct {
 	// holds the restore system table data into the given system table. If none
 	// is provided then `defaultRestoreFunc` is used.
 	customRestoreFunc func(ctx context.Context, deps customRestoreFuncDeps, txn isql.Txn, systemTableName, tempTableName string) error
+	// systemConfigCategory is the category of cluster configuration held by
+	// this system table, if any, which makes it a target of BACKUP and RESTORE
+	// SYSTEM CONFIG.
+	systemConfigCategory string
 
 	// The following fields are for testing.
 
@@ -95,6 +100,9 @@ type systemBackupConfiguration struct {
 type customRestoreFuncDeps struct {
 	settings *cluster.Settings
 	codec    keys.SQLCodec
+	// clusterID is the ID of the restoring cluster. It is only set for the
+	// restore of the system config.
+	clusterID uuid.UUID
 }
 
 // roleIDSequenceRestoreOrder is set to 1 since it must be after system.users
@@ -620,10 +628,12 @@ func roleIDSeqRestoreFunc(
 var systemTableBackupConfiguration = map[string]systemBackupConfiguration{
 	systemschema.UsersTable.GetName(): {
 		shouldIncludeInClusterBackup: optInToClusterBackup, // No desc ID columns.
+		systemConfigCategory:         systemConfigRoles,
 		customRestoreFunc:            usersRestoreFunc,
 	},
 	systemschema.ZonesTable.GetName(): {
 		shouldIncludeInClusterBackup: optInToClusterBackup, // ID in "id".
+		systemConfigCategory:         systemConfigZones,
 		// The zones table should be restored before the user data so that the range
 		// allocator properly distributes ranges during the restore.
 		migrationFunc:     rekeySystemTable("id"),
@@ -635,6 +645,7 @@ var systemTableBackupConfiguration = map[string]systemBackupConfiguration{
 		// settings before all other user and system data has been restored.
 		restoreInOrder:               math.MaxInt32,
 		shouldIncludeInClusterBackup: optInToClusterBackup, // No desc ID columns.
+		systemConfigCategory:         systemConfigSettings,
 		customRestoreFunc:            settingsRestoreFunc,
 	},
 	systemschema.LocationsTable.GetName(): {
@@ -642,11 +653,13 @@ var systemTableBackupConfiguration = map[string]systemBackupConfiguration{
 	},
 	systemschema.RoleMembersTable.GetName(): {
 		shouldIncludeInClusterBackup: optInToClusterBackup, // No desc ID columns.
+		systemConfigCategory:         systemConfigRoles,
 		customRestoreFunc:            roleMembersRestoreFunc,
 		restoreInOrder:               1, // Restore after system.users.
 	},
 	systemschema.RoleOptionsTable.GetName(): {
 		shouldIncludeInClusterBackup: optInToClusterBackup, // No desc ID columns.
+		systemConfigCategory:         systemConfigRoles,
 		customRestoreFunc:            roleOptionsRestoreFunc,
 		restoreInOrder:               1, // Restore after system.users.
 	},
@@ -662,6 +675,7 @@ var systemTableBackupConfiguration = map[string]systemBackupConfiguration{
 	},
 	systemschema.ScheduledJobsTable.GetName(): {
 		shouldIncludeInClusterBackup: optInToClusterBackup, // Desc IDs in some rows.
+		systemConfigCategory:         systemConfigScheduledJobs,
 		// Some rows, specifically those which are schedules for row-ttl, have IDs
 		// baked into their values, making the restored rows invalid. Rewriting them
 		// would be tricky since the ID is in a binary proto field, but we already
@@ -763,6 +777,7 @@ var systemTableBackupConfiguration = map[string]systemBackupConfiguration{
 	},
 	systemschema.DatabaseRoleSettingsTable.GetName(): {
 		shouldIncludeInClusterBackup: optInToClusterBackup, // ID in "database_id".
+		systemConfigCategory:         systemConfigRoles,
 		migrationFunc:                rekeySystemTable("database_id"),
 		customRestoreFunc:            systemDatabaseRoleSettingsRestoreFunc,
 		restoreInOrder:               1, // Restore after system.users.
@@ -805,6 +820,7 @@ var systemTableBackupConfiguration = map[string]systemBackupConfiguration{
 	},
 	systemschema.SystemPrivilegeTable.GetName(): {
 		shouldIncludeInClusterBackup: optInToClusterBackup, // No desc ID columns.
+		systemConfigCategory:         systemConfigRoles,
 		customRestoreFunc:            systemPrivilegesRestoreFunc,
 		restoreInOrder:               1, // Restore after system.users.
 	},
@@ -863,6 +879,12 @@ var systemTableBackupConfiguration = map[string]systemBackupConfiguration{
 	systemschema.PreparedTransactionsTable.GetName(): {
 		shouldIncludeInClusterBackup: optOutOfClusterBackup,
 	},
+	systemschema.BackupCatalogTable.GetName(): {
+		shouldIncludeInClusterBackup: optOutOfClusterBackup,
+	},
+	systemschema.TransactionDeadlocksTable.GetName(): {
+		shouldIncludeInClusterBackup: optOutOfClusterBackup,
+	},
 }
 
 func rekeySystemTable(
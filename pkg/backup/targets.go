@@ -408,6 +408,11 @@ func selectTargets(
 		return systemTables, nil, nil, nil, nil
 	}
 
+	if descriptorCoverage == tree.SystemConfig {
+		systemTables, err := selectSystemConfigTargets(targets, allDescs)
+		return systemTables, nil, nil, nil, err
+	}
+
 	if targets.TenantID.IsSet() {
 		for _, tenant := range lastBackupManifest.Tenants {
 			// TODO(dt): for now it is zero-or-one but when that changes, we should
@@ -9,6 +9,7 @@ import (
 	"context"
 	"maps"
 	"net/url"
+	"slices"
 
 	"github.com/cockroachdb/cockroach/pkg/backup/backupresolver"
 	"github.com/cockroachdb/cockroach/pkg/build"
@@ -128,6 +129,28 @@ func alterChangefeedPlanHook(
 			return errors.Errorf(`job %d is not changefeed job`, jobID)
 		}
 
+		// Unlike other alterations, pausing and resuming targets does not
+		// require the changefeed to be paused.
+		if alterTargetStates, err := isTargetStateAlteration(alterChangefeedStmt.Cmds); err != nil {
+			return err
+		} else if alterTargetStates {
+			if err := alterChangefeedTargetStates(
+				ctx, p, job, prevDetails, alterChangefeedStmt.Cmds,
+			); err != nil {
+				return err
+			}
+			telemetry.Count(telemetryPath)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case resultsCh <- tree.Datums{
+				tree.NewDInt(tree.DInt(jobID)),
+				tree.NewDString(jobPayload.Description),
+			}:
+				return nil
+			}
+		}
+
 		if job.State() != jobs.StatePaused {
 			return errors.Errorf(`job %d is not paused`, jobID)
 		}
@@ -280,6 +303,169 @@ func alterChangefeedPlanHook(
 	return fn, alterChangefeedHeader, false, nil
 }
 
+// isTargetStateAlteration returns true if the ALTER CHANGEFEED commands pause
+// or resume targets. Such commands cannot be combined with other commands.
+func isTargetStateAlteration(cmds tree.AlterChangefeedCmds) (bool, error) {
+	var targetStateCmds int
+	for _, cmd := range cmds {
+		switch cmd.(type) {
+		case *tree.AlterChangefeedPauseTarget, *tree.AlterChangefeedResumeTarget:
+			targetStateCmds++
+		}
+	}
+	if targetStateCmds > 0 && targetStateCmds < len(cmds) {
+		return false, pgerror.New(pgcode.FeatureNotSupported,
+			`cannot combine PAUSE or RESUME of targets with other ALTER CHANGEFEED commands`)
+	}
+	return targetStateCmds > 0, nil
+}
+
+// alterChangefeedTargetStates pauses or resumes tables of the changefeed. The
+// tables are recorded in the inactive targets of the job progress; a running
+// changefeed notices the change and restarts, excluding the paused tables, and
+// catching up on the changes to the resumed tables since they were paused.
+func alterChangefeedTargetStates(
+	ctx context.Context,
+	p sql.PlanHookState,
+	job *jobs.Job,
+	details jobspb.ChangefeedDetails,
+	cmds tree.AlterChangefeedCmds,
+) error {
+	if details.Select != "" {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			`cannot pause or resume targets of a CDC query changefeed`)
+	}
+	if job.State().Terminal() {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			`job %d is %s`, job.ID(), job.State())
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V25_2) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			`pausing and resuming changefeed targets requires the cluster upgrade to be finalized`)
+	}
+
+	statementTime := hlc.Timestamp{
+		WallTime: p.ExtendedEvalContext().GetStmtTimestamp().UnixNano(),
+	}
+	allDescs, err := backupresolver.LoadAllDescs(ctx, p.ExecCfg(), statementTime)
+	if err != nil {
+		return err
+	}
+	descResolver, err := backupresolver.NewDescriptorResolver(allDescs)
+	if err != nil {
+		return err
+	}
+	watched := AllTargets(details)
+	resolveTableID := func(target tree.ChangefeedTarget) (descpb.ID, error) {
+		if target.FamilyName != "" || target.IndexName != "" {
+			return 0, pgerror.Newf(pgcode.FeatureNotSupported,
+				`cannot pause or resume %q: targets are paused and resumed by table`,
+				tree.ErrString(&target))
+		}
+		desc, found, err := getTargetDesc(ctx, p, descResolver, target.TableName)
+		if err != nil {
+			return 0, err
+		}
+		if !found {
+			return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+				`target %q does not exist`, tree.ErrString(&target))
+		}
+		if found, _ := watched.EachHavingTableID(desc.GetID(), func(changefeedbase.Target) error {
+			return nil
+		}); !found {
+			return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+				`target %q is not watched by changefeed`, tree.ErrString(&target))
+		}
+		return desc.GetID(), nil
+	}
+
+	type targetStateChange struct {
+		target  tree.ChangefeedTarget
+		tableID descpb.ID
+		pause   bool
+	}
+	var changes []targetStateChange
+	for _, cmd := range cmds {
+		var targets tree.ChangefeedTargets
+		var pause bool
+		switch v := cmd.(type) {
+		case *tree.AlterChangefeedPauseTarget:
+			targets, pause = v.Targets, true
+			telemetry.CountBucketed(telemetryPath+`.paused_targets`, int64(len(v.Targets)))
+		case *tree.AlterChangefeedResumeTarget:
+			targets = v.Targets
+			telemetry.CountBucketed(telemetryPath+`.resumed_targets`, int64(len(v.Targets)))
+		}
+		for _, target := range targets {
+			id, err := resolveTableID(target)
+			if err != nil {
+				return err
+			}
+			changes = append(changes, targetStateChange{target: target, tableID: id, pause: pause})
+		}
+	}
+
+	return job.WithTxn(p.InternalSQLTxn()).Update(ctx, func(
+		txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+	) error {
+		progress := md.Progress
+		changefeedProgress := progress.GetChangefeed()
+		if changefeedProgress == nil {
+			return errors.AssertionFailedf(`job %d has no changefeed progress`, job.ID())
+		}
+		highWater := progress.GetHighWater()
+		for _, c := range changes {
+			i := findInactiveTarget(changefeedProgress, c.tableID)
+			if c.pause {
+				if i >= 0 {
+					if !changefeedProgress.InactiveTargets[i].Resuming {
+						return pgerror.Newf(pgcode.InvalidParameterValue,
+							`target %q is already paused`, tree.ErrString(&c.target))
+					}
+					// The changefeed has not started catching up on the table yet.
+					changefeedProgress.InactiveTargets[i].Resuming = false
+					continue
+				}
+				if highWater == nil || highWater.IsEmpty() {
+					return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+						`cannot pause target %q before the initial scan of the changefeed completes`,
+						tree.ErrString(&c.target))
+				}
+				// A table which is still catching up is only resolved up to its
+				// catch-up timestamp.
+				resolved := *highWater
+				if from := removeCatchUpSpans(changefeedProgress,
+					p.ExecCfg().Codec.TableSpan(uint32(c.tableID))); from.IsSet() {
+					resolved.Backward(from)
+				}
+				changefeedProgress.InactiveTargets = append(changefeedProgress.InactiveTargets,
+					jobspb.ChangefeedInactiveTarget{TableID: c.tableID, Resolved: resolved})
+			} else {
+				if i < 0 || changefeedProgress.InactiveTargets[i].Resuming {
+					return pgerror.Newf(pgcode.InvalidParameterValue,
+						`target %q is not paused`, tree.ErrString(&c.target))
+				}
+				changefeedProgress.InactiveTargets[i].Resuming = true
+			}
+		}
+
+		var numActive int
+		_ = watched.EachTableID(func(id descpb.ID) error {
+			if i := findInactiveTarget(changefeedProgress, id); i < 0 || changefeedProgress.InactiveTargets[i].Resuming {
+				numActive++
+			}
+			return nil
+		})
+		if numActive == 0 {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				`cannot pause all targets of changefeed %d; consider pausing the changefeed instead`, job.ID())
+		}
+
+		ju.UpdateProgress(progress)
+		return nil
+	})
+}
+
 func getTargetDesc(
 	ctx context.Context,
 	p sql.PlanHookState,
@@ -486,8 +672,16 @@ func generateAndValidateNewTargets(
 		}
 
 		newTarget := tree.ChangefeedTarget{
-			TableName:  tablePattern,
-			FamilyName: tree.Name(targetSpec.FamilyName),
+			TableName:        tablePattern,
+			FamilyName:       tree.Name(targetSpec.FamilyName),
+			MaterializedView: desc.MaterializedView(),
+		}
+		if targetSpec.IndexID != 0 {
+			index, err := catalog.MustFindIndexByID(desc, targetSpec.IndexID)
+			if err != nil {
+				return err
+			}
+			newTarget.IndexName = tree.UnrestrictedName(index.GetName())
 		}
 		newTargets[k] = newTarget
 		newTableDescs[targetSpec.TableID] = descResolver.DescByID[targetSpec.TableID]
@@ -497,6 +691,8 @@ func generateAndValidateNewTargets(
 			TableID:           targetSpec.TableID,
 			FamilyName:        targetSpec.FamilyName,
 			StatementTimeName: string(targetSpec.StatementTimeName),
+			IndexID:           targetSpec.IndexID,
+			IndexName:         targetSpec.IndexName,
 		}
 		return nil
 	}); err != nil {
@@ -561,6 +757,13 @@ func generateAndValidateNewTargets(
 			existingTargetSpans := fetchSpansForDescs(p, existingTargetIDs)
 			var newTargetIDs []descpb.ID
 			for _, target := range v.Targets {
+				if target.IndexName != "" {
+					return nil, nil, hlc.Timestamp{}, nil, pgerror.Newf(
+						pgcode.FeatureNotSupported,
+						`cannot add secondary index target %q to an existing changefeed`,
+						tree.ErrString(&target),
+					)
+				}
 				desc, found, err := getTargetDesc(ctx, p, descResolver, target.TableName)
 				if err != nil {
 					return nil, nil, hlc.Timestamp{}, nil, err
@@ -655,6 +858,14 @@ func generateAndValidateNewTargets(
 		if err := removeSpansFromProgress(newJobProgress, droppedTargetSpans, newJobStatementTime); err != nil {
 			return nil, nil, hlc.Timestamp{}, nil, err
 		}
+		// Dropped tables are no longer paused, quarantined or catching up.
+		if changefeedProgress := newJobProgress.GetChangefeed(); changefeedProgress != nil {
+			removeCatchUpSpans(changefeedProgress, droppedTargetSpans...)
+			changefeedProgress.InactiveTargets = slices.DeleteFunc(changefeedProgress.InactiveTargets,
+				func(t jobspb.ChangefeedInactiveTarget) bool {
+					return slices.Contains(droppedIDs, t.TableID)
+				})
+		}
 	}
 
 	newTargetList := tree.ChangefeedTargets{}
@@ -757,9 +968,11 @@ func generateNewProgress(
 
 	haveHighwater := prevHighWater != nil && prevHighWater.IsSet()
 	// TODO(#142376): Whether a checkpoint exists seems orthogonal to what
-	// we do in this function. Consider removing this flag.
+	// we do in this function. Consider removing this flag. Spans which are
+	// catching up below the high watermark are treated like a checkpoint.
 	haveCheckpoint := changefeedProgress != nil &&
-		(!changefeedProgress.Checkpoint.IsEmpty() || !changefeedProgress.SpanLevelCheckpoint.IsEmpty())
+		(!changefeedProgress.Checkpoint.IsEmpty() || !changefeedProgress.SpanLevelCheckpoint.IsEmpty() ||
+			!changefeedProgress.CatchUpSpans.IsEmpty())
 
 	// Check if the progress does not need to be updated. The progress does not
 	// need to be updated if:
@@ -786,8 +999,10 @@ func generateNewProgress(
 	// TODO(#142369): We should create a new PTS record instead of just
 	// copying the old one.
 	var ptsRecord uuid.UUID
+	var inactiveTargets []jobspb.ChangefeedInactiveTarget
 	if changefeedProgress != nil {
 		ptsRecord = changefeedProgress.ProtectedTimestampRecord
+		inactiveTargets = changefeedProgress.InactiveTargets
 	}
 
 	// Check if the user is trying to perform an initial scan while the high
@@ -811,6 +1026,7 @@ func generateNewProgress(
 					SpanLevelCheckpoint: jobspb.NewTimestampSpansMap(map[hlc.Timestamp]roachpb.Spans{
 						newStatementTime: existingTargetSpans,
 					}),
+					InactiveTargets: inactiveTargets,
 				},
 			},
 		}
@@ -850,6 +1066,7 @@ func generateNewProgress(
 			Changefeed: &jobspb.ChangefeedProgress{
 				ProtectedTimestampRecord: ptsRecord,
 				SpanLevelCheckpoint:      jobspb.NewTimestampSpansMap(checkpointSpansMap),
+				InactiveTargets:          inactiveTargets,
 			},
 		},
 	}
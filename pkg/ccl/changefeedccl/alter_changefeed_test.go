@@ -501,6 +501,105 @@ func TestAlterChangefeedDropTargetAfterTableDrop(t *testing.T) {
 	cdcTest(t, testFn, feedTestEnterpriseSinks, feedTestNoExternalConnection)
 }
 
+func TestAlterChangefeedPauseResumeTarget(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		registry := s.Server.JobRegistry().(*jobs.Registry)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `CREATE TABLE bar (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1)`)
+		sqlDB.Exec(t, `INSERT INTO bar VALUES (1)`)
+
+		testFeed := feed(t, f, `CREATE CHANGEFEED FOR foo, bar`)
+		defer closeFeed(t, testFeed)
+
+		feed, ok := testFeed.(cdctest.EnterpriseTestFeed)
+		require.True(t, ok)
+
+		assertPayloads(t, testFeed, []string{
+			`foo: [1]->{"after": {"a": 1}}`,
+			`bar: [1]->{"after": {"a": 1}}`,
+		})
+		waitForHighwater(t, feed, registry)
+
+		sqlDB.ExpectErr(t, `cannot combine PAUSE or RESUME of targets with other ALTER CHANGEFEED commands`,
+			fmt.Sprintf(`ALTER CHANGEFEED %d PAUSE bar DROP foo`, feed.JobID()))
+		sqlDB.ExpectErr(t, `cannot pause all targets of changefeed`,
+			fmt.Sprintf(`ALTER CHANGEFEED %d PAUSE foo, bar`, feed.JobID()))
+		sqlDB.ExpectErr(t, `target "foo" is not paused`,
+			fmt.Sprintf(`ALTER CHANGEFEED %d RESUME foo`, feed.JobID()))
+
+		sqlDB.Exec(t, `PAUSE JOB $1`, feed.JobID())
+		waitForJobState(sqlDB, t, feed.JobID(), `paused`)
+		sqlDB.Exec(t, fmt.Sprintf(`ALTER CHANGEFEED %d PAUSE bar`, feed.JobID()))
+		sqlDB.ExpectErr(t, `target "bar" is already paused`,
+			fmt.Sprintf(`ALTER CHANGEFEED %d PAUSE bar`, feed.JobID()))
+		sqlDB.Exec(t, `RESUME JOB $1`, feed.JobID())
+		waitForJobState(sqlDB, t, feed.JobID(), `running`)
+
+		// Changes to bar are not emitted while it is paused.
+		sqlDB.Exec(t, `INSERT INTO bar VALUES (2)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2)`)
+		assertPayloads(t, testFeed, []string{
+			`foo: [2]->{"after": {"a": 2}}`,
+		})
+
+		// A schema change to foo while bar is paused is backfilled once, and
+		// not again when the changefeed catches up on bar.
+		sqlDB.Exec(t, `ALTER TABLE foo ADD COLUMN b INT DEFAULT 0`)
+		assertPayloads(t, testFeed, []string{
+			`foo: [1]->{"after": {"a": 1, "b": 0}}`,
+			`foo: [2]->{"after": {"a": 2, "b": 0}}`,
+		})
+
+		// Wait for the changefeed to resolve past the schema change to foo, so
+		// that it is not emitted again when the changefeed catches up on bar.
+		var insertTS string
+		sqlDB.QueryRow(t, `SELECT cluster_logical_timestamp()`).Scan(&insertTS)
+		ts := parseTimeToHLC(t, insertTS)
+		testutils.SucceedsSoon(t, func() error {
+			if hw := loadProgress(t, feed, registry).GetHighWater(); hw == nil || hw.LessEq(ts) {
+				return errors.Newf("waiting for highwater to pass %s", ts)
+			}
+			return nil
+		})
+
+		// Resuming bar catches up on the changes made while it was paused,
+		// without regressing the high-water of the changefeed.
+		hwBeforeResume := *loadProgress(t, feed, registry).GetHighWater()
+		sqlDB.Exec(t, fmt.Sprintf(`ALTER CHANGEFEED %d RESUME bar`, feed.JobID()))
+		assertPayloads(t, testFeed, []string{
+			`bar: [2]->{"after": {"a": 2}}`,
+		})
+		testutils.SucceedsSoon(t, func() error {
+			progress := loadProgress(t, feed, registry)
+			if hw := progress.GetHighWater(); hw == nil || hw.Less(hwBeforeResume) {
+				t.Fatalf("high-water regressed from %s to %s", hwBeforeResume, hw)
+			}
+			if targets := progress.GetChangefeed().InactiveTargets; len(targets) > 0 {
+				return errors.Newf("expected no inactive targets, found %v", targets)
+			}
+			if catchUp := progress.GetChangefeed().CatchUpSpans; !catchUp.IsEmpty() {
+				return errors.Newf("expected no spans catching up, found %s", catchUp)
+			}
+			return nil
+		})
+
+		// Changes to both tables are emitted once bar has caught up.
+		sqlDB.Exec(t, `INSERT INTO bar VALUES (3)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (3)`)
+		assertPayloads(t, testFeed, []string{
+			`bar: [3]->{"after": {"a": 3}}`,
+			`foo: [3]->{"after": {"a": 3, "b": 0}}`,
+		})
+	}
+
+	cdcTest(t, testFn, feedTestEnterpriseSinks, feedTestNoExternalConnection)
+}
+
 func TestAlterChangefeedDropTargetFamily(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/log/logcrash"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -41,6 +42,30 @@ type SinkClient interface {
 	CheckConnection(ctx context.Context) error
 }
 
+// transactionalSinkClient is implemented by SinkClients which may produce the
+// flushed payloads within a transaction that must be committed explicitly. The
+// batchingSink of a transactional client commits the transaction once all the
+// payloads up to a resolved timestamp have been flushed; see
+// batchingSink.CommitTransaction.
+type transactionalSinkClient interface {
+	// isTransactional returns whether payloads are produced within a
+	// transaction.
+	isTransactional() bool
+	CommitTransaction(ctx context.Context) error
+}
+
+// rejectingSinkClient is implemented by SinkClients which can hand the messages
+// that the sink rejects, e.g. because they are too large, to a handler instead
+// of failing the flush.
+type rejectingSinkClient interface {
+	// setRejectedMessageHandler makes the client call the handler with each
+	// message which the sink rejects, skipping the message if the handler
+	// returns nil. It returns false if the client can't skip messages. The
+	// messages only carry their source if it was passed to the BatchBuffer in
+	// their attributes.
+	setRejectedMessageHandler(handler func(ctx context.Context, msg *messageSource, reason error) error) bool
+}
+
 // BatchBuffer is an interface to aggregate KVs into a payload that can be sent
 // to the sink.
 type BatchBuffer interface {
@@ -87,6 +112,18 @@ type batchingSink struct {
 	wg      ctxgroup.Group
 	hasher  hash.Hash32
 	doneCh  chan struct{}
+
+	// dlq, if set, receives the messages which the sink rejects.
+	dlq *sinkDeadLetterQueue
+
+	// txnClient is set if the client produces payloads within a transaction.
+	txnClient transactionalSinkClient
+	txnMu     struct {
+		syncutil.Mutex
+		// held are the rows emitted to a transactional client which are yet to
+		// be sent to the batching worker, in the order they were emitted.
+		held []*rowEvent
+	}
 }
 
 type batchingSinkKnobs struct {
@@ -102,6 +139,9 @@ type flushReq struct {
 type attributes struct {
 	tableName string
 	headers   map[string][]byte
+	// source is only set if the sink diverts the messages it rejects to the
+	// dead letter queue.
+	source *messageSource
 }
 
 type rowEvent struct {
@@ -110,13 +150,28 @@ type rowEvent struct {
 	topicDescriptor TopicDescriptor
 	headers         rowHeaders
 
-	alloc kvevent.Alloc
-	mvcc  hlc.Timestamp
+	alloc   kvevent.Alloc
+	mvcc    hlc.Timestamp
+	updated hlc.Timestamp
 }
 
 // Flush implements the Sink interface, returning the first error that has
 // occured in the past EmitRow calls.
+//
+// Flush does not commit the transaction of a transactional client. It does send
+// all the held rows to the client though, so that the memory they hold is
+// released: as they may then be committed ahead of their resolved timestamp,
+// they may be emitted again once the changefeed restarts.
 func (s *batchingSink) Flush(ctx context.Context) error {
+	if err := s.releaseHeldRows(ctx, hlc.MaxTimestamp); err != nil {
+		return err
+	}
+	return s.flushBuffered(ctx)
+}
+
+// flushBuffered waits for all buffered rows to be flushed to the sink client,
+// without committing the client's transaction.
+func (s *batchingSink) flushBuffered(ctx context.Context) error {
 	defer s.metrics.recordFlushRequestCallback()()
 	flushWaiter := make(chan struct{})
 	select {
@@ -145,7 +200,72 @@ func (s *batchingSink) Flush(ctx context.Context) error {
 	return nil
 }
 
+// isTransactional implements the transactionalSink interface.
+func (s *batchingSink) isTransactional() bool {
+	return s.txnClient != nil
+}
+
+// CommitTransaction implements the transactionalSink interface.
+func (s *batchingSink) CommitTransaction(ctx context.Context, resolved hlc.Timestamp) error {
+	if s.txnClient == nil {
+		return s.flushBuffered(ctx)
+	}
+	if err := s.releaseHeldRows(ctx, resolved); err != nil {
+		return err
+	}
+	if err := s.flushBuffered(ctx); err != nil {
+		return err
+	}
+	return s.txnClient.CommitTransaction(ctx)
+}
+
+// releaseHeldRows sends the held rows updated at or before upTo to the
+// batching worker.
+func (s *batchingSink) releaseHeldRows(ctx context.Context, upTo hlc.Timestamp) error {
+	if s.txnClient == nil {
+		return nil
+	}
+	var release []*rowEvent
+	func() {
+		s.txnMu.Lock()
+		defer s.txnMu.Unlock()
+		held := s.txnMu.held
+		s.txnMu.held = nil
+		for _, r := range held {
+			if r.updated.LessEq(upTo) {
+				release = append(release, r)
+			} else {
+				s.txnMu.held = append(s.txnMu.held, r)
+			}
+		}
+	}()
+
+	for _, r := range release {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case s.eventCh <- r:
+		case <-s.doneCh:
+			return nil
+		}
+	}
+	return nil
+}
+
+// divertRejectedMessages implements the rejectedMessageDivertingSink
+// interface. It must be called before any row is emitted.
+func (s *batchingSink) divertRejectedMessages(dlq *sinkDeadLetterQueue) bool {
+	rc, ok := s.client.(rejectingSinkClient)
+	if !ok || !rc.setRejectedMessageHandler(dlq.divert) {
+		return false
+	}
+	s.dlq = dlq
+	return true
+}
+
 var _ Sink = (*batchingSink)(nil)
+var _ transactionalSink = (*batchingSink)(nil)
+var _ rejectedMessageDivertingSink = (*batchingSink)(nil)
 
 // Topics gives the names of all topics that have been initialized
 // and will receive resolved timestamps.
@@ -207,8 +327,18 @@ func (s *batchingSink) EmitRow(
 	payload.topicDescriptor = topic
 	payload.headers = headers
 	payload.mvcc = mvcc
+	payload.updated = updated
 	payload.alloc = alloc
 
+	if s.txnClient != nil {
+		// Rows are only sent to a transactional client once the transaction
+		// which they belong to is known; see CommitTransaction.
+		s.txnMu.Lock()
+		defer s.txnMu.Unlock()
+		s.txnMu.held = append(s.txnMu.held, payload)
+		return nil
+	}
+
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -227,20 +357,43 @@ func (s *batchingSink) EmitResolvedTimestamp(
 	if err != nil {
 		return err
 	}
-	// Flush the buffered rows.
-	if err = s.Flush(ctx); err != nil {
+	// Flush the buffered rows. Transactional clients commit them together with
+	// the resolved timestamp below.
+	if err := s.releaseHeldRows(ctx, resolved); err != nil {
+		return err
+	}
+	if err = s.flushBuffered(ctx); err != nil {
 		return err
 	}
 
-	return s.client.FlushResolvedPayload(ctx, data, s.topicNamer.Each, s.retryOpts)
+	if err := s.client.FlushResolvedPayload(ctx, data, s.topicNamer.Each, s.retryOpts); err != nil {
+		return err
+	}
+	if s.txnClient != nil {
+		return s.txnClient.CommitTransaction(ctx)
+	}
+	return nil
 }
 
 // Close implements the Sink interface.
 func (s *batchingSink) Close() error {
 	close(s.doneCh)
 	_ = s.wg.Wait()
+	// The held rows are never committed: the transaction they would belong to
+	// is aborted by the client.
+	s.txnMu.Lock()
+	for _, r := range s.txnMu.held {
+		r.alloc.Release(context.Background())
+		freeRowEvent(r)
+	}
+	s.txnMu.held = nil
+	s.txnMu.Unlock()
 	s.pacer.Close()
-	return s.client.Close()
+	err := s.client.Close()
+	if s.dlq != nil {
+		err = errors.CombineErrors(err, s.dlq.Close())
+	}
+	return err
 }
 
 // Dial implements the Sink interface.
@@ -268,6 +421,9 @@ type sinkBatch struct {
 
 	alloc  kvevent.Alloc
 	hasher hash.Hash32
+	// trackSources is set if the sink diverts the messages it rejects to the
+	// dead letter queue, which requires the source of each message.
+	trackSources bool
 }
 
 // FinalizePayload closes the writer to produce a payload that is ready to be
@@ -307,10 +463,21 @@ func (sb *sinkBatch) Append(e *rowEvent) {
 		sb.bufferTime = timeutil.Now()
 	}
 
-	sb.buffer.Append(e.key, e.val, attributes{
+	attrs := attributes{
 		tableName: e.topicDescriptor.GetTableName(),
 		headers:   e.headers,
-	})
+	}
+	if sb.trackSources {
+		attrs.source = &messageSource{
+			tableID:   e.topicDescriptor.GetTopicIdentifier().TableID,
+			tableName: attrs.tableName,
+			key:       e.key,
+			value:     e.val,
+			updated:   e.updated,
+			mvcc:      e.mvcc,
+		}
+	}
+	sb.buffer.Append(e.key, e.val, attrs)
 
 	sb.keys.Add(hashToInt(sb.hasher, e.key))
 	sb.numMessages += 1
@@ -333,6 +500,7 @@ func (s *batchingSink) newBatchBuffer(topic string) *sinkBatch {
 	batch := newSinkBatch()
 	batch.buffer = s.client.MakeBatchBuffer(topic)
 	batch.hasher = s.hasher
+	batch.trackSources = s.dlq != nil
 	return batch
 }
 
@@ -568,6 +736,9 @@ func makeBatchingSink(
 		pacer:             pacerFactory(),
 		doneCh:            make(chan struct{}),
 	}
+	if tc, ok := client.(transactionalSinkClient); ok && tc.isTransactional() {
+		sink.txnClient = tc
+	}
 
 	sink.wg.GoCtx(func(ctx context.Context) error {
 		sink.runBatchingWorker(ctx)
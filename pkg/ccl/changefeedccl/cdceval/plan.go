@@ -105,6 +105,50 @@ func SpansForExpression(
 	schemaTS hlc.Timestamp,
 	target jobspb.ChangefeedTargetSpecification,
 	sc *tree.SelectClause,
+) (_ roachpb.Spans, err error) {
+	return spansForExpression(ctx, execCfg, user, sd, descr, schemaTS, target, sc, nil /* index */)
+}
+
+// IndexSpansForExpression returns the spans of the specified secondary index
+// which must be scanned in order to locate all rows matching changefeed
+// expression predicate. The returned spans may be used to perform an initial
+// scan which looks up matching rows in the primary index, instead of scanning
+// the entire primary index. Select clause expression assumed to be normalized.
+func IndexSpansForExpression(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user username.SQLUsername,
+	sd *sessiondata.SessionData,
+	descr catalog.TableDescriptor,
+	schemaTS hlc.Timestamp,
+	target jobspb.ChangefeedTargetSpecification,
+	sc *tree.SelectClause,
+	indexName string,
+) (_ roachpb.Spans, _ catalog.Index, err error) {
+	index := catalog.FindPublicNonPrimaryIndex(descr, func(idx catalog.Index) bool {
+		return idx.GetName() == indexName
+	})
+	if index == nil {
+		return nil, nil, changefeedbase.WithTerminalError(pgerror.Newf(pgcode.UndefinedObject,
+			"index %q does not exist on table %q", indexName, descr.GetName()))
+	}
+	spans, err := spansForExpression(ctx, execCfg, user, sd, descr, schemaTS, target, sc, index)
+	if err != nil {
+		return nil, nil, err
+	}
+	return spans, index, nil
+}
+
+func spansForExpression(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user username.SQLUsername,
+	sd *sessiondata.SessionData,
+	descr catalog.TableDescriptor,
+	schemaTS hlc.Timestamp,
+	target jobspb.ChangefeedTargetSpecification,
+	sc *tree.SelectClause,
+	index catalog.Index,
 ) (_ roachpb.Spans, err error) {
 	d, err := newEventDescriptorForTarget(descr, target, schemaTS, false, false)
 	if err != nil {
@@ -124,8 +168,13 @@ func SpansForExpression(
 				return err
 			}
 
-			plan, err = sql.PlanCDCExpression(ctx, execCtx,
-				norm.SelectStatementForFamily(), sql.WithExtraColumn(prevCol))
+			stmt := norm.SelectStatementForFamily()
+			opts := []sql.CDCOption{sql.WithExtraColumn(prevCol)}
+			if index != nil {
+				stmt = norm.selectStatementForIndex(index)
+				opts = append(opts, sql.WithIndex(index))
+			}
+			plan, err = sql.PlanCDCExpression(ctx, execCtx, stmt, opts...)
 			return err
 
 		}); err != nil {
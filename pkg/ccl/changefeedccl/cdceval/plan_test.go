@@ -339,3 +339,100 @@ func normalizeAndPlan(
 	}
 	return norm, withDiff, plan, nil
 }
+
+func TestIndexSpansForExpression(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(context.Background())
+	s := srv.ApplicationLayer()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `
+CREATE TABLE foo (
+  a INT PRIMARY KEY,
+  tenant INT,
+  extra STRING,
+  INDEX by_tenant (tenant),
+  INDEX by_extra (extra),
+  FAMILY main (a, tenant),
+  FAMILY extra (extra)
+)`)
+
+	execCfg := s.ExecutorConfig().(sql.ExecutorConfig)
+	codec := execCfg.Codec
+	fooDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "foo")
+	byTenant, err := catalog.MustFindIndexByName(fooDesc, "by_tenant")
+	require.NoError(t, err)
+
+	mkIndexKey := func(val int) roachpb.Key {
+		key, err := keyside.Encode(
+			codec.IndexPrefix(uint32(fooDesc.GetID()), uint32(byTenant.GetID())),
+			tree.NewDInt(tree.DInt(val)), encoding.Ascending)
+		require.NoError(t, err)
+		return key
+	}
+
+	ctx := context.Background()
+	schemaTS := s.Clock().Now()
+
+	for _, tc := range []struct {
+		name      string
+		stmt      string
+		index     string
+		expectErr string
+		spans     roachpb.Spans
+	}{
+		{
+			name:  "equality",
+			stmt:  "SELECT * FROM foo WHERE tenant = 42",
+			index: "by_tenant",
+			spans: roachpb.Spans{{Key: mkIndexKey(42), EndKey: mkIndexKey(42).PrefixEnd()}},
+		},
+		{
+			name:  "range",
+			stmt:  "SELECT * FROM foo WHERE tenant >= 10 AND tenant < 20",
+			index: "by_tenant",
+			spans: roachpb.Spans{{Key: mkIndexKey(10), EndKey: mkIndexKey(20)}},
+		},
+		{
+			name:      "missing index",
+			stmt:      "SELECT * FROM foo WHERE tenant = 42",
+			index:     "no_such_index",
+			expectErr: `index "no_such_index" does not exist`,
+		},
+		{
+			name:      "index on another family",
+			stmt:      "SELECT * FROM foo WHERE tenant = 42",
+			index:     "by_extra",
+			expectErr: `not in the target column family`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sc, err := ParseChangefeedExpression(tc.stmt)
+			require.NoError(t, err)
+			target := jobspb.ChangefeedTargetSpecification{
+				Type:              jobspb.ChangefeedTargetSpecification_COLUMN_FAMILY,
+				TableID:           fooDesc.GetID(),
+				StatementTimeName: fooDesc.GetName(),
+				FamilyName:        "main",
+			}
+
+			const splitFamilies = true
+			norm, _, _, err := normalizeAndPlan(ctx, &execCfg, username.RootUserName(),
+				defaultDBSessionData, fooDesc, schemaTS, target, sc, splitFamilies)
+			require.NoError(t, err)
+
+			spans, index, err := IndexSpansForExpression(ctx, &execCfg, username.RootUserName(),
+				defaultDBSessionData, fooDesc, schemaTS, target, norm.SelectClause, tc.index)
+			if tc.expectErr != "" {
+				require.Regexp(t, tc.expectErr, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, byTenant.GetID(), index.GetID())
+			require.Equal(t, tc.spans, spans)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cdceval
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/lib/pq/oid"
+)
+
+// RangeFeedPredicatesForExpression returns the column predicates of a
+// kvpb.RangeFeedFilter which the rows of the specified column family must
+// satisfy to match the WHERE clause of the changefeed expression. Select
+// clause expression assumed to be normalized.
+//
+// The predicates are derived from the conjuncts of the WHERE clause comparing
+// a non-key column of the family to a constant with = or !=, or checking that
+// it is not NULL; the other conjuncts are ignored. The rangefeed server may
+// therefore only drop events the expression would filter out, and the
+// expression must still be evaluated on the events it emits.
+//
+// IS NULL conjuncts are ignored too: the columns of a deleted row are NULL, so
+// the expression may match a deletion whose previous value the server would
+// not match.
+func RangeFeedPredicatesForExpression(
+	ctx context.Context,
+	desc catalog.TableDescriptor,
+	familyID descpb.FamilyID,
+	sc *tree.SelectClause,
+) []kvpb.RangeFeedFilter_ColumnPredicate {
+	if sc.Where == nil || len(sc.From.Tables) != 1 {
+		return nil
+	}
+	family := catalog.FindFamilyByID(desc, familyID)
+	if family == nil {
+		return nil
+	}
+	d := predicateDeriver{
+		desc:      desc,
+		familyID:  familyID,
+		keyCols:   desc.GetPrimaryIndex().CollectKeyColumnIDs(),
+		familyCol: catalog.MakeTableColSet(family.ColumnIDs...),
+		semaCtx:   tree.MakeSemaContext(nil /* resolver */),
+	}
+	if t, ok := sc.From.Tables[0].(*tree.AliasedTableExpr); ok {
+		d.alias = t.As.Alias
+	}
+	var preds []kvpb.RangeFeedFilter_ColumnPredicate
+	for _, conjunct := range splitConjuncts(sc.Where.Expr, nil) {
+		if p, ok := d.predicate(ctx, conjunct); ok {
+			preds = append(preds, p)
+		}
+	}
+	return preds
+}
+
+// splitConjuncts appends the conjuncts of the expression to the slice given.
+func splitConjuncts(expr tree.Expr, conjuncts []tree.Expr) []tree.Expr {
+	switch t := expr.(type) {
+	case *tree.AndExpr:
+		return splitConjuncts(t.Right, splitConjuncts(t.Left, conjuncts))
+	case *tree.ParenExpr:
+		return splitConjuncts(t.Expr, conjuncts)
+	default:
+		return append(conjuncts, expr)
+	}
+}
+
+// predicateDeriver derives rangefeed column predicates from the conjuncts of
+// a WHERE clause on the columns of a column family.
+type predicateDeriver struct {
+	desc      catalog.TableDescriptor
+	alias     tree.Name
+	familyID  descpb.FamilyID
+	keyCols   catalog.TableColSet
+	familyCol catalog.TableColSet
+	semaCtx   tree.SemaContext
+}
+
+// predicate returns the column predicate equivalent to the conjunct, if any.
+func (d *predicateDeriver) predicate(
+	ctx context.Context, conjunct tree.Expr,
+) (p kvpb.RangeFeedFilter_ColumnPredicate, ok bool) {
+	switch t := conjunct.(type) {
+	case *tree.IsNotNullExpr:
+		col, ok := d.column(t.Expr)
+		if !ok {
+			return p, false
+		}
+		return d.makePredicate(col, kvpb.RangeFeedFilter_ColumnPredicate_IS_NOT_NULL, nil), true
+
+	case *tree.ComparisonExpr:
+		var op kvpb.RangeFeedFilter_ColumnPredicate_Op
+		switch t.Operator.Symbol {
+		case treecmp.EQ:
+			op = kvpb.RangeFeedFilter_ColumnPredicate_EQ
+		case treecmp.NE:
+			op = kvpb.RangeFeedFilter_ColumnPredicate_NE
+		default:
+			return p, false
+		}
+		col, ok := d.column(t.Left)
+		constant := t.Right
+		if !ok {
+			if col, ok = d.column(t.Right); !ok {
+				return p, false
+			}
+			constant = t.Left
+		}
+		if !hasCanonicalValueEncoding(col.GetType()) {
+			return p, false
+		}
+		value, ok := d.encodeConstant(ctx, constant, col.GetType())
+		if !ok {
+			return p, false
+		}
+		return d.makePredicate(col, op, value), true
+
+	default:
+		return p, false
+	}
+}
+
+// column returns the column the expression refers to, if it is a non-key
+// column stored in the column family.
+func (d *predicateDeriver) column(expr tree.Expr) (catalog.Column, bool) {
+	if p, ok := expr.(*tree.ParenExpr); ok {
+		return d.column(p.Expr)
+	}
+	name, ok := expr.(*tree.UnresolvedName)
+	if !ok || name.Star {
+		return nil, false
+	}
+	switch name.NumParts {
+	case 1:
+	case 2:
+		if table := tree.Name(name.Parts[1]); table != d.alias && string(table) != d.desc.GetName() {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	col := catalog.FindColumnByTreeName(d.desc, tree.Name(name.Parts[0]))
+	if col == nil || !col.Public() || col.IsVirtual() || col.IsSystemColumn() ||
+		d.keyCols.Contains(col.GetID()) || !d.familyCol.Contains(col.GetID()) {
+		return nil, false
+	}
+	return col, true
+}
+
+// encodeConstant returns the value encoding of the constant expression as a
+// datum of the specified type, if it is a non-NULL constant of that type.
+func (d *predicateDeriver) encodeConstant(
+	ctx context.Context, expr tree.Expr, typ *types.T,
+) ([]byte, bool) {
+	typed, err := tree.TypeCheckAndRequire(ctx, expr, &d.semaCtx, typ, "changefeed predicate")
+	if err != nil {
+		return nil, false
+	}
+	datum, ok := typed.(tree.Datum)
+	if !ok || datum == tree.DNull || datum.ResolvedType().Family() != typ.Family() {
+		return nil, false
+	}
+	value, err := valueside.Encode(nil, valueside.NoColumnID, datum)
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func (d *predicateDeriver) makePredicate(
+	col catalog.Column, op kvpb.RangeFeedFilter_ColumnPredicate_Op, value []byte,
+) kvpb.RangeFeedFilter_ColumnPredicate {
+	return kvpb.RangeFeedFilter_ColumnPredicate{
+		FamilyID: uint32(d.familyID),
+		ColumnID: uint32(col.GetID()),
+		Op:       op,
+		Value:    value,
+	}
+}
+
+// hasCanonicalValueEncoding returns whether two datums of the type are equal
+// if and only if their value encodings are, as required by the EQ and NE
+// rangefeed column predicates.
+func hasCanonicalValueEncoding(typ *types.T) bool {
+	switch typ.Family() {
+	case types.IntFamily, types.BoolFamily, types.BytesFamily, types.UuidFamily:
+		return true
+	case types.StringFamily:
+		// CHAR values compare equal regardless of trailing spaces, and collated
+		// strings are a different family.
+		return typ.Oid() == oid.T_text || typ.Oid() == oid.T_varchar
+	default:
+		return false
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cdceval
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeFeedPredicatesForExpression(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(context.Background())
+	s := srv.ApplicationLayer()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE foo (
+a INT PRIMARY KEY,
+b INT,
+s STRING,
+c CHAR(3),
+f FLOAT,
+e STRING,
+FAMILY main (a, b, s, c, f),
+FAMILY extra (e)
+)`)
+	fooDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "foo")
+
+	encode := func(d tree.Datum) []byte {
+		v, err := valueside.Encode(nil, valueside.NoColumnID, d)
+		require.NoError(t, err)
+		return v
+	}
+	pred := func(
+		familyID, columnID uint32, op kvpb.RangeFeedFilter_ColumnPredicate_Op, value []byte,
+	) kvpb.RangeFeedFilter_ColumnPredicate {
+		return kvpb.RangeFeedFilter_ColumnPredicate{
+			FamilyID: familyID, ColumnID: columnID, Op: op, Value: value,
+		}
+	}
+	const (
+		eq        = kvpb.RangeFeedFilter_ColumnPredicate_EQ
+		ne        = kvpb.RangeFeedFilter_ColumnPredicate_NE
+		isNotNull = kvpb.RangeFeedFilter_ColumnPredicate_IS_NOT_NULL
+	)
+
+	for _, tc := range []struct {
+		name     string
+		stmt     string
+		familyID descpb.FamilyID
+		expected []kvpb.RangeFeedFilter_ColumnPredicate
+	}{
+		{
+			name: "no where clause",
+			stmt: "SELECT * FROM foo",
+		},
+		{
+			name:     "equality",
+			stmt:     "SELECT * FROM foo WHERE b = 10",
+			expected: []kvpb.RangeFeedFilter_ColumnPredicate{pred(0, 2, eq, encode(tree.NewDInt(10)))},
+		},
+		{
+			name:     "constant on the left",
+			stmt:     "SELECT * FROM foo WHERE 10 != b",
+			expected: []kvpb.RangeFeedFilter_ColumnPredicate{pred(0, 2, ne, encode(tree.NewDInt(10)))},
+		},
+		{
+			name: "conjuncts",
+			stmt: "SELECT * FROM foo AS bar WHERE (bar.s = 'open' AND foo.b IS NOT NULL) AND f > 1",
+			expected: []kvpb.RangeFeedFilter_ColumnPredicate{
+				pred(0, 3, eq, encode(tree.NewDString("open"))),
+				pred(0, 2, isNotNull, nil),
+			},
+		},
+		{
+			name: "disjunction ignored",
+			stmt: "SELECT * FROM foo WHERE b = 1 OR b = 2",
+		},
+		{
+			name: "is null ignored",
+			stmt: "SELECT * FROM foo WHERE b IS NULL",
+		},
+		{
+			name: "primary key ignored",
+			stmt: "SELECT * FROM foo WHERE a = 1",
+		},
+		{
+			name: "char and float ignored",
+			stmt: "SELECT * FROM foo WHERE c = 'abc' AND f = 1.5",
+		},
+		{
+			name: "null constant ignored",
+			stmt: "SELECT * FROM foo WHERE b = NULL",
+		},
+		{
+			name: "cdc_prev ignored",
+			stmt: "SELECT * FROM foo WHERE (cdc_prev).b = 1",
+		},
+		{
+			name: "column of another family ignored",
+			stmt: "SELECT * FROM foo WHERE e = 'x'",
+		},
+		{
+			name:     "extra family",
+			stmt:     "SELECT * FROM foo WHERE e = 'x' AND b = 1",
+			familyID: 1,
+			expected: []kvpb.RangeFeedFilter_ColumnPredicate{pred(1, 6, eq, encode(tree.NewDString("x")))},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sc, err := ParseChangefeedExpression(tc.stmt)
+			require.NoError(t, err)
+			preds := RangeFeedPredicatesForExpression(context.Background(), fooDesc, tc.familyID, sc)
+			require.Equal(t, tc.expected, preds)
+		})
+	}
+}
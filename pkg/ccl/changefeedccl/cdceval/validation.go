@@ -52,6 +52,24 @@ func (n *NormalizedSelectClause) SelectStatementForFamily() *tree.Select {
 	return &tree.Select{Select: &sc}
 }
 
+// selectStatementForIndex returns tree.Select representing this object, which
+// forces the use of the specified secondary index when scanning target table.
+func (n *NormalizedSelectClause) selectStatementForIndex(index catalog.Index) *tree.Select {
+	// Similar to SelectStatementForFamily, we must not mutate underlying select
+	// clause.
+	sc := *n.SelectClause
+	sc.From.Tables = append(tree.TableExprs(nil), n.SelectClause.From.Tables...)
+	flags := &tree.IndexFlags{IndexID: tree.IndexID(index.GetID())}
+	if n.desc.HasOtherFamilies {
+		flags.FamilyID = &n.desc.FamilyID
+	}
+	sc.From.Tables[0] = &tree.AliasedTableExpr{
+		Expr:       n.SelectClause.From.Tables[0],
+		IndexFlags: flags,
+	}
+	return &tree.Select{Select: &sc}
+}
+
 // normalizeAndValidateSelectForTarget normalizes select expression and verifies
 // expression is valid for a table and target family.
 //
@@ -291,31 +291,6 @@ func NewEventDescriptor(
 		colsByName: make(map[string]int),
 	}
 
-	// addColumn is a helper to add a column to this descriptor.
-	addColumn := func(col catalog.Column, ord int) int {
-		resultColumn := ResultColumn{
-			ResultColumn: colinfo.ResultColumn{
-				Name:           col.GetName(),
-				Typ:            col.GetType(),
-				TableID:        desc.GetID(),
-				PGAttributeNum: uint32(col.GetPGAttributeNum()),
-			},
-			Computed:  col.IsComputed(),
-			Nullable:  col.IsNullable(),
-			ord:       ord,
-			sqlString: col.ColumnDesc().SQLStringNotHumanReadable(),
-		}
-
-		colIdx := len(sd.cols)
-		sd.cols = append(sd.cols, resultColumn)
-		sd.colsByName[col.GetName()] = colIdx
-
-		if col.GetType().UserDefined() {
-			sd.udtCols = append(sd.udtCols, colIdx)
-		}
-		return colIdx
-	}
-
 	// Primary key columns must be added in the same order they
 	// appear in the primary key index.
 	primaryIdx := desc.GetPrimaryIndex()
@@ -348,7 +323,7 @@ func NewEventDescriptor(
 			if !isPKey {
 				continue
 			}
-			colIdx := addColumn(col, ord)
+			colIdx := sd.addColumn(col, ord)
 			ord++
 			sd.keyCols[pKeyOrd] = colIdx
 			sd.valueCols = append(sd.valueCols, colIdx)
@@ -361,7 +336,7 @@ func NewEventDescriptor(
 		ord := 0
 		for _, col := range desc.PublicColumns() {
 			if isVirtual := col.IsVirtual(); isVirtual && includeVirtualColumns {
-				colIdx := addColumn(col, virtualColOrd)
+				colIdx := sd.addColumn(col, virtualColOrd)
 				sd.valueCols = append(sd.valueCols, colIdx)
 				continue
 			}
@@ -370,7 +345,7 @@ func NewEventDescriptor(
 			if !isPKey && !isInFamily {
 				continue
 			}
-			colIdx := addColumn(col, ord)
+			colIdx := sd.addColumn(col, ord)
 			ord++
 			if isPKey {
 				sd.keyCols[pKeyOrd] = colIdx
@@ -381,15 +356,102 @@ func NewEventDescriptor(
 		}
 	}
 
-	allCols := make([]int, len(sd.cols))
-	for i := 0; i < len(sd.cols); i++ {
-		allCols[i] = i
+	sd.initAllCols()
+	return &sd, nil
+}
+
+// NewIndexEventDescriptor returns EventDescriptor for the entries of the
+// specified secondary index. The key of the event consists of the index key
+// columns followed by the remaining primary key columns, and the value
+// contains all columns available in the index.
+func NewIndexEventDescriptor(
+	desc catalog.TableDescriptor, index catalog.Index, keyOnly bool, schemaTS hlc.Timestamp,
+) (*EventDescriptor, error) {
+	if index.Primary() {
+		return nil, errors.AssertionFailedf("expected secondary index, found primary index %d", index.GetID())
+	}
+	family, err := catalog.MustFindFamilyByID(desc, 0 /* id */)
+	if err != nil {
+		return nil, err
+	}
+	sd := EventDescriptor{
+		Metadata: Metadata{
+			TableID:          desc.GetID(),
+			TableName:        desc.GetName(),
+			Version:          desc.GetVersion(),
+			FamilyID:         family.ID,
+			FamilyName:       family.Name,
+			HasOtherFamilies: desc.NumFamilies() > 1,
+			SchemaTS:         schemaTS,
+		},
+		td:         desc,
+		colsByName: make(map[string]int),
 	}
-	sd.allCols = allCols
 
+	keyColumns := getIndexKeyColumns(index)
+	relevantColumns := keyColumns
+	if !keyOnly {
+		relevantColumns = getRelevantColumnsForIndex(desc, index)
+	}
+
+	// Columns are added in the order they are fetched, so that the ordinal
+	// position of each column matches its position in the decoded row.
+	var colIdxByID catalog.TableColMap
+	for ord, id := range relevantColumns {
+		col, err := catalog.MustFindColumnByID(desc, id)
+		if err != nil {
+			return nil, err
+		}
+		colIdx := sd.addColumn(col, ord)
+		colIdxByID.Set(id, colIdx)
+		sd.valueCols = append(sd.valueCols, colIdx)
+	}
+	sd.keyCols = make([]int, len(keyColumns))
+	for i, id := range keyColumns {
+		colIdx, ok := colIdxByID.Get(id)
+		if !ok {
+			return nil, errors.AssertionFailedf("expected to find column %d in index %s", id, index.GetName())
+		}
+		sd.keyCols[i] = colIdx
+	}
+
+	sd.initAllCols()
 	return &sd, nil
 }
 
+// addColumn adds a column to this descriptor, and returns its index in cols.
+func (d *EventDescriptor) addColumn(col catalog.Column, ord int) int {
+	resultColumn := ResultColumn{
+		ResultColumn: colinfo.ResultColumn{
+			Name:           col.GetName(),
+			Typ:            col.GetType(),
+			TableID:        d.td.GetID(),
+			PGAttributeNum: uint32(col.GetPGAttributeNum()),
+		},
+		Computed:  col.IsComputed(),
+		Nullable:  col.IsNullable(),
+		ord:       ord,
+		sqlString: col.ColumnDesc().SQLStringNotHumanReadable(),
+	}
+
+	colIdx := len(d.cols)
+	d.cols = append(d.cols, resultColumn)
+	d.colsByName[col.GetName()] = colIdx
+
+	if col.GetType().UserDefined() {
+		d.udtCols = append(d.udtCols, colIdx)
+	}
+	return colIdx
+}
+
+func (d *EventDescriptor) initAllCols() {
+	allCols := make([]int, len(d.cols))
+	for i := 0; i < len(d.cols); i++ {
+		allCols[i] = i
+	}
+	d.allCols = allCols
+}
+
 // DebugString returns event descriptor debug information.
 func (d *EventDescriptor) DebugString() string {
 	return fmt.Sprintf("EventDescriptor{table: %q(%d) family: %q(%d) pkCols=%v valCols=%v",
@@ -434,6 +496,7 @@ func (d *EventDescriptor) TableDescriptor() catalog.TableDescriptor {
 type eventDescriptorFactory func(
 	desc catalog.TableDescriptor,
 	family *descpb.ColumnFamilyDescriptor,
+	index catalog.Index,
 	schemaTS hlc.Timestamp,
 ) (*EventDescriptor, error)
 
@@ -454,18 +517,23 @@ type eventDecoder struct {
 	fetcher  fetcher                        // Fetcher to decode KV
 	desc     catalog.TableDescriptor        // Current descriptor
 	family   *descpb.ColumnFamilyDescriptor // Current family
+	index    catalog.Index                  // Current secondary index; nil for primary index.
 	schemaTS hlc.Timestamp                  // Schema timestamp.
 }
 
 func getEventDescriptorCached(
 	desc catalog.TableDescriptor,
 	family *descpb.ColumnFamilyDescriptor,
+	index catalog.Index,
 	includeVirtual bool,
 	keyOnly bool,
 	schemaTS hlc.Timestamp,
 	cache *cache.UnorderedCache,
 ) (*EventDescriptor, error) {
 	idVer := CacheKey{ID: desc.GetID(), Version: desc.GetVersion(), FamilyID: family.ID}
+	if index != nil {
+		idVer.IndexID = index.GetID()
+	}
 
 	if v, ok := cache.Get(idVer); ok {
 		ed := v.(*EventDescriptor)
@@ -474,7 +542,13 @@ func getEventDescriptorCached(
 		}
 	}
 
-	ed, err := NewEventDescriptor(desc, family, includeVirtual, keyOnly, schemaTS)
+	var ed *EventDescriptor
+	var err error
+	if index != nil {
+		ed, err = NewIndexEventDescriptor(desc, index, keyOnly, schemaTS)
+	} else {
+		ed, err = NewEventDescriptor(desc, family, includeVirtual, keyOnly, schemaTS)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -514,9 +588,10 @@ func NewEventDecoderWithCache(
 	getEventDescriptor := func(
 		desc catalog.TableDescriptor,
 		family *descpb.ColumnFamilyDescriptor,
+		index catalog.Index,
 		schemaTS hlc.Timestamp,
 	) (*EventDescriptor, error) {
-		return getEventDescriptorCached(desc, family, includeVirtual, keyOnly, schemaTS, eventDescriptorCache)
+		return getEventDescriptorCached(desc, family, index, includeVirtual, keyOnly, schemaTS, eventDescriptorCache)
 	}
 
 	return &eventDecoder{
@@ -582,7 +657,7 @@ func (d *eventDecoder) decodeKV(
 		return Row{}, err
 	}
 
-	ed, err := d.getEventDescriptor(d.desc, d.family, schemaTS)
+	ed, err := d.getEventDescriptor(d.desc, d.family, d.index, schemaTS)
 	if err != nil {
 		return Row{}, err
 	}
@@ -601,11 +676,19 @@ func (d *eventDecoder) decodeKV(
 func (d *eventDecoder) initForKey(
 	ctx context.Context, key roachpb.Key, schemaTS hlc.Timestamp, keyOnly bool,
 ) error {
-	desc, familyID, err := d.rfCache.tableDescForKey(ctx, key, schemaTS)
+	desc, indexID, familyID, err := d.rfCache.tableDescForKey(ctx, key, schemaTS)
 	if err != nil {
 		return err
 	}
 
+	if watchedIndexID, ok := d.rfCache.watchedIndexes[desc.GetID()]; ok {
+		if indexID != watchedIndexID {
+			return errors.AssertionFailedf("unexpected key from index %d of table %s (watching index %d)",
+				indexID, desc.GetName(), watchedIndexID)
+		}
+		return d.initForIndexKey(desc, indexID, familyID, schemaTS, keyOnly)
+	}
+
 	fetcher, family, err := d.rfCache.RowFetcherForColumnFamily(desc, familyID, systemColumns, keyOnly)
 	if err != nil {
 		return err
@@ -614,6 +697,37 @@ func (d *eventDecoder) initForKey(
 	d.schemaTS = schemaTS
 	d.desc = desc
 	d.family = family
+	d.index = nil
+	d.fetcher.Fetcher = fetcher
+	return nil
+}
+
+// initForIndexKey initializes decoder state to decode an entry of the
+// watched secondary index.
+func (d *eventDecoder) initForIndexKey(
+	desc catalog.TableDescriptor,
+	indexID descpb.IndexID,
+	familyID descpb.FamilyID,
+	schemaTS hlc.Timestamp,
+	keyOnly bool,
+) error {
+	index, err := catalog.MustFindIndexByID(desc, indexID)
+	if err != nil {
+		return err
+	}
+	family, err := catalog.MustFindFamilyByID(desc, familyID)
+	if err != nil {
+		return err
+	}
+	fetcher, err := d.rfCache.RowFetcherForIndex(desc, index, systemColumns, keyOnly)
+	if err != nil {
+		return err
+	}
+
+	d.schemaTS = schemaTS
+	d.desc = desc
+	d.family = family
+	d.index = index
 	d.fetcher.Fetcher = fetcher
 	return nil
 }
@@ -771,7 +885,7 @@ func TestingMakeEventRowFromDatums(datums tree.Datums) Row {
 func TestingGetFamilyIDFromKey(
 	decoder Decoder, key roachpb.Key, ts hlc.Timestamp,
 ) (descpb.FamilyID, error) {
-	_, familyID, err := decoder.(*eventDecoder).rfCache.tableDescForKey(context.Background(), key, ts)
+	_, _, familyID, err := decoder.(*eventDecoder).rfCache.tableDescForKey(context.Background(), key, ts)
 	return familyID, err
 }
 
@@ -855,6 +969,39 @@ func TestingMakeEventRowFromEncDatums(
 	}
 }
 
+// getIndexKeyColumns returns the ids of the columns which uniquely identify an
+// entry of the specified secondary index: index key columns followed by the
+// primary key columns not already in the index key.
+func getIndexKeyColumns(index catalog.Index) []descpb.ColumnID {
+	cols := make([]descpb.ColumnID, 0, index.NumKeyColumns()+index.NumKeySuffixColumns())
+	for i := 0; i < index.NumKeyColumns(); i++ {
+		cols = append(cols, index.GetKeyColumnID(i))
+	}
+	for i := 0; i < index.NumKeySuffixColumns(); i++ {
+		cols = append(cols, index.GetKeySuffixColumnID(i))
+	}
+	return cols
+}
+
+// getRelevantColumnsForIndex returns an array of column ids for public
+// columns available in the entries of the specified secondary index, in the
+// order of tableDesc.PublicColumns().
+func getRelevantColumnsForIndex(
+	tableDesc catalog.TableDescriptor, index catalog.Index,
+) []descpb.ColumnID {
+	cols := index.CollectKeyColumnIDs()
+	cols.UnionWith(index.CollectKeySuffixColumnIDs())
+	cols.UnionWith(index.CollectSecondaryStoredColumnIDs())
+
+	result := make([]descpb.ColumnID, 0, cols.Len())
+	for _, colID := range tableDesc.PublicColumnIDs() {
+		if cols.Contains(colID) {
+			result = append(result, colID)
+		}
+	}
+	return result
+}
+
 // getRelevantColumnsForFamily returns an array of column ids for public columns
 // including only primary key columns and columns in the specified familyDesc,
 // If includeVirtual is true, virtual columns, which may be outside the specified
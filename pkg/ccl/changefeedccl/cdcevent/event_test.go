@@ -137,6 +137,77 @@ CREATE TABLE foo (
 	}
 }
 
+func TestIndexEventDescriptor(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(context.Background())
+	s := srv.ApplicationLayer()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `
+CREATE TABLE foo (
+  a INT,
+  b STRING,
+  c STRING,
+  d INT,
+  PRIMARY KEY (b, a),
+  INDEX by_c (c) STORING (d),
+  UNIQUE INDEX by_d (d)
+)`)
+
+	tableDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "foo")
+	colNames := func(it Iterator) (res []string) {
+		require.NoError(t, it.Col(func(col ResultColumn) error {
+			res = append(res, col.Name)
+			return nil
+		}))
+		return res
+	}
+
+	for _, tc := range []struct {
+		index           string
+		keyOnly         bool
+		expectedKeyCols []string
+		expectedColumns []string
+	}{
+		{
+			index:           "by_c",
+			expectedKeyCols: []string{"c", "b", "a"},
+			expectedColumns: []string{"a", "b", "c", "d"},
+		},
+		{
+			index:           "by_c",
+			keyOnly:         true,
+			expectedKeyCols: []string{"c", "b", "a"},
+			expectedColumns: []string{"c", "b", "a"},
+		},
+		{
+			index:           "by_d",
+			expectedKeyCols: []string{"d", "b", "a"},
+			expectedColumns: []string{"a", "b", "d"},
+		},
+	} {
+		t.Run(fmt.Sprintf("%s/keyOnly=%t", tc.index, tc.keyOnly), func(t *testing.T) {
+			index, err := catalog.MustFindIndexByName(tableDesc, tc.index)
+			require.NoError(t, err)
+			ed, err := NewIndexEventDescriptor(tableDesc, index, tc.keyOnly, s.Clock().Now())
+			require.NoError(t, err)
+
+			require.Equal(t, tableDesc.GetID(), ed.TableID)
+			require.False(t, ed.HasOtherFamilies)
+
+			r := Row{EventDescriptor: ed}
+			require.Equal(t, tc.expectedKeyCols, colNames(r.ForEachKeyColumn()))
+			require.Equal(t, tc.expectedColumns, colNames(r.ForEachColumn()))
+		})
+	}
+
+	_, err := NewIndexEventDescriptor(tableDesc, tableDesc.GetPrimaryIndex(), false, s.Clock().Now())
+	require.Error(t, err)
+}
+
 // TestEventDescriptorWithSchemaChanges validates that complex schema changes
 // will produce valid event descriptors.
 func TestEventDescriptorWithSchemaChanges(t *testing.T) {
@@ -0,0 +1,94 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cdcevent
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// PrimaryKeyDecoder decodes secondary index entries, and returns the primary
+// index key of the row referenced by each entry.
+type PrimaryKeyDecoder struct {
+	desc      catalog.TableDescriptor
+	fetcher   row.Fetcher
+	colMap    catalog.TableColMap
+	keyPrefix []byte
+
+	kvProvider row.KVProvider
+	alloc      tree.DatumAlloc
+}
+
+// NewPrimaryKeyDecoder returns PrimaryKeyDecoder for the specified secondary
+// index of the table.
+func NewPrimaryKeyDecoder(
+	ctx context.Context, codec keys.SQLCodec, desc catalog.TableDescriptor, index catalog.Index,
+) (*PrimaryKeyDecoder, error) {
+	if index.Primary() {
+		return nil, errors.AssertionFailedf("expected secondary index, found primary index %d", index.GetID())
+	}
+
+	// Every secondary index contains primary key columns -- either as key
+	// suffix columns, or in the value for unique indexes.
+	pkCols := desc.GetPrimaryIndex().CollectKeyColumnIDs().Ordered()
+	var spec fetchpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(&spec, codec, desc, index, pkCols); err != nil {
+		return nil, err
+	}
+
+	d := &PrimaryKeyDecoder{
+		desc:      desc,
+		keyPrefix: rowenc.MakeIndexKeyPrefix(codec, desc.GetID(), desc.GetPrimaryIndexID()),
+	}
+	for i, id := range pkCols {
+		d.colMap.Set(id, i)
+	}
+	if err := d.fetcher.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &d.alloc,
+		Spec:              &spec,
+	}); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// PrimaryKey returns the primary index key prefix of the row referenced by the
+// secondary index entry. Returns nil key if the entry does not contain primary
+// key columns (e.g. the entry stores a non-zero column family of the index).
+func (d *PrimaryKeyDecoder) PrimaryKey(
+	ctx context.Context, kv roachpb.KeyValue,
+) (roachpb.Key, error) {
+	d.kvProvider.KVs = append(d.kvProvider.KVs[:0], kv)
+	if err := d.fetcher.ConsumeKVProvider(ctx, &d.kvProvider); err != nil {
+		return nil, err
+	}
+	datums, err := d.fetcher.NextRowDecoded(ctx)
+	if err != nil || datums == nil {
+		return nil, err
+	}
+
+	// Limit prefix capacity so that each key is encoded into a new buffer.
+	prefix := d.keyPrefix[:len(d.keyPrefix):len(d.keyPrefix)]
+	key, containsNull, err := rowenc.EncodeIndexKey(
+		d.desc, d.desc.GetPrimaryIndex(), d.colMap, datums, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if containsNull {
+		return nil, errors.AssertionFailedf("index entry %s references primary key with NULL value",
+			keys.PrettyPrint(nil, kv.Key))
+	}
+	return key, nil
+}
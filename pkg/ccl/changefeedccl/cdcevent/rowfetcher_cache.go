@@ -51,6 +51,9 @@ type rowFetcherCache struct {
 	descFetcher     tableDescFetcher
 	fetchers        *cache.UnorderedCache
 	watchedFamilies map[watchedFamily]struct{}
+	// watchedIndexes maps tables watched via one of their secondary
+	// indexes to the ID of that index.
+	watchedIndexes map[descpb.ID]descpb.IndexID
 
 	rfArgs rowFetcherArgs
 
@@ -173,6 +176,7 @@ func newRowFetcherCache(
 		},
 		fetchers:        cache.NewUnorderedCache(DefaultCacheConfig),
 		watchedFamilies: watchedFamilies,
+		watchedIndexes:  watchedIndexesFromTarget(targets),
 		rfArgs: rowFetcherArgs{
 			traceKV:             log.V(row.TraceKVVerbosity),
 			traceKVLogFrequency: traceKVLogFrequency.Get(&s.SV),
@@ -199,39 +203,53 @@ func watchedFamilesFromTarget(targets changefeedbase.Targets) (map[watchedFamily
 	return watchedFamilies, nil
 }
 
+func watchedIndexesFromTarget(targets changefeedbase.Targets) map[descpb.ID]descpb.IndexID {
+	var watchedIndexes map[descpb.ID]descpb.IndexID
+	_ = targets.EachTarget(func(t changefeedbase.Target) error {
+		if t.IndexID != 0 {
+			if watchedIndexes == nil {
+				watchedIndexes = make(map[descpb.ID]descpb.IndexID)
+			}
+			watchedIndexes[t.TableID] = t.IndexID
+		}
+		return nil
+	})
+	return watchedIndexes
+}
+
 func (c *rowFetcherCache) tableDescForKey(
 	ctx context.Context, key roachpb.Key, ts hlc.Timestamp,
-) (catalog.TableDescriptor, descpb.FamilyID, error) {
+) (catalog.TableDescriptor, descpb.IndexID, descpb.FamilyID, error) {
 	key, err := c.codec.StripTenantPrefix(key)
 	if err != nil {
-		return nil, descpb.FamilyID(0), err
+		return nil, 0, descpb.FamilyID(0), err
 	}
-	remaining, tableID, _, err := rowenc.DecodePartialTableIDIndexID(key)
+	remaining, tableID, indexID, err := rowenc.DecodePartialTableIDIndexID(key)
 	if err != nil {
-		return nil, descpb.FamilyID(0), err
+		return nil, 0, descpb.FamilyID(0), err
 	}
 
 	familyID, err := keys.DecodeFamilyKey(key)
 	if err != nil {
-		return nil, descpb.FamilyID(0), err
+		return nil, 0, descpb.FamilyID(0), err
 	}
 
 	family := descpb.FamilyID(familyID)
 
 	tableDesc, err := c.descFetcher.FetchTableDesc(ctx, tableID, ts)
 	if err != nil {
-		return nil, family, err
+		return nil, indexID, family, err
 	}
 	// Skip over the column data.
 	for skippedCols := 0; skippedCols < tableDesc.GetPrimaryIndex().NumKeyColumns(); skippedCols++ {
 		l, err := encoding.PeekLength(remaining)
 		if err != nil {
-			return nil, family, err
+			return nil, indexID, family, err
 		}
 		remaining = remaining[l:]
 	}
 
-	return tableDesc, family, nil
+	return tableDesc, indexID, family, nil
 }
 
 // ErrUnwatchedFamily is a sentinel error that indicates this part of the row
@@ -329,6 +347,72 @@ func (c *rowFetcherCache) RowFetcherForColumnFamily(
 	return rf, familyDesc, nil
 }
 
+// RowFetcherForIndex returns row.Fetcher for the specified secondary index.
+// The fetcher decodes all columns available in the index entries: the index
+// key columns, the primary key columns, and the stored columns.
+func (c *rowFetcherCache) RowFetcherForIndex(
+	tableDesc catalog.TableDescriptor,
+	index catalog.Index,
+	sysCols []descpb.ColumnDescriptor,
+	keyOnly bool,
+) (*row.Fetcher, error) {
+	if watched, ok := c.watchedIndexes[tableDesc.GetID()]; !ok || watched != index.GetID() {
+		return nil, errors.AssertionFailedf(
+			"index %d of table %s is not watched", index.GetID(), tableDesc.GetName())
+	}
+
+	idVer := CacheKey{ID: tableDesc.GetID(), Version: tableDesc.GetVersion(), IndexID: index.GetID()}
+	if v, ok := c.fetchers.Get(idVer); ok {
+		f := v.(*cachedFetcher)
+		if catalog.UserDefinedTypeColsHaveSameVersion(tableDesc, f.tableDesc) {
+			return &f.fetcher, nil
+		}
+	}
+
+	f := &cachedFetcher{tableDesc: tableDesc}
+	rf := &f.fetcher
+
+	var relevantColumns descpb.ColumnIDs
+	if keyOnly {
+		relevantColumns = getIndexKeyColumns(index)
+	} else {
+		relevantColumns = getRelevantColumnsForIndex(tableDesc, index)
+	}
+
+	var spec fetchpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(
+		&spec, c.codec, tableDesc, index, relevantColumns,
+	); err != nil {
+		return nil, err
+	}
+
+	// Add system columns.
+	for _, sc := range sysCols {
+		spec.FetchedColumns = append(spec.FetchedColumns, fetchpb.IndexFetchSpec_Column{
+			ColumnID:      sc.ID,
+			Name:          sc.Name,
+			Type:          sc.Type,
+			IsNonNullable: !sc.Nullable,
+		})
+	}
+
+	if err := rf.Init(
+		context.TODO(),
+		row.FetcherInitArgs{
+			WillUseKVProvider: true,
+			Alloc:             &c.a,
+			Spec:              &spec,
+			TraceKV:           c.rfArgs.traceKV,
+			TraceKVEvery:      &util.EveryN{N: c.rfArgs.traceKVLogFrequency},
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	c.fetchers.Add(idVer, f)
+	return rf, nil
+}
+
 // fixedDescFetcher is a tableDescFetcher that returns descriptors from a given
 // fixed set of descriptors.
 type fixedDescFetcher struct {
@@ -377,6 +461,7 @@ func NewFixedRowFetcherCache(
 		},
 		fetchers:        cache.NewUnorderedCache(DefaultCacheConfig),
 		watchedFamilies: watchedFamilies,
+		watchedIndexes:  watchedIndexesFromTarget(targets),
 		rfArgs: rowFetcherArgs{
 			traceKV:             log.V(row.TraceKVVerbosity),
 			traceKVLogFrequency: traceKVLogFrequency.Get(&s.SV),
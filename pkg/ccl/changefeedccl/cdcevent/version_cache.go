@@ -24,4 +24,5 @@ type CacheKey struct {
 	ID       descpb.ID
 	Version  descpb.DescriptorVersion
 	FamilyID descpb.FamilyID
+	IndexID  descpb.IndexID // Set only when decoding secondary index entries.
 }
@@ -62,6 +62,8 @@ func AllTargets(cd jobspb.ChangefeedDetails) (targets changefeedbase.Targets) {
 					TableID:           ts.TableID,
 					FamilyName:        ts.FamilyName,
 					StatementTimeName: changefeedbase.StatementTimeName(ts.StatementTimeName),
+					IndexID:           ts.IndexID,
+					IndexName:         ts.IndexName,
 				})
 			}
 		}
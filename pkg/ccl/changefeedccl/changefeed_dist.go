@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/flowinfra"
@@ -83,6 +84,13 @@ func distChangefeedFlow(
 	localState *cachedState,
 	resultsCh chan<- tree.Datums,
 ) error {
+	// Paused and quarantined targets are excluded from the flow, while resumed
+	// targets are caught up from the timestamp at which they were paused.
+	if err := activateResumedTargets(ctx, execCtx, jobID, details, localState); err != nil {
+		return err
+	}
+	details = withoutInactiveTargets(details, localState.progress.GetChangefeed())
+
 	opts := changefeedbase.MakeStatementOptions(details.Opts)
 	progress := localState.progress
 
@@ -114,6 +122,10 @@ func distChangefeedFlow(
 			// If we have a high-water set, use it to compute the spans, since the
 			// ones at the statement time may have been garbage collected by now.
 			schemaTS = initialHighWater
+			// Resumed tables are caught up from their earlier resolved timestamps.
+			if cfProgress := progress.GetChangefeed(); cfProgress != nil && !cfProgress.CatchUpSpans.IsEmpty() {
+				schemaTS = cfProgress.CatchUpSpans.MinTimestamp()
+			}
 		}
 
 		// We want to fetch the target spans as of the timestamp following the
@@ -183,7 +195,8 @@ var changefeedResultTypes = []*types.T{
 }
 
 // fetchSpansForTable returns the set of spans for the specified table.
-// Usually, this is just the primary index span.
+// Usually, this is just the primary index span, or the secondary index span
+// if the changefeed targets a secondary index of the table.
 // However, if details.Select is not empty, the set of spans returned may be
 // restricted to satisfy predicate in the select clause.
 func fetchSpansForTables(
@@ -195,8 +208,14 @@ func fetchSpansForTables(
 ) (roachpb.Spans, error) {
 	var trackedSpans []roachpb.Span
 	if details.Select == "" {
+		targets := AllTargets(details)
+		codec := execCtx.ExecCfg().Codec
 		for _, d := range tableDescs {
-			trackedSpans = append(trackedSpans, d.PrimaryIndexSpan(execCtx.ExecCfg().Codec))
+			if indexID, ok := targets.WatchedIndexID(d.GetID()); ok {
+				trackedSpans = append(trackedSpans, d.IndexSpan(codec, indexID))
+			} else {
+				trackedSpans = append(trackedSpans, d.PrimaryIndexSpan(codec))
+			}
 		}
 		return trackedSpans, nil
 	}
@@ -223,6 +242,51 @@ func fetchSpansForTables(
 		sd, tableDescs[0], initialHighwater, target, sc)
 }
 
+// initialScanIndexSpans describes the secondary index spans which should be
+// used by the initial scan to locate rows matching changefeed expression.
+type initialScanIndexSpans struct {
+	indexID descpb.IndexID
+	spans   roachpb.Spans
+}
+
+// fetchInitialScanIndexSpans returns the spans of the secondary index
+// specified via initial_scan_index option which must be scanned in order to
+// find all rows matching changefeed expression. Returns empty
+// initialScanIndexSpans if the option was not specified.
+func fetchInitialScanIndexSpans(
+	ctx context.Context,
+	execCtx sql.JobExecContext,
+	tableDescs []catalog.TableDescriptor,
+	details jobspb.ChangefeedDetails,
+	schemaTS hlc.Timestamp,
+) (initialScanIndexSpans, error) {
+	indexName, ok := changefeedbase.MakeStatementOptions(details.Opts).GetInitialScanIndex()
+	if !ok {
+		return initialScanIndexSpans{}, nil
+	}
+	if details.Select == "" || len(tableDescs) != 1 {
+		return initialScanIndexSpans{}, changefeedbase.WithTerminalError(pgerror.Newf(
+			pgcode.InvalidParameterValue, "%s requires changefeed expression on a single target",
+			changefeedbase.OptInitialScanIndex))
+	}
+	sc, err := cdceval.ParseChangefeedExpression(details.Select)
+	if err != nil {
+		return initialScanIndexSpans{}, pgerror.Wrap(err, pgcode.InvalidParameterValue,
+			"could not parse changefeed expression")
+	}
+
+	sd := sql.NewInternalSessionData(ctx, execCtx.ExecCfg().Settings, "changefeed-fetchInitialScanIndexSpans")
+	if details.SessionData != nil {
+		sd.SessionData = *details.SessionData
+	}
+	spans, index, err := cdceval.IndexSpansForExpression(ctx, execCtx.ExecCfg(), execCtx.User(),
+		sd, tableDescs[0], schemaTS, details.TargetSpecifications[0], sc, indexName)
+	if err != nil {
+		return initialScanIndexSpans{}, err
+	}
+	return initialScanIndexSpans{indexID: index.GetID(), spans: spans}, nil
+}
+
 // startDistChangefeed starts distributed changefeed execution.
 func startDistChangefeed(
 	ctx context.Context,
@@ -253,6 +317,19 @@ func startDistChangefeed(
 	}
 	localState.trackedSpans = trackedSpans
 
+	// The secondary index spans are only needed if the initial scan has not
+	// completed yet.
+	var indexSpans initialScanIndexSpans
+	if initialHighWater.IsEmpty() {
+		indexSpans, err = fetchInitialScanIndexSpans(ctx, execCtx, tableDescs, details, schemaTS)
+		if err != nil {
+			return err
+		}
+		if log.ExpensiveLogEnabled(ctx, 2) && len(indexSpans.spans) > 0 {
+			log.Infof(ctx, "initial scan index %d spans: %s", indexSpans.indexID, indexSpans.spans)
+		}
+	}
+
 	// Changefeed flows handle transactional consistency themselves.
 	var noTxn *kv.Txn
 
@@ -267,12 +344,36 @@ func startDistChangefeed(
 	if progress := localState.progress.GetChangefeed(); progress != nil && progress.SpanLevelCheckpoint != nil {
 		spanLevelCheckpoint = progress.SpanLevelCheckpoint
 	}
+	// The flow starts from the timestamps of the spans which are catching up,
+	// while the other spans resume from the high-water.
+	if progress := localState.progress.GetChangefeed(); progress != nil && !progress.CatchUpSpans.IsEmpty() {
+		spanLevelCheckpoint, err = getSpanLevelCheckpointFromProgress(localState.progress, details.StatementTime)
+		if err != nil {
+			return err
+		}
+		checkpoint = nil
+		initialHighWater, spanLevelCheckpoint = withCatchUpSpans(
+			initialHighWater, spanLevelCheckpoint, progress.CatchUpSpans, trackedSpans)
+	}
 	p, planCtx, err := makePlan(execCtx, jobID, details, description, initialHighWater,
-		trackedSpans, checkpoint, spanLevelCheckpoint, localState.drainingNodes)(ctx, dsp)
+		trackedSpans, indexSpans, checkpoint, spanLevelCheckpoint, localState.drainingNodes)(ctx, dsp)
 	if err != nil {
 		return err
 	}
 
+	if jobID != 0 && hasTransactionalSinkConfig(details) {
+		var numAggregators int
+		for i := range p.Processors {
+			if p.Processors[i].Spec.Core.ChangeAggregator != nil {
+				numAggregators++
+			}
+		}
+		if err := prepareTransactionalSinkSlots(ctx, execCtx, jobID, details, numAggregators,
+			initialHighWater, spanLevelCheckpoint); err != nil {
+			return err
+		}
+	}
+
 	execPlan := func(ctx context.Context) error {
 		// Derive a separate context so that we can shut down the changefeed
 		// as soon as we see an error.
@@ -384,6 +485,7 @@ func makePlan(
 	description string,
 	initialHighWater hlc.Timestamp,
 	trackedSpans []roachpb.Span,
+	indexSpans initialScanIndexSpans,
 	//lint:ignore SA1019 deprecated usage
 	legacyCheckpoint *jobspb.ChangefeedProgress_Checkpoint,
 	spanLevelCheckpoint *jobspb.TimestampSpansMap,
@@ -516,6 +618,12 @@ func makePlan(
 				JobID:               jobID,
 				Select:              execinfrapb.Expression{Expr: details.Select},
 				Description:         description,
+				// Index spans may reference rows watched by any aggregator, so
+				// each aggregator receives all of them, and ignores the rows
+				// outside of its watched spans.
+				InitialScanIndexSpans: indexSpans.spans,
+				InitialScanIndexID:    indexSpans.indexID,
+				SinkSlot:              int32(i),
 			}
 		}
 
@@ -9,11 +9,14 @@ import (
 	"context"
 	"fmt"
 	"iter"
+	"math"
 	"math/rand"
 	"slices"
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdceval"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcutils"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/checkpoint"
@@ -24,12 +27,15 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/closedts"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
@@ -41,6 +47,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/cancelchecker"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/logcrash"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
@@ -80,6 +87,10 @@ type changeAggregator struct {
 	// sink is the Sink to write rows to. Resolved timestamps are never written
 	// by changeAggregator.
 	sink EventSink
+	// txnSink is set if sink emits rows within transactions, in which case the
+	// rows are committed, and only reported as resolved to the changeFrontier,
+	// up to the local frontier; see commitSinkTransaction.
+	txnSink transactionalSink
 	// changedRowBuf, if non-nil, contains changed rows to be emitted. Anything
 	// queued in `resolvedSpanBuf` is dependent on these having been emitted, so
 	// this one must be empty before moving on to that one.
@@ -318,7 +329,7 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 	ctx = logtags.AddTag(ctx, changeAggregatorLogTag, nil /* value */)
 	ctx = ca.StartInternal(ctx, changeAggregatorProcName)
 
-	spans, err := ca.setupSpansAndFrontier()
+	spans, err := ca.setupSpansAndFrontier(ctx)
 	if err != nil {
 		if log.V(2) {
 			log.Infof(ca.Ctx(), "change aggregator moving to draining due to error setting up spans and frontier: %v", err)
@@ -369,7 +380,7 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 	}
 
 	ca.sink, err = getEventSink(ctx, ca.FlowCtx.Cfg, ca.spec.Feed, timestampOracle,
-		ca.spec.User(), ca.spec.JobID, recorder)
+		ca.spec.User(), ca.spec.JobID, aggregatorSinkInstanceID(ca.spec.SinkSlot), recorder)
 	if err != nil {
 		err = changefeedbase.MarkRetryableError(err)
 		if log.V(2) {
@@ -379,6 +390,18 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 		ca.cancel()
 		return
 	}
+	if ts, ok := ca.sink.(transactionalSink); ok && ts.isTransactional() {
+		ca.txnSink = ts
+	}
+	if err := maybeDivertRejectedMessages(ctx, ca.FlowCtx.Cfg, ca.sink, opts,
+		ca.spec.JobID, ca.spec.User(), ca.sliMetrics); err != nil {
+		if log.V(2) {
+			log.Infof(ca.Ctx(), "change aggregator moving to draining due to error creating dead letter queue: %v", err)
+		}
+		ca.MoveToDraining(err)
+		ca.cancel()
+		return
+	}
 
 	// This is the correct point to set up certain hooks depending on the sink
 	// type.
@@ -515,30 +538,162 @@ func (ca *changeAggregator) makeKVFeedCfg(
 		return kvfeed.Config{}, err
 	}
 
+	onTargetError, err := config.Opts.GetOnTargetError()
+	if err != nil {
+		return kvfeed.Config{}, err
+	}
+
+	var indexLookup *kvfeed.IndexLookup
+	if needsInitialScan && len(ca.spec.InitialScanIndexSpans) > 0 {
+		indexLookup, err = ca.makeInitialScanIndexLookup(ctx)
+		if err != nil {
+			return kvfeed.Config{}, err
+		}
+	}
+
+	rangeFeedFilter, err := ca.makeRangeFeedFilter(
+		schemaChange.Policy != changefeedbase.OptSchemaChangePolicyIgnore)
+	if err != nil {
+		return kvfeed.Config{}, err
+	}
+
 	return kvfeed.Config{
-		Writer:               buf,
-		Settings:             cfg.Settings,
-		DB:                   cfg.DB.KV(),
-		Codec:                cfg.Codec,
-		Clock:                cfg.DB.KV().Clock(),
-		Spans:                spans,
-		SpanLevelCheckpoint:  ca.spec.SpanLevelCheckpoint,
-		Targets:              AllTargets(ca.spec.Feed),
-		Metrics:              &ca.metrics.KVFeedMetrics,
-		MM:                   memMon,
-		InitialHighWater:     initialHighWater,
-		EndTime:              config.EndTime,
-		WithDiff:             filters.WithDiff,
-		WithFiltering:        filters.WithFiltering,
-		WithFrontierQuantize: changefeedbase.Quantize.Get(&cfg.Settings.SV),
-		NeedsInitialScan:     needsInitialScan,
-		SchemaChangeEvents:   schemaChange.EventClass,
-		SchemaChangePolicy:   schemaChange.Policy,
-		SchemaFeed:           sf,
-		Knobs:                ca.knobs.FeedKnobs,
-		ScopedTimers:         ca.sliMetrics.Timers,
-		MonitoringCfg:        monitoringCfg,
-		ConsumerID:           int64(ca.spec.JobID),
+		Writer:                 buf,
+		Settings:               cfg.Settings,
+		DB:                     cfg.DB.KV(),
+		Codec:                  cfg.Codec,
+		Clock:                  cfg.DB.KV().Clock(),
+		Spans:                  spans,
+		SpanLevelCheckpoint:    ca.spec.SpanLevelCheckpoint,
+		Targets:                AllTargets(ca.spec.Feed),
+		Metrics:                &ca.metrics.KVFeedMetrics,
+		MM:                     memMon,
+		InitialHighWater:       initialHighWater,
+		EndTime:                config.EndTime,
+		WithDiff:               filters.WithDiff,
+		WithFiltering:          filters.WithFiltering,
+		WithFrontierQuantize:   changefeedbase.Quantize.Get(&cfg.Settings.SV),
+		NeedsInitialScan:       needsInitialScan,
+		InitialScanIndexLookup: indexLookup,
+		RangeFeedFilter:        rangeFeedFilter,
+		SchemaChangeEvents:     schemaChange.EventClass,
+		SchemaChangePolicy:     schemaChange.Policy,
+		SchemaFeed:             sf,
+		QuarantineTargets:      onTargetError == changefeedbase.OptOnTargetErrorQuarantine,
+		Knobs:                  ca.knobs.FeedKnobs,
+		ScopedTimers:           ca.sliMetrics.Timers,
+		MonitoringCfg:          monitoringCfg,
+		ConsumerID:             int64(ca.spec.JobID),
+	}, nil
+}
+
+// makeInitialScanIndexLookup returns kvfeed.IndexLookup which locates rows for
+// the initial scan by scanning secondary index spans specified in the spec.
+func (ca *changeAggregator) makeInitialScanIndexLookup(
+	ctx context.Context,
+) (*kvfeed.IndexLookup, error) {
+	execCfg := ca.FlowCtx.Cfg.ExecutorConfig.(*sql.ExecutorConfig)
+	tableDescs, err := fetchTableDescriptors(ctx, execCfg, AllTargets(ca.spec.Feed), ca.spec.Feed.StatementTime)
+	if err != nil {
+		return nil, err
+	}
+	if len(tableDescs) != 1 {
+		return nil, errors.AssertionFailedf(
+			"initial scan index lookup requires single target, found %d", len(tableDescs))
+	}
+	index, err := catalog.MustFindIndexByID(tableDescs[0], ca.spec.InitialScanIndexID)
+	if err != nil {
+		return nil, changefeedbase.WithTerminalError(err)
+	}
+	decoder, err := cdcevent.NewPrimaryKeyDecoder(ctx, execCfg.Codec, tableDescs[0], index)
+	if err != nil {
+		return nil, err
+	}
+	return &kvfeed.IndexLookup{
+		Spans:      ca.spec.InitialScanIndexSpans,
+		PrimaryKey: decoder.PrimaryKey,
+	}, nil
+}
+
+// makeRangeFeedFilter returns a function deriving the kvpb.RangeFeedFilter of
+// the rangefeed from the column families watched by the changefeed and the
+// predicate of its CDC query, or nil if nothing can be filtered on the server.
+//
+// The filter is derived from the table descriptors as of the timestamp the
+// rangefeed is started at, since the columns the query refers to by name may
+// be dropped and re-added. Column predicates are only derived if the kvfeed
+// restarts its rangefeed on schema changes, i.e. with withSchemaChanges.
+func (ca *changeAggregator) makeRangeFeedFilter(
+	withSchemaChanges bool,
+) (func(context.Context, hlc.Timestamp) (*kvpb.RangeFeedFilter, error), error) {
+	targets := AllTargets(ca.spec.Feed)
+	// familyNames are the names of the families of the COLUMN_FAMILY targets.
+	// The events of other families may only be dropped if no target watches
+	// every family of its table.
+	familyNames := make(map[descpb.ID][]string)
+	var unrestricted bool
+	if err := targets.EachTarget(func(t changefeedbase.Target) error {
+		switch t.Type {
+		case jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY:
+			// Only the primary family, whose ID is 0, may exist.
+			familyNames[t.TableID] = append(familyNames[t.TableID], "")
+		case jobspb.ChangefeedTargetSpecification_COLUMN_FAMILY:
+			familyNames[t.TableID] = append(familyNames[t.TableID], t.FamilyName)
+		default:
+			unrestricted = true
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if unrestricted {
+		return nil, nil
+	}
+
+	// Column predicates are only derived for expressions which don't need to
+	// be rewritten, see newEvaluator.
+	var sc *tree.SelectClause
+	if ca.spec.Select.Expr != "" && ca.spec.Feed.SessionData != nil &&
+		targets.NumUniqueTables() == 1 && withSchemaChanges {
+		var err error
+		if sc, err = cdceval.ParseChangefeedExpression(ca.spec.Select.Expr); err != nil {
+			return nil, err
+		}
+	}
+
+	execCfg := ca.FlowCtx.Cfg.ExecutorConfig.(*sql.ExecutorConfig)
+	return func(ctx context.Context, ts hlc.Timestamp) (*kvpb.RangeFeedFilter, error) {
+		tableDescs, err := fetchTableDescriptors(ctx, execCfg, targets, ts)
+		if err != nil {
+			return nil, err
+		}
+		filter := &kvpb.RangeFeedFilter{}
+		families := make(map[descpb.FamilyID]struct{})
+		for _, desc := range tableDescs {
+			for _, name := range familyNames[desc.GetID()] {
+				familyID, found := descpb.FamilyID(0), name == ""
+				_ = desc.ForeachFamily(func(family *descpb.ColumnFamilyDescriptor) error {
+					if name != "" && family.Name == name {
+						familyID, found = family.ID, true
+						return iterutil.StopIteration()
+					}
+					return nil
+				})
+				if !found {
+					// The family no longer exists, which the schema feed reports.
+					return nil, nil
+				}
+				if _, ok := families[familyID]; !ok {
+					families[familyID] = struct{}{}
+					filter.ColumnFamilies = append(filter.ColumnFamilies, uint32(familyID))
+				}
+				if sc != nil {
+					filter.ColumnPredicates = append(filter.ColumnPredicates,
+						cdceval.RangeFeedPredicatesForExpression(ctx, desc, familyID, sc)...)
+				}
+			}
+		}
+		return filter, nil
 	}, nil
 }
 
@@ -599,7 +754,9 @@ func (ca *changeAggregator) getInitialHighWaterAndSpans() (hlc.Timestamp, []roac
 // different SpanFrontier elsewhere for the entire changefeed. This object is
 // used to filter out some previously emitted rows, and by the cloudStorageSink
 // to name its output files in lexicographically monotonic fashion.
-func (ca *changeAggregator) setupSpansAndFrontier() (spans []roachpb.Span, err error) {
+func (ca *changeAggregator) setupSpansAndFrontier(
+	ctx context.Context,
+) (spans []roachpb.Span, err error) {
 	initialHighWater, spans := ca.getInitialHighWaterAndSpans()
 	ca.frontier, err = resolvedspan.NewAggregatorFrontier(ca.spec.Feed.StatementTime, initialHighWater, spans...)
 	if err != nil {
@@ -631,6 +788,25 @@ func (ca *changeAggregator) setupSpansAndFrontier() (spans []roachpb.Span, err e
 		return nil, err
 	}
 
+	// Spans whose rows were committed to a transactional sink past the
+	// checkpoint are resumed from the committed timestamp, so that the
+	// committed rows are not emitted again.
+	if ca.spec.JobID != 0 && hasTransactionalSinkConfig(ca.spec.Feed) {
+		committed, err := readCommittedSinkProgress(ctx, ca.FlowCtx.Cfg.DB, ca.spec.JobID)
+		if err != nil {
+			return nil, err
+		}
+		if len(committed) > 0 {
+			for _, cp := range committed {
+				if err := checkpoint.Restore(ca.frontier, cp); err != nil {
+					return nil, err
+				}
+			}
+			ca.spec.SpanLevelCheckpoint = checkpoint.Make(
+				ca.frontier.Frontier(), ca.frontier.Entries(), math.MaxInt64, nil /* metrics */)
+		}
+	}
+
 	return spans, nil
 }
 
@@ -790,7 +966,11 @@ func (ca *changeAggregator) computeTrailingMetadata(meta *execinfrapb.Changefeed
 	// elements; but we are not interested in flushing potentially large number of events;
 	// all we want to ensure is that any previously observed event (such as resolved timestamp)
 	// has been fully processed.
-	if err := ca.flushBufferedEvents(); err != nil {
+	flush := ca.flushBufferedEvents
+	if ca.txnSink != nil {
+		flush = ca.commitSinkTransaction
+	}
+	if err := flush(); err != nil {
 		// This method may be invoked during shutdown when the context already canceled.
 		// Regardless for the cause of this error, there is nothing we can do with it anyway.
 		// All we want to ensure is that if any error occurs we still return correct checkpoint,
@@ -800,6 +980,9 @@ func (ca *changeAggregator) computeTrailingMetadata(meta *execinfrapb.Changefeed
 
 	// Build out the list of frontier spans.
 	for sp, ts := range ca.frontier.Entries() {
+		if ca.txnSink != nil {
+			ts.Backward(ca.frontier.Frontier())
+		}
 		meta.Checkpoint = append(meta.Checkpoint,
 			execinfrapb.ChangefeedMeta_FrontierSpan{
 				Span:      sp,
@@ -925,7 +1108,11 @@ func (ca *changeAggregator) flushFrontier() error {
 	// otherwise, we could lose buffered messages and violate the
 	// at-least-once guarantee. This is also true for checkpointing the
 	// resolved spans in the job progress.
-	if err := ca.flushBufferedEvents(); err != nil {
+	if ca.txnSink != nil {
+		if err := ca.commitSinkTransaction(); err != nil {
+			return err
+		}
+	} else if err := ca.flushBufferedEvents(); err != nil {
 		return err
 	}
 
@@ -933,9 +1120,48 @@ func (ca *changeAggregator) flushFrontier() error {
 	batch := jobspb.ResolvedSpans{
 		ResolvedSpans: slices.Collect(ca.frontier.All()),
 	}
+	if ca.txnSink != nil {
+		// Only the rows up to the local frontier have been committed, so that is
+		// as far as any span is resolved.
+		resolved := ca.frontier.Frontier()
+		for i := range batch.ResolvedSpans {
+			if rs := &batch.ResolvedSpans[i]; resolved.Less(rs.Timestamp) {
+				rs.Timestamp = resolved
+				rs.BoundaryType = jobspb.ResolvedSpan_NONE
+			}
+		}
+	}
 	return ca.emitResolved(batch)
 }
 
+// commitSinkTransaction commits the rows emitted to the transactional sink up
+// to the local frontier, and records that the spans of the aggregator are
+// committed up to it. A restarted changefeed resumes the spans from the
+// committed timestamp even if the job progress was not checkpointed past it,
+// so that rows which were already committed are not emitted again.
+//
+// A crash after the commit but before the record is written still causes the
+// committed rows to be emitted again: there is no way to commit both the rows
+// and the record atomically.
+func (ca *changeAggregator) commitSinkTransaction() error {
+	if err := ca.eventConsumer.Flush(ca.Ctx()); err != nil {
+		return err
+	}
+	resolved := ca.frontier.Frontier()
+	if err := ca.txnSink.CommitTransaction(ca.Ctx(), resolved); err != nil {
+		return err
+	}
+	if ca.spec.JobID == 0 || resolved.IsEmpty() {
+		return nil
+	}
+	var spans roachpb.SpanGroup
+	for sp := range ca.frontier.Entries() {
+		spans.Add(sp)
+	}
+	return writeCommittedSinkProgress(ca.Ctx(), ca.FlowCtx.Cfg.DB, ca.spec.JobID, ca.spec.SinkSlot,
+		jobspb.NewTimestampSpansMap(map[hlc.Timestamp]roachpb.Spans{resolved: spans.Slice()}))
+}
+
 func (ca *changeAggregator) emitResolved(batch jobspb.ResolvedSpans) error {
 	progressUpdate := jobspb.ResolvedSpans{
 		ResolvedSpans: batch.ResolvedSpans,
@@ -1021,6 +1247,10 @@ type changeFrontier struct {
 	// CHANGEFEED statement was run at. It's used in an assertion that we never
 	// regress the job high-water.
 	highWaterAtStart hlc.Timestamp
+	// catchUpFrom, if set, is the earliest timestamp of the spans catching up on
+	// resumed tables below highWaterAtStart. The frontier starts at it, while
+	// the job high-water is held at highWaterAtStart until the spans catch up.
+	catchUpFrom hlc.Timestamp
 	// passthroughBuf, in some but not all flows, contains changed row data to
 	// pass through unchanged to the gateway node.
 	passthroughBuf encDatumRowBuffer
@@ -1100,6 +1330,12 @@ func (cs *cachedState) SetCheckpoint(checkpoint *jobspb.TimestampSpansMap) {
 	cs.progress.Details.(*jobspb.Progress_Changefeed).Changefeed.SpanLevelCheckpoint = checkpoint
 }
 
+// SetCatchUpSpans records the spans which are still catching up on resumed
+// tables below the high-water.
+func (cs *cachedState) SetCatchUpSpans(catchUpSpans *jobspb.TimestampSpansMap) {
+	cs.progress.Details.(*jobspb.Progress_Changefeed).Changefeed.CatchUpSpans = catchUpSpans
+}
+
 // AggregatorFrontierSpans returns an iterator over the spans in the aggregator
 // frontier collected during shutdown.
 func (cs *cachedState) AggregatorFrontierSpans() iter.Seq2[roachpb.Span, hlc.Timestamp] {
@@ -1310,7 +1546,7 @@ func (cf *changeFrontier) Start(ctx context.Context) {
 	cf.sliMetrics = sli
 
 	cf.sink, err = getResolvedTimestampSink(ctx, cf.FlowCtx.Cfg, cf.spec.Feed, nilOracle,
-		cf.spec.User(), cf.spec.JobID, sli)
+		cf.spec.User(), cf.spec.JobID, frontierSinkInstanceID, sli)
 	if err != nil {
 		err = changefeedbase.MarkRetryableError(err)
 		if log.V(2) {
@@ -1360,6 +1596,13 @@ func (cf *changeFrontier) Start(ctx context.Context) {
 			cf.highWaterAtStart.Forward(*ts)
 			initialHighwater = *ts
 		}
+		// Spans catching up on resumed tables start below the high-water; the
+		// spec's checkpoint holds the other spans at the high-water.
+		if cfProgress := p.GetChangefeed(); cfProgress != nil && initialHighwater.IsSet() &&
+			!cfProgress.CatchUpSpans.IsEmpty() {
+			cf.catchUpFrom = cfProgress.CatchUpSpans.MinTimestamp()
+			initialHighwater = cf.catchUpFrom
+		}
 
 		// latestResolvedKV timestamp is set to the current time to make
 		// sure that even if the target table does not have any
@@ -1623,6 +1866,11 @@ func (cf *changeFrontier) noteAggregatorProgress(d rowenc.EncDatum) error {
 
 	cf.maybeMarkJobIdle(resolvedSpans.Stats.RecentKvCount)
 
+	// Spans catching up on resumed tables are resolved below the high-water.
+	minResolved := cf.highWaterAtStart
+	if cf.catchUpFrom.IsSet() {
+		minResolved = cf.catchUpFrom
+	}
 	for _, resolved := range resolvedSpans.ResolvedSpans {
 		// Inserting a timestamp less than the one the changefeed flow started at
 		// could potentially regress the job progress. This is not expected, but it
@@ -1631,10 +1879,10 @@ func (cf *changeFrontier) noteAggregatorProgress(d rowenc.EncDatum) error {
 		// TODO(dan): This is much more naturally expressed as an assert inside the
 		// job progress update closure, but it currently doesn't pass along the info
 		// we'd need to do it that way.
-		if !resolved.Timestamp.IsEmpty() && resolved.Timestamp.Less(cf.highWaterAtStart) {
+		if !resolved.Timestamp.IsEmpty() && resolved.Timestamp.Less(minResolved) {
 			logcrash.ReportOrPanic(cf.Ctx(), &cf.FlowCtx.Cfg.Settings.SV,
 				`got a span level timestamp %s for %s that is less than the initial high-water %s`,
-				redact.Safe(resolved.Timestamp), resolved.Span, redact.Safe(cf.highWaterAtStart))
+				redact.Safe(resolved.Timestamp), resolved.Span, redact.Safe(minResolved))
 			continue
 		}
 		if err := cf.forwardFrontier(resolved); err != nil {
@@ -1757,6 +2005,25 @@ func (cf *changeFrontier) checkpointJobProgress(
 		defer func() { cf.js.lastRunStatusUpdate = timeutil.Now() }()
 	}
 	cf.metrics.FrontierUpdates.Inc(1)
+
+	// While spans are catching up on resumed tables, the frontier is below the
+	// high-water the changefeed started at. That high-water is persisted
+	// instead, so that it never regresses, along with the progress of the
+	// spans which are catching up.
+	highWater := frontier
+	var catchUpSpans *jobspb.TimestampSpansMap
+	if cf.catchUpFrom.IsSet() && frontier.Less(cf.highWaterAtStart) {
+		highWater = cf.highWaterAtStart
+		catchUpSpans = catchUpSpansBelow(cf.frontier.Entries(), highWater)
+		checkpointAbove := make(map[hlc.Timestamp]roachpb.Spans)
+		for ts, spans := range spanLevelCheckpoint.All() {
+			if highWater.Less(ts) {
+				checkpointAbove[ts] = spans
+			}
+		}
+		spanLevelCheckpoint = jobspb.NewTimestampSpansMap(checkpointAbove)
+	}
+
 	if cf.js.job != nil {
 		var ptsUpdated bool
 		var checkpointStr string
@@ -1771,26 +2038,43 @@ func (cf *changeFrontier) checkpointJobProgress(
 			// Advance resolved timestamp.
 			progress := md.Progress
 			progress.Progress = &jobspb.Progress_HighWater{
-				HighWater: &frontier,
+				HighWater: &highWater,
 			}
 
 			changefeedProgress := progress.Details.(*jobspb.Progress_Changefeed).Changefeed
+			changefeedProgress.CatchUpSpans = catchUpSpans
+			// Tables paused concurrently are caught up from their own resolved
+			// timestamps once resumed.
+			for _, t := range changefeedProgress.InactiveTargets {
+				removeCatchUpSpans(changefeedProgress, cf.FlowCtx.Codec().TableSpan(uint32(t.TableID)))
+			}
+			var persistedCheckpoint *jobspb.TimestampSpansMap
 			if cv.IsActive(cf.Ctx(), clusterversion.V25_2) {
 				changefeedProgress.SpanLevelCheckpoint = spanLevelCheckpoint
 				checkpointStr = spanLevelCheckpoint.String()
+				persistedCheckpoint = spanLevelCheckpoint
 			} else {
 				legacyCheckpoint := checkpoint.ConvertToLegacyCheckpoint(spanLevelCheckpoint)
 				changefeedProgress.Checkpoint = legacyCheckpoint
 				checkpointStr = legacyCheckpoint.String()
 			}
 
+			// The rows committed to a transactional sink up to the persisted
+			// progress no longer need to be tracked separately.
+			if hasTransactionalSinkConfig(cf.spec.Feed) {
+				if err := clearCommittedSinkProgress(cf.Ctx(), txn, cf.spec.JobID,
+					frontier, persistedCheckpoint); err != nil {
+					return err
+				}
+			}
+
 			if ptsUpdated, err = cf.manageProtectedTimestamps(cf.Ctx(), txn, changefeedProgress); err != nil {
 				log.Warningf(cf.Ctx(), "error managing protected timestamp record: %v", err)
 				return err
 			}
 
 			if updateRunStatus {
-				progress.StatusMessage = fmt.Sprintf("running: resolved=%s", frontier)
+				progress.StatusMessage = fmt.Sprintf("running: resolved=%s", highWater)
 			}
 
 			ju.UpdateProgress(progress)
@@ -1804,12 +2088,13 @@ func (cf *changeFrontier) checkpointJobProgress(
 		}
 		if log.V(2) {
 			log.Infof(cf.Ctx(), "change frontier persisted highwater=%s and checkpoint=%s",
-				frontier, checkpointStr)
+				highWater, checkpointStr)
 		}
 	}
 
-	cf.localState.SetHighwater(frontier)
+	cf.localState.SetHighwater(highWater)
 	cf.localState.SetCheckpoint(spanLevelCheckpoint)
+	cf.localState.SetCatchUpSpans(catchUpSpans)
 
 	return true, nil
 }
@@ -1836,10 +2121,22 @@ func (cf *changeFrontier) manageProtectedTimestamps(
 	if highWater.Less(cf.highWaterAtStart) {
 		highWater = cf.highWaterAtStart
 	}
+	// Paused and quarantined targets are caught up from their resolved
+	// timestamps when resumed, and resumed targets from their catch-up
+	// timestamps, so their history must remain protected.
+	if !progress.CatchUpSpans.IsEmpty() {
+		highWater.Backward(progress.CatchUpSpans.MinTimestamp())
+	}
+	for _, t := range progress.InactiveTargets {
+		if t.Resolved.IsSet() && t.Resolved.Less(highWater) {
+			highWater = t.Resolved
+		}
+	}
+	targets := targetsToProtect(cf.spec.Feed, progress)
 
 	if progress.ProtectedTimestampRecord == uuid.Nil {
 		ptr := createProtectedTimestampRecord(
-			ctx, cf.FlowCtx.Codec(), cf.spec.JobID, AllTargets(cf.spec.Feed), highWater,
+			ctx, cf.FlowCtx.Codec(), cf.spec.JobID, targets, highWater,
 		)
 		progress.ProtectedTimestampRecord = ptr.ID.GetUUID()
 		return true, pts.Protect(ctx, ptr)
@@ -1857,7 +2154,7 @@ func (cf *changeFrontier) manageProtectedTimestamps(
 		if preserveDeprecatedPts := cf.knobs.PreserveDeprecatedPts != nil && cf.knobs.PreserveDeprecatedPts(); preserveDeprecatedPts {
 			return false, nil
 		}
-		if err := cf.remakePTSRecord(ctx, pts, progress, highWater); err != nil {
+		if err := cf.remakePTSRecord(ctx, pts, progress, targets, highWater); err != nil {
 			return false, err
 		}
 		return true, nil
@@ -1866,11 +2163,11 @@ func (cf *changeFrontier) manageProtectedTimestamps(
 	// If we've identified more tables that need to be protected since this
 	// changefeed was created, it will be missing here. If so, we "migrate" it
 	// to include all the appropriate targets.
-	if targets := AllTargets(cf.spec.Feed); !makeTargetToProtect(targets).Equal(rec.Target) {
+	if !makeTargetToProtect(targets).Equal(rec.Target) {
 		if preservePTSTargets := cf.knobs.PreservePTSTargets != nil && cf.knobs.PreservePTSTargets(); preservePTSTargets {
 			return false, nil
 		}
-		if err := cf.remakePTSRecord(ctx, pts, progress, highWater); err != nil {
+		if err := cf.remakePTSRecord(ctx, pts, progress, targets, highWater); err != nil {
 			return false, err
 		}
 		return true, nil
@@ -1892,11 +2189,12 @@ func (cf *changeFrontier) remakePTSRecord(
 	ctx context.Context,
 	pts protectedts.Storage,
 	progress *jobspb.ChangefeedProgress,
+	targets changefeedbase.Targets,
 	resolved hlc.Timestamp,
 ) error {
 	prevRecordId := progress.ProtectedTimestampRecord
 	ptr := createProtectedTimestampRecord(
-		ctx, cf.FlowCtx.Codec(), cf.spec.JobID, AllTargets(cf.spec.Feed), resolved,
+		ctx, cf.FlowCtx.Codec(), cf.spec.JobID, targets, resolved,
 	)
 	if err := pts.Protect(ctx, ptr); err != nil {
 		return err
@@ -1916,6 +2214,11 @@ func (cf *changeFrontier) maybeEmitResolved(newResolved hlc.Timestamp) error {
 	if cf.freqEmitResolved == emitNoResolved || newResolved.IsEmpty() {
 		return nil
 	}
+	// Resolved timestamps are not emitted while spans are catching up on
+	// resumed tables, since they would regress.
+	if newResolved.Less(cf.highWaterAtStart) {
+		return nil
+	}
 	sinceEmitted := newResolved.GoTime().Sub(cf.lastEmitResolved)
 	atBoundary, _, _ := cf.frontier.AtBoundary()
 	shouldEmit := sinceEmitted >= cf.freqEmitResolved || atBoundary
@@ -8,6 +8,7 @@ package changefeedccl
 import (
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -286,3 +287,52 @@ func TestSetupSpansAndFrontierWithNewSpec(t *testing.T) {
 		})
 	}
 }
+
+// TestCommittedSinkProgressCovered tests which committed sink progress records
+// are considered covered by the persisted changefeed progress, and thus
+// cleared.
+func TestCommittedSinkProgressCovered(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+	span := func(start, end string) roachpb.Span {
+		return roachpb.Span{Key: roachpb.Key(start), EndKey: roachpb.Key(end)}
+	}
+	spanLevelCheckpoint := jobspb.NewTimestampSpansMap(map[hlc.Timestamp]roachpb.Spans{
+		ts(20): {span("a", "b")},
+		ts(30): {span("b", "c")},
+	})
+
+	for _, tc := range []struct {
+		name      string
+		committed map[hlc.Timestamp]roachpb.Spans
+		covered   bool
+	}{
+		{
+			name:      "below the frontier",
+			committed: map[hlc.Timestamp]roachpb.Spans{ts(10): {span("a", "d")}},
+			covered:   true,
+		},
+		{
+			name:      "covered by the checkpoint",
+			committed: map[hlc.Timestamp]roachpb.Spans{ts(20): {span("a", "c")}},
+			covered:   true,
+		},
+		{
+			name:      "ahead of the checkpoint",
+			committed: map[hlc.Timestamp]roachpb.Spans{ts(25): {span("a", "c")}},
+			covered:   false,
+		},
+		{
+			name:      "span not in the checkpoint",
+			committed: map[hlc.Timestamp]roachpb.Spans{ts(15): {span("c", "d")}},
+			covered:   false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.covered, committedSinkProgressCovered(
+				jobspb.NewTimestampSpansMap(tc.committed), ts(10), spanLevelCheckpoint))
+		})
+	}
+}
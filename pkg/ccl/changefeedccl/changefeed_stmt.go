@@ -581,7 +581,11 @@ func createChangefeedJobRecord(
 		}
 	}
 	if checkPrivs {
-		if err := authorizeUserToCreateChangefeed(ctx, p, sinkURI, hasSelectPrivOnAllTables, hasChangefeedPrivOnAllTables, opts.GetConfluentSchemaRegistry()); err != nil {
+		otherExternalURIs := []string{opts.GetConfluentSchemaRegistry()}
+		if dlq, ok := opts.GetDeadLetterQueue(); ok && isDeadLetterQueueURI(dlq) {
+			otherExternalURIs = append(otherExternalURIs, dlq)
+		}
+		if err := authorizeUserToCreateChangefeed(ctx, p, sinkURI, hasSelectPrivOnAllTables, hasChangefeedPrivOnAllTables, otherExternalURIs...); err != nil {
 			return nil, err
 		}
 	}
@@ -661,6 +665,10 @@ func createChangefeedJobRecord(
 		return nil, err
 	}
 
+	if err := prepareDeadLetterQueue(ctx, p, opts, unspecifiedSink); err != nil {
+		return nil, err
+	}
+
 	// Validate the encoder. We can pass an empty slimetrics struct and source provider
 	// here since the encoder will not be used.
 	encodingOpts, err := opts.GetEncodingOptions()
@@ -941,6 +949,16 @@ func getTargetsAndTables(
 		if !ok {
 			return nil, nil, errors.Errorf(`CHANGEFEED cannot target %s`, tree.AsString(&ct))
 		}
+		if ct.MaterializedView && !td.MaterializedView() {
+			return nil, nil, pgerror.Newf(pgcode.WrongObjectType,
+				`%q is not a materialized view`, tree.ErrString(ct.TableName))
+		}
+		if !ct.MaterializedView && td.MaterializedView() {
+			return nil, nil, errors.WithHint(
+				pgerror.Newf(pgcode.WrongObjectType, `%q is a materialized view`, tree.ErrString(ct.TableName)),
+				"use CHANGEFEED FOR MATERIALIZED VIEW instead",
+			)
+		}
 
 		if spec, ok := originalSpecs[ct]; ok {
 			targets[i] = spec
@@ -974,12 +992,29 @@ func getTargetsAndTables(
 					typ = jobspb.ChangefeedTargetSpecification_EACH_FAMILY
 				}
 			}
+			// Targeting the primary index by name is the same as targeting the
+			// table itself.
+			var index catalog.Index
+			if ct.IndexName != "" && string(ct.IndexName) != td.GetPrimaryIndex().GetName() {
+				index = catalog.FindPublicNonPrimaryIndex(td, func(idx catalog.Index) bool {
+					return idx.GetName() == string(ct.IndexName)
+				})
+				if index == nil {
+					return nil, nil, pgerror.Newf(pgcode.UndefinedObject,
+						`index %q does not exist on table %q`, ct.IndexName, tree.ErrString(ct.TableName))
+				}
+				typ = jobspb.ChangefeedTargetSpecification_SECONDARY_INDEX
+			}
 			targets[i] = jobspb.ChangefeedTargetSpecification{
 				Type:              typ,
 				TableID:           td.GetID(),
 				FamilyName:        string(ct.FamilyName),
 				StatementTimeName: tables[td.GetID()].StatementTimeName,
 			}
+			if index != nil {
+				targets[i].IndexID = index.GetID()
+				targets[i].IndexName = index.GetName()
+			}
 		}
 		if dup, isDup := seen[targets[i]]; isDup {
 			return nil, nil, errors.Errorf(
@@ -990,6 +1025,21 @@ func getTargetsAndTables(
 		seen[targets[i]] = ct
 	}
 
+	// A secondary index target replaces the table's primary index as the source
+	// of events, so it cannot be combined with other targets on the same table.
+	for i := range targets {
+		if targets[i].Type != jobspb.ChangefeedTargetSpecification_SECONDARY_INDEX {
+			continue
+		}
+		for j := range targets {
+			if i != j && targets[j].TableID == targets[i].TableID {
+				return nil, nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"CHANGEFEED targets %s and %s refer to the same table",
+					tree.AsString(&rawTargets[i]), tree.AsString(&rawTargets[j]))
+			}
+		}
+	}
+
 	return targets, tables, nil
 }
 
@@ -1027,7 +1077,7 @@ func validateSink(
 
 	var nilOracle timestampLowerBoundOracle
 	canarySink, err := getAndDialSink(ctx, &p.ExecCfg().DistSQLSrv.ServerConfig, details,
-		nilOracle, p.User(), jobID, sli)
+		nilOracle, p.User(), jobID, canarySinkInstanceID, sli)
 	if err != nil {
 		return err
 	}
@@ -1206,6 +1256,16 @@ func validateAndNormalizeChangefeedExpression(
 	if err != nil {
 		return nil, false, err
 	}
+	if indexName, ok := opts.GetInitialScanIndex(); ok {
+		// Verify that the index can be used to locate the rows matching the
+		// expression so that we fail early if the index does not exist, or if
+		// it cannot be used with the target column family.
+		if _, _, err := cdceval.IndexSpansForExpression(ctx, execCtx.ExecCfg(), execCtx.User(),
+			execCtx.SessionData().Clone(), tableDescr, statementTime, targets[0], norm.SelectClause, indexName,
+		); err != nil {
+			return nil, false, err
+		}
+	}
 	return norm, withDiff, nil
 }
 
@@ -1419,6 +1479,7 @@ func (b *changefeedResumer) resumeWithRetries(
 			}
 
 			confPoller := make(chan struct{})
+			startProgress := localState.progress.GetChangefeed()
 			g := ctxgroup.WithContext(ctx)
 			g.GoCtx(func(ctx context.Context) error {
 				defer close(confPoller)
@@ -1434,12 +1495,14 @@ func (b *changefeedResumer) resumeWithRetries(
 					case <-confPoller:
 						return nil
 					case <-t.C:
-						newDest, err := reloadDest(ctx, jobID, execCfg)
+						newDest, newProgress, err := reloadConfig(ctx, jobID, execCfg)
 						if err != nil {
 							log.Warningf(ctx, "failed to check for updated configuration: %v", err)
 						} else if newDest != resolvedDest {
 							resolvedDest = newDest
 							return replanErr
+						} else if inactiveTargetsChanged(startProgress, newProgress.GetChangefeed()) {
+							return replanErr
 						}
 					}
 				}
@@ -1453,6 +1516,10 @@ func (b *changefeedResumer) resumeWithRetries(
 
 			if errors.Is(flowErr, replanErr) {
 				log.Infof(ctx, "restarting changefeed due to updated configuration")
+				// Pick up the targets paused or resumed since the flow started.
+				if err := reconcileJobStateWithLocalState(ctx, jobID, localState, execCfg); err != nil {
+					return jobs.MarkAsRetryJobError(err)
+				}
 				continue
 			}
 
@@ -1461,6 +1528,26 @@ func (b *changefeedResumer) resumeWithRetries(
 			}
 		}
 
+		// Quarantine the failing target, if requested, and restart without it.
+		if quarantined, err := maybeQuarantineTarget(
+			ctx, execCfg, jobID, details, localState, flowErr,
+		); err != nil {
+			return err
+		} else if quarantined {
+			tableID, _ := changefeedbase.TargetErrorTableID(flowErr)
+			log.Warningf(ctx, `CHANGEFEED %d quarantined table %d (cause: %v)`, jobID, tableID, flowErr)
+			lastRunStatusUpdate = b.setJobStatusMessage(ctx, lastRunStatusUpdate,
+				"quarantined table %d: %s", tableID, flowErr)
+			if metrics, ok := execCfg.JobRegistry.MetricsStruct().Changefeed.(*Metrics); ok {
+				sli, err := metrics.getSLIMetrics(details.Opts[changefeedbase.OptMetricsScope])
+				if err != nil {
+					return err
+				}
+				sli.TargetsQuarantined.Inc(1)
+			}
+			continue
+		}
+
 		// Terminate changefeed if needed.
 		if err := changefeedbase.AsTerminalError(ctx, jobExec.ExecCfg().LeaseManager, flowErr); err != nil {
 			log.Infof(ctx, "CHANGEFEED %d shutting down (cause: %v)", jobID, err)
@@ -1536,13 +1623,19 @@ func resolveDest(ctx context.Context, execCfg *sql.ExecutorConfig, sinkURI strin
 	return resolved, err
 }
 
-func reloadDest(ctx context.Context, id jobspb.JobID, execCfg *sql.ExecutorConfig) (string, error) {
+// reloadConfig returns the resolved sink destination and the progress of the
+// changefeed job, which are checked for changes that require the changefeed to
+// be replanned.
+func reloadConfig(
+	ctx context.Context, id jobspb.JobID, execCfg *sql.ExecutorConfig,
+) (string, jobspb.Progress, error) {
 	reloadedJob, err := execCfg.JobRegistry.LoadJob(ctx, id)
 	if err != nil {
-		return "", err
+		return "", jobspb.Progress{}, err
 	}
 	newDetails := reloadedJob.Details().(jobspb.ChangefeedDetails)
-	return resolveDest(ctx, execCfg, newDetails.SinkURI)
+	dest, err := resolveDest(ctx, execCfg, newDetails.SinkURI)
+	return dest, reloadedJob.Progress(), err
 }
 
 // reconcileJobStateWithLocalState ensures that the job progress information
@@ -1577,11 +1670,21 @@ func reconcileJobStateWithLocalState(
 		highWater = *hw
 	}
 
-	// Build frontier based on tracked spans.
-	sf, err := span.MakeFrontierAt(highWater, localState.trackedSpans...)
+	// Build frontier based on tracked spans. Spans which are catching up on
+	// resumed tables start below the high-water.
+	var catchUpSpans *jobspb.TimestampSpansMap
+	if cfProgress := localState.progress.GetChangefeed(); cfProgress != nil {
+		catchUpSpans = cfProgress.CatchUpSpans
+	}
+	frontierStart, catchUpCheckpoint := withCatchUpSpans(
+		highWater, nil /* checkpoint */, catchUpSpans, localState.trackedSpans)
+	sf, err := span.MakeFrontierAt(frontierStart, localState.trackedSpans...)
 	if err != nil {
 		return err
 	}
+	if err := checkpoint.Restore(sf, catchUpCheckpoint); err != nil {
+		return err
+	}
 	// Advance frontier based on the information received from the aggregators.
 	for sp, ts := range localState.AggregatorFrontierSpans() {
 		_, err := sf.Forward(sp, ts)
@@ -1590,23 +1693,33 @@ func reconcileJobStateWithLocalState(
 		}
 	}
 
+	// The high-water never regresses while spans are catching up.
+	newHighWater := sf.Frontier()
+	newHighWater.Forward(highWater)
+	var newCatchUpSpans *jobspb.TimestampSpansMap
+	if !catchUpSpans.IsEmpty() {
+		newCatchUpSpans = catchUpSpansBelow(sf.Entries(), highWater)
+	}
+
 	maxBytes := changefeedbase.SpanCheckpointMaxBytes.Get(&execCfg.Settings.SV)
 	checkpoint := checkpoint.Make(
-		sf.Frontier(),
+		newHighWater,
 		localState.AggregatorFrontierSpans(),
 		maxBytes,
 		nil, /* metrics */
 	)
 
 	// Update checkpoint.
-	updateHW := highWater.Less(sf.Frontier())
+	updateHW := highWater.Less(newHighWater)
 	updateSpanCheckpoint := !checkpoint.IsEmpty()
+	updateCatchUpSpans := !newCatchUpSpans.Equal(catchUpSpans)
 
-	if updateHW || updateSpanCheckpoint {
+	if updateHW || updateSpanCheckpoint || updateCatchUpSpans {
 		if updateHW {
-			localState.SetHighwater(sf.Frontier())
+			localState.SetHighwater(newHighWater)
 		}
 		localState.SetCheckpoint(checkpoint)
+		localState.SetCatchUpSpans(newCatchUpSpans)
 		if log.V(1) {
 			log.Infof(ctx, "Applying checkpoint to job record:  hw=%v, cf=%v",
 				localState.progress.GetHighWater(), localState.progress.GetChangefeed())
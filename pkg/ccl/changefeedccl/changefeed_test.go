@@ -3335,6 +3335,89 @@ func TestChangefeedSingleColumnFamily(t *testing.T) {
 	cdcTest(t, testFn)
 }
 
+func TestChangefeedSecondaryIndex(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+
+		sqlDB.Exec(t, `CREATE TABLE users (id INT PRIMARY KEY, email STRING, name STRING, age INT, INDEX by_email (email) STORING (name))`)
+		sqlDB.Exec(t, `INSERT INTO users VALUES (1, 'bob@example.com', 'bob', 30), (2, 'alice@example.com', 'alice', 40)`)
+
+		sqlDB.ExpectErrWithTimeout(t, `index "nosuchindex" does not exist`,
+			`CREATE CHANGEFEED FOR users@nosuchindex`)
+		sqlDB.ExpectErrWithTimeout(t, `refer to the same table`,
+			`CREATE CHANGEFEED FOR users@by_email, users`)
+
+		byEmail := feed(t, f, `CREATE CHANGEFEED FOR users@by_email`)
+		defer closeFeed(t, byEmail)
+		assertPayloads(t, byEmail, []string{
+			`users.by_email: ["alice@example.com", 2]->{"after": {"email": "alice@example.com", "id": 2, "name": "alice"}}`,
+			`users.by_email: ["bob@example.com", 1]->{"after": {"email": "bob@example.com", "id": 1, "name": "bob"}}`,
+		})
+
+		sqlDB.Exec(t, `UPDATE users SET email = 'robert@example.com' WHERE id = 1`)
+		assertPayloads(t, byEmail, []string{
+			`users.by_email: ["bob@example.com", 1]->{"after": null}`,
+			`users.by_email: ["robert@example.com", 1]->{"after": {"email": "robert@example.com", "id": 1, "name": "bob"}}`,
+		})
+
+		// Targeting the primary index by name watches the table itself.
+		byPK := feed(t, f, `CREATE CHANGEFEED FOR users@users_pkey`)
+		defer closeFeed(t, byPK)
+		assertPayloads(t, byPK, []string{
+			`users: [1]->{"after": {"age": 30, "email": "robert@example.com", "id": 1, "name": "bob"}}`,
+			`users: [2]->{"after": {"age": 40, "email": "alice@example.com", "id": 2, "name": "alice"}}`,
+		})
+	}
+	cdcTest(t, testFn)
+}
+
+func TestChangefeedMaterializedView(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'a')`)
+		sqlDB.Exec(t, `CREATE MATERIALIZED VIEW v AS SELECT a, b FROM foo`)
+
+		sqlDB.ExpectErrWithTimeout(t, `"v" is a materialized view`, `CREATE CHANGEFEED FOR v`)
+		sqlDB.ExpectErrWithTimeout(t, `"foo" is not a materialized view`,
+			`CREATE CHANGEFEED FOR MATERIALIZED VIEW foo`)
+
+		expectedPayloads := func() (payloads []string, deletes []string) {
+			for _, row := range sqlDB.QueryStr(t, `SELECT a, b, rowid FROM v ORDER BY a`) {
+				payloads = append(payloads, fmt.Sprintf(
+					`v: [%[3]s]->{"after": {"a": %[1]s, "b": "%[2]s", "rowid": %[3]s}}`, row[0], row[1], row[2]))
+				deletes = append(deletes, fmt.Sprintf(`v: [%s]->{"after": null}`, row[2]))
+			}
+			return payloads, deletes
+		}
+
+		mv := feed(t, f, `CREATE CHANGEFEED FOR MATERIALIZED VIEW v`)
+		defer closeFeed(t, mv)
+		payloads, deletes := expectedPayloads()
+		assertPayloads(t, mv, payloads)
+
+		// Each refresh deletes the rows of the view from before the refresh,
+		// and emits its refreshed contents.
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2, 'b')`)
+		sqlDB.Exec(t, `DELETE FROM foo WHERE a = 1`)
+		sqlDB.Exec(t, `REFRESH MATERIALIZED VIEW v`)
+		payloads, nextDeletes := expectedPayloads()
+		assertPayloads(t, mv, append(deletes, payloads...))
+
+		sqlDB.Exec(t, `REFRESH MATERIALIZED VIEW v`)
+		payloads, _ = expectedPayloads()
+		assertPayloads(t, mv, append(nextDeletes, payloads...))
+	}
+	cdcTest(t, testFn)
+}
+
 func TestChangefeedSingleColumnFamilySchemaChanges(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -3868,6 +3951,54 @@ func TestChangefeedColumnFamilyAvro(t *testing.T) {
 	cdcTest(t, testFn, feedTestForceSink("kafka"))
 }
 
+func TestChangefeedDeadLetterQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		registry := s.Server.JobRegistry().(*jobs.Registry)
+		metrics := registry.MetricsStruct().Changefeed.(*Metrics)
+
+		// Infinite dates cannot be encoded with avro.
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, d DATE)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, NULL), (2, 'infinity'), (3, NULL)`)
+
+		sqlDB.ExpectErr(t, `on_error_row='dlq' requires the dead_letter_queue option`,
+			`CREATE CHANGEFEED FOR foo WITH format=avro, on_error_row='dlq'`)
+
+		// The dead letter queue table is created in the transaction of the
+		// statement, so it is not left behind when creating the changefeed fails.
+		sqlDB.ExpectErr(t, `default metrics scope`,
+			`CREATE CHANGEFEED FOR foo INTO 'null://' WITH on_error_row='dlq', dead_letter_queue='orphan_dlq', metrics_label='default'`)
+		sqlDB.CheckQueryResults(t,
+			`SELECT count(*) FROM [SHOW TABLES] WHERE table_name = 'orphan_dlq'`, [][]string{{"0"}})
+
+		foo := feed(t, f, `CREATE CHANGEFEED FOR foo WITH format=avro, on_error_row='dlq', dead_letter_queue='foo_dlq'`)
+		defer closeFeed(t, foo)
+		assertPayloads(t, foo, []string{
+			`foo: {"a":{"long":1}}->{"after":{"foo":{"a":{"long":1},"d":null}}}`,
+			`foo: {"a":{"long":3}}->{"after":{"foo":{"a":{"long":3},"d":null}}}`,
+		})
+
+		sqlDB.Exec(t, `UPDATE foo SET d = '-infinity' WHERE a = 1`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (4, NULL)`)
+		assertPayloads(t, foo, []string{
+			`foo: {"a":{"long":4}}->{"after":{"foo":{"a":{"long":4},"d":null}}}`,
+		})
+
+		sqlDB.CheckQueryResultsRetry(t,
+			`SELECT table_name, incoming_row->>'a', incoming_row->>'d', dlq_reason LIKE '%infinite date%'
+FROM foo_dlq ORDER BY incoming_row->>'a'`,
+			[][]string{
+				{"foo", "1", "-infinity", "true"},
+				{"foo", "2", "infinity", "true"},
+			})
+		require.Equal(t, int64(2), metrics.AggMetrics.DLQRows.Value())
+	}
+	cdcTest(t, testFn, feedTestForceSink("kafka"))
+}
+
 func TestChangefeedBareAvro(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -7,10 +7,14 @@ package changefeedbase
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/errors"
+	"github.com/gogo/protobuf/proto"
 )
 
 // FailureType is the reason for the changefeed failure that maps to the
@@ -143,5 +147,69 @@ func AsTerminalError(ctx context.Context, dh drainHelper, cause error) (termErr
 	return nil
 }
 
+// WithTargetError annotates the error with the ID of the table which caused
+// it, so that the table can be quarantined rather than failing the changefeed
+// when on_target_error='quarantine' is set.
+func WithTargetError(cause error, tableID descpb.ID) error {
+	if cause == nil {
+		return nil
+	}
+	return &targetError{cause: cause, tableID: tableID}
+}
+
+// TargetErrorTableID returns the ID of the table which caused the error, or
+// false if the error was not caused by a specific table.
+func TargetErrorTableID(err error) (descpb.ID, bool) {
+	if e := (*targetError)(nil); errors.As(err, &e) {
+		return e.tableID, true
+	}
+	return 0, false
+}
+
+type targetError struct {
+	cause   error
+	tableID descpb.ID
+}
+
+var _ errors.SafeFormatter = (*targetError)(nil)
+var _ fmt.Formatter = (*targetError)(nil)
+
+func (e *targetError) Error() string { return e.cause.Error() }
+func (e *targetError) Cause() error  { return e.cause }
+func (e *targetError) Unwrap() error { return e.cause }
+
+func (e *targetError) Format(s fmt.State, verb rune) { errors.FormatError(e, s, verb) }
+
+func (e *targetError) SafeFormatError(p errors.Printer) (next error) {
+	if p.Detail() {
+		p.Printf("changefeed target: %d", e.tableID)
+	}
+	return e.cause
+}
+
+func encodeTargetError(_ context.Context, err error) (string, []string, proto.Message) {
+	e := err.(*targetError)
+	return "", []string{strconv.FormatUint(uint64(e.tableID), 10)}, nil
+}
+
+func decodeTargetError(
+	_ context.Context, cause error, _ string, safeDetails []string, _ proto.Message,
+) error {
+	if len(safeDetails) != 1 {
+		return nil
+	}
+	id, err := strconv.ParseUint(safeDetails[0], 10, 32)
+	if err != nil {
+		return nil
+	}
+	return &targetError{cause: cause, tableID: descpb.ID(id)}
+}
+
+func init() {
+	key := errors.GetTypeKey((*targetError)(nil))
+	errors.RegisterWrapperEncoder(key, encodeTargetError)
+	errors.RegisterWrapperDecoder(key, decodeTargetError)
+}
+
 // ErrNodeDraining indicates that this node is being drained.
 var ErrNodeDraining = errors.New("node draining")
@@ -12,6 +12,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
@@ -71,3 +72,24 @@ func TestAsTerminalError(t *testing.T) {
 		require.Contains(t, cause.Error(), termErr.Error())
 	})
 }
+
+func TestTargetError(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	_, ok := changefeedbase.TargetErrorTableID(errors.New("some error happened"))
+	require.False(t, ok)
+
+	cause := changefeedbase.WithTerminalError(
+		changefeedbase.WithTargetError(errors.New("table was dropped"), 42))
+	id, ok := changefeedbase.TargetErrorTableID(cause)
+	require.True(t, ok)
+	require.Equal(t, descpb.ID(42), id)
+
+	// The table ID survives the encoding used to send errors between nodes.
+	decoded := errors.DecodeError(context.Background(), errors.EncodeError(context.Background(), cause))
+	id, ok = changefeedbase.TargetErrorTableID(decoded)
+	require.True(t, ok)
+	require.Equal(t, descpb.ID(42), id)
+	require.Equal(t, cause.Error(), decoded.Error())
+}
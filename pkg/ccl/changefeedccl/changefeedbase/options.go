@@ -44,6 +44,12 @@ type FormatType string
 // OnErrorType configures the job behavior when an error occurs.
 type OnErrorType string
 
+// OnErrorRowType configures the job behavior when a row cannot be encoded.
+type OnErrorRowType string
+
+// OnTargetErrorType configures the job behavior when one of its targets fails.
+type OnTargetErrorType string
+
 // SchemaChangeEventClass defines a set of schema change event types which
 // trigger the action defined by the SchemaChangeEventPolicy.
 type SchemaChangeEventClass string
@@ -106,6 +112,8 @@ const (
 	OptWebhookAuthHeader                  = `webhook_auth_header`
 	OptWebhookClientTimeout               = `webhook_client_timeout`
 	OptOnError                            = `on_error`
+	OptOnErrorRow                         = `on_error_row`
+	OptOnTargetError                      = `on_target_error`
 	OptMetricsScope                       = `metrics_label`
 	OptUnordered                          = `unordered`
 	OptVirtualColumns                     = `virtual_columns`
@@ -118,6 +126,11 @@ const (
 	// sinks as well (eg cloudstorage, webhook, ..). Currently it's kafka-only.
 	OptHeadersJSONColumnName = `headers_json_column_name`
 
+	// OptDeadLetterQueue is the destination of rows diverted by
+	// on_error_row='dlq'. It is either the name of a table, which is created if
+	// it does not exist, or an external storage URI.
+	OptDeadLetterQueue = `dead_letter_queue`
+
 	OptVirtualColumnsOmitted VirtualColumnVisibility = `omitted`
 	OptVirtualColumnsNull    VirtualColumnVisibility = `null`
 
@@ -158,6 +171,12 @@ const (
 
 	OptInitialScanOnly = `initial_scan_only`
 
+	// OptInitialScanIndex names a secondary index which the initial scan of a
+	// CDC query should use to locate the rows matching the query predicate.
+	// Matching rows are then read from the primary index, so that a selective
+	// predicate does not require a scan of the whole table.
+	OptInitialScanIndex = `initial_scan_index`
+
 	OptEnrichedProperties = `enriched_properties`
 
 	OptEnvelopeKeyOnly       EnvelopeType = `key_only`
@@ -175,6 +194,19 @@ const (
 	OptOnErrorFail  OnErrorType = `fail`
 	OptOnErrorPause OnErrorType = `pause`
 
+	// OptOnErrorRowFail fails the changefeed when a row cannot be encoded.
+	OptOnErrorRowFail OnErrorRowType = `fail`
+	// OptOnErrorRowDLQ writes rows which cannot be encoded, or which the sink
+	// rejects, e.g. because they are too large, to the dead letter queue named
+	// by OptDeadLetterQueue, and continues past them.
+	OptOnErrorRowDLQ OnErrorRowType = `dlq`
+
+	// OptOnTargetErrorFail fails the changefeed when one of its targets fails.
+	OptOnTargetErrorFail OnTargetErrorType = `fail`
+	// OptOnTargetErrorQuarantine stops emitting changes to a failing target,
+	// while the changefeed continues to emit changes to its other targets.
+	OptOnTargetErrorQuarantine OnTargetErrorType = `quarantine`
+
 	DeprecatedOptFormatAvro                   = `experimental_avro`
 	DeprecatedSinkSchemeCloudStorageAzure     = `experimental-azure`
 	DeprecatedSinkSchemeCloudStorageGCS       = `experimental-gs`
@@ -390,6 +422,7 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptInitialScan:                        enum("yes", "no", "only").orEmptyMeans("yes"),
 	OptNoInitialScan:                      flagOption,
 	OptInitialScanOnly:                    flagOption,
+	OptInitialScanIndex:                   stringOption,
 	DeprecatedOptProtectDataFromGCOnPause: flagOption,
 	OptExpirePTSAfter:                     durationOption.thatCanBeZero(),
 	OptKafkaSinkConfig:                    jsonOption,
@@ -398,6 +431,9 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptWebhookAuthHeader:                  stringOption,
 	OptWebhookClientTimeout:               durationOption,
 	OptOnError:                            enum("pause", "fail"),
+	OptOnErrorRow:                         enum("fail", "dlq"),
+	OptOnTargetError:                      enum("fail", "quarantine"),
+	OptDeadLetterQueue:                    stringOption,
 	OptMetricsScope:                       stringOption,
 	OptUnordered:                          flagOption,
 	OptVirtualColumns:                     enum("omitted", "null"),
@@ -417,8 +453,8 @@ var CommonOptions = makeStringSet(OptCursor, OptEndTime, OptEnvelope,
 	OptResolvedTimestamps, OptUpdatedTimestamps,
 	OptMVCCTimestamps, OptDiff, OptSplitColumnFamilies,
 	OptSchemaChangeEvents, OptSchemaChangePolicy,
-	OptOnError,
-	OptInitialScan, OptNoInitialScan, OptInitialScanOnly, OptUnordered, OptCustomKeyColumn,
+	OptOnError, OptOnErrorRow, OptDeadLetterQueue, OptOnTargetError,
+	OptInitialScan, OptNoInitialScan, OptInitialScanOnly, OptInitialScanIndex, OptUnordered, OptCustomKeyColumn,
 	OptMinCheckpointFrequency, OptMetricsScope, OptVirtualColumns, Topics, OptExpirePTSAfter,
 	OptExecutionLocality, OptLaggingRangesThreshold, OptLaggingRangesPollingInterval,
 	OptIgnoreDisableChangefeedReplication, OptEncodeJSONValueNullAsObject, OptEnrichedProperties,
@@ -449,7 +485,7 @@ var ExternalConnectionValidOptions = unionStringSets(SQLValidOptions, KafkaValid
 
 // CaseInsensitiveOpts options which supports case Insensitive value
 var CaseInsensitiveOpts = makeStringSet(OptFormat, OptEnvelope, OptCompression, OptSchemaChangeEvents,
-	OptSchemaChangePolicy, OptOnError, OptInitialScan)
+	OptSchemaChangePolicy, OptOnError, OptOnErrorRow, OptOnTargetError, OptInitialScan)
 
 // RetiredOptions are the options which are no longer active.
 var RetiredOptions = makeStringSet(DeprecatedOptProtectDataFromGCOnPause)
@@ -474,11 +510,29 @@ func RedactUserFromURI(uri string) (string, error) {
 	return u.String(), nil
 }
 
+// redactDeadLetterQueue redacts the user and query parameters, which may hold
+// credentials, of a dead letter queue storage URI. Table names are returned
+// unchanged.
+func redactDeadLetterQueue(dlq string) (string, error) {
+	u, err := url.Parse(dlq)
+	if err != nil || u.Scheme == `` {
+		return dlq, nil //nolint:returnerrcheck
+	}
+	if u.User != nil {
+		u.User = url.User(`redacted`)
+	}
+	if u.RawQuery != `` {
+		u.RawQuery = `redacted`
+	}
+	return u.String(), nil
+}
+
 // RedactedOptions are options whose values should be replaced with "redacted" in job descriptions and errors.
 var RedactedOptions = map[string]redactionFunc{
 	OptWebhookAuthHeader:       redactSimple,
 	SinkParamClientKey:         redactSimple,
 	OptConfluentSchemaRegistry: RedactUserFromURI,
+	OptDeadLetterQueue:         redactDeadLetterQueue,
 }
 
 // NoLongerExperimental aliases options prefixed with experimental that no longer need to be
@@ -511,7 +565,7 @@ var ParquetFormatUnsupportedOptions OptionsSet = makeStringSet(OptTopicInValue)
 // allowed to alter either of these options. We need to support the alteration
 // of these fields.
 var AlterChangefeedUnsupportedOptions OptionsSet = makeStringSet(OptCursor, OptInitialScan,
-	OptNoInitialScan, OptInitialScanOnly, OptEndTime)
+	OptNoInitialScan, OptInitialScanOnly, OptInitialScanIndex, OptEndTime)
 
 // AlterChangefeedOptionExpectValues is used to parse alter changefeed options
 // using PlanHookState.TypeAsStringOpts().
@@ -786,6 +840,14 @@ func (s StatementOptions) GetInitialScanType() (InitialScanType, error) {
 	return NoInitialScan, nil
 }
 
+// GetInitialScanIndex returns the name of the secondary index the initial scan
+// should use to locate rows matching the changefeed predicate, or false if
+// the whole primary index should be scanned.
+func (s StatementOptions) GetInitialScanIndex() (string, bool) {
+	v, ok := s.m[OptInitialScanIndex]
+	return v, ok && v != ``
+}
+
 func (s StatementOptions) IsInitialScanSpecified() bool {
 	_, initialScanSet := s.m[OptInitialScan]
 	_, initialScanOnlySet := s.m[OptInitialScanOnly]
@@ -1202,6 +1264,41 @@ func (s StatementOptions) GetOnError() (OnErrorType, error) {
 	return OnErrorType(v), nil
 }
 
+// GetOnErrorRow validates and returns the desired behavior when a row cannot be
+// encoded.
+func (s StatementOptions) GetOnErrorRow() (OnErrorRowType, error) {
+	v, err := s.getEnumValue(OptOnErrorRow)
+	if err != nil || v == `` {
+		return OptOnErrorRowFail, err
+	}
+	return OnErrorRowType(v), nil
+}
+
+// GetOnTargetError validates and returns the desired behavior when one of the
+// changefeed's targets fails.
+func (s StatementOptions) GetOnTargetError() (OnTargetErrorType, error) {
+	v, err := s.getEnumValue(OptOnTargetError)
+	if err != nil || v == `` {
+		return OptOnTargetErrorFail, err
+	}
+	return OnTargetErrorType(v), nil
+}
+
+// GetDeadLetterQueue returns the destination of rows which cannot be encoded or
+// are rejected by the sink, or false if such rows should fail the changefeed.
+func (s StatementOptions) GetDeadLetterQueue() (string, bool) {
+	if onErrorRow, err := s.GetOnErrorRow(); err != nil || onErrorRow != OptOnErrorRowDLQ {
+		return ``, false
+	}
+	v, ok := s.m[OptDeadLetterQueue]
+	return v, ok && v != ``
+}
+
+// SetDeadLetterQueue sets the destination of rows which cannot be encoded.
+func (s StatementOptions) SetDeadLetterQueue(dlq string) {
+	s.m[OptDeadLetterQueue] = dlq
+}
+
 func describeEnum(strs ...string) string {
 	switch len(strs) {
 	case 1:
@@ -1264,6 +1361,32 @@ func (s StatementOptions) ValidateForCreateChangefeed(isPredicateChangefeed bool
 			return errors.Newf(`%s=%s is only usable with %s`, OptFormat, OptFormatCSV, OptInitialScanOnly)
 		}
 	}
+	if s.IsSet(OptInitialScanIndex) {
+		if !isPredicateChangefeed {
+			return errors.Newf(`%s can only be used with a changefeed expression`, OptInitialScanIndex)
+		}
+		if scanType == NoInitialScan {
+			return errors.Newf(`%s requires an initial scan`, OptInitialScanIndex)
+		}
+		if s.m[OptInitialScanIndex] == `` {
+			return errors.Newf(`%s requires an index name`, OptInitialScanIndex)
+		}
+	}
+	if onErrorRow, err := s.GetOnErrorRow(); err != nil {
+		return err
+	} else if onErrorRow == OptOnErrorRowDLQ && s.m[OptDeadLetterQueue] == `` {
+		return errors.Newf(`%s='%s' requires the %s option`, OptOnErrorRow, OptOnErrorRowDLQ, OptDeadLetterQueue)
+	} else if onErrorRow != OptOnErrorRowDLQ && s.IsSet(OptDeadLetterQueue) {
+		return errors.Newf(`%s requires %s='%s'`, OptDeadLetterQueue, OptOnErrorRow, OptOnErrorRowDLQ)
+	} else if onErrorRow == OptOnErrorRowDLQ && s.m[OptFormat] == string(OptFormatParquet) {
+		return errors.Newf(`cannot specify both format=%s and %s='%s'`, OptFormatParquet, OptOnErrorRow, OptOnErrorRowDLQ)
+	}
+	if onTargetError, err := s.GetOnTargetError(); err != nil {
+		return err
+	} else if onTargetError == OptOnTargetErrorQuarantine && isPredicateChangefeed {
+		return errors.Newf(`%s='%s' cannot be used with a changefeed expression`,
+			OptOnTargetError, OptOnTargetErrorQuarantine)
+	}
 	// Right now parquet does not support any of these options
 	if s.m[OptFormat] == string(OptFormatParquet) {
 		if err := validateUnsupportedOptions(ParquetFormatUnsupportedOptions, fmt.Sprintf("format=%s", OptFormatParquet)); err != nil {
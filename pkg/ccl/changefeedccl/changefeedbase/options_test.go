@@ -37,6 +37,19 @@ func TestOptionsValidations(t *testing.T) {
 		{map[string]string{"initial_scan_only": "", "resolved": ""}, true, "cannot specify both initial_scan='only'"},
 		{map[string]string{"initial_scan_only": "", "resolved": ""}, true, "cannot specify both initial_scan='only'"},
 		{map[string]string{"key_column": "b"}, false, "requires the unordered option"},
+		{map[string]string{"initial_scan_index": "idx"}, false, "can only be used with a changefeed expression"},
+		{map[string]string{"initial_scan_index": "idx", "initial_scan": "no"}, true, "requires an initial scan"},
+		{map[string]string{"initial_scan_index": ""}, true, "requires an index name"},
+		{map[string]string{"initial_scan_index": "idx"}, true, ""},
+		{map[string]string{"on_error_row": "dlq"}, false, "requires the dead_letter_queue option"},
+		{map[string]string{"dead_letter_queue": "dlq"}, false, "requires on_error_row='dlq'"},
+		{map[string]string{"on_error_row": "fail", "dead_letter_queue": "dlq"}, false, "requires on_error_row='dlq'"},
+		{map[string]string{"on_error_row": "dlq", "dead_letter_queue": "dlq", "format": "parquet"}, false, "cannot specify both"},
+		{map[string]string{"on_error_row": "skip"}, false, "unknown on_error_row"},
+		{map[string]string{"on_error_row": "dlq", "dead_letter_queue": "dlq"}, false, ""},
+		{map[string]string{"on_target_error": "skip"}, false, "unknown on_target_error"},
+		{map[string]string{"on_target_error": "quarantine"}, true, "cannot be used with a changefeed expression"},
+		{map[string]string{"on_target_error": "quarantine"}, false, ""},
 	}
 
 	for _, test := range tests {
@@ -17,6 +17,9 @@ type Target struct {
 	TableID           descpb.ID
 	FamilyName        string
 	StatementTimeName StatementTimeName
+	// IndexID and IndexName are set only for SECONDARY_INDEX targets.
+	IndexID   descpb.IndexID
+	IndexName string
 }
 
 // StatementTimeName is the original way a table was referred to when it was added to
@@ -130,6 +133,16 @@ func (ts *Targets) EachHavingTableID(id descpb.ID, f func(Target) error) (bool,
 	return ok, targets.each(f)
 }
 
+// WatchedIndexID returns the ID of the secondary index watched for the
+// given table, or false if the table is watched via its primary index.
+func (ts *Targets) WatchedIndexID(tableID descpb.ID) (descpb.IndexID, bool) {
+	tbt, ok := ts.m[tableID]
+	if !ok || tbt.wholeTable == nil || tbt.wholeTable.IndexID == 0 {
+		return 0, false
+	}
+	return tbt.wholeTable.IndexID, true
+}
+
 // NumUniqueTables gives the number of unique TableIDs referenced in Targets.
 func (ts *Targets) NumUniqueTables() int {
 	return len(ts.m)
@@ -9,6 +9,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
 	"github.com/cockroachdb/errors"
 )
 
@@ -19,7 +20,8 @@ func ValidateTable(
 	canHandle changefeedbase.CanHandle,
 ) error {
 	if err := validateTable(targets, tableDesc, canHandle); err != nil {
-		return changefeedbase.WithTerminalError(err)
+		return changefeedbase.WithTerminalError(
+			changefeedbase.WithTargetError(err, tableDesc.GetID()))
 	}
 	return nil
 }
@@ -37,7 +39,7 @@ func validateTable(
 	if catalog.IsSystemDescriptor(tableDesc) {
 		return errors.Errorf(`CHANGEFEEDs are not supported on system tables`)
 	}
-	if tableDesc.IsView() {
+	if tableDesc.IsView() && !tableDesc.MaterializedView() {
 		return errors.Errorf(`CHANGEFEED cannot target views: %s`, tableDesc.GetName())
 	}
 	if tableDesc.IsVirtualTable() {
@@ -77,6 +79,22 @@ func validateTable(
 			if cols == 0 {
 				return errors.Errorf("CHANGEFEED targeting nonexistent or removed column family %s of table %s", t.FamilyName, tableDesc.GetName())
 			}
+		case jobspb.ChangefeedTargetSpecification_SECONDARY_INDEX:
+			if len(tableDesc.GetFamilies()) != 1 {
+				return errors.Errorf(
+					`CHANGEFEED targeting index %s of table %s with %d column families is not supported`,
+					t.IndexName, tableDesc.GetName(), len(tableDesc.GetFamilies()))
+			}
+			idx := catalog.FindPublicNonPrimaryIndex(tableDesc, func(idx catalog.Index) bool {
+				return idx.GetID() == t.IndexID
+			})
+			if idx == nil {
+				return errors.Errorf("CHANGEFEED targeting nonexistent or removed index %s of table %s", t.IndexName, tableDesc.GetName())
+			}
+			if !idx.GetType().HasLinearOrdering() {
+				return errors.Errorf("CHANGEFEED cannot target %s: %s@%s",
+					idxtype.ErrorText(idx.GetType()), tableDesc.GetName(), t.IndexName)
+			}
 		}
 		return nil
 	})
@@ -0,0 +1,198 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+)
+
+// committedSinkProgressInfoKeyPrefix is the prefix of the job info keys under
+// which the change aggregators of a changefeed emitting to a transactional sink
+// record the spans, and the timestamp, up to which their rows are committed.
+// There is one such key per aggregator, suffixed with its sink slot.
+const committedSinkProgressInfoKeyPrefix = "~changefeed-committed-sink-progress-"
+
+// transactionalSinkSlotsInfoKey is the job info key under which the largest
+// number of aggregators of any plan of a changefeed emitting to a
+// transactional sink is recorded, so that the producers of the slots unused by
+// a later plan can be fenced off.
+const transactionalSinkSlotsInfoKey = "~changefeed-transactional-sink-slots"
+
+// writeCommittedSinkProgress records that the rows of the given spans are
+// committed to the sink of the aggregator in the given sink slot, up to the
+// given timestamps.
+func writeCommittedSinkProgress(
+	ctx context.Context,
+	db isql.DB,
+	jobID jobspb.JobID,
+	slot int32,
+	committed *jobspb.TimestampSpansMap,
+) error {
+	value, err := protoutil.Marshal(committed)
+	if err != nil {
+		return err
+	}
+	infoKey := fmt.Sprintf("%s%d", committedSinkProgressInfoKeyPrefix, slot)
+	return db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return jobs.InfoStorageForJob(txn, jobID).Write(ctx, infoKey, value)
+	})
+}
+
+// readCommittedSinkProgress returns the committed progress recorded by all the
+// aggregators of the job, including those of previous plans of the changefeed
+// which were not yet cleared by clearCommittedSinkProgress. The records never
+// overstate what is committed, so stale records, e.g. of aggregators whose
+// spans were since reassigned, are still correct, if less useful.
+func readCommittedSinkProgress(
+	ctx context.Context, db isql.DB, jobID jobspb.JobID,
+) ([]*jobspb.TimestampSpansMap, error) {
+	var committed []*jobspb.TimestampSpansMap
+	if err := db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		committed = committed[:0]
+		return jobs.InfoStorageForJob(txn, jobID).Iterate(ctx, committedSinkProgressInfoKeyPrefix,
+			func(infoKey string, value []byte) error {
+				cp := &jobspb.TimestampSpansMap{}
+				if err := protoutil.Unmarshal(value, cp); err != nil {
+					return errors.Wrapf(err, "decoding %s", infoKey)
+				}
+				committed = append(committed, cp)
+				return nil
+			})
+	}); err != nil {
+		return nil, err
+	}
+	return committed, nil
+}
+
+// clearCommittedSinkProgress deletes the committed progress records of the job
+// which are covered by the given persisted progress of the changefeed: its
+// frontier, and the span-level checkpoint of the spans ahead of it. Those
+// records would otherwise pile up, and keep being restored, as the spans of the
+// aggregators are reassigned across plans. The records which are ahead of the
+// persisted progress are kept, as they are the only trace of the rows committed
+// past it.
+func clearCommittedSinkProgress(
+	ctx context.Context,
+	txn isql.Txn,
+	jobID jobspb.JobID,
+	frontier hlc.Timestamp,
+	spanLevelCheckpoint *jobspb.TimestampSpansMap,
+) error {
+	infoStorage := jobs.InfoStorageForJob(txn, jobID)
+	var covered []string
+	if err := infoStorage.Iterate(ctx, committedSinkProgressInfoKeyPrefix,
+		func(infoKey string, value []byte) error {
+			cp := &jobspb.TimestampSpansMap{}
+			if err := protoutil.Unmarshal(value, cp); err != nil {
+				return errors.Wrapf(err, "decoding %s", infoKey)
+			}
+			if committedSinkProgressCovered(cp, frontier, spanLevelCheckpoint) {
+				covered = append(covered, infoKey)
+			}
+			return nil
+		}); err != nil {
+		return err
+	}
+	for _, infoKey := range covered {
+		if err := infoStorage.Delete(ctx, infoKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// committedSinkProgressCovered returns whether every span of the committed
+// progress is resolved at or above its committed timestamp according to the
+// given frontier and span-level checkpoint.
+func committedSinkProgressCovered(
+	committed *jobspb.TimestampSpansMap,
+	frontier hlc.Timestamp,
+	spanLevelCheckpoint *jobspb.TimestampSpansMap,
+) bool {
+	for ts, spans := range committed.All() {
+		if ts.LessEq(frontier) {
+			continue
+		}
+		var ahead roachpb.SpanGroup
+		for checkpointTS, checkpointSpans := range spanLevelCheckpoint.All() {
+			if ts.LessEq(checkpointTS) {
+				ahead.Add(checkpointSpans...)
+			}
+		}
+		if !ahead.Encloses(spans...) {
+			return false
+		}
+	}
+	return true
+}
+
+// prepareTransactionalSinkSlots is called before the flow of a changefeed
+// emitting to a transactional sink is started with the given number of
+// aggregators. It records the number of sink slots in use, clears the committed
+// progress records covered by the progress the flow starts from, and fences off
+// the producers of the slots which were used by a previous plan with more
+// aggregators, aborting the transactions they left open. The producers of the
+// slots in use are fenced off by the aggregators occupying them.
+func prepareTransactionalSinkSlots(
+	ctx context.Context,
+	execCtx sql.JobExecContext,
+	jobID jobspb.JobID,
+	details jobspb.ChangefeedDetails,
+	numSlots int,
+	frontier hlc.Timestamp,
+	spanLevelCheckpoint *jobspb.TimestampSpansMap,
+) error {
+	execCfg := execCtx.ExecCfg()
+	var prevSlots int
+	if err := execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		infoStorage := jobs.InfoStorageForJob(txn, jobID)
+		prevSlots = 0
+		value, ok, err := infoStorage.Get(ctx, "changefeed-get-sink-slots", transactionalSinkSlotsInfoKey)
+		if err != nil {
+			return err
+		}
+		if ok {
+			if prevSlots, err = strconv.Atoi(string(value)); err != nil {
+				return errors.Wrapf(err, "decoding %s", transactionalSinkSlotsInfoKey)
+			}
+		}
+		if numSlots > prevSlots {
+			if err := infoStorage.Write(ctx, transactionalSinkSlotsInfoKey,
+				[]byte(strconv.Itoa(numSlots))); err != nil {
+				return err
+			}
+		}
+		return clearCommittedSinkProgress(ctx, txn, jobID, frontier, spanLevelCheckpoint)
+	}); err != nil {
+		return err
+	}
+
+	// Creating the transactional sink of a slot fences off its previous
+	// producers.
+	var nilOracle timestampLowerBoundOracle
+	for slot := numSlots; slot < prevSlots; slot++ {
+		sink, err := getAndDialSink(ctx, &execCfg.DistSQLSrv.ServerConfig, details, nilOracle,
+			execCtx.User(), jobID, aggregatorSinkInstanceID(int32(slot)), (*sliMetrics)(nil))
+		if err != nil {
+			return errors.Wrapf(err, "fencing off the sink of slot %d", slot)
+		}
+		if err := sink.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
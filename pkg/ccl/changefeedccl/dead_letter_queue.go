@@ -0,0 +1,354 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	createDeadLetterQueueTableStmt = `CREATE TABLE IF NOT EXISTS %s (
+		id             INT8 DEFAULT unique_rowid(),
+		job_id         INT8 NOT NULL,
+		table_id       INT8 NOT NULL,
+		table_name     STRING NOT NULL,
+		dlq_timestamp  TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+		updated        STRING NOT NULL,
+		mvcc_timestamp STRING NOT NULL,
+		dlq_reason     STRING NOT NULL,
+		incoming_row   JSONB,
+		PRIMARY KEY (job_id, dlq_timestamp, id) USING HASH
+	)`
+	insertDeadLetterQueueStmt = `INSERT INTO %s (
+		job_id, table_id, table_name, updated, mvcc_timestamp, dlq_reason, incoming_row
+	) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+)
+
+// deadLetterQueue receives the rows which the changefeed failed to encode, as
+// well as the messages which the sink rejected, e.g. because they are too
+// large, so that the changefeed can continue past them.
+type deadLetterQueue interface {
+	// Log records the row along with the error which prevented it from being
+	// emitted.
+	Log(ctx context.Context, row cdcevent.Row, updated hlc.Timestamp, reason error) error
+	// LogRejected records the message along with the error with which the sink
+	// rejected it.
+	LogRejected(ctx context.Context, msg *messageSource, reason error) error
+	Close() error
+}
+
+// messageSource describes the row a message emitted to a sink was encoded
+// from. It is only tracked by sinks which divert the messages they reject to
+// the dead letter queue.
+type messageSource struct {
+	tableID    descpb.ID
+	tableName  string
+	key, value []byte
+	updated    hlc.Timestamp
+	mvcc       hlc.Timestamp
+}
+
+// rejectedMessageDivertingSink is implemented by sinks which can divert the
+// messages they reject to the dead letter queue instead of failing the
+// changefeed.
+type rejectedMessageDivertingSink interface {
+	// divertRejectedMessages makes the sink divert the messages it rejects to
+	// the given dead letter queue, which the sink takes ownership of. It
+	// returns false if the sink can't divert messages, in which case the
+	// caller keeps ownership of the dead letter queue.
+	divertRejectedMessages(dlq *sinkDeadLetterQueue) bool
+}
+
+// sinkDeadLetterQueue diverts the messages rejected by a sink to the dead
+// letter queue of the changefeed.
+type sinkDeadLetterQueue struct {
+	dlq     deadLetterQueue
+	metrics *sliMetrics
+}
+
+// divert records the rejected message in the dead letter queue.
+func (d *sinkDeadLetterQueue) divert(ctx context.Context, msg *messageSource, reason error) error {
+	if dlqLogLim.ShouldLog() {
+		log.Warningf(ctx, "writing message of table %s at %s rejected by the sink to %s: %v",
+			msg.tableName, msg.updated, changefeedbase.OptDeadLetterQueue, reason)
+	}
+	if err := d.dlq.LogRejected(ctx, msg, reason); err != nil {
+		return errors.CombineErrors(reason, err)
+	}
+	d.metrics.DLQRows.Inc(1)
+	return nil
+}
+
+// Close closes the underlying dead letter queue.
+func (d *sinkDeadLetterQueue) Close() error {
+	return d.dlq.Close()
+}
+
+// maybeDivertRejectedMessages makes the sink divert the messages it rejects to
+// the dead letter queue of the changefeed, if it has one and the sink supports
+// it.
+func maybeDivertRejectedMessages(
+	ctx context.Context,
+	cfg *execinfra.ServerConfig,
+	sink EventSink,
+	opts changefeedbase.StatementOptions,
+	jobID jobspb.JobID,
+	user username.SQLUsername,
+	metrics *sliMetrics,
+) error {
+	ds, ok := sink.(rejectedMessageDivertingSink)
+	if !ok {
+		return nil
+	}
+	dlq, err := makeDeadLetterQueue(ctx, cfg, opts, jobID, user)
+	if err != nil || dlq == nil {
+		return err
+	}
+	if !ds.divertRejectedMessages(&sinkDeadLetterQueue{dlq: dlq, metrics: metrics}) {
+		return dlq.Close()
+	}
+	return nil
+}
+
+// isDeadLetterQueueURI returns true if the dead letter queue destination is an
+// external storage URI rather than a table name.
+func isDeadLetterQueueURI(dest string) bool {
+	u, err := url.Parse(dest)
+	return err == nil && u.Scheme != ``
+}
+
+// prepareDeadLetterQueue validates the dead letter queue destination when a
+// changefeed is created. The user must be allowed to access a storage
+// destination. A table destination is qualified with the current database and
+// created, as the user creating the changefeed, if it does not already exist.
+// The table of a changefeed job is created in the transaction of the
+// statement, so that it is not left behind if creating the changefeed fails. A
+// sinkless changefeed runs within the transaction of the statement, so it could
+// not write to a table created in that transaction.
+func prepareDeadLetterQueue(
+	ctx context.Context, p sql.PlanHookState, opts changefeedbase.StatementOptions, sinkless bool,
+) error {
+	dest, ok := opts.GetDeadLetterQueue()
+	if !ok {
+		return nil
+	}
+	if isDeadLetterQueueURI(dest) {
+		return sql.CheckDestinationPrivileges(ctx, p, []string{dest})
+	}
+
+	un, err := parser.ParseTableName(dest)
+	if err != nil {
+		return pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+			"invalid %s table name %q", changefeedbase.OptDeadLetterQueue, dest)
+	}
+	tn := un.ToTableName()
+	if !tn.ExplicitCatalog {
+		tn.CatalogName = tree.Name(p.CurrentDatabase())
+		tn.ExplicitCatalog = true
+	}
+	if !tn.ExplicitSchema {
+		tn.SchemaName = catconstants.PublicSchemaName
+		tn.ExplicitSchema = true
+	}
+
+	var ex isql.Executor = p.InternalSQLTxn()
+	txn := p.Txn()
+	if sinkless {
+		ex, txn = p.ExecCfg().InternalDB.Executor(), nil
+	}
+	if _, err := ex.ExecEx(ctx, "create-changefeed-dlq", txn,
+		sessiondata.InternalExecutorOverride{User: p.User()},
+		fmt.Sprintf(createDeadLetterQueueTableStmt, tn.String()),
+	); err != nil {
+		return errors.Wrapf(err, "failed to create %s table %s", changefeedbase.OptDeadLetterQueue, tn.String())
+	}
+	opts.SetDeadLetterQueue(tn.String())
+	return nil
+}
+
+// makeDeadLetterQueue returns the dead letter queue of the changefeed, or nil
+// if rows which cannot be encoded should fail the changefeed.
+func makeDeadLetterQueue(
+	ctx context.Context,
+	cfg *execinfra.ServerConfig,
+	opts changefeedbase.StatementOptions,
+	jobID jobspb.JobID,
+	user username.SQLUsername,
+) (deadLetterQueue, error) {
+	dest, ok := opts.GetDeadLetterQueue()
+	if !ok {
+		return nil, nil
+	}
+	if isDeadLetterQueueURI(dest) {
+		es, err := cfg.ExternalStorageFromURI(ctx, dest, user, cloud.WithClientName("cdc"))
+		if err != nil {
+			return nil, err
+		}
+		return &storageDeadLetterQueue{es: es, jobID: jobID}, nil
+	}
+	return &tableDeadLetterQueue{db: cfg.DB, tableName: dest, jobID: jobID, user: user}, nil
+}
+
+// deadLetterQueueMessageJSON returns the encoded key and value of a message as
+// JSON. Binary encodings, e.g. avro, are base64 encoded.
+func deadLetterQueueMessageJSON(msg *messageSource) json.JSON {
+	b := json.NewObjectBuilder(2)
+	for _, f := range []struct {
+		name string
+		val  []byte
+	}{{"key", msg.key}, {"value", msg.value}} {
+		if utf8.Valid(f.val) {
+			b.Add(f.name, json.FromString(string(f.val)))
+		} else {
+			b.Add(f.name+"_base64", json.FromString(base64.StdEncoding.EncodeToString(f.val)))
+		}
+	}
+	return b.Build()
+}
+
+// deadLetterQueueRowJSON returns the row as JSON. Since the row could not be
+// encoded, converting it may fail as well, in which case its debug string is
+// returned instead.
+func deadLetterQueueRowJSON(ctx context.Context, row cdcevent.Row) json.JSON {
+	j, err := row.ToJSON()
+	if err != nil {
+		log.Warningf(ctx, "failed to convert changefeed row to json: %v", err)
+		return json.FromString(row.DebugString())
+	}
+	return j.JSON
+}
+
+// tableDeadLetterQueue inserts diverted rows into a table.
+type tableDeadLetterQueue struct {
+	db        isql.DB
+	tableName string
+	jobID     jobspb.JobID
+	user      username.SQLUsername
+}
+
+// Log implements deadLetterQueue.
+func (dlq *tableDeadLetterQueue) Log(
+	ctx context.Context, row cdcevent.Row, updated hlc.Timestamp, reason error,
+) error {
+	if _, err := dlq.db.Executor().ExecEx(ctx, "insert-changefeed-dlq", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: dlq.user},
+		fmt.Sprintf(insertDeadLetterQueueStmt, dlq.tableName),
+		int64(dlq.jobID),
+		int64(row.TableID),
+		row.TableName,
+		updated.AsOfSystemTime(),
+		row.MvccTimestamp.AsOfSystemTime(),
+		reason.Error(),
+		tree.NewDJSON(deadLetterQueueRowJSON(ctx, row)),
+	); err != nil {
+		return errors.Wrapf(err, "failed to write row to %s table %s", changefeedbase.OptDeadLetterQueue, dlq.tableName)
+	}
+	return nil
+}
+
+// LogRejected implements deadLetterQueue.
+func (dlq *tableDeadLetterQueue) LogRejected(
+	ctx context.Context, msg *messageSource, reason error,
+) error {
+	if _, err := dlq.db.Executor().ExecEx(ctx, "insert-changefeed-dlq", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: dlq.user},
+		fmt.Sprintf(insertDeadLetterQueueStmt, dlq.tableName),
+		int64(dlq.jobID),
+		int64(msg.tableID),
+		msg.tableName,
+		msg.updated.AsOfSystemTime(),
+		msg.mvcc.AsOfSystemTime(),
+		reason.Error(),
+		tree.NewDJSON(deadLetterQueueMessageJSON(msg)),
+	); err != nil {
+		return errors.Wrapf(err, "failed to write message to %s table %s", changefeedbase.OptDeadLetterQueue, dlq.tableName)
+	}
+	return nil
+}
+
+// Close implements deadLetterQueue.
+func (dlq *tableDeadLetterQueue) Close() error {
+	return nil
+}
+
+// storageDeadLetterQueue writes each diverted row as a JSON file to external
+// storage, under a directory named after the job.
+type storageDeadLetterQueue struct {
+	es    cloud.ExternalStorage
+	jobID jobspb.JobID
+}
+
+// Log implements deadLetterQueue.
+func (dlq *storageDeadLetterQueue) Log(
+	ctx context.Context, row cdcevent.Row, updated hlc.Timestamp, reason error,
+) error {
+	return dlq.write(ctx, row.TableID, row.TableName, updated, row.MvccTimestamp, reason,
+		deadLetterQueueRowJSON(ctx, row))
+}
+
+// LogRejected implements deadLetterQueue.
+func (dlq *storageDeadLetterQueue) LogRejected(
+	ctx context.Context, msg *messageSource, reason error,
+) error {
+	return dlq.write(ctx, msg.tableID, msg.tableName, msg.updated, msg.mvcc, reason,
+		deadLetterQueueMessageJSON(msg))
+}
+
+func (dlq *storageDeadLetterQueue) write(
+	ctx context.Context,
+	tableID descpb.ID,
+	tableName string,
+	updated, mvcc hlc.Timestamp,
+	reason error,
+	incoming json.JSON,
+) error {
+	b := json.NewObjectBuilder(7)
+	b.Add("job_id", json.FromInt64(int64(dlq.jobID)))
+	b.Add("table_id", json.FromInt64(int64(tableID)))
+	b.Add("table_name", json.FromString(tableName))
+	b.Add("updated", json.FromString(updated.AsOfSystemTime()))
+	b.Add("mvcc_timestamp", json.FromString(mvcc.AsOfSystemTime()))
+	b.Add("dlq_reason", json.FromString(reason.Error()))
+	b.Add("incoming_row", incoming)
+
+	name := fmt.Sprintf("%d/%s-%d-%s.json", dlq.jobID,
+		strings.Replace(updated.AsOfSystemTime(), ".", "_", 1), tableID, uuid.MakeV4())
+	if err := cloud.WriteFile(ctx, dlq.es, name, strings.NewReader(b.Build().String())); err != nil {
+		return errors.Wrapf(err, "failed to write row to %s", changefeedbase.OptDeadLetterQueue)
+	}
+	return nil
+}
+
+// Close implements deadLetterQueue.
+func (dlq *storageDeadLetterQueue) Close() error {
+	return dlq.es.Close()
+}
@@ -82,6 +82,8 @@ func getTableName(
 		return fmt.Sprintf("%s%s.%s", schemaPrefix, target.StatementTimeName, eventMeta.FamilyName), nil
 	case jobspb.ChangefeedTargetSpecification_COLUMN_FAMILY:
 		return fmt.Sprintf("%s%s.%s", schemaPrefix, target.StatementTimeName, target.FamilyName), nil
+	case jobspb.ChangefeedTargetSpecification_SECONDARY_INDEX:
+		return fmt.Sprintf("%s%s.%s", schemaPrefix, target.StatementTimeName, target.IndexName), nil
 	default:
 		return "", errors.AssertionFailedf("Found a matching target with unimplemented type %s", target.Type)
 	}
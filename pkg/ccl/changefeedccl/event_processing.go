@@ -69,6 +69,10 @@ type kvEventToRowConsumer struct {
 	metrics *sliMetrics
 	sv      *settings.Values
 
+	// dlq, if set, receives the rows which cannot be encoded instead of
+	// failing the changefeed.
+	dlq deadLetterQueue
+
 	// This pacer is used to incorporate event consumption to elastic CPU
 	// control. This helps ensure that event encoding/decoding does not throttle
 	// foreground SQL traffic.
@@ -148,9 +152,14 @@ func newEventConsumer(
 			)
 		}
 
+		dlq, err := makeDeadLetterQueue(ctx, cfg, feed.Opts, spec.JobID, spec.User())
+		if err != nil {
+			return nil, err
+		}
+
 		execCfg := cfg.ExecutorConfig.(*sql.ExecutorConfig)
 		return newKVEventToRowConsumer(ctx, execCfg, frontier, cursor, s,
-			encoder, feed, spec, knobs, topicNamer, sliMetrics, pacer)
+			encoder, feed, spec, knobs, topicNamer, sliMetrics, pacer, dlq)
 	}
 
 	numWorkers := changefeedbase.EventConsumerWorkers.Get(&cfg.Settings.SV)
@@ -231,6 +240,7 @@ func newKVEventToRowConsumer(
 	topicNamer *TopicNamer,
 	metrics *sliMetrics,
 	pacer *admission.Pacer,
+	dlq deadLetterQueue,
 ) (_ *kvEventToRowConsumer, err error) {
 	includeVirtual := details.Opts.IncludeVirtual()
 	keyOnly := details.Opts.KeyOnly()
@@ -267,6 +277,7 @@ func newKVEventToRowConsumer(
 		metrics:              metrics,
 		pacer:                pacer,
 		sv:                   cfg.SV(),
+		dlq:                  dlq,
 	}, nil
 }
 
@@ -442,14 +453,14 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 	var keyCopy, valueCopy []byte
 	encodedKey, err := c.encoder.EncodeKey(ctx, updatedRow)
 	if err != nil {
-		return err
+		return c.maybeDivertToDLQ(ctx, updatedRow, schemaTS, alloc, err)
 	}
 	c.scratch, keyCopy = c.scratch.Copy(encodedKey, 0 /* extraCap */)
 	// TODO(yevgeniy): Some refactoring is needed in the encoder: namely, prevRow
 	// might not be available at all when working with changefeed expressions.
 	encodedValue, err := c.encoder.EncodeValue(ctx, evCtx, updatedRow, prevRow)
 	if err != nil {
-		return err
+		return c.maybeDivertToDLQ(ctx, updatedRow, schemaTS, alloc, err)
 	}
 	c.scratch, valueCopy = c.scratch.Copy(encodedValue, 0 /* extraCap */)
 
@@ -481,6 +492,33 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 	return nil
 }
 
+// maybeDivertToDLQ writes a row which failed to encode to the dead letter
+// queue, if the changefeed has one, and skips the row. Otherwise, the encoding
+// error is returned.
+func (c *kvEventToRowConsumer) maybeDivertToDLQ(
+	ctx context.Context,
+	row cdcevent.Row,
+	updated hlc.Timestamp,
+	alloc kvevent.Alloc,
+	encodeErr error,
+) error {
+	if c.dlq == nil || errors.Is(encodeErr, context.Canceled) {
+		return encodeErr
+	}
+	if dlqLogLim.ShouldLog() {
+		log.Warningf(ctx, "writing row of table %s at %s to %s: %v",
+			row.TableName, updated, changefeedbase.OptDeadLetterQueue, encodeErr)
+	}
+	if err := c.dlq.Log(ctx, row, updated, encodeErr); err != nil {
+		return errors.CombineErrors(encodeErr, err)
+	}
+	c.metrics.DLQRows.Inc(1)
+	alloc.Release(ctx)
+	return nil
+}
+
+var dlqLogLim = log.Every(10 * time.Second)
+
 var jsonHeaderWrongTypeLogLim = log.Every(1 * time.Minute)
 var jsonHeaderWrongValTypeLogLim = log.Every(1 * time.Minute)
 
@@ -541,6 +579,9 @@ func (c *kvEventToRowConsumer) Close() error {
 	if c.evaluator != nil {
 		c.evaluator.Close()
 	}
+	if c.dlq != nil {
+		return c.dlq.Close()
+	}
 	return nil
 }
 
@@ -0,0 +1,358 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+)
+
+// Tables of a changefeed may be paused with ALTER CHANGEFEED ... PAUSE, or
+// quarantined after failing when on_target_error='quarantine' is set. Such
+// tables are recorded in the InactiveTargets of the changefeed progress along
+// with the resolved timestamp of the changefeed at the time, and are excluded
+// from the changefeed flow. Once resumed with ALTER CHANGEFEED ... RESUME, the
+// changefeed restarts and catches up on the changes to the tables since that
+// timestamp.
+
+// findInactiveTarget returns the index of the inactive target for the table, or
+// -1 if the table is not inactive.
+func findInactiveTarget(progress *jobspb.ChangefeedProgress, tableID descpb.ID) int {
+	if progress == nil {
+		return -1
+	}
+	return slices.IndexFunc(progress.InactiveTargets, func(t jobspb.ChangefeedInactiveTarget) bool {
+		return t.TableID == tableID
+	})
+}
+
+// inactiveTargetsChanged returns true if targets were paused or resumed between
+// the two versions of the changefeed progress.
+func inactiveTargetsChanged(prev, cur *jobspb.ChangefeedProgress) bool {
+	var prevTargets, curTargets []jobspb.ChangefeedInactiveTarget
+	if prev != nil {
+		prevTargets = prev.InactiveTargets
+	}
+	if cur != nil {
+		curTargets = cur.InactiveTargets
+	}
+	return !slices.EqualFunc(prevTargets, curTargets, func(a, b jobspb.ChangefeedInactiveTarget) bool {
+		return a.TableID == b.TableID && a.Resuming == b.Resuming
+	})
+}
+
+// filterTargets returns a copy of the changefeed details which only contains
+// the targets whose table ID satisfies the predicate.
+func filterTargets(
+	details jobspb.ChangefeedDetails, keep func(descpb.ID) bool,
+) jobspb.ChangefeedDetails {
+	filtered := details
+	filtered.TargetSpecifications = nil
+	for _, ts := range details.TargetSpecifications {
+		if keep(ts.TableID) {
+			filtered.TargetSpecifications = append(filtered.TargetSpecifications, ts)
+		}
+	}
+	filtered.Tables = make(jobspb.ChangefeedTargets, len(details.Tables))
+	for id, t := range details.Tables {
+		if keep(id) {
+			filtered.Tables[id] = t
+		}
+	}
+	return filtered
+}
+
+// withoutInactiveTargets returns a copy of the changefeed details without the
+// targets which are paused, quarantined or waiting to be resumed.
+func withoutInactiveTargets(
+	details jobspb.ChangefeedDetails, progress *jobspb.ChangefeedProgress,
+) jobspb.ChangefeedDetails {
+	if progress == nil || len(progress.InactiveTargets) == 0 {
+		return details
+	}
+	return filterTargets(details, func(id descpb.ID) bool {
+		return findInactiveTarget(progress, id) < 0
+	})
+}
+
+// fetchTargetSpans returns the spans watched for the targets of the changefeed
+// details as of the specified timestamp.
+func fetchTargetSpans(
+	ctx context.Context, execCtx sql.JobExecContext, details jobspb.ChangefeedDetails, ts hlc.Timestamp,
+) (roachpb.Spans, error) {
+	descs, err := fetchTableDescriptors(ctx, execCtx.ExecCfg(), AllTargets(details), ts)
+	if err != nil {
+		return nil, err
+	}
+	return fetchSpansForTables(ctx, execCtx, descs, details, ts)
+}
+
+// activateResumedTargets updates the changefeed progress so that the changefeed
+// catches up on the changes to the tables resumed since it last started.
+//
+// The spans of the resumed tables are added to the catch-up spans of the
+// progress at the resolved timestamps of the tables. The high-water of the
+// changefeed is left as is, so that the resolved timestamps it emits never
+// regress; it only advances again once the resumed tables have caught up.
+func activateResumedTargets(
+	ctx context.Context,
+	execCtx sql.JobExecContext,
+	jobID jobspb.JobID,
+	details jobspb.ChangefeedDetails,
+	localState *cachedState,
+) error {
+	prevProgress := localState.progress.GetChangefeed()
+	if jobID == 0 || prevProgress == nil || !slices.ContainsFunc(prevProgress.InactiveTargets,
+		func(t jobspb.ChangefeedInactiveTarget) bool { return t.Resuming }) {
+		return nil
+	}
+
+	newProgress := *protoutil.Clone(&localState.progress).(*jobspb.Progress)
+	cfProgress := newProgress.GetChangefeed()
+	cfProgress.InactiveTargets = slices.DeleteFunc(cfProgress.InactiveTargets,
+		func(t jobspb.ChangefeedInactiveTarget) bool { return t.Resuming })
+
+	// If the changefeed has not completed its initial scan, the spans of the
+	// resumed tables are not in the checkpoint, so the initial scan covers them.
+	if hw := localState.progress.GetHighWater(); hw != nil && hw.IsSet() {
+		catchUpSpans := maps.Collect(cfProgress.CatchUpSpans.All())
+		var resumedSpans roachpb.Spans
+		for _, t := range prevProgress.InactiveTargets {
+			if !t.Resuming {
+				continue
+			}
+			spans, err := fetchTargetSpans(ctx, execCtx, filterTargets(details, func(id descpb.ID) bool {
+				return id == t.TableID
+			}), hw.Next())
+			if err != nil {
+				return err
+			}
+			resumedSpans = append(resumedSpans, spans...)
+			if t.Resolved.Less(*hw) {
+				catchUpSpans[t.Resolved] = append(catchUpSpans[t.Resolved], spans...)
+			}
+		}
+		cfProgress.CatchUpSpans = jobspb.NewTimestampSpansMap(catchUpSpans)
+
+		// The checkpoint may still hold the progress of the resumed tables from
+		// before they were paused.
+		prevCheckpoint, err := getSpanLevelCheckpointFromProgress(localState.progress, details.StatementTime)
+		if err != nil {
+			return err
+		}
+		cfProgress.Checkpoint = nil
+		cfProgress.SpanLevelCheckpoint = subtractSpans(prevCheckpoint, resumedSpans...)
+		if !cfProgress.CatchUpSpans.IsEmpty() {
+			log.Infof(ctx, "changefeed %d catching up on resumed targets from %s",
+				jobID, cfProgress.CatchUpSpans.MinTimestamp())
+		}
+	}
+
+	job, err := execCtx.ExecCfg().JobRegistry.LoadClaimedJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if err := job.NoTxn().Update(ctx, func(
+		txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+	) error {
+		if err := md.CheckRunningOrReverting(); err != nil {
+			return err
+		}
+		if inactiveTargetsChanged(prevProgress, md.Progress.GetChangefeed()) {
+			return errors.Newf("targets of changefeed %d were altered concurrently", jobID)
+		}
+		ju.UpdateProgress(&newProgress)
+		return nil
+	}); err != nil {
+		return err
+	}
+	localState.progress = newProgress
+	return nil
+}
+
+// withCatchUpSpans returns the initial high-water and the span-level checkpoint
+// of a changefeed flow which catches up on the catch-up spans of the progress.
+// The flow starts at the earliest timestamp of the catch-up spans, and the
+// checkpoint holds every other tracked span at the high-water or above, so that
+// only the changes to the catch-up spans are emitted again.
+func withCatchUpSpans(
+	highWater hlc.Timestamp,
+	checkpoint *jobspb.TimestampSpansMap,
+	catchUpSpans *jobspb.TimestampSpansMap,
+	trackedSpans roachpb.Spans,
+) (hlc.Timestamp, *jobspb.TimestampSpansMap) {
+	if highWater.IsEmpty() || catchUpSpans.IsEmpty() {
+		return highWater, checkpoint
+	}
+	var catchingUp, checkpointed roachpb.SpanGroup
+	newCheckpoint := make(map[hlc.Timestamp]roachpb.Spans)
+	for ts, spans := range catchUpSpans.All() {
+		catchingUp.Add(spans...)
+		newCheckpoint[ts] = spans
+	}
+	for ts, spans := range subtractSpans(checkpoint, catchingUp.Slice()...).All() {
+		checkpointed.Add(spans...)
+		newCheckpoint[ts] = append(newCheckpoint[ts], spans...)
+	}
+	var atHighWater roachpb.SpanGroup
+	atHighWater.Add(trackedSpans...)
+	atHighWater.Sub(catchingUp.Slice()...)
+	atHighWater.Sub(checkpointed.Slice()...)
+	if atHighWater.Len() > 0 {
+		newCheckpoint[highWater] = append(newCheckpoint[highWater], atHighWater.Slice()...)
+	}
+	return catchUpSpans.MinTimestamp(), jobspb.NewTimestampSpansMap(newCheckpoint)
+}
+
+// catchUpSpansBelow returns the spans of the frontier entries which are
+// resolved below the high-water, i.e. which are still catching up.
+func catchUpSpansBelow(
+	entries iter.Seq2[roachpb.Span, hlc.Timestamp], highWater hlc.Timestamp,
+) *jobspb.TimestampSpansMap {
+	m := make(map[hlc.Timestamp]roachpb.Spans)
+	for sp, ts := range entries {
+		if ts.Less(highWater) {
+			m[ts] = append(m[ts], sp)
+		}
+	}
+	return jobspb.NewTimestampSpansMap(m)
+}
+
+// removeCatchUpSpans removes the given spans from the catch-up spans of the
+// progress, and returns the earliest timestamp of the removed spans, which is
+// empty if none of the spans were catching up.
+func removeCatchUpSpans(
+	progress *jobspb.ChangefeedProgress, spans ...roachpb.Span,
+) (removedFrom hlc.Timestamp) {
+	for ts, catchUp := range progress.CatchUpSpans.All() {
+		for _, sp := range catchUp {
+			if slices.ContainsFunc(spans, sp.Overlaps) && (removedFrom.IsEmpty() || ts.Less(removedFrom)) {
+				removedFrom = ts
+			}
+		}
+	}
+	progress.CatchUpSpans = subtractSpans(progress.CatchUpSpans, spans...)
+	return removedFrom
+}
+
+// subtractSpans returns a copy of the spans map without the given spans.
+func subtractSpans(
+	tsm *jobspb.TimestampSpansMap, spans ...roachpb.Span,
+) *jobspb.TimestampSpansMap {
+	m := make(map[hlc.Timestamp]roachpb.Spans)
+	for ts, sp := range tsm.All() {
+		var sg roachpb.SpanGroup
+		sg.Add(sp...)
+		sg.Sub(spans...)
+		if sg.Len() > 0 {
+			m[ts] = sg.Slice()
+		}
+	}
+	return jobspb.NewTimestampSpansMap(m)
+}
+
+// maybeQuarantineTarget quarantines the table which caused the changefeed to
+// fail when on_target_error='quarantine' is set, so that the changefeed can
+// restart without it. Returns false if the error should fail the changefeed as
+// usual: if it was not caused by a specific table, if the table is the last
+// active target of the changefeed, or if the changefeed has not completed its
+// initial scan, in which case there is no resolved timestamp from which the
+// table could be caught up when resumed.
+func maybeQuarantineTarget(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	jobID jobspb.JobID,
+	details jobspb.ChangefeedDetails,
+	localState *cachedState,
+	cause error,
+) (bool, error) {
+	if jobID == 0 || ctx.Err() != nil {
+		return false, nil
+	}
+	onTargetError, err := changefeedbase.MakeStatementOptions(details.Opts).GetOnTargetError()
+	if err != nil || onTargetError != changefeedbase.OptOnTargetErrorQuarantine {
+		return false, err
+	}
+	tableID, ok := changefeedbase.TargetErrorTableID(cause)
+	if !ok {
+		return false, nil
+	}
+	active := AllTargets(withoutInactiveTargets(details, localState.progress.GetChangefeed()))
+	if found, _ := active.EachHavingTableID(tableID, func(changefeedbase.Target) error {
+		return nil
+	}); !found || active.NumUniqueTables() < 2 {
+		return false, nil
+	}
+
+	// Persist the progress made by the changefeed so that the table can later
+	// be caught up from the latest possible timestamp.
+	if err := reconcileJobStateWithLocalState(ctx, jobID, localState, execCfg); err != nil {
+		return false, jobs.MarkAsRetryJobError(err)
+	}
+	if hw := localState.progress.GetHighWater(); hw == nil || hw.IsEmpty() {
+		return false, nil
+	}
+
+	job, err := execCfg.JobRegistry.LoadClaimedJob(ctx, jobID)
+	if err != nil {
+		return false, jobs.MarkAsRetryJobError(err)
+	}
+	var newProgress jobspb.Progress
+	if err := job.NoTxn().Update(ctx, func(
+		txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+	) error {
+		if err := md.CheckRunningOrReverting(); err != nil {
+			return err
+		}
+		progress := md.Progress
+		cfProgress := progress.GetChangefeed()
+		hw := progress.GetHighWater()
+		if cfProgress == nil || hw == nil || hw.IsEmpty() {
+			return errors.AssertionFailedf("changefeed %d has no resolved timestamp", jobID)
+		}
+		if i := findInactiveTarget(cfProgress, tableID); i >= 0 {
+			// The table was paused concurrently; keep its resolved timestamp.
+			t := &cfProgress.InactiveTargets[i]
+			t.Resuming = false
+			t.Quarantined = true
+			t.Error = cause.Error()
+		} else {
+			// A table which is still catching up is only resolved up to its
+			// catch-up timestamp.
+			resolved := *hw
+			if from := removeCatchUpSpans(cfProgress, execCfg.Codec.TableSpan(uint32(tableID))); from.IsSet() {
+				resolved.Backward(from)
+			}
+			cfProgress.InactiveTargets = append(cfProgress.InactiveTargets, jobspb.ChangefeedInactiveTarget{
+				TableID:     tableID,
+				Resolved:    resolved,
+				Quarantined: true,
+				Error:       cause.Error(),
+			})
+		}
+		ju.UpdateProgress(progress)
+		newProgress = *progress
+		return nil
+	}); err != nil {
+		return false, jobs.MarkAsRetryJobError(err)
+	}
+	localState.progress = newProgress
+	return true, nil
+}
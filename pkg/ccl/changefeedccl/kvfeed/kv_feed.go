@@ -10,6 +10,7 @@
 package kvfeed
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"time"
@@ -23,8 +24,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -52,6 +55,21 @@ type MonitoringConfig struct {
 	OnBackfillRangeCallback func(int64) (func(), func())
 }
 
+// IndexLookup configures the initial scan to locate rows by scanning spans of
+// a secondary index, and then looking up the referenced rows in the watched
+// primary index spans, instead of scanning the watched spans in their
+// entirety. This is beneficial when only a small fraction of the rows in the
+// watched spans are of interest to the changefeed.
+type IndexLookup struct {
+	// Spans are the secondary index spans to scan.
+	Spans []roachpb.Span
+	// PrimaryKey returns the primary index key of the row referenced by the
+	// specified secondary index entry. It may return a nil key if the entry
+	// does not reference a row (e.g. KV for a non-zero column family of the
+	// secondary index); such entries are skipped.
+	PrimaryKey func(ctx context.Context, kv roachpb.KeyValue) (roachpb.Key, error)
+}
+
 // Config configures a kvfeed.
 type Config struct {
 	Settings            *cluster.Settings
@@ -70,12 +88,28 @@ type Config struct {
 	SchemaChangePolicy  changefeedbase.SchemaChangePolicy
 	SchemaFeed          schemafeed.SchemaFeed
 
+	// QuarantineTargets, if set, causes a schema change which stops the feed
+	// under the stop policy to fail the feed with an error identifying the
+	// changed table, so that the table can be quarantined instead.
+	QuarantineTargets bool
+
 	// If true, the feed will begin with a dump of data at exactly the
 	// InitialHighWater. This is a peculiar behavior. In general the
 	// InitialHighWater is a point in time at which all data is known to have
 	// been seen.
 	NeedsInitialScan bool
 
+	// InitialScanIndexLookup, if set, configures the initial scan to locate
+	// rows via a secondary index lookup. It is ignored for scans other than
+	// the initial scan (e.g. backfills due to schema changes).
+	InitialScanIndexLookup *IndexLookup
+
+	// RangeFeedFilter, if set, returns the filter the rangefeed server applies
+	// to the events of a rangefeed started at the given timestamp, or nil if
+	// none. It is called each time the rangefeed is (re)started, e.g. after a
+	// schema change.
+	RangeFeedFilter func(ctx context.Context, ts hlc.Timestamp) (*kvpb.RangeFeedFilter, error)
+
 	// InitialHighWater is the timestamp after which new events are guaranteed to
 	// be produced.
 	InitialHighWater hlc.Timestamp
@@ -139,6 +173,9 @@ func Run(ctx context.Context, cfg Config) error {
 		cfg.SchemaFeed,
 		sc, pff, bf, cfg.Targets, cfg.ScopedTimers, cfg.Knobs)
 	f.onBackfillCallback = cfg.MonitoringCfg.OnBackfillCallback
+	f.initialScanIndexLookup = cfg.InitialScanIndexLookup
+	f.rangeFeedFilter = cfg.RangeFeedFilter
+	f.quarantineTargets = cfg.QuarantineTargets
 	f.rangeObserver = startLaggingRangesObserver(g, cfg.MonitoringCfg.LaggingRangesCallback,
 		cfg.MonitoringCfg.LaggingRangesPollingInterval, cfg.MonitoringCfg.LaggingRangesThreshold)
 
@@ -265,10 +302,13 @@ type kvFeed struct {
 	writer               kvevent.Writer
 	codec                keys.SQLCodec
 
-	onBackfillCallback func() func()
-	rangeObserver      kvcoord.RangeObserver
-	schemaChangeEvents changefeedbase.SchemaChangeEventClass
-	schemaChangePolicy changefeedbase.SchemaChangePolicy
+	onBackfillCallback     func() func()
+	initialScanIndexLookup *IndexLookup
+	rangeFeedFilter        func(context.Context, hlc.Timestamp) (*kvpb.RangeFeedFilter, error)
+	rangeObserver          kvcoord.RangeObserver
+	schemaChangeEvents     changefeedbase.SchemaChangeEventClass
+	schemaChangePolicy     changefeedbase.SchemaChangePolicy
+	quarantineTargets      bool
 
 	targets changefeedbase.Targets
 	timers  *timers.ScopedTimers
@@ -356,7 +396,7 @@ func (f *kvFeed) run(ctx context.Context) (err error) {
 	for i := 0; ; i++ {
 		initialScan := i == 0
 		initialScanOnly := f.endTime == f.initialHighWater
-		scannedSpans, scannedTS, err := f.scanIfShould(ctx, initialScan, initialScanOnly, rangeFeedResumeFrontier.Frontier())
+		scannedSpans, scannedTS, err := f.scanIfShould(ctx, initialScan, initialScanOnly, rangeFeedResumeFrontier)
 		if err != nil {
 			return err
 		}
@@ -427,6 +467,19 @@ func (f *kvFeed) run(ctx context.Context) (err error) {
 		} else if f.schemaChangePolicy == changefeedbase.OptSchemaChangePolicyStop {
 			boundaryType = jobspb.ResolvedSpan_EXIT
 		}
+		// If the stop policy was triggered by a single table, fail with an error
+		// identifying the table so that it can be quarantined while the rest of
+		// the changefeed continues.
+		if boundaryType == jobspb.ResolvedSpan_EXIT && f.quarantineTargets {
+			if tableID, ok := singleTableEvents(events); ok {
+				return changefeedbase.WithTerminalError(changefeedbase.WithTargetError(
+					errors.Newf("schema change occurred at %v and %s=%q",
+						schemaChangeTS.AsOfSystemTime(), changefeedbase.OptSchemaChangePolicy,
+						changefeedbase.OptSchemaChangePolicyStop),
+					tableID))
+			}
+		}
+
 		// Resolve all of the spans as a boundary if the policy indicates that
 		// we should do so.
 		if f.schemaChangePolicy != changefeedbase.OptSchemaChangePolicyNoBackfill ||
@@ -446,6 +499,21 @@ func (f *kvFeed) run(ctx context.Context) (err error) {
 	}
 }
 
+// singleTableEvents returns the ID of the table which all events belong to, or
+// false if the events belong to multiple tables.
+func singleTableEvents(events []schemafeed.TableEvent) (descpb.ID, bool) {
+	if len(events) == 0 {
+		return 0, false
+	}
+	tableID := events[0].After.GetID()
+	for _, ev := range events[1:] {
+		if ev.After.GetID() != tableID {
+			return 0, false
+		}
+	}
+	return tableID, true
+}
+
 func isPrimaryKeyChange(
 	events []schemafeed.TableEvent, targets changefeedbase.Targets,
 ) (isPrimaryIndexChange, hasNoColumnChanges bool) {
@@ -455,6 +523,13 @@ func isPrimaryKeyChange(
 			isPrimaryIndexChange = true
 			hasNoColumnChanges = hasNoColumnChanges && noColumnChange
 		}
+		// Refreshing a materialized view replaces its primary index, so the
+		// changefeed must restart to watch the new one. The refreshed contents
+		// are then emitted by the backfill following the restart.
+		if schemafeed.IsMaterializedViewRefresh(ev) {
+			isPrimaryIndexChange = true
+			hasNoColumnChanges = false
+		}
 	}
 	return isPrimaryIndexChange, isPrimaryIndexChange && hasNoColumnChanges
 }
@@ -472,6 +547,23 @@ func filterCheckpointSpans(
 	return sg.Slice()
 }
 
+// filterResolvedSpans returns the parts of the spans which the frontier has not
+// resolved up to the given timestamp.
+func filterResolvedSpans(
+	spans []roachpb.Span, frontier span.Frontier, ts hlc.Timestamp,
+) []roachpb.Span {
+	var sg roachpb.SpanGroup
+	sg.Add(spans...)
+	for _, sp := range spans {
+		for entry, resolved := range frontier.SpanEntries(sp) {
+			if ts.LessEq(resolved) {
+				sg.Sub(entry)
+			}
+		}
+	}
+	return sg.Slice()
+}
+
 // scanIfShould performs a scan of KV pairs in watched span if
 // - this is the initial scan, or
 // - table schema is changed (a column is added/dropped) and a re-scan is needed.
@@ -481,11 +573,13 @@ func filterCheckpointSpans(
 // or from a table descriptor change. It is *not* responsible for capturing data changes
 // from DMLs (INSERT, UPDATE, etc.). That is handled elsewhere from the underlying rangefeed.
 //
-// `highWater` is the largest timestamp at or below which we know all events in
-// watched span have been seen (i.e. frontier.smallestTS).
+// `resumeFrontier` tracks the timestamps up to which the events in the watched
+// spans have been seen; its frontier is the largest timestamp at or below which
+// we know all events in watched span have been seen.
 func (f *kvFeed) scanIfShould(
-	ctx context.Context, initialScan bool, initialScanOnly bool, highWater hlc.Timestamp,
+	ctx context.Context, initialScan bool, initialScanOnly bool, resumeFrontier span.Frontier,
 ) ([]roachpb.Span, hlc.Timestamp, error) {
+	highWater := resumeFrontier.Frontier()
 	scanTime := highWater.Next()
 
 	events, err := f.tableFeed.Peek(ctx, scanTime)
@@ -545,6 +639,12 @@ func (f *kvFeed) scanIfShould(
 	// If we have initial checkpoint information specified, filter out
 	// spans which we no longer need to scan.
 	spansToBackfill := filterCheckpointSpans(spansToScan, f.spanLevelCheckpoint)
+	if !isInitialScan {
+		// Spans which are already resolved at or past the scan time, e.g. the
+		// spans of the other tables while the changefeed catches up on a resumed
+		// table, were backfilled for the schema change before.
+		spansToBackfill = filterResolvedSpans(spansToBackfill, resumeFrontier, scanTime)
+	}
 	if len(spansToBackfill) == 0 {
 		return spansToScan, scanTime, nil
 	}
@@ -557,12 +657,21 @@ func (f *kvFeed) scanIfShould(
 	if initialScanOnly {
 		boundaryType = jobspb.ResolvedSpan_EXIT
 	}
+	var indexLookup *IndexLookup
+	if isInitialScan {
+		indexLookup = f.initialScanIndexLookup
+	} else {
+		if err := f.scanMaterializedViewRefreshDeletes(ctx, events, scanTime); err != nil {
+			return nil, hlc.Timestamp{}, err
+		}
+	}
 	if err := f.scanner.Scan(ctx, f.writer, scanConfig{
-		Spans:     spansToBackfill,
-		Timestamp: scanTime,
-		WithDiff:  !isInitialScan && f.withDiff,
-		Knobs:     f.knobs,
-		Boundary:  boundaryType,
+		Spans:       spansToBackfill,
+		Timestamp:   scanTime,
+		WithDiff:    !isInitialScan && f.withDiff,
+		Knobs:       f.knobs,
+		Boundary:    boundaryType,
+		IndexLookup: indexLookup,
 	}); err != nil {
 		return nil, hlc.Timestamp{}, err
 	}
@@ -572,6 +681,97 @@ func (f *kvFeed) scanIfShould(
 	return spansToScan, scanTime, nil
 }
 
+// scanMaterializedViewRefreshDeletes emits a deletion, at the scan time, for
+// every row of the materialized views which were refreshed by the specified
+// events. Refreshing a view replaces its primary index with a new one which
+// is backfilled with the results of the view query, so the backfill following
+// the refresh only emits the rows present after the refresh; the rows which
+// were present before it would otherwise never be deleted downstream.
+//
+// The rows of a materialized view are keyed by a hidden rowid column, whose
+// values are never reused by a refresh, so every row present before the
+// refresh is deleted. The old primary index is read just before the refresh,
+// which is protected from garbage collection by the changefeed's protected
+// timestamp, and the deletions are emitted with keys of the new primary index
+// so that they are decoded with the descriptor in effect after the refresh.
+func (f *kvFeed) scanMaterializedViewRefreshDeletes(
+	ctx context.Context, events []schemafeed.TableEvent, scanTime hlc.Timestamp,
+) error {
+	for _, ev := range events {
+		if !schemafeed.IsMaterializedViewRefresh(ev) {
+			continue
+		}
+		newIndexSpan := ev.After.PrimaryIndexSpan(f.codec)
+		watched := false
+		for _, sp := range f.spans {
+			watched = watched || sp.Overlaps(newIndexSpan)
+		}
+		if !watched {
+			continue
+		}
+		w := &refreshDeletesWriter{
+			Writer:    f.writer,
+			oldPrefix: f.codec.IndexPrefix(uint32(ev.Before.GetID()), uint32(ev.Before.GetPrimaryIndexID())),
+			newPrefix: f.codec.IndexPrefix(uint32(ev.After.GetID()), uint32(ev.After.GetPrimaryIndexID())),
+			deleteTS:  scanTime,
+		}
+		if err := f.scanner.Scan(ctx, w, scanConfig{
+			Spans:     []roachpb.Span{ev.Before.PrimaryIndexSpan(f.codec)},
+			Timestamp: scanTime.Prev(),
+			Knobs:     f.knobs,
+			Boundary:  jobspb.ResolvedSpan_NONE,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshDeletesWriter is a kvevent.Writer which turns the rows of the old
+// primary index of a refreshed materialized view into deletions of those rows
+// in its new primary index. Resolved events for the old primary index are
+// dropped, since it is not watched by the changefeed.
+type refreshDeletesWriter struct {
+	kvevent.Writer
+	oldPrefix, newPrefix roachpb.Key
+	deleteTS             hlc.Timestamp
+	// lastRow is the row prefix of the last key for which a deletion was
+	// emitted; the other column families of a row are skipped.
+	lastRow roachpb.Key
+}
+
+var _ kvevent.Writer = (*refreshDeletesWriter)(nil)
+
+// Add implements kvevent.Writer.
+func (w *refreshDeletesWriter) Add(ctx context.Context, e kvevent.Event) error {
+	if e.Type() != kvevent.TypeKV {
+		return nil
+	}
+	key := e.KV().Key
+	if !bytes.HasPrefix(key, w.oldPrefix) {
+		return errors.AssertionFailedf("key %s is not in the index with prefix %s", key, w.oldPrefix)
+	}
+	row, err := keys.EnsureSafeSplitKey(key)
+	if err != nil {
+		return err
+	}
+	if row.Equal(w.lastRow) {
+		return nil
+	}
+	w.lastRow = row
+	newRow := append(w.newPrefix[:len(w.newPrefix):len(w.newPrefix)], row[len(w.oldPrefix):]...)
+	return w.Writer.Add(ctx, kvevent.NewBackfillKVEvent(
+		keys.MakeFamilyKey(newRow, 0), w.deleteTS, nil /* val */, false /* withDiff */, w.deleteTS))
+}
+
+// AcquireMemory implements kvevent.MemAllocator if the wrapped writer does.
+func (w *refreshDeletesWriter) AcquireMemory(ctx context.Context, n int64) (kvevent.Alloc, error) {
+	if a, ok := w.Writer.(kvevent.MemAllocator); ok {
+		return a.AcquireMemory(ctx, n)
+	}
+	return kvevent.Alloc{}, nil
+}
+
 // runUntilTableEvent starts rangefeeds for the spans being watched by
 // the kv feed and runs until a table event (schema change) is encountered.
 //
@@ -603,12 +803,20 @@ func (f *kvFeed) runUntilTableEvent(ctx context.Context, resumeFrontier span.Fro
 		stps = append(stps, kvcoord.SpanTimePair{Span: s, StartAfter: ts})
 	}
 
+	var filter *kvpb.RangeFeedFilter
+	if f.rangeFeedFilter != nil {
+		if filter, err = f.rangeFeedFilter(ctx, startFrom); err != nil {
+			return err
+		}
+	}
+
 	g := ctxgroup.WithContext(ctx)
 	physicalCfg := rangeFeedConfig{
 		Spans:                stps,
 		Frontier:             resumeFrontier.Frontier(),
 		WithDiff:             f.withDiff,
 		WithFiltering:        f.withFiltering,
+		Filter:               filter,
 		WithFrontierQuantize: f.withFrontierQuantize,
 		ConsumerID:           f.consumerID,
 		Knobs:                f.knobs,
@@ -107,6 +107,7 @@ func TestKVFeed(t *testing.T) {
 		endTime              hlc.Timestamp
 		spans                []roachpb.Span
 		spanLevelCheckpoint  *jobspb.TimestampSpansMap
+		indexLookup          *IndexLookup
 		events               []kvpb.RangeFeedEvent
 
 		descs []catalog.TableDescriptor
@@ -154,6 +155,7 @@ func TestKVFeed(t *testing.T) {
 			tf, sf, rangefeedFactory(ref.run), bufferFactory,
 			changefeedbase.Targets{},
 			st, TestingKnobs{})
+		f.initialScanIndexLookup = tc.indexLookup
 		ctx, cancel := context.WithCancel(context.Background())
 		g := ctxgroup.WithContext(ctx)
 		g.GoCtx(func(ctx context.Context) error {
@@ -170,6 +172,10 @@ func TestKVFeed(t *testing.T) {
 				assert.Equal(t, expScans[0], scan.Timestamp)
 				assert.Equal(t, tc.withDiff, scan.WithDiff)
 				assert.Equal(t, spansToScan, scan.Spans)
+				if tc.indexLookup != nil && len(expScans) == len(tc.expScans) {
+					// Only the initial scan uses the index lookup.
+					assert.Same(t, tc.indexLookup, scan.IndexLookup)
+				}
 			}
 			return nil
 		})
@@ -277,6 +283,30 @@ func TestKVFeed(t *testing.T) {
 			},
 			expEventsCount: 2,
 		},
+		{
+			name:               "index lookup - partial backfill",
+			schemaChangeEvents: changefeedbase.OptSchemaChangeEventClassDefault,
+			schemaChangePolicy: changefeedbase.OptSchemaChangePolicyBackfill,
+			needsInitialScan:   true,
+			initialHighWater:   ts(2),
+			spans: []roachpb.Span{
+				tableSpan(codec, 42),
+			},
+			spanLevelCheckpoint: jobspb.NewTimestampSpansMap(map[hlc.Timestamp]roachpb.Spans{
+				ts(2).Next(): {makeSpan(codec, 42, "a", "q")},
+			}),
+			indexLookup: &IndexLookup{
+				Spans: []roachpb.Span{makeSpan(codec, 42, "idx", "idy")},
+			},
+			events: []kvpb.RangeFeedEvent{
+				kvEvent(codec, 42, "a", "val", ts(3)),
+				kvEvent(codec, 42, "d", "val", ts(3)),
+			},
+			expScans: []hlc.Timestamp{
+				ts(2),
+			},
+			expEventsCount: 2,
+		},
 		{
 			name:               "one table event - backfill",
 			schemaChangeEvents: changefeedbase.OptSchemaChangeEventClassDefault,
@@ -829,3 +859,30 @@ func TestFrontierQuantizationRand(t *testing.T) {
 	require.LessOrEqual(t, quantizedEntries, entries)
 	require.True(t, quantizedHW.LessEq(hw))
 }
+
+func TestFilterResolvedSpans(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ts := func(wt int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wt} }
+	sp := func(key, endKey string) roachpb.Span {
+		return roachpb.Span{Key: roachpb.Key(key), EndKey: roachpb.Key(endKey)}
+	}
+
+	frontier, err := span.MakeFrontierAt(ts(5), sp("a", "z"))
+	require.NoError(t, err)
+	defer frontier.Release()
+	_, err = frontier.Forward(sp("c", "f"), ts(10))
+	require.NoError(t, err)
+	_, err = frontier.Forward(sp("f", "h"), ts(7))
+	require.NoError(t, err)
+
+	// Only the parts of the spans which are not resolved up to the scan time
+	// need to be backfilled.
+	require.Equal(t, []roachpb.Span{sp("a", "c"), sp("f", "h")},
+		filterResolvedSpans([]roachpb.Span{sp("a", "h")}, frontier, ts(8)))
+	require.Equal(t, []roachpb.Span{sp("a", "c")},
+		filterResolvedSpans([]roachpb.Span{sp("a", "h")}, frontier, ts(7)))
+	require.Equal(t, []roachpb.Span{sp("a", "h")},
+		filterResolvedSpans([]roachpb.Span{sp("a", "h")}, frontier, ts(11)))
+}
@@ -32,6 +32,7 @@ type rangeFeedConfig struct {
 	Spans                []kvcoord.SpanTimePair
 	WithDiff             bool
 	WithFiltering        bool
+	Filter               *kvpb.RangeFeedFilter
 	WithFrontierQuantize time.Duration
 	ConsumerID           int64
 	RangeObserver        kvcoord.RangeObserver
@@ -96,6 +97,9 @@ func (p rangefeedFactory) Run(ctx context.Context, sink kvevent.Writer, cfg rang
 	if cfg.WithFiltering {
 		rfOpts = append(rfOpts, kvcoord.WithFiltering())
 	}
+	if cfg.Filter != nil {
+		rfOpts = append(rfOpts, kvcoord.WithRangeFeedFilter(cfg.Filter))
+	}
 	if cfg.RangeObserver != nil {
 		rfOpts = append(rfOpts, kvcoord.WithRangeObserver(cfg.RangeObserver))
 	}
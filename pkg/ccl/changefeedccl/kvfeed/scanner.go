@@ -39,6 +39,9 @@ type scanConfig struct {
 	WithDiff  bool
 	Knobs     TestingKnobs
 	Boundary  jobspb.ResolvedSpan_BoundaryType
+	// IndexLookup, if set, indicates that the rows in Spans should be located
+	// by scanning the secondary index spans instead of scanning Spans.
+	IndexLookup *IndexLookup
 }
 
 type kvScanner interface {
@@ -65,6 +68,10 @@ func (p *scanRequestScanner) Scan(ctx context.Context, sink kvevent.Writer, cfg
 			sp, cfg.Timestamp, cfg.WithDiff)
 	}
 
+	if cfg.IndexLookup != nil {
+		return p.lookupScan(ctx, sink, cfg)
+	}
+
 	sender := p.db.NonTransactionalSender()
 	distSender := sender.(*kv.CrossRangeTxnWrapperSender).Wrapped().(*kvcoord.DistSender)
 	spans, numNodesHint, err := getRangesToProcess(ctx, distSender, cfg.Spans)
@@ -190,19 +197,7 @@ func (p *scanRequestScanner) exportSpan(
 		r.ScanFormat = kvpb.BATCH_RESPONSE
 		b.Header.TargetBytes = targetBytesPerScan
 		b.Header.ConnectionClass = rpc.RangefeedClass
-		b.AdmissionHeader = kvpb.AdmissionHeader{
-			// TODO(irfansharif): Make this configurable if we want system table
-			// scanners or support "high priority" changefeeds to run at higher
-			// priorities. We use higher AC priorities for system-internal
-			// rangefeeds listening in on system table changes.
-			Priority: int32(admissionpb.BulkNormalPri),
-			// We specify a creation time for each batch (as opposed to at the
-			// txn level) -- this way later batches from earlier txns don't just
-			// out compete batches from newer txns.
-			CreateTime:               start.UnixNano(),
-			Source:                   kvpb.AdmissionHeader_FROM_SQL,
-			NoMemoryReservedAtSource: true,
-		}
+		b.AdmissionHeader = scanAdmissionHeader(start)
 		// NB: We use a raw request rather than the Scan() method because we want
 		// the MVCC timestamps which are encoded in the response but are filtered
 		// during result parsing.
@@ -247,6 +242,205 @@ func (p *scanRequestScanner) exportSpan(
 	return nil
 }
 
+// maxIndexLookupBatchSize is the maximum number of rows looked up in the
+// primary index in a single batch during an index lookup scan.
+const maxIndexLookupBatchSize = 1024
+
+// lookupScan performs a scan of the rows in cfg.Spans which are referenced by
+// the entries in cfg.IndexLookup.Spans secondary index spans. Index spans are
+// scanned sequentially, and the referenced rows are read from the primary
+// index in batches. Since the index spans may reference rows outside of the
+// spans watched by this feed, such rows are skipped.
+//
+// Each page of index entries and each batch of rows is read in its own
+// transaction at the scan timestamp, so that the lookup doesn't hold a single
+// transaction open for the duration of the backfill. Once the rows of a batch
+// have been written to the sink, their spans are resolved at the scan
+// timestamp, which lets the span-level checkpoint advance as the lookup makes
+// progress. Once all index spans have been processed, all of cfg.Spans are
+// resolved at the scan timestamp.
+//
+// Like for a regular scan, cfg.Spans excludes the spans which were already
+// resolved at the scan timestamp according to the span-level checkpoint, so
+// rows in those spans are skipped even if they are referenced by an index
+// entry, and are not emitted again when a changefeed resumes.
+func (p *scanRequestScanner) lookupScan(
+	ctx context.Context, sink kvevent.Writer, cfg scanConfig,
+) error {
+	var watched roachpb.SpanGroup
+	watched.Add(cfg.Spans...)
+
+	var backfillDec, backfillClear func()
+	if p.onBackfillRangeCallback != nil {
+		backfillDec, backfillClear = p.onBackfillRangeCallback(int64(len(cfg.IndexLookup.Spans)))
+		defer backfillClear()
+	}
+
+	spanAlloc, err := p.tryAcquireMemory(ctx, sink)
+	if err != nil {
+		return err
+	}
+	defer spanAlloc.Release(ctx)
+
+	var rows []roachpb.Span
+	var lastKey roachpb.Key
+	flush := func() error {
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := p.readAt(ctx, cfg.Timestamp, func(ctx context.Context, txn *kv.Txn) error {
+			return p.lookupRows(ctx, txn, rows, cfg, sink)
+		}); err != nil {
+			return err
+		}
+		for _, sp := range rows {
+			if err := sink.Add(ctx, kvevent.NewBackfillResolvedEvent(sp, cfg.Timestamp, cfg.Boundary)); err != nil {
+				return err
+			}
+		}
+		// The rows are resolved, so they don't need to be emitted again if they
+		// are referenced by other index entries.
+		watched.Sub(rows...)
+		rows = rows[:0]
+		return nil
+	}
+
+	targetBytesPerScan := changefeedbase.ScanRequestSize.Get(&p.settings.SV)
+	for _, indexSpan := range cfg.IndexLookup.Spans {
+		for remaining := &indexSpan; remaining != nil; {
+			var entries []kv.KeyValue
+			var resume *roachpb.Span
+			if err := p.readAt(ctx, cfg.Timestamp, func(ctx context.Context, txn *kv.Txn) error {
+				b := txn.NewBatch()
+				b.Header.TargetBytes = targetBytesPerScan
+				b.Header.ConnectionClass = rpc.RangefeedClass
+				b.AdmissionHeader = scanAdmissionHeader(timeutil.Now())
+				b.Scan(remaining.Key, remaining.EndKey)
+				if cfg.Knobs.BeforeScanRequest != nil {
+					if err := cfg.Knobs.BeforeScanRequest(b); err != nil {
+						return err
+					}
+				}
+				if err := txn.Run(ctx, b); err != nil {
+					return errors.Wrapf(err, `fetching index entries for %s`, indexSpan)
+				}
+				entries, resume = b.Results[0].Rows, b.Results[0].ResumeSpan
+				return nil
+			}); err != nil {
+				return err
+			}
+			remaining = resume
+
+			for _, entry := range entries {
+				pk, err := cfg.IndexLookup.PrimaryKey(ctx, roachpb.KeyValue{Key: entry.Key, Value: *entry.Value})
+				if err != nil {
+					return errors.Wrapf(err, `decoding index entry %s`, entry.Key)
+				}
+				// Multiple index entries (column families) may reference the same
+				// row; those are adjacent since they share the index key prefix.
+				if pk == nil || pk.Equal(lastKey) || !watched.Contains(pk) {
+					continue
+				}
+				lastKey = pk
+				rows = append(rows, roachpb.Span{Key: pk, EndKey: pk.PrefixEnd()})
+				if len(rows) >= maxIndexLookupBatchSize {
+					if err := flush(); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if backfillDec != nil {
+			backfillDec()
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	for _, sp := range cfg.Spans {
+		if err := sink.Add(ctx, kvevent.NewBackfillResolvedEvent(sp, cfg.Timestamp, cfg.Boundary)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readAt runs f in a transaction reading at the given fixed timestamp. Since
+// the transaction reads at a fixed timestamp, kvs written to the sink by f
+// before a transaction retry are written again with the same timestamp, which
+// is permitted by the changefeed's at-least-once delivery guarantee.
+func (p *scanRequestScanner) readAt(
+	ctx context.Context, ts hlc.Timestamp, f func(ctx context.Context, txn *kv.Txn) error,
+) error {
+	return p.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		if err := txn.SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		return f(ctx, txn)
+	})
+}
+
+// lookupRows reads the specified row spans from the primary index and writes
+// the contained kvs into the sink.
+func (p *scanRequestScanner) lookupRows(
+	ctx context.Context, txn *kv.Txn, rows []roachpb.Span, cfg scanConfig, sink kvevent.Writer,
+) error {
+	targetBytesPerScan := changefeedbase.ScanRequestSize.Get(&p.settings.SV)
+	for pending := rows; len(pending) > 0; {
+		b := txn.NewBatch()
+		b.Header.TargetBytes = targetBytesPerScan
+		b.Header.ConnectionClass = rpc.RangefeedClass
+		b.AdmissionHeader = scanAdmissionHeader(timeutil.Now())
+		for _, sp := range pending {
+			r := kvpb.NewScan(sp.Key, sp.EndKey).(*kvpb.ScanRequest)
+			r.ScanFormat = kvpb.BATCH_RESPONSE
+			b.AddRawRequest(r)
+		}
+		if cfg.Knobs.BeforeScanRequest != nil {
+			if err := cfg.Knobs.BeforeScanRequest(b); err != nil {
+				return err
+			}
+		}
+		if err := txn.Run(ctx, b); err != nil {
+			return errors.Wrapf(err, `fetching rows for %d index entries`, len(pending))
+		}
+
+		// Requests which were not (fully) processed due to the byte limit will
+		// have a resume span; those must be retried.
+		var resume []roachpb.Span
+		for i, ru := range b.RawResponse().Responses {
+			res := ru.GetScan()
+			if err := slurpScanResponse(ctx, sink, res, cfg.Timestamp, cfg.WithDiff, pending[i]); err != nil {
+				return err
+			}
+			if res.ResumeSpan != nil {
+				resume = append(resume, *res.ResumeSpan)
+			}
+		}
+		pending = resume
+	}
+	return nil
+}
+
+// scanAdmissionHeader returns the admission header for the scan requests
+// issued by the changefeed backfill.
+func scanAdmissionHeader(createTime time.Time) kvpb.AdmissionHeader {
+	return kvpb.AdmissionHeader{
+		// TODO(irfansharif): Make this configurable if we want system table
+		// scanners or support "high priority" changefeeds to run at higher
+		// priorities. We use higher AC priorities for system-internal
+		// rangefeeds listening in on system table changes.
+		Priority: int32(admissionpb.BulkNormalPri),
+		// We specify a creation time for each batch (as opposed to at the
+		// txn level) -- this way later batches from earlier txns don't just
+		// out compete batches from newer txns.
+		CreateTime:               createTime.UnixNano(),
+		Source:                   kvpb.AdmissionHeader_FROM_SQL,
+		NoMemoryReservedAtSource: true,
+	}
+}
+
 // getRangesToProcess returns the list of ranges covering input list of spans.
 // Returns the number of nodes that are leaseholders for those spans.
 func getRangesToProcess(
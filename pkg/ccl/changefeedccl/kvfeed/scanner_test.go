@@ -10,13 +10,19 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/desctestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/stretchr/testify/require"
@@ -24,12 +30,16 @@ import (
 
 type recordResolvedWriter struct {
 	resolved    []jobspb.ResolvedSpan
+	keys        []roachpb.Key
 	memAcquired bool
 }
 
 func (r *recordResolvedWriter) Add(ctx context.Context, e kvevent.Event) error {
-	if e.Type() == kvevent.TypeResolved {
+	switch e.Type() {
+	case kvevent.TypeResolved:
 		r.resolved = append(r.resolved, e.Resolved())
+	case kvevent.TypeKV:
+		r.keys = append(r.keys, e.KV().Key)
 	}
 	return nil
 }
@@ -100,3 +110,92 @@ INSERT INTO t VALUES (1), (2), (3);
 	require.Equal(t, span, sink.resolved[2].Span)
 	require.Equal(t, exportTime, sink.resolved[2].Timestamp)
 }
+
+func TestIndexLookupScan(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, kvdb := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `
+CREATE TABLE t (a INT PRIMARY KEY, b INT, INDEX by_b (b));
+INSERT INTO t VALUES (1, 10), (2, 20), (3, 10), (4, 30), (5, 10);
+`)
+
+	codec := s.Codec()
+	descr := desctestutils.TestingGetPublicTableDescriptor(kvdb, codec, "defaultdb", "t")
+	index, err := catalog.MustFindIndexByName(descr, "by_b")
+	require.NoError(t, err)
+	decoder, err := cdcevent.NewPrimaryKeyDecoder(ctx, codec, descr, index)
+	require.NoError(t, err)
+
+	encodeInt := func(prefix roachpb.Key, v int) roachpb.Key {
+		key, err := keyside.Encode(prefix, tree.NewDInt(tree.DInt(v)), encoding.Ascending)
+		require.NoError(t, err)
+		return key
+	}
+	rowKey := func(a int) roachpb.Key {
+		return keys.MakeFamilyKey(encodeInt(codec.IndexPrefix(uint32(descr.GetID()), 1), a), 0)
+	}
+	indexKey := encodeInt(codec.IndexPrefix(uint32(descr.GetID()), uint32(index.GetID())), 10)
+	lookup := &IndexLookup{
+		Spans:      []roachpb.Span{{Key: indexKey, EndKey: indexKey.PrefixEnd()}},
+		PrimaryKey: decoder.PrimaryKey,
+	}
+
+	primarySpan := descr.PrimaryIndexSpan(codec)
+	scanner := &scanRequestScanner{
+		settings: s.ClusterSettings(),
+		db:       kvdb,
+	}
+
+	for _, tc := range []struct {
+		name    string
+		watched roachpb.Span
+		rows    []int
+	}{
+		{name: "all rows watched", watched: primarySpan, rows: []int{1, 3, 5}},
+		{
+			name: "subset of rows watched",
+			watched: roachpb.Span{
+				Key:    encodeInt(codec.IndexPrefix(uint32(descr.GetID()), 1), 2),
+				EndKey: primarySpan.EndKey,
+			},
+			rows: []int{3, 5},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			exportTime := kvdb.Clock().Now()
+			sink := &recordResolvedWriter{}
+			require.NoError(t, scanner.Scan(ctx, sink, scanConfig{
+				Spans:       []roachpb.Span{tc.watched},
+				Timestamp:   exportTime,
+				IndexLookup: lookup,
+			}))
+			require.True(t, sink.memAcquired)
+
+			var expected []roachpb.Key
+			var expectedResolved []roachpb.Span
+			for _, a := range tc.rows {
+				expected = append(expected, rowKey(a))
+				pk := encodeInt(codec.IndexPrefix(uint32(descr.GetID()), 1), a)
+				expectedResolved = append(expectedResolved, roachpb.Span{Key: pk, EndKey: pk.PrefixEnd()})
+			}
+			require.Equal(t, expected, sink.keys)
+
+			// The rows are resolved once they are written, and the entire watched
+			// span once the lookup completes.
+			expectedResolved = append(expectedResolved, tc.watched)
+			var resolved []roachpb.Span
+			for _, r := range sink.resolved {
+				resolved = append(resolved, r.Span)
+				require.Equal(t, exportTime, r.Timestamp)
+			}
+			require.Equal(t, expectedResolved, resolved)
+		})
+	}
+}
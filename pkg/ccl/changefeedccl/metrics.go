@@ -85,6 +85,8 @@ type AggMetrics struct {
 	CloudstorageBufferedBytes   *aggmetric.AggGauge
 	KafkaThrottlingNanos        *aggmetric.AggHistogram
 	SinkErrors                  *aggmetric.AggCounter
+	DLQRows                     *aggmetric.AggCounter
+	TargetsQuarantined          *aggmetric.AggCounter
 	MaxBehindNanos              *aggmetric.AggGauge
 
 	Timers *timers.Timers
@@ -170,6 +172,8 @@ type sliMetrics struct {
 	CloudstorageBufferedBytes   *aggmetric.Gauge
 	KafkaThrottlingNanos        *aggmetric.Histogram
 	SinkErrors                  *aggmetric.Counter
+	DLQRows                     *aggmetric.Counter
+	TargetsQuarantined          *aggmetric.Counter
 	MaxBehindNanos              *aggmetric.Gauge
 
 	Timers *timers.ScopedTimers
@@ -983,6 +987,18 @@ func newAggregateMetrics(histogramWindow time.Duration, lookup *cidr.Lookup) *Ag
 		Measurement: "Count",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaDLQRows := metric.Metadata{
+		Name:        "changefeed.dead_letter_queue_rows",
+		Help:        "Rows which could not be encoded and were written to the dead letter queue",
+		Measurement: "Rows",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaTargetsQuarantined := metric.Metadata{
+		Name:        "changefeed.targets_quarantined",
+		Help:        "Changefeed targets which failed and were quarantined while the changefeed continued",
+		Measurement: "Targets",
+		Unit:        metric.Unit_COUNT,
+	}
 	// TODO(dan): This was intended to be a measure of the minimum distance of
 	// any changefeed ahead of its gc ttl threshold, but keeping that correct in
 	// the face of changing zone configs is much harder, so this will have to do
@@ -1101,11 +1117,13 @@ func newAggregateMetrics(histogramWindow time.Duration, lookup *cidr.Lookup) *Ag
 			SigFigs:      2,
 			BucketConfig: metric.ChangefeedBatchLatencyBuckets,
 		}),
-		SinkErrors:        b.Counter(metaSinkErrors),
-		MaxBehindNanos:    b.FunctionalGauge(metaChangefeedMaxBehindNanos, functionalGaugeMaxFn),
-		Timers:            timers.New(histogramWindow),
-		NetMetrics:        lookup.MakeNetMetrics(metaNetworkBytesOut, metaNetworkBytesIn, "sink"),
-		CheckpointMetrics: checkpoint.NewAggMetrics(b),
+		SinkErrors:         b.Counter(metaSinkErrors),
+		DLQRows:            b.Counter(metaDLQRows),
+		TargetsQuarantined: b.Counter(metaTargetsQuarantined),
+		MaxBehindNanos:     b.FunctionalGauge(metaChangefeedMaxBehindNanos, functionalGaugeMaxFn),
+		Timers:             timers.New(histogramWindow),
+		NetMetrics:         lookup.MakeNetMetrics(metaNetworkBytesOut, metaNetworkBytesIn, "sink"),
+		CheckpointMetrics:  checkpoint.NewAggMetrics(b),
 	}
 	a.mu.sliMetrics = make(map[string]*sliMetrics)
 	_, err := a.getOrCreateScope(defaultSLIScope)
@@ -1175,6 +1193,8 @@ func (a *AggMetrics) getOrCreateScope(scope string) (*sliMetrics, error) {
 		CloudstorageBufferedBytes:   a.CloudstorageBufferedBytes.AddChild(scope),
 		KafkaThrottlingNanos:        a.KafkaThrottlingNanos.AddChild(scope),
 		SinkErrors:                  a.SinkErrors.AddChild(scope),
+		DLQRows:                     a.DLQRows.AddChild(scope),
+		TargetsQuarantined:          a.TargetsQuarantined.AddChild(scope),
 
 		Timers: a.Timers.GetOrCreateScopedTimers(scope),
 
@@ -53,6 +53,22 @@ var systemTablesToProtect = []descpb.ID{
 	// These can be identified by the TestChangefeedIdentifyDependentTablesForProtecting test.
 }
 
+// targetsToProtect returns the targets of the changefeed whose history must be
+// protected: the targets watched by the running flow, as well as the paused and
+// quarantined targets, which are excluded from the flow.
+func targetsToProtect(
+	details jobspb.ChangefeedDetails, progress *jobspb.ChangefeedProgress,
+) changefeedbase.Targets {
+	targets := AllTargets(details)
+	for _, t := range progress.InactiveTargets {
+		targets.Add(changefeedbase.Target{
+			Type:    jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+			TableID: t.TableID,
+		})
+	}
+	return targets
+}
+
 func makeTargetToProtect(targets changefeedbase.Targets) *ptpb.Target {
 	tablesToProtect := make(descpb.IDs, 0, targets.NumUniqueTables()+len(systemTablesToProtect))
 	_ = targets.EachTableID(func(id descpb.ID) error {
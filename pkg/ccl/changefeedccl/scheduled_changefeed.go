@@ -286,7 +286,9 @@ func makeScheduledChangefeedSpec(
 
 	newTargets := make([]tree.ChangefeedTarget, 0)
 	for i, table := range qualifiedTablePatterns {
-		newTargets = append(newTargets, tree.ChangefeedTarget{TableName: table, FamilyName: schedule.Targets[i].FamilyName})
+		target := schedule.Targets[i]
+		target.TableName = table
+		newTargets = append(newTargets, target)
 	}
 
 	schedule.Targets = newTargets
@@ -78,6 +78,14 @@ func SetLocalityRegionalByRow(desc catalog.TableDescriptor) catalog.TableDescrip
 	return tabledesc.NewBuilder(desc.TableDesc()).BuildImmutableTable()
 }
 
+// SetMaterializedView turns the table descriptor into a materialized view
+// descriptor. Yes, this does modify an immutable.
+func SetMaterializedView(desc catalog.TableDescriptor) catalog.TableDescriptor {
+	desc.TableDesc().ViewQuery = "SELECT 1"
+	desc.TableDesc().IsMaterializedView = true
+	return tabledesc.NewBuilder(desc.TableDesc()).BuildImmutableTable()
+}
+
 // AddColumnDropBackfillMutation adds a mutation to desc to drop a column.
 // Yes, this does modify an immutable.
 func AddColumnDropBackfillMutation(desc catalog.TableDescriptor) catalog.TableDescriptor {
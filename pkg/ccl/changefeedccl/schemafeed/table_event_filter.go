@@ -30,6 +30,7 @@ const (
 	tableEventPrimaryKeyChange
 	tableEventLocalityRegionalByRowChange
 	tableEventAddHiddenColumn
+	tableEventMaterializedViewRefresh
 	numEventTypes int = iota
 )
 
@@ -44,6 +45,7 @@ var (
 		tableEventPrimaryKeyChange:            false,
 		tableEventLocalityRegionalByRowChange: false,
 		tableEventAddHiddenColumn:             true,
+		tableEventMaterializedViewRefresh:     false,
 	}
 
 	columnChangeTableEventFilter = tableEventFilter{
@@ -54,6 +56,7 @@ var (
 		tableEventPrimaryKeyChange:            false,
 		tableEventLocalityRegionalByRowChange: false,
 		tableEventAddHiddenColumn:             true,
+		tableEventMaterializedViewRefresh:     false,
 	}
 
 	schemaChangeEventFilters = map[changefeedbase.SchemaChangeEventClass]tableEventFilter{
@@ -109,6 +112,7 @@ func classifyTableEvent(e TableEvent) tableEventTypeSet {
 		{tableEventDropColumn, hasNewVisibleColumnDropBackfillMutation},
 		{tableEventTruncate, tableTruncated},
 		{tableEventLocalityRegionalByRowChange, regionalByRowChanged},
+		{tableEventMaterializedViewRefresh, materializedViewRefreshed},
 	} {
 		if c.predicate(e) {
 			et |= c.eventType.mask()
@@ -320,7 +324,16 @@ func tableTruncated(e TableEvent) bool {
 	// A table was truncated if the primary index has changed, but an ALTER
 	// PRIMARY KEY statement was not performed. TRUNCATE operates by creating
 	// a new set of indexes for the table, including a new primary index.
-	return e.Before.GetPrimaryIndexID() != e.After.GetPrimaryIndexID() && !pkChangeMutationExists(e.Before)
+	return e.Before.GetPrimaryIndexID() != e.After.GetPrimaryIndexID() &&
+		!pkChangeMutationExists(e.Before) && !e.After.MaterializedView()
+}
+
+func materializedViewRefreshed(e TableEvent) bool {
+	// REFRESH MATERIALIZED VIEW operates like TRUNCATE: it backfills a new
+	// primary index with the results of the view query, and swaps it in place
+	// of the old one.
+	return e.After.MaterializedView() &&
+		e.Before.GetPrimaryIndexID() != e.After.GetPrimaryIndexID()
 }
 
 func primaryKeyChanged(e TableEvent) bool {
@@ -417,6 +430,13 @@ func IsOnlyPrimaryIndexChange(e TableEvent) bool {
 	return classifyTableEvent(e) == tableEventPrimaryKeyChange.mask()
 }
 
+// IsMaterializedViewRefresh returns true if the event corresponds to a
+// REFRESH of a materialized view, which replaces the primary index of the
+// view with a new one containing the refreshed data.
+func IsMaterializedViewRefresh(e TableEvent) bool {
+	return classifyTableEvent(e).Contains(tableEventMaterializedViewRefresh)
+}
+
 // IsRegionalByRowChange returns true if the event corresponds to a
 // change in the table's locality to or from RegionalByRow.
 func IsRegionalByRowChange(e TableEvent) bool {
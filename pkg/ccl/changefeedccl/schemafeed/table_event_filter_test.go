@@ -67,6 +67,56 @@ func TestTableEventIsRegionalByRowChange(t *testing.T) {
 	}
 }
 
+func TestTableEventIsMaterializedViewRefresh(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ts := func(seconds int) hlc.Timestamp {
+		return hlc.Timestamp{WallTime: (time.Duration(seconds) * time.Second).Nanoseconds()}
+	}
+	var (
+		mkTableDesc = schematestutils.MakeTableDesc
+		setMatView  = schematestutils.SetMaterializedView
+	)
+	for _, c := range []struct {
+		name string
+		e    TableEvent
+		exp  bool
+	}{
+		{
+			name: "materialized view refresh",
+			e: TableEvent{
+				Before: setMatView(mkTableDesc(42, 1, ts(2), 2, 1)),
+				After:  setMatView(mkTableDesc(42, 2, ts(3), 2, 2)),
+			},
+			exp: true,
+		},
+		{
+			name: "table truncate",
+			e: TableEvent{
+				Before: mkTableDesc(42, 1, ts(2), 2, 1),
+				After:  mkTableDesc(42, 2, ts(3), 2, 2),
+			},
+			exp: false,
+		},
+		{
+			name: "unknown materialized view event",
+			e: TableEvent{
+				Before: setMatView(mkTableDesc(42, 1, ts(2), 2, 1)),
+				After:  setMatView(mkTableDesc(42, 2, ts(3), 2, 1)),
+			},
+			exp: false,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equalf(t, c.exp, IsMaterializedViewRefresh(c.e), "event %v", c.e)
+			// A refresh must not be mistaken for a truncation, which fails the
+			// changefeed.
+			_, err := defaultTableEventFilter.shouldFilter(context.Background(), c.e, changefeedbase.Targets{})
+			require.Equal(t, !c.exp && tableTruncated(c.e), err != nil)
+		})
+	}
+}
+
 func TestTableEventIsPrimaryIndexChange(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
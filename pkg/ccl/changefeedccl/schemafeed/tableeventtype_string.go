@@ -21,6 +21,7 @@ func _() {
 	_ = x[tableEventPrimaryKeyChange-5]
 	_ = x[tableEventLocalityRegionalByRowChange-6]
 	_ = x[tableEventAddHiddenColumn-7]
+	_ = x[tableEventMaterializedViewRefresh-8]
 }
 
 func (i tableEventType) String() string {
@@ -41,6 +42,8 @@ func (i tableEventType) String() string {
 		return "LocalityRegionalByRowChange"
 	case tableEventAddHiddenColumn:
 		return "AddHiddenColumn"
+	case tableEventMaterializedViewRefresh:
+		return "MaterializedViewRefresh"
 	default:
 		return "tableEventType(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -8,6 +8,7 @@ package changefeedccl
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"math"
 	"net/url"
 	"runtime"
@@ -138,6 +139,45 @@ type SinkWithTopics interface {
 	Topics() []string
 }
 
+// transactionalSink is implemented by event sinks which may emit rows within
+// transactions that only become visible to consumers once committed.
+type transactionalSink interface {
+	EventSink
+
+	// isTransactional returns whether the sink emits rows within transactions.
+	// If it does, Flush does not commit the open transaction, and rows updated
+	// after the resolved timestamp of the last commit are held back until the
+	// commit that covers them.
+	isTransactional() bool
+
+	// CommitTransaction commits the rows emitted at or before the resolved
+	// timestamp, and only those, in a single transaction. Newer rows are held
+	// back for a later commit, so that a changefeed restarted from the resolved
+	// timestamp does not re-emit rows which were already committed.
+	CommitTransaction(ctx context.Context, resolved hlc.Timestamp) error
+}
+
+// sinkInstanceID identifies a sink among the sinks of a changefeed job: the
+// sink of each change aggregator, the sink of the change frontier, as well as
+// the short-lived canary sinks used to validate the sink URI. Sinks which must
+// keep their identity across job restarts, e.g. transactional kafka producers
+// which fence off their previous incarnations, derive it from the job ID and
+// the instance ID.
+type sinkInstanceID string
+
+const (
+	frontierSinkInstanceID sinkInstanceID = "frontier"
+	canarySinkInstanceID   sinkInstanceID = "canary"
+)
+
+// aggregatorSinkInstanceID returns the instance ID of the sink of the change
+// aggregator in the given sink slot. Unlike processor IDs, slots are reused
+// when the changefeed is replanned: the aggregators of every plan occupy slots
+// 0 to the number of aggregators.
+func aggregatorSinkInstanceID(slot int32) sinkInstanceID {
+	return sinkInstanceID(fmt.Sprintf("aggregator-%d", slot))
+}
+
 func getEventSink(
 	ctx context.Context,
 	serverCfg *execinfra.ServerConfig,
@@ -145,9 +185,10 @@ func getEventSink(
 	timestampOracle timestampLowerBoundOracle,
 	user username.SQLUsername,
 	jobID jobspb.JobID,
+	instance sinkInstanceID,
 	m metricsRecorder,
 ) (EventSink, error) {
-	return getAndDialSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, m)
+	return getAndDialSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, instance, m)
 }
 
 func getResolvedTimestampSink(
@@ -157,9 +198,10 @@ func getResolvedTimestampSink(
 	timestampOracle timestampLowerBoundOracle,
 	user username.SQLUsername,
 	jobID jobspb.JobID,
+	instance sinkInstanceID,
 	m metricsRecorder,
 ) (ResolvedTimestampSink, error) {
-	return getAndDialSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, m)
+	return getAndDialSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, instance, m)
 }
 
 func getAndDialSink(
@@ -169,9 +211,10 @@ func getAndDialSink(
 	timestampOracle timestampLowerBoundOracle,
 	user username.SQLUsername,
 	jobID jobspb.JobID,
+	instance sinkInstanceID,
 	m metricsRecorder,
 ) (Sink, error) {
-	sink, err := getSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, m)
+	sink, err := getSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, instance, m)
 	if err != nil {
 		return nil, err
 	}
@@ -202,6 +245,7 @@ func getSink(
 	timestampOracle timestampLowerBoundOracle,
 	user username.SQLUsername,
 	jobID jobspb.JobID,
+	instance sinkInstanceID,
 	m metricsRecorder,
 ) (Sink, error) {
 	u, err := url.Parse(feedCfg.SinkURI)
@@ -252,7 +296,7 @@ func getSink(
 				if KafkaV2Enabled.Get(&serverCfg.Settings.SV) {
 					return makeKafkaSinkV2(ctx, &changefeedbase.SinkURL{URL: u}, AllTargets(feedCfg), opts.GetKafkaConfigJSON(),
 						numSinkIOWorkers(serverCfg), newCPUPacerFactory(ctx, serverCfg), timeutil.DefaultTimeSource{},
-						serverCfg.Settings, metricsBuilder, kafkaSinkV2Knobs{})
+						serverCfg.Settings, metricsBuilder, jobID, instance, kafkaSinkV2Knobs{})
 				} else {
 					return makeKafkaSink(ctx, &changefeedbase.SinkURL{URL: u}, AllTargets(feedCfg), opts.GetKafkaConfigJSON(), serverCfg.Settings, metricsBuilder)
 				}
@@ -308,7 +352,7 @@ func getSink(
 			return validateOptionsAndMakeSink(changefeedbase.ExternalConnectionValidOptions, func() (Sink, error) {
 				return makeExternalConnectionSink(
 					ctx, &changefeedbase.SinkURL{URL: u}, user, makeExternalConnectionProvider(ctx, serverCfg.DB),
-					serverCfg, feedCfg, timestampOracle, jobID, m,
+					serverCfg, feedCfg, timestampOracle, jobID, instance, m,
 				)
 			})
 		case u.Scheme == "":
@@ -204,6 +204,14 @@ type saramaConfig struct {
 	RequiredAcks string `json:",omitempty"`
 
 	Version string `json:",omitempty"`
+
+	// Transactional enables the kafka transactional producer. Each change
+	// aggregator then commits the messages up to its resolved timestamp
+	// atomically whenever it checkpoints its progress, and a restarted
+	// changefeed resumes from the last commit, so that read_committed consumers
+	// don't observe duplicate messages. It is only supported by the v2 kafka
+	// sink.
+	Transactional bool `json:",omitempty"`
 }
 
 func (c saramaConfig) Validate() error {
@@ -800,6 +808,10 @@ func (c *saramaConfig) Apply(kafka *sarama.Config) error {
 	kafka.Producer.Flush.MaxMessages = c.Flush.MaxMessages
 	kafka.ClientID = c.ClientID
 
+	if c.Transactional {
+		return errors.Newf(`Transactional requires %s to be enabled`, KafkaV2Enabled.Name())
+	}
+
 	kafka.Producer.Compression = sarama.CompressionCodec(c.Compression)
 	kafka.Producer.CompressionLevel = c.CompressionLevel
 
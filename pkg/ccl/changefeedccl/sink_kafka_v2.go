@@ -10,6 +10,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"hash/fnv"
 	"io"
 	"net"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/IBM/sarama"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/cidr"
@@ -46,6 +48,23 @@ type kafkaSinkClientV2 struct {
 
 	topicsForConnectionCheck []string
 
+	// onRejected, if set, is called with each message which the brokers
+	// reject; see setRejectedMessageHandler.
+	onRejected func(ctx context.Context, msg *messageSource, reason error) error
+
+	// transactional is set when the client uses the kafka transactional
+	// producer. Payloads are then produced within an open transaction, which is
+	// committed by CommitTransaction.
+	transactional bool
+	txnMu         struct {
+		syncutil.Mutex
+		// open is set once a transaction has begun and until it is ended.
+		open bool
+		// err is the first error encountered while producing messages in the
+		// open transaction. Such a transaction can only be aborted.
+		err error
+	}
+
 	// we need to fetch and keep track of this ourselves since kgo doesnt expose metadata to us
 	metadataMu struct {
 		syncutil.Mutex
@@ -66,13 +85,11 @@ func newKafkaSinkClientV2(
 	knobs kafkaSinkV2Knobs,
 	mb metricsRecorderBuilder,
 	topicsForConnectionCheck []string,
+	transactionalID string,
 ) (*kafkaSinkClientV2, error) {
 	bootstrapBrokers := strings.Split(bootstrapAddrsStr, `,`)
 
 	baseOpts := []kgo.Opt{
-		// Disable idempotency to maintain parity with the v1 sink and not add surface area for unknowns.
-		kgo.DisableIdempotentWrite(),
-
 		kgo.SeedBrokers(bootstrapBrokers...),
 		kgo.WithLogger(kgoLogAdapter{ctx: ctx}),
 		kgo.RecordPartitioner(newKgoChangefeedPartitioner()),
@@ -93,6 +110,16 @@ func newKafkaSinkClientV2(
 		}),
 	}
 
+	if transactionalID != "" {
+		// The transactional producer requires idempotent writes, which in turn
+		// require acks from all in-sync replicas. buildKgoConfig validates that
+		// RequiredAcks is compatible.
+		baseOpts = append(baseOpts, kgo.TransactionalID(transactionalID))
+	} else {
+		// Disable idempotency to maintain parity with the v1 sink and not add surface area for unknowns.
+		baseOpts = append(baseOpts, kgo.DisableIdempotentWrite())
+	}
+
 	recordResize := func(numRecords int64) {}
 	if m := mb(requiresResourceAccounting); m != nil { // `m` can be nil in tests.
 		baseOpts = append(baseOpts, kgo.WithHooks(&kgoMetricsAdapter{throttling: m.getKafkaThrottlingMetrics(settings)}))
@@ -125,14 +152,30 @@ func newKafkaSinkClientV2(
 		canTryResizing:           changefeedbase.BatchReductionRetryEnabled.Get(&settings.SV),
 		recordResize:             recordResize,
 		topicsForConnectionCheck: topicsForConnectionCheck,
+		transactional:            transactionalID != "",
 	}
 	c.metadataMu.allTopicPartitions = make(map[string][]int32)
 
+	if c.transactional {
+		// Initialize the producer ID right away rather than on the first
+		// transaction, so that the previous producers with the same
+		// transactional ID are fenced off, and their open transactions aborted,
+		// even if the sink has nothing to emit for a while. Until then, those
+		// transactions hold back read_committed consumers.
+		if _, _, err := client.ProducerID(ctx); err != nil {
+			client.Close()
+			return nil, errors.Wrap(err, `failed to initialize kafka transactional producer`)
+		}
+	}
+
 	return c, nil
 }
 
 // Close implements SinkClient.
 func (k *kafkaSinkClientV2) Close() error {
+	if k.transactional {
+		k.abortOpenTransaction()
+	}
 	k.client.Close()
 	return nil
 }
@@ -141,10 +184,25 @@ func (k *kafkaSinkClientV2) Close() error {
 func (k *kafkaSinkClientV2) Flush(ctx context.Context, payload SinkPayload) (retErr error) {
 	msgs := payload.([]*kgo.Record)
 
+	if k.transactional {
+		if err := k.maybeBeginTransaction(); err != nil {
+			return err
+		}
+		defer func() {
+			if retErr != nil {
+				k.recordTransactionError(retErr)
+			}
+		}()
+	}
+
 	var flushMsgs func(msgs []*kgo.Record) error
 	flushMsgs = func(msgs []*kgo.Record) error {
-		if err := k.client.ProduceSync(ctx, msgs...).FirstErr(); err != nil {
-			if k.shouldTryResizing(err, msgs) {
+		results := k.client.ProduceSync(ctx, msgs...)
+		if err := results.FirstErr(); err != nil {
+			// Messages are diverted one at a time, so that the messages which
+			// the brokers would accept on their own are not diverted with them.
+			divertible := k.onRejected != nil && isRejectedMessageErr(err) && len(msgs) > 1
+			if k.shouldTryResizing(err, msgs) || divertible {
 				a, b := msgs[0:len(msgs)/2], msgs[len(msgs)/2:]
 				// Recurse. This is a little odd because the client's batch
 				// state doesn't consist only of this payload, but the inflight
@@ -164,7 +222,7 @@ func (k *kafkaSinkClientV2) Flush(ctx context.Context, payload SinkPayload) (ret
 				}
 				return nil
 			} else {
-				return err
+				return k.maybeDivertRejected(ctx, results)
 			}
 		}
 		return nil
@@ -172,6 +230,50 @@ func (k *kafkaSinkClientV2) Flush(ctx context.Context, payload SinkPayload) (ret
 	return flushMsgs(msgs)
 }
 
+// setRejectedMessageHandler implements rejectingSinkClient. Messages can't be
+// skipped by a transactional producer, since a failed message fails the whole
+// transaction.
+func (k *kafkaSinkClientV2) setRejectedMessageHandler(
+	handler func(ctx context.Context, msg *messageSource, reason error) error,
+) bool {
+	if k.transactional {
+		return false
+	}
+	k.onRejected = handler
+	return true
+}
+
+// maybeDivertRejected hands a single message which the brokers rejected for
+// being invalid, e.g. too large, to the rejected message handler. Otherwise,
+// it returns the first error of the produce results.
+func (k *kafkaSinkClientV2) maybeDivertRejected(
+	ctx context.Context, results kgo.ProduceResults,
+) error {
+	r, err := results.First()
+	if err == nil || len(results) != 1 || k.onRejected == nil || !isRejectedMessageErr(err) ||
+		r == nil || r.Context == nil {
+		return err
+	}
+	src, ok := r.Context.Value(messageSourceKey{}).(*messageSource)
+	if !ok {
+		return err
+	}
+	return k.onRejected(ctx, src, err)
+}
+
+// isRejectedMessageErr returns true if the error is one with which the brokers
+// reject a message for good, independently of the state of the cluster, so
+// that retrying the message is futile.
+func isRejectedMessageErr(err error) bool {
+	return errors.Is(err, kerr.MessageTooLarge) ||
+		errors.Is(err, kerr.RecordListTooLarge) ||
+		errors.Is(err, kerr.InvalidRecord)
+}
+
+// messageSourceKey is the key under which a kafka record's context holds the
+// source of the message, if it is tracked.
+type messageSourceKey struct{}
+
 // FlushResolvedPayload implements SinkClient.
 func (k *kafkaSinkClientV2) FlushResolvedPayload(
 	ctx context.Context,
@@ -211,6 +313,114 @@ func (k *kafkaSinkClientV2) FlushResolvedPayload(
 	})
 }
 
+// isTransactional implements transactionalSinkClient.
+func (k *kafkaSinkClientV2) isTransactional() bool {
+	return k.transactional
+}
+
+// CommitTransaction implements transactionalSinkClient. It commits the open
+// transaction, if any. If producing any of the messages in the transaction
+// failed, the transaction is aborted instead and the error is returned; the
+// messages of an aborted transaction are never visible to read_committed
+// consumers, and will be re-emitted once the changefeed retries.
+func (k *kafkaSinkClientV2) CommitTransaction(ctx context.Context) error {
+	if !k.transactional {
+		return nil
+	}
+	k.txnMu.Lock()
+	defer k.txnMu.Unlock()
+
+	if !k.txnMu.open {
+		return nil
+	}
+	k.txnMu.open = false
+	if txnErr := k.txnMu.err; txnErr != nil {
+		k.txnMu.err = nil
+		if err := k.client.EndTransaction(ctx, kgo.TryAbort); err != nil {
+			return errors.CombineErrors(txnErr, errors.Wrap(err, `failed to abort kafka transaction`))
+		}
+		return errors.Wrap(txnErr, `kafka transaction aborted`)
+	}
+	if err := k.client.EndTransaction(ctx, kgo.TryCommit); err != nil {
+		err = errors.Wrap(err, `failed to commit kafka transaction`)
+		if abortErr := k.client.EndTransaction(ctx, kgo.TryAbort); abortErr != nil {
+			err = errors.CombineErrors(err, errors.Wrap(abortErr, `failed to abort kafka transaction`))
+		}
+		return err
+	}
+	return nil
+}
+
+// maybeBeginTransaction begins a new transaction unless one is already open.
+// It returns the error which failed the open transaction, if any, since
+// messages produced after a failure would be aborted along with it.
+func (k *kafkaSinkClientV2) maybeBeginTransaction() error {
+	k.txnMu.Lock()
+	defer k.txnMu.Unlock()
+
+	if k.txnMu.err != nil {
+		return k.txnMu.err
+	}
+	if k.txnMu.open {
+		return nil
+	}
+	if err := k.client.BeginTransaction(); err != nil {
+		return errors.Wrap(err, `failed to begin kafka transaction`)
+	}
+	k.txnMu.open = true
+	return nil
+}
+
+func (k *kafkaSinkClientV2) recordTransactionError(err error) {
+	k.txnMu.Lock()
+	defer k.txnMu.Unlock()
+	if k.txnMu.open && k.txnMu.err == nil {
+		k.txnMu.err = err
+	}
+}
+
+// abortOpenTransaction makes a best-effort attempt to abort the open
+// transaction, if any, when the client is closed. Were it not aborted, the
+// broker would only abort it after the transaction timeout elapses, holding
+// back read_committed consumers of the affected partitions until then.
+func (k *kafkaSinkClientV2) abortOpenTransaction() {
+	k.txnMu.Lock()
+	defer k.txnMu.Unlock()
+
+	if !k.txnMu.open {
+		return
+	}
+	k.txnMu.open = false
+	k.txnMu.err = nil
+	ctx, cancel := context.WithTimeout(context.Background(), kafkaTransactionAbortTimeout)
+	defer cancel()
+	if err := k.client.EndTransaction(ctx, kgo.TryAbort); err != nil {
+		log.Warningf(ctx, `failed to abort kafka transaction on close: %v`, err)
+	}
+}
+
+// kafkaTransactionAbortTimeout bounds the time Close spends aborting an open
+// transaction.
+const kafkaTransactionAbortTimeout = 10 * time.Second
+
+// kafkaTransactionalID returns the transactional ID used by a transactional
+// kafka sink of the given job. Every sink needs its own producer -- including
+// the aggregator and frontier sinks of a job that run on the same node -- so
+// the ID includes the sink instance. The ID is otherwise deterministic, so that
+// once a job restarts, the producer of a sink fences off the producer of its
+// previous incarnation, aborting any transaction the latter left open.
+func kafkaTransactionalID(jobID jobspb.JobID, instance sinkInstanceID) string {
+	return fmt.Sprintf(`crdb-changefeed-%d-%s`, jobID, instance)
+}
+
+// hasTransactionalSinkConfig returns whether the changefeed is configured to
+// emit to a kafka sink through the transactional producer.
+func hasTransactionalSinkConfig(details jobspb.ChangefeedDetails) bool {
+	opts := changefeedbase.MakeStatementOptions(details.Opts)
+	sinkCfg, err := getSaramaConfig(opts.GetKafkaConfigJSON())
+	return err == nil && sinkCfg.Transactional
+}
+
 func (k *kafkaSinkClientV2) CheckConnection(ctx context.Context) error {
 	return k.maybeUpdateTopicPartitions(ctx, func(cb func(topic string) error) error {
 		for _, topic := range k.topicsForConnectionCheck {
@@ -276,6 +486,9 @@ func (k *kafkaSinkClientV2) shouldTryResizing(err error, msgs []*kgo.Record) boo
 // KafkaClientV2 is a small interface restricting the functionality in *kgo.Client
 type KafkaClientV2 interface {
 	ProduceSync(ctx context.Context, msgs ...*kgo.Record) kgo.ProduceResults
+	BeginTransaction() error
+	EndTransaction(ctx context.Context, commit kgo.TransactionEndTry) error
+	ProducerID(ctx context.Context) (int64, int16, error)
 	Close()
 }
 
@@ -291,6 +504,8 @@ type kafkaSinkV2Knobs struct {
 }
 
 var _ SinkClient = (*kafkaSinkClientV2)(nil)
+var _ transactionalSinkClient = (*kafkaSinkClientV2)(nil)
+var _ rejectingSinkClient = (*kafkaSinkClientV2)(nil)
 var _ SinkPayload = ([]*kgo.Record)(nil) // NOTE: This doesn't actually assert anything, but it's good documentation.
 
 type kafkaBuffer struct {
@@ -313,7 +528,11 @@ func (b *kafkaBuffer) Append(key []byte, value []byte, attrs attributes) {
 		headers = append(headers, kgo.RecordHeader{Key: k, Value: v})
 	}
 
-	b.messages = append(b.messages, &kgo.Record{Key: key, Value: value, Topic: b.topic, Headers: headers})
+	rec := &kgo.Record{Key: key, Value: value, Topic: b.topic, Headers: headers}
+	if attrs.source != nil {
+		rec.Context = context.WithValue(context.Background(), messageSourceKey{}, attrs.source)
+	}
+	b.messages = append(b.messages, rec)
 	b.byteCount += len(value)
 }
 
@@ -337,6 +556,8 @@ func makeKafkaSinkV2(
 	timeSource timeutil.TimeSource,
 	settings *cluster.Settings,
 	mb metricsRecorderBuilder,
+	jobID jobspb.JobID,
+	instance sinkInstanceID,
 	knobs kafkaSinkV2Knobs,
 ) (Sink, error) {
 	batchCfg, retryOpts, err := getSinkConfigFromJson(jsonConfig, sinkJSONConfig{
@@ -376,8 +597,15 @@ func makeKafkaSinkV2(
 			`unknown kafka sink query parameters: %s`, strings.Join(unknownParams, ", "))
 	}
 
+	// buildKgoConfig has already surfaced any error parsing the config.
+	var transactionalID string
+	if sinkCfg, _ := getSaramaConfig(jsonConfig); sinkCfg != nil && sinkCfg.Transactional {
+		transactionalID = kafkaTransactionalID(jobID, instance)
+	}
+
 	topicsForConnectionCheck := topicNamer.DisplayNamesSlice()
-	client, err := newKafkaSinkClientV2(ctx, clientOpts, batchCfg, u.Host, settings, knobs, mb, topicsForConnectionCheck)
+	client, err := newKafkaSinkClientV2(ctx, clientOpts, batchCfg, u.Host, settings, knobs, mb,
+		topicsForConnectionCheck, transactionalID)
 	if err != nil {
 		return nil, err
 	}
@@ -471,6 +699,16 @@ func buildKgoConfig(
 		opts = append(opts, kgo.ClientID(sinkCfg.ClientID))
 	}
 
+	if sinkCfg.Transactional {
+		// The transactional producer requires acks from all in-sync replicas.
+		switch strings.ToUpper(sinkCfg.RequiredAcks) {
+		case ``, `ALL`, `-1`:
+		default:
+			return nil, errors.Errorf(`Transactional requires RequiredAcks to be ALL, found: %s`, sinkCfg.RequiredAcks)
+		}
+		sinkCfg.RequiredAcks = `ALL`
+	}
+
 	switch strings.ToUpper(sinkCfg.RequiredAcks) {
 	case ``, `ONE`, `1`: // This is our default.
 		opts = append(opts, kgo.RequiredAcks(kgo.LeaderAck()))
@@ -18,13 +18,17 @@ import (
 	"github.com/IBM/sarama"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/mocks"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -183,6 +187,315 @@ func TestKafkaSinkClientV2_Resize(t *testing.T) {
 	})
 }
 
+func TestKafkaSinkClientV2_Transactional(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	makePayload := func(fx *kafkaSinkV2Fx, vals ...string) SinkPayload {
+		buf := fx.sink.MakeBatchBuffer("t")
+		for _, v := range vals {
+			buf.Append([]byte("k"), []byte(v), attributes{})
+		}
+		payload, err := buf.Close()
+		require.NoError(t, err)
+		return payload
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		fx := newKafkaSinkV2Fx(t, withTransactionalID("txn"))
+		defer fx.close()
+
+		p1, p2 := makePayload(fx, "v1"), makePayload(fx, "v2", "v3")
+		// Both payloads are produced within a single transaction.
+		gomock.InOrder(
+			fx.kc.EXPECT().BeginTransaction().Times(1).Return(nil),
+			fx.kc.EXPECT().ProduceSync(fx.ctx, p1.([]*kgo.Record)).Times(1).Return(nil),
+			fx.kc.EXPECT().ProduceSync(fx.ctx, p2.([]*kgo.Record)).Times(1).Return(nil),
+			fx.kc.EXPECT().EndTransaction(fx.ctx, kgo.TryCommit).Times(1).Return(nil),
+		)
+		require.NoError(t, fx.sink.Flush(fx.ctx, p1))
+		require.NoError(t, fx.sink.Flush(fx.ctx, p2))
+		require.NoError(t, fx.sink.CommitTransaction(fx.ctx))
+
+		// There is no open transaction to commit.
+		require.NoError(t, fx.sink.CommitTransaction(fx.ctx))
+	})
+
+	t.Run("abort after produce error", func(t *testing.T) {
+		fx := newKafkaSinkV2Fx(t, withTransactionalID("txn"))
+		defer fx.close()
+
+		p1, p2 := makePayload(fx, "v1"), makePayload(fx, "v2")
+		pr := kgo.ProduceResults{kgo.ProduceResult{Err: fmt.Errorf("boom")}}
+		gomock.InOrder(
+			fx.kc.EXPECT().BeginTransaction().Times(1).Return(nil),
+			fx.kc.EXPECT().ProduceSync(fx.ctx, p1.([]*kgo.Record)).Times(1).Return(pr),
+			fx.kc.EXPECT().EndTransaction(fx.ctx, kgo.TryAbort).Times(1).Return(nil),
+		)
+		require.ErrorContains(t, fx.sink.Flush(fx.ctx, p1), "boom")
+		// The failed transaction can't be used to produce further messages.
+		require.ErrorContains(t, fx.sink.Flush(fx.ctx, p2), "boom")
+		require.ErrorContains(t, fx.sink.CommitTransaction(fx.ctx), "kafka transaction aborted")
+	})
+
+	t.Run("abort on close", func(t *testing.T) {
+		fx := newKafkaSinkV2Fx(t, withTransactionalID("txn"))
+
+		p1 := makePayload(fx, "v1")
+		fx.kc.EXPECT().BeginTransaction().Times(1).Return(nil)
+		fx.kc.EXPECT().ProduceSync(fx.ctx, p1.([]*kgo.Record)).Times(1).Return(nil)
+		require.NoError(t, fx.sink.Flush(fx.ctx, p1))
+
+		fx.kc.EXPECT().EndTransaction(gomock.Any(), kgo.TryAbort).Times(1).Return(nil)
+		fx.close()
+	})
+
+	t.Run("requires acks from all replicas", func(t *testing.T) {
+		var err error
+		fx := newKafkaSinkV2Fx(t, withJSONConfig(`{"Transactional": true, "RequiredAcks": "ONE"}`),
+			withCreateClientErrorCb(func(e error) { err = e }))
+		defer fx.close()
+		require.ErrorContains(t, err, "Transactional requires RequiredAcks to be ALL")
+	})
+
+	t.Run("unsupported by v1 sink", func(t *testing.T) {
+		cfg := &saramaConfig{Transactional: true}
+		require.ErrorContains(t, cfg.Apply(sarama.NewConfig()), "Transactional requires")
+	})
+}
+
+func TestKafkaSinkClientV2_DivertRejected(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	fx := newKafkaSinkV2Fx(t)
+	defer fx.close()
+
+	var diverted []string
+	require.True(t, fx.sink.setRejectedMessageHandler(
+		func(ctx context.Context, msg *messageSource, reason error) error {
+			require.ErrorIs(t, reason, kerr.MessageTooLarge)
+			diverted = append(diverted, string(msg.value))
+			return nil
+		}))
+
+	payload := makeTestPayload(t, fx.sink, "small", "large")
+	msgs := payload.([]*kgo.Record)
+
+	// The batch is rejected because of the large message, so the messages are
+	// retried one at a time, and only the large one is diverted.
+	tooLarge := func(msgs ...*kgo.Record) kgo.ProduceResults {
+		var res kgo.ProduceResults
+		for _, m := range msgs {
+			res = append(res, kgo.ProduceResult{Record: m, Err: kerr.MessageTooLarge})
+		}
+		return res
+	}
+	gomock.InOrder(
+		fx.kc.EXPECT().ProduceSync(fx.ctx, msgs[0], msgs[1]).Times(1).Return(tooLarge(msgs...)),
+		fx.kc.EXPECT().ProduceSync(fx.ctx, msgs[0]).Times(1).Return(nil),
+		fx.kc.EXPECT().ProduceSync(fx.ctx, msgs[1]).Times(1).Return(tooLarge(msgs[1])),
+	)
+	require.NoError(t, fx.sink.Flush(fx.ctx, payload))
+	require.Equal(t, []string{"large"}, diverted)
+
+	// Other errors still fail the flush.
+	payload = makeTestPayload(t, fx.sink, "v")
+	fx.kc.EXPECT().ProduceSync(fx.ctx, payload.([]*kgo.Record)[0]).Times(1).Return(
+		kgo.ProduceResults{kgo.ProduceResult{Err: errors.New("boom")}})
+	require.ErrorContains(t, fx.sink.Flush(fx.ctx, payload), "boom")
+
+	// A transactional producer can't skip messages.
+	txnFx := newKafkaSinkV2Fx(t, withTransactionalID("txn"))
+	defer txnFx.close()
+	require.False(t, txnFx.sink.setRejectedMessageHandler(
+		func(context.Context, *messageSource, error) error { return nil }))
+}
+
+// makeTestPayload returns a payload of messages with the given values, which
+// carry their source.
+func makeTestPayload(t *testing.T, sink *kafkaSinkClientV2, vals ...string) SinkPayload {
+	buf := sink.MakeBatchBuffer("t")
+	for _, v := range vals {
+		buf.Append([]byte("k"), []byte(v), attributes{source: &messageSource{value: []byte(v)}})
+	}
+	payload, err := buf.Close()
+	require.NoError(t, err)
+	return payload
+}
+
+// TestKafkaSinkV2_TransactionalRestart checks that a transactional kafka sink
+// which is restarted from the resolved timestamp of its last commit emits every
+// row exactly once, and that the previous incarnation of the sink is fenced off.
+func TestKafkaSinkV2_TransactionalRestart(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	settings := cluster.MakeTestingClusterSettings()
+	broker := newFakeTxnKafkaBroker()
+
+	const jobID = jobspb.JobID(42)
+	instance := aggregatorSinkInstanceID(3)
+	txnID := kafkaTransactionalID(jobID, instance)
+	// The ID is deterministic, so that a restarted sink fences off its previous
+	// incarnation.
+	require.Equal(t, `crdb-changefeed-42-aggregator-3`, txnID)
+
+	makeSink := func() *batchingSink {
+		u, err := url.Parse("kafka://localhost:9092")
+		require.NoError(t, err)
+		knobs := kafkaSinkV2Knobs{
+			OverrideClient: func(opts []kgo.Opt) (KafkaClientV2, KafkaAdminClientV2) {
+				return broker.newClient(txnID), nil
+			},
+		}
+		s, err := makeKafkaSinkV2(ctx, &changefeedbase.SinkURL{URL: u}, makeChangefeedTargets("t"),
+			`{"Transactional": true}`, 1, nilPacerFactory, timeutil.DefaultTimeSource{}, settings,
+			nilMetricsRecorderBuilder, jobID, instance, knobs)
+		require.NoError(t, err)
+		return s.(*batchingSink)
+	}
+	emit := func(s *batchingSink, val string, updated int64) {
+		ts := hlc.Timestamp{WallTime: updated}
+		require.NoError(t, s.EmitRow(ctx, topic(`t`), []byte(`k`+val), []byte(val), ts, ts, zeroAlloc, nil))
+	}
+
+	s1 := makeSink()
+	emit(s1, "v1", 1)
+	emit(s1, "v2", 2)
+	emit(s1, "v3", 3)
+	// Only the rows up to the resolved timestamp are committed.
+	require.NoError(t, s1.CommitTransaction(ctx, hlc.Timestamp{WallTime: 2}))
+	require.Equal(t, []string{"v1", "v2"}, broker.committedValues())
+	emit(s1, "v4", 4)
+	// A flush, e.g. under memory pressure, produces the held rows, but does not
+	// commit them.
+	require.NoError(t, s1.Flush(ctx))
+	require.Equal(t, []string{"v1", "v2"}, broker.committedValues())
+
+	// The changefeed restarts from the resolved timestamp of the last commit,
+	// re-emitting the rows after it. The restarted sink fences off the previous
+	// one, whose open transaction is aborted.
+	s2 := makeSink()
+	require.Error(t, s1.CommitTransaction(ctx, hlc.Timestamp{WallTime: 4}))
+	require.NoError(t, s1.Close())
+
+	emit(s2, "v3", 3)
+	emit(s2, "v4", 4)
+	require.NoError(t, s2.CommitTransaction(ctx, hlc.Timestamp{WallTime: 4}))
+	require.NoError(t, s2.Close())
+
+	require.Equal(t, []string{"v1", "v2", "v3", "v4"}, broker.committedValues())
+}
+
+// fakeTxnKafkaBroker is an in-memory stand-in for the transactional producer
+// semantics of a kafka cluster: produced records only become visible once their
+// transaction commits, and a new producer with the same transactional ID fences
+// off the previous ones.
+type fakeTxnKafkaBroker struct {
+	syncutil.Mutex
+	committed []*kgo.Record
+	epochs    map[string]int
+}
+
+func newFakeTxnKafkaBroker() *fakeTxnKafkaBroker {
+	return &fakeTxnKafkaBroker{epochs: make(map[string]int)}
+}
+
+func (b *fakeTxnKafkaBroker) newClient(txnID string) *fakeTxnKafkaClient {
+	b.Lock()
+	defer b.Unlock()
+	b.epochs[txnID]++
+	return &fakeTxnKafkaClient{broker: b, txnID: txnID, epoch: b.epochs[txnID]}
+}
+
+func (b *fakeTxnKafkaBroker) committedValues() []string {
+	b.Lock()
+	defer b.Unlock()
+	var vals []string
+	for _, r := range b.committed {
+		vals = append(vals, string(r.Value))
+	}
+	return vals
+}
+
+type fakeTxnKafkaClient struct {
+	broker *fakeTxnKafkaBroker
+	txnID  string
+	epoch  int
+
+	// open and pending are protected by the broker's mutex.
+	open    bool
+	pending []*kgo.Record
+}
+
+var _ KafkaClientV2 = (*fakeTxnKafkaClient)(nil)
+
+// checkLocked returns an error if the producer was fenced off.
+func (c *fakeTxnKafkaClient) checkLocked() error {
+	if c.broker.epochs[c.txnID] != c.epoch {
+		c.open = false
+		c.pending = nil
+		return kerr.ProducerFenced
+	}
+	return nil
+}
+
+func (c *fakeTxnKafkaClient) ProduceSync(
+	ctx context.Context, msgs ...*kgo.Record,
+) kgo.ProduceResults {
+	c.broker.Lock()
+	defer c.broker.Unlock()
+	err := c.checkLocked()
+	if err == nil && !c.open {
+		err = errors.New("produce outside of a transaction")
+	}
+	results := make(kgo.ProduceResults, 0, len(msgs))
+	for _, m := range msgs {
+		results = append(results, kgo.ProduceResult{Record: m, Err: err})
+	}
+	if err == nil {
+		c.pending = append(c.pending, msgs...)
+	}
+	return results
+}
+
+func (c *fakeTxnKafkaClient) BeginTransaction() error {
+	c.broker.Lock()
+	defer c.broker.Unlock()
+	if err := c.checkLocked(); err != nil {
+		return err
+	}
+	c.open = true
+	return nil
+}
+
+func (c *fakeTxnKafkaClient) EndTransaction(ctx context.Context, commit kgo.TransactionEndTry) error {
+	c.broker.Lock()
+	defer c.broker.Unlock()
+	if err := c.checkLocked(); err != nil {
+		return err
+	}
+	if commit == kgo.TryCommit {
+		c.broker.committed = append(c.broker.committed, c.pending...)
+	}
+	c.open = false
+	c.pending = nil
+	return nil
+}
+
+func (c *fakeTxnKafkaClient) ProducerID(ctx context.Context) (int64, int16, error) {
+	c.broker.Lock()
+	defer c.broker.Unlock()
+	if err := c.checkLocked(); err != nil {
+		return 0, 0, err
+	}
+	return 1, int16(c.epoch), nil
+}
+
+func (c *fakeTxnKafkaClient) Close() {}
+
 // These are really tests of the TopicNamer and our configuration of it.
 func TestKafkaSinkClientV2_Naming(t *testing.T) {
 	defer leaktest.AfterTest(t)()
@@ -614,6 +927,7 @@ type kafkaSinkV2Fx struct {
 	additionalKOpts     []kgo.Opt
 	createClientErrorCb func(error)
 	uri                 string
+	transactionalID     string
 
 	sink *kafkaSinkClientV2
 	bs   *batchingSink
@@ -669,6 +983,12 @@ func withKOptsClient(kOpts []kgo.Opt) fxOpt {
 	}
 }
 
+func withTransactionalID(id string) fxOpt {
+	return func(fx *kafkaSinkV2Fx) {
+		fx.transactionalID = id
+	}
+}
+
 func withCreateClientErrorCb(cb func(error)) fxOpt {
 	return func(fx *kafkaSinkV2Fx) {
 		fx.createClientErrorCb = cb
@@ -703,6 +1023,11 @@ func newKafkaSinkV2Fx(t *testing.T, opts ...fxOpt) *kafkaSinkV2Fx {
 		knobs.OverrideClient = func(opts []kgo.Opt) (KafkaClientV2, KafkaAdminClientV2) {
 			return kc, ac
 		}
+		if fx.transactionalID != "" {
+			// The transactional producer is initialized when the client is
+			// created.
+			kc.EXPECT().ProducerID(gomock.Any()).Times(1).Return(int64(1), int16(0), nil)
+		}
 	}
 
 	uri := "kafka://localhost:9092"
@@ -711,7 +1036,7 @@ func newKafkaSinkV2Fx(t *testing.T, opts ...fxOpt) *kafkaSinkV2Fx {
 	}
 
 	var err error
-	fx.sink, err = newKafkaSinkClientV2(ctx, fx.additionalKOpts, fx.batchConfig, uri, settings, knobs, nilMetricsRecorderBuilder, nil)
+	fx.sink, err = newKafkaSinkClientV2(ctx, fx.additionalKOpts, fx.batchConfig, uri, settings, knobs, nilMetricsRecorderBuilder, nil, fx.transactionalID)
 	if err != nil && fx.createClientErrorCb != nil {
 		fx.createClientErrorCb(err)
 		return fx
@@ -732,7 +1057,7 @@ func newKafkaSinkV2Fx(t *testing.T, opts ...fxOpt) *kafkaSinkV2Fx {
 	}
 	u.RawQuery = q.Encode()
 
-	bs, err := makeKafkaSinkV2(ctx, &changefeedbase.SinkURL{URL: u}, targets, fx.sinkJSONConfig, 1, nilPacerFactory, timeutil.DefaultTimeSource{}, settings, nilMetricsRecorderBuilder, knobs)
+	bs, err := makeKafkaSinkV2(ctx, &changefeedbase.SinkURL{URL: u}, targets, fx.sinkJSONConfig, 1, nilPacerFactory, timeutil.DefaultTimeSource{}, settings, nilMetricsRecorderBuilder, jobspb.JobID(0), canarySinkInstanceID, knobs)
 	if err != nil && fx.createClientErrorCb != nil {
 		fx.createClientErrorCb(err)
 		return fx
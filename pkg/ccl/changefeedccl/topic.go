@@ -183,6 +183,8 @@ func (tn *TopicNamer) makeName(s changefeedbase.Target, td TopicDescriptor) (str
 		return tn.nameFromComponents(s.StatementTimeName), nil
 	case jobspb.ChangefeedTargetSpecification_COLUMN_FAMILY:
 		return tn.nameFromComponents(s.StatementTimeName, s.FamilyName), nil
+	case jobspb.ChangefeedTargetSpecification_SECONDARY_INDEX:
+		return tn.nameFromComponents(s.StatementTimeName, s.IndexName), nil
 	case jobspb.ChangefeedTargetSpecification_EACH_FAMILY:
 		if td == nil {
 			return tn.nameFromComponents(s.StatementTimeName, familyPlaceholder), nil
@@ -300,6 +302,40 @@ func (cft *columnFamilyTopic) GetTableName() string {
 
 var _ TopicDescriptor = &columnFamilyTopic{}
 
+type secondaryIndexTopic struct {
+	cdcevent.Metadata
+	spec changefeedbase.Target
+}
+
+// GetNameComponents implements the TopicDescriptor interface
+func (sit *secondaryIndexTopic) GetNameComponents() (changefeedbase.StatementTimeName, []string) {
+	return sit.spec.StatementTimeName, []string{sit.spec.IndexName}
+}
+
+// GetTopicIdentifier implements the TopicDescriptor interface
+func (sit *secondaryIndexTopic) GetTopicIdentifier() TopicIdentifier {
+	return TopicIdentifier{
+		TableID: sit.TableID,
+	}
+}
+
+// GetVersion implements the TopicDescriptor interface
+func (sit *secondaryIndexTopic) GetVersion() descpb.DescriptorVersion {
+	return sit.Version
+}
+
+// GetTargetSpecification implements the TopicDescriptor interface
+func (sit *secondaryIndexTopic) GetTargetSpecification() changefeedbase.Target {
+	return sit.spec
+}
+
+// GetTableName implements the TopicDescriptor interface
+func (sit *secondaryIndexTopic) GetTableName() string {
+	return sit.TableName
+}
+
+var _ TopicDescriptor = &secondaryIndexTopic{}
+
 type noTopic struct{}
 
 var noStatementTimeName changefeedbase.StatementTimeName = ""
@@ -341,6 +377,11 @@ func makeTopicDescriptorFromSpec(
 			Metadata: src,
 			spec:     s,
 		}, nil
+	case jobspb.ChangefeedTargetSpecification_SECONDARY_INDEX:
+		return &secondaryIndexTopic{
+			Metadata: src,
+			spec:     s,
+		}, nil
 	default:
 		return noTopic{}, errors.AssertionFailedf("Unsupported target type %s", s.Type)
 	}
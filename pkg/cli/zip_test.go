@@ -71,6 +71,8 @@ FROM [ SELECT table_name FROM [ SHOW TABLES FROM crdb_internal ] ]
 WHERE
 table_name NOT IN (
 	-- allowlisted tables that don't need to be in debug zip
+	'backup_catalog',
+	'backup_catalog_tables',
 	'backward_dependencies',
 	'builtin_functions',
 	'cluster_contended_keys',
@@ -100,6 +102,7 @@ table_name NOT IN (
 	'kv_flow_token_deductions',
 	'kv_flow_token_deductions_v2',
 	'lost_descriptors_with_data',
+	'node_append_only_hotspots',
 	'table_columns',
 	'table_row_statistics',
 	'ranges',
@@ -118,6 +121,7 @@ table_name NOT IN (
 	'cluster_transaction_statistics',
 	'statement_statistics',
 	'transaction_activity',
+	'transaction_deadlocks',
 	'transaction_statistics_persisted',
 	'transaction_statistics_persisted_v22_2',
 	'transaction_statistics',
@@ -71,6 +71,16 @@ const (
 	// storage class for written objects.
 	S3StorageClassParam = "S3_STORAGE_CLASS"
 
+	// S3ObjectLockModeParam is the query parameter used in S3 URIs to configure
+	// the Object Lock retention mode, GOVERNANCE or COMPLIANCE, of written
+	// objects.
+	S3ObjectLockModeParam = "S3_OBJECT_LOCK_MODE"
+
+	// S3ObjectLockRetentionDaysParam is the query parameter used in S3 URIs to
+	// configure for how many days written objects are retained when
+	// S3ObjectLockModeParam is set.
+	S3ObjectLockRetentionDaysParam = "S3_OBJECT_LOCK_RETENTION_DAYS"
+
 	// S3RegionParam is the query parameter for the 'endpoint' in an S3 URI.
 	S3RegionParam = "AWS_REGION"
 
@@ -277,6 +287,10 @@ func S3URI(bucket, path string, conf *cloudpb.ExternalStorage_S3) string {
 	setIf(AWSServerSideEncryptionMode, conf.ServerEncMode)
 	setIf(AWSServerSideEncryptionKMSID, conf.ServerKMSID)
 	setIf(S3StorageClassParam, conf.StorageClass)
+	setIf(S3ObjectLockModeParam, conf.ObjectLockMode)
+	if conf.ObjectLockRetentionDays != 0 {
+		q.Set(S3ObjectLockRetentionDaysParam, strconv.FormatInt(conf.ObjectLockRetentionDays, 10))
+	}
 	if conf.UsePathStyle {
 		q.Set(AWSUsePathStyle, "true")
 	}
@@ -326,23 +340,35 @@ func parseS3URL(uri *url.URL) (cloudpb.ExternalStorage, error) {
 		}
 	}
 
+	var objectLockRetentionDays int64
+	if s := s3URL.ConsumeParam(S3ObjectLockRetentionDaysParam); s != "" {
+		var err error
+		objectLockRetentionDays, err = strconv.ParseInt(s, 10, 64)
+		if err != nil || objectLockRetentionDays <= 0 {
+			return cloudpb.ExternalStorage{}, errors.Newf(
+				"%s must be a positive number of days", S3ObjectLockRetentionDaysParam)
+		}
+	}
+
 	conf.S3Config = &cloudpb.ExternalStorage_S3{
-		Bucket:                s3URL.Host,
-		Prefix:                s3URL.Path,
-		AccessKey:             s3URL.ConsumeParam(AWSAccessKeyParam),
-		Secret:                s3URL.ConsumeParam(AWSSecretParam),
-		TempToken:             s3URL.ConsumeParam(AWSTempTokenParam),
-		Endpoint:              s3URL.ConsumeParam(AWSEndpointParam),
-		UsePathStyle:          pathStyleBool,
-		Region:                s3URL.ConsumeParam(S3RegionParam),
-		Auth:                  s3URL.ConsumeParam(cloud.AuthParam),
-		ServerEncMode:         s3URL.ConsumeParam(AWSServerSideEncryptionMode),
-		ServerKMSID:           s3URL.ConsumeParam(AWSServerSideEncryptionKMSID),
-		StorageClass:          s3URL.ConsumeParam(S3StorageClassParam),
-		RoleARN:               assumeRole,
-		DelegateRoleARNs:      delegateRoles,
-		AssumeRoleProvider:    assumeRoleProvider,
-		DelegateRoleProviders: delegateRoleProviders,
+		Bucket:                  s3URL.Host,
+		Prefix:                  s3URL.Path,
+		AccessKey:               s3URL.ConsumeParam(AWSAccessKeyParam),
+		Secret:                  s3URL.ConsumeParam(AWSSecretParam),
+		TempToken:               s3URL.ConsumeParam(AWSTempTokenParam),
+		Endpoint:                s3URL.ConsumeParam(AWSEndpointParam),
+		UsePathStyle:            pathStyleBool,
+		Region:                  s3URL.ConsumeParam(S3RegionParam),
+		Auth:                    s3URL.ConsumeParam(cloud.AuthParam),
+		ServerEncMode:           s3URL.ConsumeParam(AWSServerSideEncryptionMode),
+		ServerKMSID:             s3URL.ConsumeParam(AWSServerSideEncryptionKMSID),
+		StorageClass:            s3URL.ConsumeParam(S3StorageClassParam),
+		ObjectLockMode:          strings.ToUpper(s3URL.ConsumeParam(S3ObjectLockModeParam)),
+		ObjectLockRetentionDays: objectLockRetentionDays,
+		RoleARN:                 assumeRole,
+		DelegateRoleARNs:        delegateRoles,
+		AssumeRoleProvider:      assumeRoleProvider,
+		DelegateRoleProviders:   delegateRoleProviders,
 		/* NB: additions here should also update s3QueryParams() serializer */
 	}
 	conf.S3Config.Prefix = strings.TrimLeft(conf.S3Config.Prefix, "/")
@@ -402,6 +428,23 @@ func parseS3URL(uri *url.URL) (cloudpb.ExternalStorage, error) {
 		}
 	}
 
+	// Ensure that the Object Lock mode and retention period are set together.
+	switch types.ObjectLockMode(conf.S3Config.ObjectLockMode) {
+	case "":
+		if conf.S3Config.ObjectLockRetentionDays != 0 {
+			return cloudpb.ExternalStorage{}, errors.Newf("%s param must be set when %s is set",
+				S3ObjectLockModeParam, S3ObjectLockRetentionDaysParam)
+		}
+	case types.ObjectLockModeGovernance, types.ObjectLockModeCompliance:
+		if conf.S3Config.ObjectLockRetentionDays == 0 {
+			return cloudpb.ExternalStorage{}, errors.Newf("%s param must be set when %s is set",
+				S3ObjectLockRetentionDaysParam, S3ObjectLockModeParam)
+		}
+	default:
+		return cloudpb.ExternalStorage{}, errors.Newf("unsupported object lock mode %s. "+
+			"Supported values are `GOVERNANCE` and `COMPLIANCE`.", conf.S3Config.ObjectLockMode)
+	}
+
 	return conf, nil
 }
 
@@ -731,12 +774,14 @@ func (s *s3Storage) putUploader(ctx context.Context, basename string) (io.WriteC
 	return &putUploader{
 		b: buf,
 		input: &s3.PutObjectInput{
-			Bucket:               s.bucket,
-			Key:                  aws.String(path.Join(s.prefix, basename)),
-			ServerSideEncryption: types.ServerSideEncryption(s.conf.ServerEncMode),
-			SSEKMSKeyId:          nilIfEmpty(s.conf.ServerKMSID),
-			StorageClass:         types.StorageClass(s.conf.StorageClass),
-			ChecksumAlgorithm:    checksumAlgorithm,
+			Bucket:                    s.bucket,
+			Key:                       aws.String(path.Join(s.prefix, basename)),
+			ServerSideEncryption:      types.ServerSideEncryption(s.conf.ServerEncMode),
+			SSEKMSKeyId:               nilIfEmpty(s.conf.ServerKMSID),
+			StorageClass:              types.StorageClass(s.conf.StorageClass),
+			ChecksumAlgorithm:         checksumAlgorithm,
+			ObjectLockMode:            types.ObjectLockMode(s.conf.ObjectLockMode),
+			ObjectLockRetainUntilDate: s.objectLockRetainUntil(),
 		},
 		client: client,
 	}, nil
@@ -759,13 +804,15 @@ func (s *s3Storage) Writer(ctx context.Context, basename string) (io.WriteCloser
 		// Upload the file to S3.
 		// TODO(dt): test and tune the uploader parameters.
 		_, err := uploader.Upload(ctx, &s3.PutObjectInput{
-			Bucket:               s.bucket,
-			Key:                  aws.String(path.Join(s.prefix, basename)),
-			Body:                 r,
-			ServerSideEncryption: types.ServerSideEncryption(s.conf.ServerEncMode),
-			SSEKMSKeyId:          nilIfEmpty(s.conf.ServerKMSID),
-			StorageClass:         types.StorageClass(s.conf.StorageClass),
-			ChecksumAlgorithm:    checksumAlgorithm,
+			Bucket:                    s.bucket,
+			Key:                       aws.String(path.Join(s.prefix, basename)),
+			Body:                      r,
+			ServerSideEncryption:      types.ServerSideEncryption(s.conf.ServerEncMode),
+			SSEKMSKeyId:               nilIfEmpty(s.conf.ServerKMSID),
+			StorageClass:              types.StorageClass(s.conf.StorageClass),
+			ChecksumAlgorithm:         checksumAlgorithm,
+			ObjectLockMode:            types.ObjectLockMode(s.conf.ObjectLockMode),
+			ObjectLockRetainUntilDate: s.objectLockRetainUntil(),
 		})
 		err = interpretAWSError(err)
 		err = errors.Wrap(err, "upload failed")
@@ -778,6 +825,16 @@ func (s *s3Storage) Writer(ctx context.Context, basename string) (io.WriteCloser
 	}), nil
 }
 
+// objectLockRetainUntil returns the date until which an object written now
+// must be retained, or nil if objects are not written with Object Lock.
+func (s *s3Storage) objectLockRetainUntil() *time.Time {
+	if s.conf.ObjectLockMode == "" {
+		return nil
+	}
+	until := timeutil.Now().AddDate(0, 0, int(s.conf.ObjectLockRetentionDays))
+	return &until
+}
+
 // openStreamAt opens a stream of object data, starting at offset <pos>.
 // If endPos is non-zero, returns data up to that offset (exclusive).
 func (s *s3Storage) openStreamAt(
@@ -480,6 +480,42 @@ func TestS3DisallowImplicitCredentials(t *testing.T) {
 	require.True(t, strings.Contains(err.Error(), "implicit"))
 }
 
+func TestParseS3URLObjectLock(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	parse := func(query string) (cloudpb.ExternalStorage, error) {
+		uri, err := url.Parse("s3://bucket/path?AUTH=implicit&" + query)
+		require.NoError(t, err)
+		return parseS3URL(uri)
+	}
+
+	conf, err := parse(fmt.Sprintf("%s=governance&%s=30", S3ObjectLockModeParam, S3ObjectLockRetentionDaysParam))
+	require.NoError(t, err)
+	require.Equal(t, "GOVERNANCE", conf.S3Config.ObjectLockMode)
+	require.Equal(t, int64(30), conf.S3Config.ObjectLockRetentionDays)
+
+	// The parameters survive a round trip through S3URI.
+	roundTrip, err := url.Parse(S3URI("bucket", "path", conf.S3Config))
+	require.NoError(t, err)
+	reparsed, err := parseS3URL(roundTrip)
+	require.NoError(t, err)
+	require.Equal(t, conf.S3Config.ObjectLockMode, reparsed.S3Config.ObjectLockMode)
+	require.Equal(t, conf.S3Config.ObjectLockRetentionDays, reparsed.S3Config.ObjectLockRetentionDays)
+
+	for _, tc := range []struct {
+		query string
+		err   string
+	}{
+		{fmt.Sprintf("%s=COMPLIANCE", S3ObjectLockModeParam), "S3_OBJECT_LOCK_RETENTION_DAYS param must be set"},
+		{fmt.Sprintf("%s=7", S3ObjectLockRetentionDaysParam), "S3_OBJECT_LOCK_MODE param must be set"},
+		{fmt.Sprintf("%s=LEGAL&%s=7", S3ObjectLockModeParam, S3ObjectLockRetentionDaysParam), "unsupported object lock mode"},
+		{fmt.Sprintf("%s=COMPLIANCE&%s=-1", S3ObjectLockModeParam, S3ObjectLockRetentionDaysParam), "must be a positive number of days"},
+	} {
+		_, err := parse(tc.query)
+		require.ErrorContains(t, err, tc.err, tc.query)
+	}
+}
+
 type awserror struct {
 	error
 	code, message string
@@ -227,6 +227,20 @@ const (
 
 	V25_2_AddSqlActivityFlushJob
 
+	// V25_2_BackupCatalogTable adds the system.backup_catalog table, which
+	// records the backups written by jobs in the cluster or found in their
+	// collections.
+	V25_2_BackupCatalogTable
+
+	// V25_2_ReadOnlyTables adds the read_only table storage parameter, which
+	// makes replicas reject writes to the table's span.
+	V25_2_ReadOnlyTables
+
+	// V25_2_TransactionDeadlocksTable adds the system.transaction_deadlocks
+	// table, which records the members of the deadlocks detected by the txn
+	// wait queues.
+	V25_2_TransactionDeadlocksTable
+
 	// *************************************************
 	// Step (1) Add new versions above this comment.
 	// Do not add new versions to a patch release.
@@ -278,8 +292,11 @@ var versionTable = [numKeys]roachpb.Version{
 	V25_1: {Major: 25, Minor: 1, Internal: 0},
 
 	// v25.2 versions. Internal versions must be even.
-	V25_2_Start:                  {Major: 25, Minor: 1, Internal: 2},
-	V25_2_AddSqlActivityFlushJob: {Major: 25, Minor: 1, Internal: 4},
+	V25_2_Start:                     {Major: 25, Minor: 1, Internal: 2},
+	V25_2_AddSqlActivityFlushJob:    {Major: 25, Minor: 1, Internal: 4},
+	V25_2_BackupCatalogTable:        {Major: 25, Minor: 1, Internal: 6},
+	V25_2_ReadOnlyTables:            {Major: 25, Minor: 1, Internal: 8},
+	V25_2_TransactionDeadlocksTable: {Major: 25, Minor: 1, Internal: 10},
 
 	// *************************************************
 	// Step (2): Add new versions above this comment.
@@ -30,6 +30,7 @@ const (
 	Constraints            // constraints
 	VoterConstraints       // voter_constraints
 	LeasePreferences       // lease_preferences
+	GCKeepVersions         // gc.keep_versions
 
 	// NumFields is the number of fields in the config.
 	NumFields int = iota - 1
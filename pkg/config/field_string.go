@@ -22,6 +22,7 @@ func _() {
 	_ = x[Constraints-7]
 	_ = x[VoterConstraints-8]
 	_ = x[LeasePreferences-9]
+	_ = x[GCKeepVersions-10]
 }
 
 func (i Field) String() string {
@@ -44,6 +45,8 @@ func (i Field) String() string {
 		return "voter_constraints"
 	case LeasePreferences:
 		return "lease_preferences"
+	case GCKeepVersions:
+		return "gc.keep_versions"
 	default:
 		return "Field(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -424,6 +424,9 @@ func (z *ZoneConfig) Validate() error {
 	if z.GC != nil && z.GC.TTLSeconds < 1 {
 		return fmt.Errorf("GC.TTLSeconds %d less than minimum allowed 1", z.GC.TTLSeconds)
 	}
+	if z.GC != nil && z.GC.KeepVersions != nil && *z.GC.KeepVersions < 0 {
+		return fmt.Errorf("GC.KeepVersions %d less than minimum allowed 0", *z.GC.KeepVersions)
+	}
 
 	for _, constraints := range z.Constraints {
 		for _, constraint := range constraints.Constraints {
@@ -591,6 +594,15 @@ func (z *ZoneConfig) InheritFromParent(parent *ZoneConfig) {
 	if z.ShouldInheritGC(parent) {
 		tempGC := *parent.GC
 		z.GC = &tempGC
+	} else if z.GC != nil && parent.GC != nil {
+		// The TTL and the number of versions kept are inherited separately, as
+		// either can be set on the zone without the other.
+		if z.GC.TTLSeconds == 0 {
+			z.GC.TTLSeconds = parent.GC.TTLSeconds
+		}
+		if z.GC.KeepVersions == nil && parent.GC.KeepVersions != nil {
+			z.GC.KeepVersions = proto.Int32(*parent.GC.KeepVersions)
+		}
 	}
 	if z.ShouldInheritConstraints(parent) {
 		z.Constraints = parent.Constraints
@@ -637,10 +649,22 @@ func (z *ZoneConfig) CopyFromZone(other ZoneConfig, fieldList []tree.Name) {
 				z.GlobalReads = proto.Bool(*other.GlobalReads)
 			}
 		case "gc.ttlseconds":
+			var keepVersions *int32
+			if z.GC != nil {
+				keepVersions = z.GC.KeepVersions
+			}
 			z.GC = nil
 			if other.GC != nil {
-				tempGC := *other.GC
-				z.GC = &tempGC
+				z.GC = &GCPolicy{TTLSeconds: other.GC.TTLSeconds, KeepVersions: keepVersions}
+			}
+		case "gc.keep_versions":
+			if other.GC != nil && other.GC.KeepVersions != nil {
+				if z.GC == nil {
+					z.GC = &GCPolicy{}
+				}
+				z.GC.KeepVersions = proto.Int32(*other.GC.KeepVersions)
+			} else if z.GC != nil {
+				z.GC.KeepVersions = nil
 			}
 		case "constraints":
 			z.Constraints = other.Constraints
@@ -776,13 +800,31 @@ func (z *ZoneConfig) DiffWithZone(
 			if other.GC == nil && z.GC == nil {
 				continue
 			}
-			if z.GC == nil || other.GC == nil || *z.GC != *other.GC {
+			if z.GC == nil || other.GC == nil || z.GC.TTLSeconds != other.GC.TTLSeconds {
 				return false, DiffWithZoneMismatch{
 					Field:    "gc.ttlseconds",
 					Expected: int32ToString(&other.GC.TTLSeconds),
 					Actual:   int32ToString(&z.GC.TTLSeconds),
 				}, nil
 			}
+		case "gc.keep_versions":
+			keepVersions := func(gc *GCPolicy) *int32 {
+				if gc == nil {
+					return nil
+				}
+				return gc.KeepVersions
+			}
+			expected, actual := keepVersions(other.GC), keepVersions(z.GC)
+			if expected == nil && actual == nil {
+				continue
+			}
+			if expected == nil || actual == nil || *expected != *actual {
+				return false, DiffWithZoneMismatch{
+					Field:    "gc.keep_versions",
+					Expected: int32ToString(expected),
+					Actual:   int32ToString(actual),
+				}, nil
+			}
 		case "constraints":
 			if other.Constraints == nil && z.Constraints == nil {
 				continue
@@ -1317,6 +1359,9 @@ func (z *ZoneConfig) toSpanConfig() (roachpb.SpanConfig, error) {
 	sc.RangeMinBytes = *z.RangeMinBytes
 	sc.RangeMaxBytes = *z.RangeMaxBytes
 	sc.GCPolicy.TTLSeconds = z.GC.TTLSeconds
+	if z.GC.KeepVersions != nil {
+		sc.GCPolicy.KeepVersions = *z.GC.KeepVersions
+	}
 
 	// GlobalReads is false by default.
 	if z.GlobalReads != nil {
@@ -487,6 +487,46 @@ func TestZoneConfigSubzones(t *testing.T) {
 
 // TestZoneConfigMarshalYAML makes sure that ZoneConfig is correctly marshaled
 // to YAML and back.
+// TestZoneConfigInheritGCPolicy tests that the GC TTL and the number of
+// versions kept are inherited from the parent zone independently.
+func TestZoneConfigInheritGCPolicy(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	parent := DefaultZoneConfig()
+	parent.GC = &GCPolicy{TTLSeconds: 100, KeepVersions: proto.Int32(3)}
+	for _, tc := range []struct {
+		name     string
+		gc       *GCPolicy
+		expected GCPolicy
+	}{
+		{
+			name:     "inherit all",
+			expected: GCPolicy{TTLSeconds: 100, KeepVersions: proto.Int32(3)},
+		},
+		{
+			name:     "set ttl",
+			gc:       &GCPolicy{TTLSeconds: 10},
+			expected: GCPolicy{TTLSeconds: 10, KeepVersions: proto.Int32(3)},
+		},
+		{
+			name:     "set keep versions",
+			gc:       &GCPolicy{KeepVersions: proto.Int32(5)},
+			expected: GCPolicy{TTLSeconds: 100, KeepVersions: proto.Int32(5)},
+		},
+		{
+			name:     "disable keep versions",
+			gc:       &GCPolicy{TTLSeconds: 10, KeepVersions: proto.Int32(0)},
+			expected: GCPolicy{TTLSeconds: 10, KeepVersions: proto.Int32(0)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			zone := ZoneConfig{GC: tc.gc}
+			zone.InheritFromParent(&parent)
+			require.Equal(t, tc.expected, *zone.GC)
+		})
+	}
+}
+
 func TestZoneConfigMarshalYAML(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -35,6 +35,7 @@ type Server struct {
 	handlesV1, handlesV2                   kvflowcontrol.InspectHandles
 	kvflowControllerV1, kvflowControllerV2 kvflowcontrol.InspectController
 	storeLiveness                          kvserver.InspectAllStoreLiveness
+	appendOnlyHotspots                     kvserver.InspectAllAppendOnlyHotspots
 }
 
 var _ inspectzpb.InspectzServer = &Server{}
@@ -45,6 +46,7 @@ func NewServer(
 	handlesV1, handlesV2 kvflowcontrol.InspectHandles,
 	kvflowControllerV1, kvflowControllerV2 kvflowcontrol.InspectController,
 	storeLiveness kvserver.InspectAllStoreLiveness,
+	appendOnlyHotspots kvserver.InspectAllAppendOnlyHotspots,
 ) *Server {
 	mux := http.NewServeMux()
 	server := &Server{
@@ -56,6 +58,7 @@ func NewServer(
 		kvflowControllerV1: kvflowControllerV1,
 		kvflowControllerV2: kvflowControllerV2,
 		storeLiveness:      storeLiveness,
+		appendOnlyHotspots: appendOnlyHotspots,
 	}
 	mux.Handle("/inspectz/v1/kvflowhandles", server.makeKVFlowHandlesHandler(server.KVFlowHandles))
 	mux.Handle("/inspectz/v1/kvflowcontroller", server.makeKVFlowControllerHandler(server.KVFlowController))
@@ -69,6 +72,7 @@ func NewServer(
 		"/inspectz/storeliveness/supportFor",
 		server.makeStoreLivenessHandler(server.StoreLivenessSupportFor),
 	)
+	mux.Handle("/inspectz/appendonlyhotspots", server.makeAppendOnlyHotspotsHandler())
 
 	return server
 }
@@ -134,6 +138,19 @@ func (s *Server) makeStoreLivenessHandler(
 	}
 }
 
+func (s *Server) makeAppendOnlyHotspotsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.AnnotateCtx(context.Background())
+		resp, err := s.AppendOnlyHotspots(ctx, &inspectzpb.AppendOnlyHotspotsRequest{})
+		if err != nil {
+			log.ErrorfDepth(ctx, 1, "%s", err)
+			http.Error(w, "internal error: check logs for details", http.StatusInternalServerError)
+			return
+		}
+		respond(ctx, w, http.StatusOK, resp)
+	}
+}
+
 // KVFlowController implements the InspectzServer interface.
 func (s *Server) KVFlowController(
 	ctx context.Context, request *kvflowinspectpb.ControllerRequest,
@@ -182,6 +199,30 @@ func (s *Server) StoreLivenessSupportFor(
 	return resp, err
 }
 
+// AppendOnlyHotspots implements the InspectzServer interface.
+func (s *Server) AppendOnlyHotspots(
+	_ context.Context, _ *inspectzpb.AppendOnlyHotspotsRequest,
+) (*inspectzpb.AppendOnlyHotspotsResponse, error) {
+	hotspots, err := s.appendOnlyHotspots.InspectAllAppendOnlyHotspots()
+	if err != nil {
+		return nil, err
+	}
+	resp := &inspectzpb.AppendOnlyHotspotsResponse{}
+	for _, h := range hotspots {
+		resp.Hotspots = append(resp.Hotspots, inspectzpb.AppendOnlyHotspot{
+			NodeID:          h.NodeID,
+			StoreID:         h.StoreID,
+			Prefix:          h.Prefix,
+			Splits:          int64(h.Splits),
+			LastSplitKey:    h.LastSplitKey,
+			WritesPerSecond: h.WritesPerSecond,
+			Detected:        h.Detected,
+			LastSeen:        h.LastSeen,
+		})
+	}
+	return resp, nil
+}
+
 // ServeHTTP serves various tools under the /debug endpoint.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.mux.ServeHTTP(w, r)
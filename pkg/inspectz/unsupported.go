@@ -61,3 +61,10 @@ func (u Unsupported) StoreLivenessSupportFor(
 ) (*slpb.InspectStoreLivenessResponse, error) {
 	return nil, errorutil.UnsupportedUnderClusterVirtualization(errorutil.FeatureNotAvailableToNonSystemTenantsIssue)
 }
+
+// AppendOnlyHotspots is part of the inspectzpb.InspectzServer interface.
+func (u Unsupported) AppendOnlyHotspots(
+	_ context.Context, _ *inspectzpb.AppendOnlyHotspotsRequest,
+) (*inspectzpb.AppendOnlyHotspotsResponse, error) {
+	return nil, errorutil.UnsupportedUnderClusterVirtualization(errorutil.FeatureNotAvailableToNonSystemTenantsIssue)
+}
@@ -47,6 +47,7 @@ var (
 	_ Details = UpdateTableMetadataCacheDetails{}
 	_ Details = StandbyReadTSPollerDetails{}
 	_ Details = SqlActivityFlushDetails{}
+	_ Details = ExportBackupDetails{}
 )
 
 // ProgressDetails is a marker interface for job progress details proto structs.
@@ -78,6 +79,7 @@ var (
 	_ ProgressDetails = UpdateTableMetadataCacheProgress{}
 	_ ProgressDetails = StandbyReadTSPollerProgress{}
 	_ ProgressDetails = SqlActivityFlushProgress{}
+	_ ProgressDetails = ExportBackupProgress{}
 )
 
 // Type returns the payload's job type and panics if the type is invalid.
@@ -244,6 +246,8 @@ func DetailsType(d isPayload_Details) (Type, error) {
 		return TypeStandbyReadTSPoller, nil
 	case *Payload_SqlActivityFlushDetails:
 		return TypeSQLActivityFlush, nil
+	case *Payload_ExportBackupDetails:
+		return TypeExportBackup, nil
 	default:
 		return TypeUnspecified, errors.Newf("Payload.Type called on a payload with an unknown details type: %T", d)
 	}
@@ -298,6 +302,7 @@ var JobDetailsForEveryJobType = map[Type]Details{
 	TypeUpdateTableMetadataCache:     UpdateTableMetadataCacheDetails{},
 	TypeStandbyReadTSPoller:          StandbyReadTSPollerDetails{},
 	TypeSQLActivityFlush:             SqlActivityFlushDetails{},
+	TypeExportBackup:                 ExportBackupDetails{},
 }
 
 // WrapProgressDetails wraps a ProgressDetails object in the protobuf wrapper
@@ -367,6 +372,8 @@ func WrapProgressDetails(details ProgressDetails) interface {
 		return &Progress_StandbyReadTsPoller{StandbyReadTsPoller: &d}
 	case SqlActivityFlushProgress:
 		return &Progress_SqlActivityFlush{SqlActivityFlush: &d}
+	case ExportBackupProgress:
+		return &Progress_ExportBackup{ExportBackup: &d}
 	default:
 		panic(errors.AssertionFailedf("WrapProgressDetails: unknown progress type %T", d))
 	}
@@ -434,6 +441,8 @@ func (p *Payload) UnwrapDetails() Details {
 		return *d.StandbyReadTsPollerDetails
 	case *Payload_SqlActivityFlushDetails:
 		return *d.SqlActivityFlushDetails
+	case *Payload_ExportBackupDetails:
+		return *d.ExportBackupDetails
 	default:
 		return nil
 	}
@@ -501,6 +510,8 @@ func (p *Progress) UnwrapDetails() ProgressDetails {
 		return *d.StandbyReadTsPoller
 	case *Progress_SqlActivityFlush:
 		return *d.SqlActivityFlush
+	case *Progress_ExportBackup:
+		return *d.ExportBackup
 	default:
 		return nil
 	}
@@ -592,6 +603,8 @@ func WrapPayloadDetails(details Details) interface {
 		return &Payload_StandbyReadTsPollerDetails{StandbyReadTsPollerDetails: &d}
 	case SqlActivityFlushDetails:
 		return &Payload_SqlActivityFlushDetails{SqlActivityFlushDetails: &d}
+	case ExportBackupDetails:
+		return &Payload_ExportBackupDetails{ExportBackupDetails: &d}
 	default:
 		panic(errors.AssertionFailedf("jobs.WrapPayloadDetails: unknown details type %T", d))
 	}
@@ -627,7 +640,7 @@ const (
 func (Type) SafeValue() {}
 
 // NumJobTypes is the number of jobs types.
-const NumJobTypes = 32
+const NumJobTypes = 33
 
 // ChangefeedDetailsMarshaler allows for dependency injection of
 // cloud.SanitizeExternalStorageURI to avoid the dependency from this
@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 )
@@ -107,3 +108,20 @@ func StorePerNodeProcessorProgressFraction(
 			jobID, err.Error())
 	}
 }
+
+// StoreProgressEstimate stores the latest progress estimate of a BACKUP or
+// RESTORE job in the job info table, replacing the previous estimate.
+func StoreProgressEstimate(
+	ctx context.Context, db isql.DB, jobID jobspb.JobID, estimate *jobspb.BulkProgressEstimate,
+) {
+	if err := db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		estimateBytes, err := protoutil.Marshal(estimate)
+		if err != nil {
+			return err
+		}
+		return jobs.InfoStorageForJob(txn, jobID).Write(ctx, profilerconstants.ProgressEstimateInfoKey, estimateBytes)
+	}); err != nil {
+		log.Warningf(ctx, "failed to write progress estimate for job %d: %v",
+			jobID, err.Error())
+	}
+}
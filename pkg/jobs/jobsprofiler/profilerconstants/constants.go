@@ -35,6 +35,10 @@ func MakeNodeProcessorProgressInfoKey(flowID string, instanceID string, processo
 	return fmt.Sprintf("%s%s,%s,%d", NodeProcessorProgressInfoKeyPrefix, flowID, instanceID, processorID)
 }
 
+// ProgressEstimateInfoKey is the info key of the row that stores the latest
+// jobspb.BulkProgressEstimate of a BACKUP or RESTORE job.
+const ProgressEstimateInfoKey = "~progress-estimate"
+
 // ExecutionDetailsChunkKeyPrefix is the prefix of the info key used for rows that
 // store chunks of a job's execution details.
 const ExecutionDetailsChunkKeyPrefix = "~profiler/"
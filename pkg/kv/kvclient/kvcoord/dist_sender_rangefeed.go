@@ -66,6 +66,7 @@ type rangeFeedConfig struct {
 	withFiltering         bool
 	withMetadata          bool
 	withMatchingOriginIDs []uint32
+	filter                *kvpb.RangeFeedFilter
 	rangeObserver         RangeObserver
 	consumerID            int64
 
@@ -125,6 +126,17 @@ func WithMatchingOriginIDs(originIDs ...uint32) RangeFeedOption {
 	})
 }
 
+// WithRangeFeedFilter opts the rangefeed into having the server drop the value
+// events which cannot match the filter, before they are sent to the client.
+// The filter is conservative, so the client must still evaluate its own
+// predicates on the events it receives. Servers which predate the filter
+// ignore it and send every event.
+func WithRangeFeedFilter(filter *kvpb.RangeFeedFilter) RangeFeedOption {
+	return optionFunc(func(c *rangeFeedConfig) {
+		c.filter = filter
+	})
+}
+
 // WithRangeObserver is called when the rangefeed starts with a function that
 // can be used to iterate over all the ranges.
 func WithRangeObserver(observer RangeObserver) RangeFeedOption {
@@ -631,6 +643,7 @@ func makeRangeFeedRequest(
 	withDiff bool,
 	withFiltering bool,
 	withMatchingOriginIDs []uint32,
+	filter *kvpb.RangeFeedFilter,
 	consumerID int64,
 ) kvpb.RangeFeedRequest {
 	admissionPri := admissionpb.BulkNormalPri
@@ -647,6 +660,7 @@ func makeRangeFeedRequest(
 		WithDiff:              withDiff,
 		WithFiltering:         withFiltering,
 		WithMatchingOriginIDs: withMatchingOriginIDs,
+		Filter:                filter,
 		AdmissionHeader: kvpb.AdmissionHeader{
 			// NB: AdmissionHeader is used only at the start of the range feed
 			// stream since the initial catch-up scan is expensive.
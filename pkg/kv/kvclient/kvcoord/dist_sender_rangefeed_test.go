@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -872,3 +873,96 @@ func TestMuxRangeFeedDoesNotDeadlockWithLocalStreams(t *testing.T) {
 	defer closeFeed()
 	channelWaitWithTimeout(t, allSeen)
 }
+
+// TestRangeFeedFilterDropsEventsOnServer verifies that the value events which
+// do not match the filter of a rangefeed are dropped by the rangefeed server,
+// both during the catch-up scan and afterwards: the DistSender passes the
+// events it receives through without filtering them.
+func TestRangeFeedFilterDropsEventsOnServer(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc := testcluster.StartTestCluster(t, 1, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+
+	ts := tc.Server(0)
+	sqlDB := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+	kvserver.RangefeedEnabled.Override(ctx, &ts.ClusterSettings().SV, true)
+
+	// Both non-key columns are in the primary family, so that its values are
+	// tuples the filter can be evaluated on.
+	sqlDB.Exec(t, `CREATE TABLE foo (key INT PRIMARY KEY, a INT, b INT, FAMILY (key, a, b))`)
+	fooDesc := desctestutils.TestingGetPublicTableDescriptor(
+		ts.DB(), keys.SystemSQLCodec, "defaultdb", "foo")
+	fooSpan := fooDesc.PrimaryIndexSpan(keys.SystemSQLCodec)
+	rowKey := func(key int64) roachpb.Key {
+		k := keys.SystemSQLCodec.IndexPrefix(uint32(fooDesc.GetID()), uint32(fooDesc.GetPrimaryIndexID()))
+		return roachpb.Key(keys.MakeFamilyKey(encoding.EncodeVarintAscending(k, key), 0))
+	}
+
+	startTime := ts.Clock().Now()
+	sqlDB.Exec(t, `INSERT INTO foo SELECT k, k % 2, k FROM generate_series(1, 100) AS k`)
+
+	// Only the rows whose column a, with ID 2, is 1 match.
+	filter := &kvpb.RangeFeedFilter{
+		ColumnFamilies: []uint32{0},
+		ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{{
+			FamilyID: 0,
+			ColumnID: 2,
+			Op:       kvpb.RangeFeedFilter_ColumnPredicate_EQ,
+			Value:    encoding.EncodeIntValue(nil, encoding.NoColumnID, 1),
+		}},
+	}
+
+	var mu struct {
+		syncutil.Mutex
+		seen []roachpb.Key
+	}
+	sentinels := map[string]chan struct{}{
+		string(rowKey(99)):  make(chan struct{}),
+		string(rowKey(201)): make(chan struct{}),
+	}
+	onValue := func(ev kvcoord.RangeFeedMessage) {
+		if ev.Val == nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		mu.seen = append(mu.seen, ev.Val.Key)
+		if ch, ok := sentinels[string(ev.Val.Key)]; ok {
+			close(ch)
+		}
+	}
+	closeFeed := rangeFeed(ts.DistSenderI(), fooSpan, startTime, onValue,
+		kvcoord.WithRangeFeedFilter(filter))
+	defer closeFeed()
+
+	checkSeen := func(expected []int64) {
+		t.Helper()
+		mu.Lock()
+		defer mu.Unlock()
+		var expectedKeys []roachpb.Key
+		for _, key := range expected {
+			expectedKeys = append(expectedKeys, rowKey(key))
+		}
+		require.Equal(t, expectedKeys, mu.seen)
+	}
+
+	// The catch-up scan emits the rows in key order, so that all of them were
+	// seen once the last matching one is.
+	channelWaitWithTimeout(t, sentinels[string(rowKey(99))])
+	var expected []int64
+	for key := int64(1); key <= 100; key += 2 {
+		expected = append(expected, key)
+	}
+	checkSeen(expected)
+
+	// Afterwards, events are emitted in the order they are written.
+	sqlDB.Exec(t, `INSERT INTO foo VALUES (200, 0, 200)`)
+	sqlDB.Exec(t, `UPDATE foo SET a = 0 WHERE key = 1`)
+	sqlDB.Exec(t, `UPDATE foo SET a = 1 WHERE key = 2`)
+	sqlDB.Exec(t, `INSERT INTO foo VALUES (201, 1, 201)`)
+	channelWaitWithTimeout(t, sentinels[string(rowKey(201))])
+	checkSeen(append(expected, 2, 201))
+}
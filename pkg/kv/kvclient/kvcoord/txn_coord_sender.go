@@ -1161,6 +1161,28 @@ func (tc *TxnCoordSender) SetOmitInRangefeeds() {
 	tc.mu.txn.OmitInRangefeeds = true
 }
 
+// GetTransactionClass is part of the kv.TxnSender interface.
+func (tc *TxnCoordSender) GetTransactionClass() roachpb.TransactionClass {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return tc.mu.txn.Class
+}
+
+// SetTransactionClass is part of the kv.TxnSender interface.
+func (tc *TxnCoordSender) SetTransactionClass(class roachpb.TransactionClass) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if tc.mu.txn.Class == class {
+		return
+	}
+
+	if tc.mu.active {
+		panic("cannot change the class of a running transaction")
+	}
+	tc.mu.txn.Class = class
+}
+
 // SetBufferedWritesEnabled is part of the kv.TxnSender interface.
 func (tc *TxnCoordSender) SetBufferedWritesEnabled(enabled bool) {
 	tc.mu.Lock()
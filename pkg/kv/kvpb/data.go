@@ -51,6 +51,7 @@ func PrepareTransactionForRetry(
 		// TODO(andrei): Should we preserve the ObservedTimestamps across the
 		// restart?
 		errTxnPri := txn.Priority
+		errTxnClass := txn.Class
 		// Start the new transaction at the current time from the local clock.
 		// The local hlc should have been advanced to at least the error's
 		// timestamp already.
@@ -70,6 +71,8 @@ func PrepareTransactionForRetry(
 		)
 		// Use the priority communicated back by the server.
 		txn.Priority = errTxnPri
+		// The new transaction retains the class of the aborted one.
+		txn.Class = errTxnClass
 	case *ReadWithinUncertaintyIntervalError:
 		txn.WriteTimestamp.Forward(tErr.RetryTimestamp())
 	case *TransactionPushError:
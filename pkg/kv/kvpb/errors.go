@@ -296,6 +296,7 @@ const (
 	LockConflictErrType                     ErrorDetailType = 45
 	ReplicaUnavailableErrType               ErrorDetailType = 46
 	ProxyFailedErrType                      ErrorDetailType = 47
+	ReadOnlySpanErrType                     ErrorDetailType = 48
 	// When adding new error types, don't forget to update NumErrors below.
 
 	// CommunicationErrType indicates a gRPC error; this is not an ErrorDetail.
@@ -305,7 +306,7 @@ const (
 	// detail. The value 25 is chosen because it's reserved in the errors proto.
 	InternalErrType ErrorDetailType = 25
 
-	NumErrors int = 48
+	NumErrors int = 49
 )
 
 // Register the migration of all errors that used to be in the roachpb package
@@ -1766,6 +1767,28 @@ var _ errors.SafeFormatter = (*ProxyFailedError)(nil)
 var _ fmt.Formatter = (*ProxyFailedError)(nil)
 var _ errors.Wrapper = (*ProxyFailedError)(nil)
 
+// NewReadOnlySpanError creates a new ReadOnlySpanError.
+func NewReadOnlySpanError(span roachpb.Span) *ReadOnlySpanError {
+	return &ReadOnlySpanError{Span: span}
+}
+
+// Type is part of the ErrorDetailInterface.
+func (e *ReadOnlySpanError) Type() ErrorDetailType {
+	return ReadOnlySpanErrType
+}
+
+func (e *ReadOnlySpanError) Error() string {
+	return redact.Sprint(e).StripMarkers()
+}
+
+// SafeFormatError is part of the SafeFormatter.
+func (e *ReadOnlySpanError) SafeFormatError(p errors.Printer) (next error) {
+	p.Printf("cannot write to read-only span %s", e.Span)
+	return nil
+}
+
+var _ ErrorDetailInterface = &ReadOnlySpanError{}
+
 // KeyCollisionError represents a failed attempt to ingest the same key twice.
 type KeyCollisionError struct {
 	Key   roachpb.Key
@@ -1853,4 +1876,5 @@ var _ errors.SafeFormatter = &MVCCHistoryMutationError{}
 var _ errors.SafeFormatter = &UnhandledRetryableError{}
 var _ errors.SafeFormatter = &ReplicaUnavailableError{}
 var _ errors.SafeFormatter = &ProxyFailedError{}
+var _ errors.SafeFormatter = &ReadOnlySpanError{}
 var _ errors.SafeFormatter = &KeyCollisionError{}
@@ -393,6 +393,46 @@ func NewStorePool(
 	return sp
 }
 
+// Clone returns a copy of the store pool, which is not updated through gossip
+// and does not call the capacity change callbacks. The local store estimates
+// of the copy, updated through UpdateLocalStoreAfterRebalance and
+// UpdateLocalStoresAfterLeaseTransfer, are independent of those of the
+// original, so the copy can be used to simulate a sequence of allocator
+// decisions without affecting the decisions actually made.
+func (sp *StorePool) Clone() *StorePool {
+	c := &StorePool{
+		AmbientContext: sp.AmbientContext,
+		st:             sp.st,
+		clock:          sp.clock,
+		gossip:         sp.gossip,
+		nodeCountFn:    sp.nodeCountFn,
+		NodeLivenessFn: sp.NodeLivenessFn,
+		startTime:      sp.startTime,
+		deterministic:  sp.deterministic,
+		OverrideIsStoreReadyForRoutineReplicaTransferFn: sp.OverrideIsStoreReadyForRoutineReplicaTransferFn,
+	}
+	sp.DetailsMu.RLock()
+	c.DetailsMu.StoreDetails = make(map[roachpb.StoreID]*StoreDetail, len(sp.DetailsMu.StoreDetails))
+	for storeID, detail := range sp.DetailsMu.StoreDetails {
+		// The local store estimates are updated in place, so the descriptors
+		// must not be shared.
+		cDetail := *detail
+		if detail.Desc != nil {
+			desc := *detail.Desc
+			cDetail.Desc = &desc
+		}
+		c.DetailsMu.StoreDetails[storeID] = &cDetail
+	}
+	sp.DetailsMu.RUnlock()
+	sp.localitiesMu.RLock()
+	c.localitiesMu.nodeLocalities = make(map[roachpb.NodeID]localityWithString, len(sp.localitiesMu.nodeLocalities))
+	for nodeID, locality := range sp.localitiesMu.nodeLocalities {
+		c.localitiesMu.nodeLocalities[nodeID] = locality
+	}
+	sp.localitiesMu.RUnlock()
+	return c
+}
+
 func (sp *StorePool) String() string {
 	return redact.StringWithoutMarkers(sp)
 }
@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/allocator"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -434,6 +435,36 @@ func TestStorePoolGetStoreDetails(t *testing.T) {
 	}
 }
 
+// TestStorePoolClone verifies that local store estimates updated on a cloned
+// store pool don't affect the original.
+func TestStorePoolClone(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	stopper, g, _, sp, _ := CreateTestStorePool(ctx, st,
+		liveness.TestTimeUntilNodeDead, false, /* deterministic */
+		func() int { return 10 }, /* nodeCount */
+		livenesspb.NodeLivenessStatus_LIVE)
+	defer stopper.Stop(ctx)
+	sg := gossiputil.NewStoreGossiper(g)
+	sg.GossipStores(uniqueStore, t)
+
+	clone := sp.Clone()
+	clone.UpdateLocalStoreAfterRebalance(
+		roachpb.StoreID(2), allocator.RangeUsageInfo{LogicalBytes: 10}, roachpb.ADD_VOTER)
+
+	desc, ok := clone.GetStoreDescriptor(roachpb.StoreID(2))
+	require.True(t, ok)
+	require.Equal(t, int32(1), desc.Capacity.RangeCount)
+	require.Equal(t, int64(10), desc.Capacity.LogicalBytes)
+
+	desc, ok = sp.GetStoreDescriptor(roachpb.StoreID(2))
+	require.True(t, ok)
+	require.Equal(t, int32(0), desc.Capacity.RangeCount)
+	require.Equal(t, int64(0), desc.Capacity.LogicalBytes)
+}
+
 func TestStorePoolFindDeadReplicas(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
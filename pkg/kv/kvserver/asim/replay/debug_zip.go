@@ -0,0 +1,214 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package replay
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	sysconfig "github.com/cockroachdb/cockroach/pkg/config"
+	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/errors/oserror"
+	"gopkg.in/yaml.v2"
+)
+
+// The files of a debug zip a snapshot is read from, relative to its debug
+// directory.
+const (
+	nodesFile  = "nodes.json"
+	nodesDir   = "nodes"
+	rangesFile = "ranges.json"
+	zonesFile  = "crdb_internal.zones.txt"
+)
+
+// ReadDebugZip reads a snapshot from an unzipped debug zip, which must have
+// been collected from the system tenant with range information included. The
+// directory given may be the root of the debug zip or its debug directory.
+//
+// The nodes, their localities and stores are read from nodes.json. The ranges
+// and their load are read from the ranges.json of every node, the descriptor of
+// a range being taken from the replica with the most recent one and its load
+// from its leaseholder. The zone configs are read from
+// crdb_internal.zones.txt, when present.
+//
+// A tsdump does not contain range descriptors and cannot be replayed.
+func ReadDebugZip(dir string) (*Snapshot, error) {
+	if _, err := os.Stat(filepath.Join(dir, "debug", nodesFile)); err == nil {
+		dir = filepath.Join(dir, "debug")
+	}
+	s := &Snapshot{Zones: make(map[sysconfig.ObjectID]zonepb.ZoneConfig)}
+	if err := readNodes(filepath.Join(dir, nodesFile), s); err != nil {
+		return nil, err
+	}
+	rangeFiles, err := filepath.Glob(filepath.Join(dir, nodesDir, "*", rangesFile))
+	if err != nil {
+		return nil, err
+	}
+	if len(rangeFiles) == 0 {
+		return nil, errors.Newf("no %s found in %s, the debug zip must include range information",
+			rangesFile, filepath.Join(dir, nodesDir))
+	}
+	if err := readRanges(rangeFiles, s); err != nil {
+		return nil, err
+	}
+	if err := readZones(filepath.Join(dir, zonesFile), s); err != nil {
+		return nil, err
+	}
+	s.normalize()
+	return s, nil
+}
+
+func readJSON(path string, v interface{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return errors.Wrapf(json.NewDecoder(f).Decode(v), "decoding %s", path)
+}
+
+// readNodes reads the nodes and stores of the snapshot from the node statuses
+// of a debug zip.
+func readNodes(path string, s *Snapshot) error {
+	var nodes serverpb.NodesResponse
+	if err := readJSON(path, &nodes); err != nil {
+		return err
+	}
+	if len(nodes.Nodes) == 0 {
+		return errors.Newf("no node statuses found in %s, the debug zip must be "+
+			"collected from the system tenant", path)
+	}
+	for _, ns := range nodes.Nodes {
+		n := Node{NodeID: ns.Desc.NodeID, Locality: ns.Desc.Locality}
+		for _, ss := range ns.StoreStatuses {
+			n.Stores = append(n.Stores, Store{
+				StoreID:  ss.Desc.StoreID,
+				Capacity: ss.Desc.Capacity.Capacity,
+			})
+		}
+		s.Nodes = append(s.Nodes, n)
+	}
+	return nil
+}
+
+// readRanges reads the ranges of the snapshot from the range information of
+// every node of a debug zip.
+func readRanges(paths []string, s *Snapshot) error {
+	type rangeReplicas struct {
+		latest, leaseholder *serverpb.RangeInfo
+	}
+	ranges := make(map[roachpb.RangeID]*rangeReplicas)
+	for _, path := range paths {
+		var infos []serverpb.RangeInfo
+		if err := readJSON(path, &infos); err != nil {
+			return err
+		}
+		for i := range infos {
+			info := &infos[i]
+			desc := info.State.Desc
+			if desc == nil {
+				continue
+			}
+			r, ok := ranges[desc.RangeID]
+			if !ok {
+				r = &rangeReplicas{}
+				ranges[desc.RangeID] = r
+			}
+			if r.latest == nil || r.latest.State.Desc.Generation < desc.Generation {
+				r.latest = info
+			}
+			if info.IsLeaseholder {
+				r.leaseholder = info
+			}
+		}
+	}
+
+	for _, r := range ranges {
+		rng := Range{Desc: *r.latest.State.Desc}
+		if r.latest.State.Stats != nil {
+			rng.Size = r.latest.State.Stats.Total()
+		}
+		if r.latest.State.Lease != nil {
+			rng.Leaseholder = r.latest.State.Lease.Replica.StoreID
+		}
+		// Only the leaseholder tracks the load of the whole range.
+		if lh := r.leaseholder; lh != nil {
+			rng.Leaseholder = lh.SourceStoreID
+			rng.Load = RangeLoad{
+				ReadsPerSecond:      max(lh.Stats.QueriesPerSecond-lh.Stats.WritesPerSecond, 0),
+				WritesPerSecond:     lh.Stats.WritesPerSecond,
+				ReadBytesPerSecond:  lh.Stats.ReadBytesPerSecond,
+				WriteBytesPerSecond: lh.Stats.WriteBytesPerSecond,
+				CPUNanosPerSecond:   lh.Stats.CPUTimePerSecond,
+			}
+		}
+		s.Ranges = append(s.Ranges, rng)
+	}
+	return nil
+}
+
+// readZones reads the zone configs of the snapshot from the crdb_internal.zones
+// table of a debug zip, which is written as TSV. Only the zone configs of whole
+// objects are read, subzone configs are not.
+func readZones(path string, s *Snapshot) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if oserror.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comma = '\t'
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	header, err := r.Read()
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", path)
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		cols[name] = i
+	}
+	for _, name := range []string{"zone_id", "subzone_id", "full_config_yaml"} {
+		if _, ok := cols[name]; !ok {
+			return errors.Newf("%s has no %s column", path, name)
+		}
+	}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return errors.Wrapf(err, "reading %s", path)
+		}
+		if len(record) != len(header) || record[cols["subzone_id"]] != "0" {
+			continue
+		}
+		id, err := strconv.ParseUint(record[cols["zone_id"]], 10, 32)
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", path)
+		}
+		var zone zonepb.ZoneConfig
+		if err := yaml.UnmarshalStrict([]byte(record[cols["full_config_yaml"]]), &zone); err != nil {
+			return errors.Wrapf(err, "decoding zone config of zone %d in %s", id, path)
+		}
+		if err := zone.EnsureFullyHydrated(); err != nil {
+			return errors.Wrapf(err, "zone config of zone %d in %s", id, path)
+		}
+		s.Zones[sysconfig.ObjectID(id)] = zone
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package replay
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/config"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/state"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/workload"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/server/status/statuspb"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v2"
+)
+
+func writeJSON(t *testing.T, path string, v interface{}) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	b, err := json.MarshalIndent(v, "", "  ")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, b, 0644))
+}
+
+func testNode(nodeID roachpb.NodeID, locality string, storeIDs ...roachpb.StoreID) statuspb.NodeStatus {
+	var ns statuspb.NodeStatus
+	ns.Desc.NodeID = nodeID
+	if err := ns.Desc.Locality.Set(locality); err != nil {
+		panic(err)
+	}
+	for _, storeID := range storeIDs {
+		var ss statuspb.StoreStatus
+		ss.Desc.StoreID = storeID
+		ss.Desc.Node = ns.Desc
+		ss.Desc.Capacity.Capacity = 1 << 40
+		ns.StoreStatuses = append(ns.StoreStatuses, ss)
+	}
+	return ns
+}
+
+func testRangeInfo(
+	desc roachpb.RangeDescriptor, source, leaseholder roachpb.StoreID, stats serverpb.RangeStatistics,
+) serverpb.RangeInfo {
+	return serverpb.RangeInfo{
+		SourceStoreID: source,
+		State: kvserverpb.RangeInfo{
+			ReplicaState: kvserverpb.ReplicaState{
+				Desc:  &desc,
+				Lease: &roachpb.Lease{Replica: roachpb.ReplicaDescriptor{StoreID: leaseholder}},
+				Stats: &enginepb.MVCCStats{KeyBytes: 10 << 20, ValBytes: 20 << 20},
+			},
+		},
+		IsLeaseholder: source == leaseholder,
+		Stats:         stats,
+	}
+}
+
+func testZoneYAML(t *testing.T, numReplicas, numVoters int32) string {
+	zone := zonepb.DefaultZoneConfig()
+	zone.NumReplicas = proto.Int32(numReplicas)
+	zone.NumVoters = proto.Int32(numVoters)
+	b, err := yaml.Marshal(&zone)
+	require.NoError(t, err)
+	return string(b)
+}
+
+// writeTestDebugZip writes a debug zip of a cluster with 3 nodes and 4 stores,
+// whose store and node IDs are not contiguous. The cluster has a range at the
+// start of the keyspace, a range of table 104 which has a zone config and a
+// range of table 105 whose only replica is on a store which is not part of the
+// cluster.
+func writeTestDebugZip(t *testing.T) string {
+	dir := t.TempDir()
+	debug := filepath.Join(dir, "debug")
+
+	writeJSON(t, filepath.Join(debug, nodesFile), serverpb.NodesResponse{
+		Nodes: []statuspb.NodeStatus{
+			testNode(4, "region=us-west,zone=a", 5),
+			testNode(1, "region=us-east,zone=a", 1),
+			testNode(2, "region=us-east,zone=b", 3, 2),
+		},
+	})
+
+	meta := roachpb.RangeDescriptor{
+		RangeID:  1,
+		StartKey: roachpb.RKeyMin,
+		EndKey:   roachpb.RKey(keys.SystemSQLCodec.TablePrefix(104)),
+		InternalReplicas: []roachpb.ReplicaDescriptor{
+			{StoreID: 1, Type: roachpb.VOTER_FULL},
+			{StoreID: 2, Type: roachpb.VOTER_FULL},
+			{StoreID: 5, Type: roachpb.VOTER_FULL},
+		},
+		Generation: 2,
+	}
+	staleMeta := meta
+	staleMeta.Generation = 1
+	staleMeta.InternalReplicas = meta.InternalReplicas[:2]
+	table := roachpb.RangeDescriptor{
+		RangeID:  2,
+		StartKey: roachpb.RKey(keys.SystemSQLCodec.TablePrefix(104)),
+		EndKey:   roachpb.RKey(keys.SystemSQLCodec.TablePrefix(105)),
+		InternalReplicas: []roachpb.ReplicaDescriptor{
+			{StoreID: 2, Type: roachpb.VOTER_FULL},
+			{StoreID: 5, Type: roachpb.VOTER_FULL},
+			{StoreID: 3, Type: roachpb.NON_VOTER},
+			{StoreID: 1, Type: roachpb.LEARNER},
+		},
+	}
+	orphan := roachpb.RangeDescriptor{
+		RangeID:          3,
+		StartKey:         roachpb.RKey(keys.SystemSQLCodec.TablePrefix(105)),
+		EndKey:           roachpb.RKeyMax,
+		InternalReplicas: []roachpb.ReplicaDescriptor{{StoreID: 9}},
+	}
+	tableStats := serverpb.RangeStatistics{
+		QueriesPerSecond:    100,
+		WritesPerSecond:     20,
+		ReadBytesPerSecond:  8000,
+		WriteBytesPerSecond: 4000,
+		CPUTimePerSecond:    1e6,
+	}
+
+	writeJSON(t, filepath.Join(debug, nodesDir, "1", rangesFile), []serverpb.RangeInfo{
+		testRangeInfo(meta, 1, 1, serverpb.RangeStatistics{QueriesPerSecond: 10}),
+	})
+	writeJSON(t, filepath.Join(debug, nodesDir, "2", rangesFile), []serverpb.RangeInfo{
+		testRangeInfo(staleMeta, 2, 1, serverpb.RangeStatistics{}),
+		testRangeInfo(table, 2, 5, serverpb.RangeStatistics{}),
+		testRangeInfo(orphan, 2, 9, serverpb.RangeStatistics{}),
+	})
+	writeJSON(t, filepath.Join(debug, nodesDir, "4", rangesFile), []serverpb.RangeInfo{
+		testRangeInfo(table, 5, 5, tableStats),
+	})
+
+	f, err := os.Create(filepath.Join(debug, zonesFile))
+	require.NoError(t, err)
+	w := csv.NewWriter(f)
+	w.Comma = '\t'
+	require.NoError(t, w.WriteAll([][]string{
+		{"zone_id", "subzone_id", "target", "full_config_yaml"},
+		{"0", "0", "RANGE default", testZoneYAML(t, 3, 3)},
+		{"104", "0", "TABLE db.public.t", testZoneYAML(t, 5, 2)},
+		{"104", "1", "INDEX db.public.t@idx", testZoneYAML(t, 7, 7)},
+	}))
+	require.NoError(t, f.Close())
+	return dir
+}
+
+func TestReadDebugZip(t *testing.T) {
+	s, err := ReadDebugZip(writeTestDebugZip(t))
+	require.NoError(t, err)
+
+	require.Equal(t, "nodes=3, stores=4, ranges=3, zones=2", s.String())
+	require.Equal(t, []Node{
+		{NodeID: 1, Locality: s.Nodes[0].Locality, Stores: []Store{{StoreID: 1, Capacity: 1 << 40}}},
+		{NodeID: 2, Locality: s.Nodes[1].Locality, Stores: []Store{
+			{StoreID: 2, Capacity: 1 << 40}, {StoreID: 3, Capacity: 1 << 40}}},
+		{NodeID: 4, Locality: s.Nodes[2].Locality, Stores: []Store{{StoreID: 5, Capacity: 1 << 40}}},
+	}, s.Nodes)
+	require.Equal(t, "region=us-west,zone=a", s.Nodes[2].Locality.String())
+
+	// The descriptor of a range is the most recent one, its load is that of its
+	// leaseholder.
+	require.Len(t, s.Ranges, 3)
+	require.Equal(t, roachpb.RangeID(1), s.Ranges[0].Desc.RangeID)
+	require.Len(t, s.Ranges[0].Desc.InternalReplicas, 3)
+	require.Equal(t, roachpb.StoreID(1), s.Ranges[0].Leaseholder)
+	require.Equal(t, int64(30<<20), s.Ranges[0].Size)
+	require.Equal(t, RangeLoad{
+		ReadsPerSecond:      80,
+		WritesPerSecond:     20,
+		ReadBytesPerSecond:  8000,
+		WriteBytesPerSecond: 4000,
+		CPUNanosPerSecond:   1e6,
+	}, s.Ranges[1].Load)
+	require.Equal(t, roachpb.StoreID(5), s.Ranges[1].Leaseholder)
+
+	// Subzone configs are not read.
+	require.Equal(t, int32(5), *s.Zones[104].NumReplicas)
+	require.Equal(t, int32(3), *s.Zones[0].NumReplicas)
+}
+
+func TestReplaySnapshot(t *testing.T) {
+	s, err := ReadDebugZip(filepath.Join(writeTestDebugZip(t), "debug"))
+	require.NoError(t, err)
+
+	// The orphaned range is not loaded, the learner is dropped and the stores
+	// are numbered sequentially in the simulator.
+	rangesInfo := s.RangesInfo()
+	require.Len(t, rangesInfo, 2)
+	require.Equal(t, state.Key(0), state.ToKey(rangesInfo[0].Descriptor.StartKey.AsRawKey()))
+	require.Equal(t, state.StoreID(1), rangesInfo[0].Leaseholder)
+	require.Equal(t, int32(3), rangesInfo[0].Config.NumReplicas)
+	require.Equal(t, state.Key(1000), state.ToKey(rangesInfo[1].Descriptor.StartKey.AsRawKey()))
+	require.Equal(t, state.StoreID(4), rangesInfo[1].Leaseholder)
+	require.Equal(t, int32(5), rangesInfo[1].Config.NumReplicas)
+	require.Equal(t, int32(2), rangesInfo[1].Config.NumVoters)
+	require.Equal(t, []roachpb.ReplicaDescriptor{
+		{StoreID: 2, Type: roachpb.VOTER_FULL},
+		{StoreID: 4, Type: roachpb.VOTER_FULL},
+		{StoreID: 3, Type: roachpb.NON_VOTER},
+	}, rangesInfo[1].Descriptor.InternalReplicas)
+
+	require.Equal(t, []workload.RangeLoad{
+		{StartKey: 0, EndKey: 1000, ReadsPerSecond: 10},
+		{
+			StartKey:            1000,
+			EndKey:              2000,
+			ReadsPerSecond:      80,
+			WritesPerSecond:     20,
+			ReadBytesPerSecond:  8000,
+			WriteBytesPerSecond: 4000,
+			CPUNanosPerSecond:   1e6,
+		},
+	}, s.RangeLoads())
+
+	require.Equal(t, []state.Region{
+		{Name: "us-east", Zones: []state.Zone{state.NewZone("a", 1, 1), state.NewZone("b", 1, 2)}},
+		{Name: "us-west", Zones: []state.Zone{state.NewZone("a", 1, 1)}},
+	}, Cluster{Snapshot: s}.Regions())
+
+	settings := config.DefaultSimulationSettings()
+	st := Cluster{Snapshot: s}.Generate(0, settings)
+	st = Ranges{Snapshot: s}.Generate(0, settings, st)
+	require.Len(t, st.Nodes(), 3)
+	require.Len(t, st.Stores(), 4)
+	require.Equal(t, int64(2), st.RangeCount())
+	lh, ok := st.LeaseholderStore(st.RangeFor(1500).RangeID())
+	require.True(t, ok)
+	require.Equal(t, state.StoreID(4), lh.StoreID())
+	require.Equal(t, "region=us-west,zone=a", lh.Descriptor().Node.Locality.String())
+}
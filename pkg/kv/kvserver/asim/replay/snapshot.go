@@ -0,0 +1,322 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package replay loads the topology, ranges, zone configs and per-range load of
+// a real cluster into the allocation simulator, so that allocation settings can
+// be evaluated offline against the cluster they would be applied to.
+package replay
+
+import (
+	"fmt"
+	"sort"
+
+	sysconfig "github.com/cockroachdb/cockroach/pkg/config"
+	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/config"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/gen"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/state"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/workload"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+)
+
+// rangeKeySpan is the size of the simulated keyspace assigned to each range of
+// the snapshot. The simulator uses integer keys, so the real keyspace of the
+// cluster is mapped onto it by assigning every range, in key order, an equally
+// sized span of simulated keys.
+const rangeKeySpan = 1000
+
+// Store is a store of a node in a snapshot.
+type Store struct {
+	StoreID  roachpb.StoreID
+	Capacity int64
+}
+
+// Node is a node in a snapshot.
+type Node struct {
+	NodeID   roachpb.NodeID
+	Locality roachpb.Locality
+	Stores   []Store
+}
+
+// RangeLoad is the load observed on a range, averaged over the period tracked
+// by the range's leaseholder.
+type RangeLoad struct {
+	ReadsPerSecond      float64
+	WritesPerSecond     float64
+	ReadBytesPerSecond  float64
+	WriteBytesPerSecond float64
+	CPUNanosPerSecond   float64
+}
+
+// Range is a range in a snapshot.
+type Range struct {
+	Desc        roachpb.RangeDescriptor
+	Leaseholder roachpb.StoreID
+	Size        int64
+	Load        RangeLoad
+}
+
+// Snapshot is the state of a real cluster to be replayed by the simulator.
+type Snapshot struct {
+	Nodes  []Node
+	Ranges []Range
+	// Zones contains the zone configs of the cluster, by the ID of the object
+	// they apply to. The zone config of a range is that of its table, when the
+	// table has one, and otherwise that of the default zone (the zone of
+	// keys.RootNamespaceID). Database and subzone configs are not applied.
+	Zones map[sysconfig.ObjectID]zonepb.ZoneConfig
+}
+
+// storeIDs returns the simulated store ID of each store in the snapshot. The
+// simulator assigns store IDs sequentially as stores are added, in the order
+// the snapshot's nodes and stores are sorted in.
+func (s *Snapshot) storeIDs() map[roachpb.StoreID]state.StoreID {
+	ids := make(map[roachpb.StoreID]state.StoreID)
+	for _, n := range s.Nodes {
+		for _, store := range n.Stores {
+			ids[store.StoreID] = state.StoreID(len(ids) + 1)
+		}
+	}
+	return ids
+}
+
+// normalize sorts the nodes, stores and ranges of the snapshot.
+func (s *Snapshot) normalize() {
+	sort.Slice(s.Nodes, func(i, j int) bool {
+		return s.Nodes[i].NodeID < s.Nodes[j].NodeID
+	})
+	for _, n := range s.Nodes {
+		sort.Slice(n.Stores, func(i, j int) bool {
+			return n.Stores[i].StoreID < n.Stores[j].StoreID
+		})
+	}
+	sort.Slice(s.Ranges, func(i, j int) bool {
+		return s.Ranges[i].Desc.StartKey.Less(s.Ranges[j].Desc.StartKey)
+	})
+}
+
+// LoadCluster returns a new simulator state containing the nodes and stores of
+// the snapshot, with their localities and disk capacities.
+func (s *Snapshot) LoadCluster(settings *config.SimulationSettings) state.State {
+	st := state.NewState(settings)
+	for _, n := range s.Nodes {
+		node := st.AddNode()
+		st.SetNodeLocality(node.NodeID(), n.Locality)
+		for _, store := range n.Stores {
+			newStore, ok := st.AddStore(node.NodeID())
+			if !ok {
+				panic(fmt.Sprintf("unable to load snapshot: cannot add store s%d", store.StoreID))
+			}
+			st.SetStoreCapacity(newStore.StoreID(), store.Capacity)
+		}
+	}
+	return st
+}
+
+// rangeKeys returns the simulated start key of each range of the snapshot
+// which is loaded into the simulator, by its index. Ranges without a voter on
+// any store of the snapshot are not loaded.
+func (s *Snapshot) rangeKeys(storeIDs map[roachpb.StoreID]state.StoreID) map[int]state.Key {
+	ret := make(map[int]state.Key, len(s.Ranges))
+	for i, r := range s.Ranges {
+		for _, repl := range r.Desc.InternalReplicas {
+			if _, ok := storeIDs[repl.StoreID]; ok && isVoter(repl.Type) {
+				ret[i] = state.MinKey + state.Key(len(ret)*rangeKeySpan)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// isVoter returns whether a replica of the type given is loaded into the
+// simulator as a voter. Learners are transient, the simulator does not model
+// them.
+func isVoter(typ roachpb.ReplicaType) bool {
+	return typ != roachpb.LEARNER && typ != roachpb.NON_VOTER
+}
+
+// spanConfig returns the span config of the range starting at the key given.
+func (s *Snapshot) spanConfig(startKey roachpb.RKey) roachpb.SpanConfig {
+	id, _ := sysconfig.DecodeKeyIntoZoneIDAndSuffix(keys.SystemSQLCodec, startKey)
+	zone, ok := s.Zones[id]
+	if !ok {
+		if zone, ok = s.Zones[keys.RootNamespaceID]; !ok {
+			zone = *zonepb.DefaultZoneConfigRef()
+		}
+	}
+	return zone.AsSpanConfig()
+}
+
+// RangesInfo returns the ranges of the snapshot, with their replicas placed on
+// the simulated stores and their span configs derived from the zone configs of
+// the snapshot.
+func (s *Snapshot) RangesInfo() state.RangesInfo {
+	storeIDs := s.storeIDs()
+	rangeKeys := s.rangeKeys(storeIDs)
+	ret := make(state.RangesInfo, 0, len(rangeKeys))
+	for i, r := range s.Ranges {
+		startKey, ok := rangeKeys[i]
+		if !ok {
+			continue
+		}
+		var voters, nonVoters []state.StoreID
+		var leaseholder state.StoreID
+		for _, repl := range r.Desc.InternalReplicas {
+			storeID, ok := storeIDs[repl.StoreID]
+			if !ok {
+				continue
+			}
+			if isVoter(repl.Type) {
+				voters = append(voters, storeID)
+				if repl.StoreID == r.Leaseholder {
+					leaseholder = storeID
+				}
+			} else if repl.Type == roachpb.NON_VOTER {
+				nonVoters = append(nonVoters, storeID)
+			}
+		}
+		if leaseholder == 0 {
+			leaseholder = voters[0]
+		}
+		conf := s.spanConfig(r.Desc.StartKey)
+		info := state.RangeInfoWithReplicas(startKey, voters, nonVoters, leaseholder, &conf)
+		info.Size = r.Size
+		ret = append(ret, info)
+	}
+	return ret
+}
+
+// RangeLoads returns the load of the ranges of the snapshot, over the simulated
+// keys assigned to each range.
+func (s *Snapshot) RangeLoads() []workload.RangeLoad {
+	rangeKeys := s.rangeKeys(s.storeIDs())
+	ret := make([]workload.RangeLoad, 0, len(rangeKeys))
+	for i, r := range s.Ranges {
+		startKey, ok := rangeKeys[i]
+		if !ok {
+			continue
+		}
+		ret = append(ret, workload.RangeLoad{
+			StartKey:            int64(startKey),
+			EndKey:              int64(startKey) + rangeKeySpan,
+			ReadsPerSecond:      r.Load.ReadsPerSecond,
+			WritesPerSecond:     r.Load.WritesPerSecond,
+			ReadBytesPerSecond:  r.Load.ReadBytesPerSecond,
+			WriteBytesPerSecond: r.Load.WriteBytesPerSecond,
+			CPUNanosPerSecond:   r.Load.CPUNanosPerSecond,
+		})
+	}
+	return ret
+}
+
+// Regions returns the regions and zones of the snapshot's nodes, from the
+// region and zone tiers of their localities.
+func (s *Snapshot) Regions() []state.Region {
+	var regions []state.Region
+	regionIdx := make(map[string]int)
+	for _, n := range s.Nodes {
+		region, _ := n.Locality.Find("region")
+		zone, _ := n.Locality.Find("zone")
+		idx, ok := regionIdx[region]
+		if !ok {
+			idx = len(regions)
+			regionIdx[region] = idx
+			regions = append(regions, state.Region{Name: region})
+		}
+		r := &regions[idx]
+		found := false
+		for j := range r.Zones {
+			if r.Zones[j].Name == zone {
+				r.Zones[j].NodeCount++
+				found = true
+				break
+			}
+		}
+		if !found {
+			storesPerNode := len(n.Stores)
+			if storesPerNode < 1 {
+				storesPerNode = 1
+			}
+			r.Zones = append(r.Zones, state.NewZone(zone, 1, storesPerNode))
+		}
+	}
+	return regions
+}
+
+func (s *Snapshot) String() string {
+	stores := 0
+	for _, n := range s.Nodes {
+		stores += len(n.Stores)
+	}
+	return fmt.Sprintf("nodes=%d, stores=%d, ranges=%d, zones=%d",
+		len(s.Nodes), stores, len(s.Ranges), len(s.Zones))
+}
+
+// Cluster implements the gen.ClusterGen interface, generating the nodes and
+// stores of a snapshot.
+type Cluster struct {
+	Snapshot *Snapshot
+}
+
+var _ gen.ClusterGen = Cluster{}
+
+// Generate returns a new simulator state containing the nodes and stores of
+// the snapshot. There is no randomness in this cluster generation.
+func (c Cluster) Generate(seed int64, settings *config.SimulationSettings) state.State {
+	return c.Snapshot.LoadCluster(settings)
+}
+
+func (c Cluster) String() string {
+	return fmt.Sprintf("replayed cluster with %s", c.Snapshot)
+}
+
+func (c Cluster) Regions() []state.Region {
+	return c.Snapshot.Regions()
+}
+
+// Ranges implements the gen.RangeGen interface, generating the ranges of a
+// snapshot.
+type Ranges struct {
+	Snapshot *Snapshot
+}
+
+var _ gen.RangeGen = Ranges{}
+
+// Generate returns an updated simulator state, where the cluster is loaded
+// with the ranges of the snapshot. The state must have been generated by the
+// Cluster of the same snapshot. There is no randomness in this range
+// generation.
+func (r Ranges) Generate(
+	seed int64, settings *config.SimulationSettings, s state.State,
+) state.State {
+	state.LoadRangeInfo(s, r.Snapshot.RangesInfo()...)
+	return s
+}
+
+func (r Ranges) String() string {
+	return fmt.Sprintf("replayed ranges with ranges=%d", len(r.Snapshot.Ranges))
+}
+
+// Load implements the gen.LoadGen interface, generating the load of the ranges
+// of a snapshot.
+type Load struct {
+	Snapshot *Snapshot
+}
+
+var _ gen.LoadGen = Load{}
+
+// Generate returns a workload generator which replays the load of each range
+// of the snapshot at a constant rate.
+func (l Load) Generate(seed int64, settings *config.SimulationSettings) []workload.Generator {
+	return []workload.Generator{
+		workload.NewReplayGenerator(settings.StartTime, l.Snapshot.RangeLoads()),
+	}
+}
+
+func (l Load) String() string {
+	return fmt.Sprintf("replayed load of ranges=%d", len(l.Snapshot.Ranges))
+}
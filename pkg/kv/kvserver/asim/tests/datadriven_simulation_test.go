@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/gen"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/history"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/metrics"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/replay"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/state"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
 	"github.com/cockroachdb/cockroach/pkg/spanconfig/spanconfigtestutils"
@@ -56,6 +57,14 @@ import (
 //     regions having 3 zones. complex: 28 nodes, 3 regions with a skewed
 //     number of nodes per region.
 //
+//   - "load_replay" debug_zip=<path>
+//     Replay the nodes, stores, ranges, zone configs and per-range load of a
+//     real cluster, read from an unzipped debug zip collected with range
+//     information. This replaces the cluster, range and load generators: the
+//     simulation starts with the cluster's topology and replica placement, and
+//     the load recorded by each range's leaseholder is applied at a constant
+//     rate.
+//
 //   - "gen_ranges" [ranges=<int>] [placement_skew=<bool>] [repl_factor=<int>]
 //     [keyspace=<int>] [range_bytes=<int>]
 //     Initialize the range generator parameters. On the next call to eval, the
@@ -158,7 +167,7 @@ func TestDataDriven(t *testing.T) {
 	dir := datapathutils.TestDataPath(t, "non_rand")
 	datadriven.Walk(t, dir, func(t *testing.T, path string) {
 		const defaultKeyspace = 10000
-		loadGen := gen.BasicLoad{}
+		var loadGen gen.LoadGen = gen.BasicLoad{}
 		var clusterGen gen.ClusterGen
 		var rangeGen gen.RangeGen = gen.BasicRanges{
 			BaseRanges: gen.BaseRanges{
@@ -187,13 +196,15 @@ func TestDataDriven(t *testing.T) {
 				scanIfExists(t, d, "min_key", &minKey)
 				scanIfExists(t, d, "max_key", &maxKey)
 
-				loadGen.SkewedAccess = accessSkew
-				loadGen.MinKey = minKey
-				loadGen.MaxKey = maxKey
-				loadGen.RWRatio = rwRatio
-				loadGen.Rate = rate
-				loadGen.MaxBlockSize = maxBlock
-				loadGen.MinBlockSize = minBlock
+				loadGen = gen.BasicLoad{
+					SkewedAccess: accessSkew,
+					MinKey:       minKey,
+					MaxKey:       maxKey,
+					RWRatio:      rwRatio,
+					Rate:         rate,
+					MaxBlockSize: maxBlock,
+					MinBlockSize: minBlock,
+				}
 				return ""
 			case "gen_ranges":
 				var ranges, replFactor, keyspace = 1, 3, defaultKeyspace
@@ -242,6 +253,15 @@ func TestDataDriven(t *testing.T) {
 				scanArg(t, d, "config", &config)
 				clusterGen = loadClusterInfo(config)
 				return ""
+			case "load_replay":
+				var path string
+				scanArg(t, d, "debug_zip", &path)
+				snapshot, err := replay.ReadDebugZip(path)
+				require.NoError(t, err)
+				clusterGen = replay.Cluster{Snapshot: snapshot}
+				rangeGen = replay.Ranges{Snapshot: snapshot}
+				loadGen = replay.Load{Snapshot: snapshot}
+				return snapshot.String()
 			case "add_node":
 				var delay time.Duration
 				var numStores = 1
@@ -0,0 +1,126 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package workload
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// replayKeysPerRange is the number of keys within each range that the replayed
+// load of the range is spread across, so that the range may be split by load.
+const replayKeysPerRange = 10
+
+// RangeLoad is the load observed on a range of a real cluster, applied to the
+// keys [StartKey, EndKey) of the simulated keyspace.
+type RangeLoad struct {
+	StartKey, EndKey    int64
+	ReadsPerSecond      float64
+	WritesPerSecond     float64
+	ReadBytesPerSecond  float64
+	WriteBytesPerSecond float64
+	CPUNanosPerSecond   float64
+}
+
+func (rl RangeLoad) String() string {
+	return fmt.Sprintf("[%d,%d): reads=%.2f/s writes=%.2f/s read_bytes=%.2f/s "+
+		"write_bytes=%.2f/s cpu=%s/s",
+		rl.StartKey, rl.EndKey, rl.ReadsPerSecond, rl.WritesPerSecond,
+		rl.ReadBytesPerSecond, rl.WriteBytesPerSecond,
+		time.Duration(rl.CPUNanosPerSecond))
+}
+
+// ReplayGenerator generates the load recorded on the ranges of a real cluster
+// at a constant rate. The load of a range is spread evenly across a fixed set
+// of keys within the range.
+type ReplayGenerator struct {
+	ranges  []RangeLoad
+	lastRun time.Time
+	// owed is the fractional number of reads and writes of each range which
+	// were not generated on the last tick, carried over to the next one.
+	owedReads, owedWrites []float64
+}
+
+// NewReplayGenerator returns a generator that generates the load of the ranges
+// given, starting at the start time.
+func NewReplayGenerator(start time.Time, ranges []RangeLoad) Generator {
+	ranges = append([]RangeLoad(nil), ranges...)
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].StartKey < ranges[j].StartKey
+	})
+	return &ReplayGenerator{
+		ranges:     ranges,
+		lastRun:    start,
+		owedReads:  make([]float64, len(ranges)),
+		owedWrites: make([]float64, len(ranges)),
+	}
+}
+
+// Tick returns the load events up till time tick, from the last time the
+// workload generator was called.
+func (rg *ReplayGenerator) Tick(maxTime time.Time) LoadBatch {
+	elapsed := maxTime.Sub(rg.lastRun).Seconds()
+	if elapsed <= 0 {
+		return LoadBatch{}
+	}
+	rg.lastRun = maxTime
+
+	ret := LoadBatch{}
+	for i, rl := range rg.ranges {
+		rg.owedReads[i] += rl.ReadsPerSecond * elapsed
+		rg.owedWrites[i] += rl.WritesPerSecond * elapsed
+		reads, writes := int64(rg.owedReads[i]), int64(rg.owedWrites[i])
+		if reads == 0 && writes == 0 {
+			continue
+		}
+		rg.owedReads[i] -= float64(reads)
+		rg.owedWrites[i] -= float64(writes)
+
+		// The bytes and CPU time of the range are attributed evenly to each of
+		// its reads and writes.
+		var readSize, writeSize float64
+		if reads > 0 {
+			readSize = float64(reads) * rl.ReadBytesPerSecond / rl.ReadsPerSecond
+		}
+		if writes > 0 {
+			writeSize = float64(writes) * rl.WriteBytesPerSecond / rl.WritesPerSecond
+		}
+		cpu := float64(reads+writes) * rl.CPUNanosPerSecond / (rl.ReadsPerSecond + rl.WritesPerSecond)
+
+		keys := int64(replayKeysPerRange)
+		if span := rl.EndKey - rl.StartKey; span < keys {
+			keys = span
+		}
+		if keys < 1 {
+			keys = 1
+		}
+		stride := (rl.EndKey - rl.StartKey) / keys
+		for k := int64(0); k < keys; k++ {
+			event := LoadEvent{
+				Key:        rl.StartKey + k*stride,
+				Reads:      reads / keys,
+				Writes:     writes / keys,
+				ReadSize:   int64(readSize) / keys,
+				WriteSize:  int64(writeSize) / keys,
+				RequestCPU: int64(cpu) / keys,
+			}
+			// The remainder of the division goes to the first key.
+			if k == 0 {
+				event.Reads += reads % keys
+				event.Writes += writes % keys
+				event.ReadSize += int64(readSize) % keys
+				event.WriteSize += int64(writeSize) % keys
+				event.RequestCPU += int64(cpu) % keys
+			}
+			if event.Reads == 0 && event.Writes == 0 {
+				continue
+			}
+			ret = append(ret, event)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package workload
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestReplayGenerator asserts that the replay generator generates the recorded
+// rates of each range, spread across the keys of the range, and that the
+// fractional load of a tick is carried over to the next.
+func TestReplayGenerator(t *testing.T) {
+	start := time.Unix(0, 0)
+	gen := NewReplayGenerator(start, []RangeLoad{
+		{
+			StartKey:            1000,
+			EndKey:              2000,
+			ReadsPerSecond:      10,
+			WritesPerSecond:     0.5,
+			ReadBytesPerSecond:  1000,
+			WriteBytesPerSecond: 50,
+			CPUNanosPerSecond:   1e6,
+		},
+		{StartKey: 0, EndKey: 1000, WritesPerSecond: 20},
+		{StartKey: 2000, EndKey: 2005, ReadsPerSecond: 5},
+	})
+
+	sum := func(batch LoadBatch, from, to int64) (reads, writes, readSize, writeSize, cpu int64) {
+		for _, le := range batch {
+			if le.Key < from || le.Key >= to {
+				continue
+			}
+			reads += le.Reads
+			writes += le.Writes
+			readSize += le.ReadSize
+			writeSize += le.WriteSize
+			cpu += le.RequestCPU
+		}
+		return reads, writes, readSize, writeSize, cpu
+	}
+
+	batch := gen.Tick(start.Add(time.Second))
+	require.True(t, isSorted(batch))
+	reads, writes, readSize, writeSize, cpu := sum(batch, 1000, 2000)
+	require.Equal(t, []int64{10, 0, 1000, 0, 952380}, []int64{reads, writes, readSize, writeSize, cpu})
+	_, writes, _, _, _ = sum(batch, 0, 1000)
+	require.Equal(t, int64(20), writes)
+	// The load of a range narrower than the number of replayed keys is spread
+	// across every key of the range.
+	reads, _, _, _, _ = sum(batch, 2000, 2005)
+	require.Equal(t, int64(5), reads)
+	for _, le := range batch {
+		if le.Key >= 2000 {
+			require.Equal(t, int64(1), le.Reads)
+		}
+	}
+
+	// The half write owed from the last tick is generated on this one.
+	batch = gen.Tick(start.Add(2 * time.Second))
+	reads, writes, _, writeSize, _ = sum(batch, 1000, 2000)
+	require.Equal(t, []int64{10, 1, 100}, []int64{reads, writes, writeSize})
+
+	// No time has elapsed, so there is no load.
+	require.Empty(t, gen.Tick(start.Add(2*time.Second)))
+}
+
+func isSorted(batch LoadBatch) bool {
+	for i := 1; i < len(batch); i++ {
+		if batch[i-1].Key > batch[i].Key {
+			return false
+		}
+	}
+	return true
+}
@@ -21,6 +21,10 @@ type LoadEvent struct {
 	WriteSize int64
 	Reads     int64
 	ReadSize  int64
+	// RequestCPU is the CPU time, in nanoseconds, spent serving the reads and
+	// writes of the event. It is only set by workloads which replay the load
+	// of a real cluster.
+	RequestCPU int64
 }
 
 // LoadBatch is a sorted list of load events.
@@ -234,6 +234,10 @@ func PushTxn(
 	case txnwait.CanPushWithPriority(pushType, pusherIso, pusheeIso, pusherPri, pusheePri, pusheeStatus):
 		reason = "pusher has priority"
 		pusherWins = true
+	case args.Yield &&
+		txnwait.CanPushWithYield(args.PusherTxn.Class, reply.PusheeTxn.Class, pusheeStatus):
+		reason = "batch pushee yields to interactive pusher"
+		pusherWins = true
 	case args.Force:
 		reason = "forced push"
 		pusherWins = true
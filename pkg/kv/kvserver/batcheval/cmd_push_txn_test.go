@@ -7,8 +7,10 @@ package batcheval_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval/result"
@@ -21,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -97,3 +100,82 @@ func TestPushTxnAmbiguousAbort(t *testing.T) {
 		}, resp)
 	})
 }
+
+// TestPushTxnYield tests that a push with the Yield flag set succeeds against a
+// pushee of equal priority only if the pushee's transaction record indicates
+// that it is a batch transaction and the pusher is an interactive transaction.
+// The push keeps its type: a PUSH_ABORT aborts the pushee and a PUSH_TIMESTAMP
+// only pushes its timestamp.
+func TestPushTxnYield(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	clock := hlc.NewClockForTesting(timeutil.NewManualTime(timeutil.Now()))
+	evalCtx := (&batcheval.MockEvalCtx{Clock: clock}).EvalContext()
+
+	interactive := roachpb.TransactionClass_INTERACTIVE
+	batch := roachpb.TransactionClass_BATCH
+	testCases := []struct {
+		pushType    kvpb.PushTxnType
+		yield       bool
+		pusherClass roachpb.TransactionClass
+		pusheeClass roachpb.TransactionClass
+		expPush     bool
+	}{
+		{kvpb.PUSH_ABORT, false, interactive, batch, false},
+		{kvpb.PUSH_ABORT, true, interactive, interactive, false},
+		{kvpb.PUSH_ABORT, true, batch, batch, false},
+		{kvpb.PUSH_ABORT, true, interactive, batch, true},
+		{kvpb.PUSH_TIMESTAMP, false, interactive, batch, false},
+		{kvpb.PUSH_TIMESTAMP, true, interactive, batch, true},
+	}
+	for _, tc := range testCases {
+		name := fmt.Sprintf("%s/yield=%t/pusher=%s/pushee=%s",
+			tc.pushType, tc.yield, tc.pusherClass, tc.pusheeClass)
+		t.Run(name, func(t *testing.T) {
+			engine := storage.NewDefaultInMemForTesting()
+			defer engine.Close()
+
+			key := roachpb.Key("foo")
+			pushee := roachpb.MakeTransaction("pushee", key, 0, 0, clock.Now(), 0, 1, 0, false /* omitInRangefeeds */)
+			pushee.Class = tc.pusheeClass
+			pusheeRecord := pushee.AsRecord()
+			txnKey := keys.TransactionKey(pushee.Key, pushee.ID)
+			require.NoError(t, storage.MVCCPutProto(
+				ctx, engine, txnKey, hlc.Timestamp{}, &pusheeRecord, storage.MVCCWriteOptions{}))
+
+			pusher := roachpb.MakeTransaction("pusher", key, 0, 0, clock.Now(), 0, 1, 0, false /* omitInRangefeeds */)
+			pusher.Priority = pushee.Priority
+			pusher.Class = tc.pusherClass
+
+			now := clock.Now()
+			resp := kvpb.PushTxnResponse{}
+			_, err := batcheval.PushTxn(ctx, engine, batcheval.CommandArgs{
+				EvalCtx: evalCtx,
+				Header: kvpb.Header{
+					Timestamp: now,
+				},
+				Args: &kvpb.PushTxnRequest{
+					RequestHeader: kvpb.RequestHeader{Key: key},
+					PusherTxn:     pusher,
+					PusheeTxn:     pushee.TxnMeta,
+					PushTo:        now,
+					PushType:      tc.pushType,
+					Yield:         tc.yield,
+				},
+			}, &resp)
+			if tc.expPush {
+				require.NoError(t, err)
+				if tc.pushType == kvpb.PUSH_ABORT {
+					require.Equal(t, roachpb.ABORTED, resp.PusheeTxn.Status)
+				} else {
+					require.Equal(t, roachpb.PENDING, resp.PusheeTxn.Status)
+					require.True(t, pushee.WriteTimestamp.Less(resp.PusheeTxn.WriteTimestamp))
+				}
+			} else {
+				require.True(t, errors.HasType(err, (*kvpb.TransactionPushError)(nil)), "%+v", err)
+			}
+		})
+	}
+}
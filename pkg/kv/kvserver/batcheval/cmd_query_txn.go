@@ -85,5 +85,6 @@ func QueryTxn(
 
 	// Get the list of txns waiting on this txn.
 	reply.WaitingTxns = cArgs.EvalCtx.GetConcurrencyManager().GetDependents(args.Txn.ID)
+	reply.WaitingTxnDetails = cArgs.EvalCtx.GetConcurrencyManager().GetWaitingTxns(args.Txn.ID)
 	return result.Result{}, nil
 }
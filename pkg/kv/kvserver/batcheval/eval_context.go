@@ -338,7 +338,11 @@ func (m *mockEvalCtxImpl) Release() {}
 type noopIntentResolver struct{}
 
 func (m *noopIntentResolver) PushTransaction(
-	ctx context.Context, txn *enginepb.TxnMeta, h kvpb.Header, pushType kvpb.PushTxnType,
+	ctx context.Context,
+	txn *enginepb.TxnMeta,
+	lockKey roachpb.Key,
+	h kvpb.Header,
+	pushType kvpb.PushTxnType,
 ) (*roachpb.Transaction, bool, *concurrency.Error) {
 	panic("unimplemented")
 }
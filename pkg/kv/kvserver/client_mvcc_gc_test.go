@@ -82,6 +82,76 @@ func TestMVCCGCCorrectStats(t *testing.T) {
 	require.Equal(t, oldValBytes, newStats.ValBytes)
 }
 
+// TestMVCCGCKeepVersionsAdvancesGCThreshold verifies that the versions which
+// the mvcc gc queue collects per the gc.keep_versions zone configuration can't
+// be read anymore: reads at their timestamps fail as they are below the GC
+// threshold, while reads of the retained versions succeed.
+func TestMVCCGCKeepVersionsAdvancesGCThreshold(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, sqlDB, kvDB := serverutils.StartServer(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestIsSpecificToStorageLayerAndNeedsASystemTenant,
+	})
+	defer s.Stopper().Stop(ctx)
+	store, err := s.GetStores().(*kvserver.Stores).GetStore(s.GetFirstStoreID())
+	require.NoError(t, err)
+
+	runner := sqlutils.MakeSQLRunner(sqlDB)
+	runner.Exec(t, `SET CLUSTER SETTING kv.closed_timestamp.target_duration = '100ms'`)
+	runner.Exec(t, `SET CLUSTER SETTING kv.gc.keep_versions.min_age = '0s'`)
+	runner.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v INT)`)
+	runner.Exec(t, `ALTER TABLE t CONFIGURE ZONE USING gc.keep_versions = 1`)
+	var tableID uint32
+	runner.QueryRow(t, `SELECT 't'::REGCLASS::OID`).Scan(&tableID)
+	tablePrefix := s.Codec().TablePrefix(tableID)
+	require.NoError(t, kvDB.AdminSplit(ctx, tablePrefix, hlc.MaxTimestamp /* expirationTime */))
+	repl := store.LookupReplica(roachpb.RKey(tablePrefix))
+	testutils.SucceedsSoon(t, func() error {
+		cfg, err := repl.LoadSpanConfig(ctx)
+		if err != nil {
+			return err
+		}
+		if cfg.GCPolicy.KeepVersions != 1 {
+			return errors.New("waiting for span config to apply")
+		}
+		return nil
+	})
+
+	// Write three versions of the row. The oldest two are collected, as they
+	// are shadowed by at least one newer version.
+	var writeTS [3]string
+	for i := range writeTS {
+		runner.Exec(t, `UPSERT INTO t VALUES (1, $1)`, i)
+		runner.QueryRow(t, `SELECT crdb_internal_mvcc_timestamp FROM t WHERE k = 1`).Scan(&writeTS[i])
+	}
+	readAt := func(ts string) (v int, _ error) {
+		err := sqlDB.QueryRow(
+			fmt.Sprintf(`SELECT v FROM t AS OF SYSTEM TIME %s WHERE k = 1`, ts),
+		).Scan(&v)
+		return v, err
+	}
+	v, err := readAt(writeTS[1])
+	require.NoError(t, err)
+	require.Equal(t, 1, v)
+
+	testutils.SucceedsSoon(t, func() error {
+		require.NoError(t, store.ManualMVCCGC(repl))
+		if _, err := readAt(writeTS[1]); err == nil {
+			return errors.New("versions not collected yet")
+		} else if !testutils.IsError(err, "must be after replica GC threshold") {
+			t.Fatal(err)
+		}
+		return nil
+	})
+	_, err = readAt(writeTS[0])
+	require.True(t, testutils.IsError(err, "must be after replica GC threshold"), "%v", err)
+	v, err = readAt(writeTS[2])
+	require.NoError(t, err)
+	require.Equal(t, 2, v)
+}
+
 // TestSystemSpanConfigProtectionPoliciesApplyAfterGC is a regression test for
 // https://github.com/cockroachdb/cockroach/issues/113867. This test attempts
 // to recreate the following observed timeline:
@@ -838,6 +838,119 @@ func TestSnapshotAfterTruncation(t *testing.T) {
 	}
 }
 
+// TestDeltaSnapshotAfterTruncation tests that a replica which fell behind the
+// truncation point of the log while its server was down is caught up with a
+// delta snapshot that only streams the spans of user keys which changed, and
+// that a full snapshot is streamed instead when most of the data changed. In
+// both cases, the replicas are consistent afterwards.
+func TestDeltaSnapshotAfterTruncation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numKeys, valueSize = 1000, 1024
+	const dataSize = numKeys * valueSize
+	testutils.RunTrueAndFalse(t, "diverged", func(t *testing.T, diverged bool) {
+		lisReg := listenerutil.NewListenerRegistry()
+		defer lisReg.Close()
+
+		ctx := context.Background()
+		const numServers int = 3
+		stickyServerArgs := make(map[int]base.TestServerArgs)
+		for i := 0; i < numServers; i++ {
+			st := cluster.MakeTestingClusterSettings()
+			kvserver.DeltaSnapshotsEnabled.Override(ctx, &st.SV, true)
+			kvserver.DeltaSnapshotSpanSize.Override(ctx, &st.SV, 64<<10)
+			stickyServerArgs[i] = base.TestServerArgs{
+				Settings: st,
+				StoreSpecs: []base.StoreSpec{
+					{
+						InMemory:    true,
+						StickyVFSID: strconv.FormatInt(int64(i), 10),
+					},
+				},
+				Knobs: base.TestingKnobs{
+					Server: &server.TestingKnobs{
+						StickyVFSRegistry: fs.NewStickyRegistry(),
+					},
+				},
+			}
+		}
+		tc := testcluster.StartTestCluster(t, numServers,
+			base.TestClusterArgs{
+				ReplicationMode:     base.ReplicationManual,
+				ReusableListenerReg: lisReg,
+				ServerArgsPerNode:   stickyServerArgs,
+			})
+		defer tc.Stopper().Stop(ctx)
+		store := tc.GetFirstStoreFromServer(t, 0)
+		const laggingStore = 1
+
+		prefix := roachpb.Key("a")
+		tc.SplitRangeOrFatal(t, prefix)
+		tc.AddVotersOrFatal(t, prefix, tc.Targets(1, 2)...)
+		rng, _ := randutil.NewTestRand()
+		writeKeys := func(n int) {
+			for i := 0; i < n; i++ {
+				key := append(prefix[:len(prefix):len(prefix)], fmt.Sprintf("%06d", i)...)
+				value := randutil.RandBytes(rng, valueSize)
+				_, pErr := kv.SendWrapped(ctx, store.TestSender(), putArgs(key, value))
+				require.NoError(t, pErr.GoError())
+			}
+		}
+		writeKeys(numKeys)
+		repl := store.LookupReplica(roachpb.RKey(prefix))
+		testutils.SucceedsSoon(t, func() error {
+			r := tc.GetFirstStoreFromServer(t, laggingStore).LookupReplica(roachpb.RKey(prefix))
+			if r == nil || r.GetLastIndex() < repl.GetLastIndex() {
+				return errors.New("replica has not caught up")
+			}
+			return nil
+		})
+
+		// Stop the server of the lagging replica, change the data of the range
+		// and truncate the log, so that the replica needs a snapshot when its
+		// server is restarted. Most of the keys are changed if the data diverges,
+		// beyond the maximum divergence of a delta snapshot.
+		tc.StopServer(laggingStore)
+		if diverged {
+			writeKeys(numKeys)
+		} else {
+			writeKeys(numKeys / 100)
+		}
+		index := repl.GetLastIndex()
+		truncArgs := truncateLogArgs(index+1, repl.GetRangeID())
+		_, pErr := kv.SendWrapped(ctx, store.TestSender(), truncArgs)
+		require.NoError(t, pErr.GoError())
+		waitForTruncationForTesting(t, repl, index)
+
+		require.NoError(t, tc.RestartServer(laggingStore))
+		lagging := tc.GetFirstStoreFromServer(t, laggingStore)
+		testutils.SucceedsSoon(t, func() error {
+			r := lagging.LookupReplica(roachpb.RKey(prefix))
+			if r == nil || r.GetLastIndex() < index {
+				return errors.New("replica has not caught up")
+			}
+			return nil
+		})
+
+		rcvdBytes := lagging.Metrics().RangeSnapshotRecoveryRcvdBytes.Count()
+		if diverged {
+			require.GreaterOrEqual(t, rcvdBytes, int64(dataSize))
+		} else {
+			require.Positive(t, rcvdBytes)
+			require.Less(t, rcvdBytes, int64(dataSize/4))
+		}
+
+		desc := repl.Desc()
+		resp, pErr := kv.SendWrapped(ctx, store.TestSender(), checkConsistencyArgs(desc))
+		require.NoError(t, pErr.GoError())
+		ccResp := resp.(*kvpb.CheckConsistencyResponse)
+		require.Len(t, ccResp.Result, 1)
+		require.Equal(t, kvpb.CheckConsistencyResponse_RANGE_CONSISTENT, ccResp.Result[0].Status,
+			"%+v", ccResp.Result[0])
+	})
+}
+
 func waitForTruncationForTesting(t *testing.T, r *kvserver.Replica, compacted kvpb.RaftIndex) {
 	testutils.SucceedsSoon(t, func() error {
 		// Flush the engine to advance durability, which triggers truncation.
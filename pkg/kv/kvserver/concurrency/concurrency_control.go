@@ -275,6 +275,11 @@ type TransactionManager interface {
 	// transaction either directly or indirectly. The method is used to perform
 	// deadlock detection. See txnWaitQueue for more.
 	GetDependents(uuid.UUID) []uuid.UUID
+
+	// GetWaitingTxns describes what the transactions waiting on the specified
+	// transaction, either directly or indirectly, are waiting on. The method is
+	// used to describe the members of the deadlocks once they are detected.
+	GetWaitingTxns(uuid.UUID) []kvpb.WaitingTxn
 }
 
 // RangeStateListener is concerned with observing updates to the concurrency
@@ -1025,6 +1030,10 @@ type txnWaitQueue interface {
 	// deadlock detection.
 	GetDependents(uuid.UUID) []uuid.UUID
 
+	// GetWaitingTxns describes what the transactions waiting on the specified
+	// transaction, either directly or indirectly, are waiting on.
+	GetWaitingTxns(uuid.UUID) []kvpb.WaitingTxn
+
 	// MaybeWaitForPush checks whether there is a queue already established for
 	// transaction being pushed by the provided request. If not, or if the
 	// PushTxn request isn't queueable, the method returns immediately. If there
@@ -167,6 +167,7 @@ type Config struct {
 	IntentResolver IntentResolver
 	// Metrics.
 	TxnWaitMetrics     *txnwait.Metrics
+	TxnWaitDeadlocks   *txnwait.DeadlockHistory
 	SlowLatchGauge     *metric.Gauge
 	LatchWaitDurations metric.IHistogram
 	// Configs + Knobs.
@@ -219,7 +220,9 @@ func NewManager(cfg Config) Manager {
 			DB:        cfg.DB,
 			Clock:     cfg.Clock,
 			Stopper:   cfg.Stopper,
+			Settings:  cfg.Settings,
 			Metrics:   cfg.TxnWaitMetrics,
+			Deadlocks: cfg.TxnWaitDeadlocks,
 			Knobs:     cfg.TxnWaitKnobs,
 		}),
 	}
@@ -599,6 +602,11 @@ func (m *managerImpl) GetDependents(txnID uuid.UUID) []uuid.UUID {
 	return m.twq.GetDependents(txnID)
 }
 
+// GetWaitingTxns implements the TransactionManager interface.
+func (m *managerImpl) GetWaitingTxns(txnID uuid.UUID) []kvpb.WaitingTxn {
+	return m.twq.GetWaitingTxns(txnID)
+}
+
 // OnRangeDescUpdated implements the RangeStateListener interface.
 func (m *managerImpl) OnRangeDescUpdated(desc *roachpb.RangeDescriptor) {
 	m.twq.OnRangeDescUpdated(desc)
@@ -767,7 +767,11 @@ func (c *cluster) makeConfig() concurrency.Config {
 
 // PushTransaction implements the concurrency.IntentResolver interface.
 func (c *cluster) PushTransaction(
-	ctx context.Context, pushee *enginepb.TxnMeta, h kvpb.Header, pushType kvpb.PushTxnType,
+	ctx context.Context,
+	pushee *enginepb.TxnMeta,
+	_ roachpb.Key,
+	h kvpb.Header,
+	pushType kvpb.PushTxnType,
 ) (*roachpb.Transaction, bool, *kvpb.Error) {
 	pusheeRecord, err := c.getTxnRecord(pushee.ID)
 	if err != nil {
@@ -97,9 +97,10 @@ type IntentResolver interface {
 	// PushTransaction pushes the provided transaction. The method will push the
 	// provided pushee transaction immediately, if possible. Otherwise, it will
 	// block until the pushee transaction is finalized or eventually can be
-	// pushed successfully.
+	// pushed successfully. The key is the key of the lock, or of the lock
+	// wait-queue, on which the pusher is blocked.
 	PushTransaction(
-		context.Context, *enginepb.TxnMeta, kvpb.Header, kvpb.PushTxnType,
+		context.Context, *enginepb.TxnMeta, roachpb.Key, kvpb.Header, kvpb.PushTxnType,
 	) (*roachpb.Transaction, bool, *Error)
 
 	// ResolveIntent synchronously resolves the provided intent.
@@ -470,7 +471,7 @@ func (w *lockTableWaiterImpl) pushLockTxn(
 		log.VEventf(ctx, 2, "pushing txn %s to abort", ws.txn.Short())
 	}
 
-	pusheeTxn, _, err := w.ir.PushTransaction(ctx, ws.txn, h, pushType)
+	pusheeTxn, _, err := w.ir.PushTransaction(ctx, ws.txn, ws.key, h, pushType)
 	if err != nil {
 		// If pushing with an Error WaitPolicy and the push fails, then the lock
 		// holder is still active. Transform the error into a WriteIntentError.
@@ -652,7 +653,7 @@ func (w *lockTableWaiterImpl) pushRequestTxn(
 	pushType := kvpb.PUSH_ABORT
 	log.VEventf(ctx, 3, "pushing txn %s to detect request deadlock", ws.txn.Short())
 
-	_, _, err := w.ir.PushTransaction(ctx, ws.txn, h, pushType)
+	_, _, err := w.ir.PushTransaction(ctx, ws.txn, ws.key, h, pushType)
 	if err != nil {
 		return err
 	}
@@ -40,7 +40,11 @@ type mockIntentResolver struct {
 
 // mockIntentResolver implements the IntentResolver interface.
 func (m *mockIntentResolver) PushTransaction(
-	ctx context.Context, txn *enginepb.TxnMeta, h kvpb.Header, pushType kvpb.PushTxnType,
+	ctx context.Context,
+	txn *enginepb.TxnMeta,
+	_ roachpb.Key,
+	h kvpb.Header,
+	pushType kvpb.PushTxnType,
 ) (*roachpb.Transaction, bool, *Error) {
 	return m.pushTxn(ctx, txn, h, pushType)
 }
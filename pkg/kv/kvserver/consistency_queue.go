@@ -51,6 +51,19 @@ var skipConsitencyQueueForExternalBytes = settings.RegisterBoolSetting(
 	true,
 )
 
+// consistencyCheckAutoRepairEnabled controls whether the consistency queue
+// repairs an inconsistency instead of terminating the nodes holding the
+// inconsistent replicas.
+var consistencyCheckAutoRepairEnabled = settings.RegisterBoolSetting(
+	settings.SystemOnly,
+	"server.consistency_check.auto_repair.enabled",
+	"if enabled, replicas found to be inconsistent with a majority of the "+
+		"replicas of their range (which includes the leaseholder) are removed "+
+		"and rebuilt from a snapshot of a healthy replica, instead of "+
+		"terminating the nodes holding them",
+	false,
+)
+
 // consistencyCheckRateBurstFactor we use this to set the burst parameter on the
 // quotapool.RateLimiter. It seems overkill to provide a user setting for this,
 // so we use a factor to scale the burst setting based on the rate defined above.
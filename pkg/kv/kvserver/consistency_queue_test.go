@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/storage/fs"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
@@ -426,6 +427,102 @@ func TestCheckConsistencyInconsistent(t *testing.T) {
 	require.NotEmpty(t, b)
 }
 
+// TestCheckConsistencyInconsistentRepair verifies that, with auto repair
+// enabled, a replica which diverged from a consistent quorum is removed and
+// rebuilt from a healthy replica instead of terminating its node, and that the
+// repair is recorded in the system event log.
+func TestCheckConsistencyInconsistentRepair(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// Test expects simple MVCC value encoding.
+	storage.DisableMetamorphicSimpleValueEncoding(t)
+
+	ctx := context.Background()
+	testKnobs := kvserver.StoreTestingKnobs{DisableConsistencyQueue: true}
+	testKnobs.ConsistencyTestingKnobs.OnBadChecksumFatal = func(s roachpb.StoreIdent) {
+		t.Errorf("unexpected termination of %s", s)
+	}
+	tc := testcluster.StartTestCluster(t, 3, base.TestClusterArgs{
+		ReplicationMode: base.ReplicationAuto,
+		ServerArgs: base.TestServerArgs{
+			Knobs: base.TestingKnobs{Store: &testKnobs},
+		},
+	})
+	defer tc.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+	sqlDB.Exec(t, `SET CLUSTER SETTING server.consistency_check.auto_repair.enabled = true`)
+
+	key := tc.ScratchRange(t)
+	require.NoError(t, tc.WaitForFullReplication())
+	desc := tc.LookupRangeOrFatal(t, key)
+	// The replicas agreeing with the leaseholder must form the majority.
+	tc.TransferRangeLeaseOrFatal(t, desc, tc.Target(0))
+
+	store := tc.GetFirstStoreFromServer(t, 0)
+	for _, k := range []roachpb.Key{key, key.Next()} {
+		_, pErr := kv.SendWrapped(ctx, store.DB().NonTransactionalSender(), putArgs(k, []byte("v")))
+		require.NoError(t, pErr.GoError())
+	}
+	runConsistencyCheck := func() *kvpb.CheckConsistencyResponse {
+		req := kvpb.CheckConsistencyRequest{
+			RequestHeader: kvpb.RequestHeader{Key: key, EndKey: key.PrefixEnd()},
+			Mode:          kvpb.ChecksumMode_CHECK_VIA_QUEUE,
+		}
+		resp, pErr := kv.SendWrapped(ctx, store.DB().NonTransactionalSender(), &req)
+		require.NoError(t, pErr.GoError())
+		return resp.(*kvpb.CheckConsistencyResponse)
+	}
+	resp := runConsistencyCheck()
+	require.Len(t, resp.Result, 1)
+	require.Equal(t, kvpb.CheckConsistencyResponse_RANGE_CONSISTENT, resp.Result[0].Status)
+
+	// Put an inconsistent key to s2, and have s1 and s3 still agree.
+	s2 := tc.GetFirstStoreFromServer(t, 1)
+	oldReplica, ok := desc.GetReplicaDescriptor(s2.StoreID())
+	require.True(t, ok)
+	var val roachpb.Value
+	val.SetInt(42)
+	_, err := storage.MVCCPut(ctx, s2.TODOEngine(), key.Next().Next(), tc.Server(0).Clock().Now(),
+		val, storage.MVCCWriteOptions{})
+	require.NoError(t, err)
+
+	resp = runConsistencyCheck()
+	require.Len(t, resp.Result, 1)
+	require.Equal(t, kvpb.CheckConsistencyResponse_RANGE_INCONSISTENT, resp.Result[0].Status)
+	require.Equal(t, int64(1), store.Metrics().ConsistencyQueueRepairs.Count())
+
+	// The replica on s2 is rebuilt with a new replica ID from a snapshot of a
+	// healthy replica, after which the range is consistent again.
+	testutils.SucceedsSoon(t, func() error {
+		desc := tc.LookupRangeOrFatal(t, key)
+		newReplica, ok := desc.GetReplicaDescriptor(s2.StoreID())
+		if !ok || newReplica.ReplicaID == oldReplica.ReplicaID || !newReplica.IsVoterNewConfig() {
+			return errors.Errorf("replica on s2 not rebuilt yet: %s", desc)
+		}
+		if len(desc.Replicas().VoterDescriptors()) != 3 {
+			return errors.Errorf("range not fully replicated yet: %s", desc)
+		}
+		return nil
+	})
+	testutils.SucceedsSoon(t, func() error {
+		resp := runConsistencyCheck()
+		if status := resp.Result[0].Status; status != kvpb.CheckConsistencyResponse_RANGE_CONSISTENT {
+			return errors.Errorf("range is %s", status)
+		}
+		return nil
+	})
+
+	// The repair is recorded in the system event log.
+	sqlDB.CheckQueryResultsRetry(t, fmt.Sprintf(`
+SELECT count(*)
+  FROM system.eventlog
+ WHERE "eventType" = 'range_inconsistency_repaired'
+       AND (info::JSONB->>'RangeID')::INT = %d
+       AND info::JSONB->'RemovedReplicas'->>0 = '%s'`, desc.RangeID, oldReplica),
+		[][]string{{"1"}})
+}
+
 // TestConsistencyQueueRecomputeStats is an end-to-end test of the mechanism CockroachDB
 // employs to adjust incorrect MVCCStats ("incorrect" meaning not an inconsistency of
 // these stats between replicas, but a delta between persisted stats and those one
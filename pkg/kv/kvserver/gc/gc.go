@@ -136,6 +136,18 @@ var ClearRangeMinKeys = settings.RegisterIntSetting(settings.SystemOnly,
 	settings.NonNegativeInt,
 )
 
+// KeepVersionsMinAge is the minimum duration for which a version must have
+// been shadowed by a newer version before it can be garbage collected ahead of
+// the GC TTL, per the KeepVersions GC policy of its zone.
+var KeepVersionsMinAge = settings.RegisterDurationSetting(
+	settings.SystemOnly,
+	"kv.gc.keep_versions.min_age",
+	"the minimum duration for which a version must have been overwritten before it can be "+
+		"garbage collected per the gc.keep_versions zone configuration, ahead of gc.ttlseconds",
+	time.Minute,
+	settings.NonNegativeDuration,
+)
+
 // AdmissionPriority determines the admission priority level to use for MVCC GC
 // work.
 var AdmissionPriority = settings.RegisterEnumSetting(
@@ -240,7 +252,9 @@ type Info struct {
 	// ResolveTotal is the total number of attempted intent resolutions in
 	// this cycle.
 	ResolveTotal int
-	// Threshold is the computed expiration timestamp. Equal to `Now - GCTTL`.
+	// Threshold is the computed expiration timestamp. Equal to `Now - GCTTL`,
+	// unless it was advanced further to garbage collect versions per the
+	// KeepVersions GC policy.
 	Threshold hlc.Timestamp
 	// AffectedVersionsKeyBytes is the number of (fully encoded) bytes deleted from keys in the storage engine.
 	// Note that this does not account for compression that the storage engine uses to store data on disk. Real
@@ -263,6 +277,10 @@ type Info struct {
 	ClearRangeSpanOperations int
 	// ClearRangeSpanFailures number of ClearRange requests GC failed to perform.
 	ClearRangeSpanFailures int
+	// KeepVersionsAffected is the number of versions above the GC threshold
+	// which were garbage collected because they exceeded the number of versions
+	// kept by the GC policy.
+	KeepVersionsAffected int
 }
 
 // RunOptions contains collection of limits that GC run applies when performing operations
@@ -302,6 +320,16 @@ type RunOptions struct {
 	// to issuing point delete requests for the oldest batch to free up memory
 	// before resuming further iteration.
 	MaxPendingKeysSize int64
+	// KeepVersions, if positive, is the number of most recent versions of each
+	// key which are retained above the GC threshold. Older versions are garbage
+	// collected if the version which shadows them is at or below
+	// KeepVersionsThreshold, after the GC threshold is advanced to the
+	// shadowing version.
+	KeepVersions int
+	// KeepVersionsThreshold bounds the GC threshold set to collect versions per
+	// KeepVersions. It must be at or below the closed timestamp and below
+	// protected timestamps.
+	KeepVersionsThreshold hlc.Timestamp
 }
 
 // CleanupIntentsFunc synchronously resolves the supplied intents
@@ -368,6 +396,13 @@ func Run(
 	if err != nil {
 		return Info{}, err
 	}
+	if options.KeepVersions > 0 && !fastPath {
+		if err := processKeepVersions(ctx, desc, snap, newThreshold, options.KeepVersions,
+			options.KeepVersionsThreshold, populateBatcherOptions(options).batchGCKeysBytesThreshold,
+			gcer, &info); err != nil {
+			return Info{}, err
+		}
+	}
 
 	// From now on, all keys processed are range-local and inline (zero timestamp).
 
@@ -520,6 +555,131 @@ func processReplicatedKeyRange(
 		})
 }
 
+// processKeepVersions garbage collects the versions of user keys above the GC
+// threshold which are shadowed by at least keepVersions newer versions. A
+// version is only collected if the version immediately newer than it is at or
+// below keepThreshold. Versions whose shadowing version is at or below the GC
+// threshold are left to processReplicatedKeyRange, and keys with an intent are
+// skipped entirely.
+//
+// Before each batch of versions is garbage collected, the GC threshold of the
+// range is advanced to the newest of the shadowing versions in the batch, so
+// that reads which could observe the collected versions are rejected with a
+// BatchTimestampBeforeGCError instead of returning incorrect results. The GC
+// threshold can't be advanced in the same request that garbage collects keys.
+//
+// The logic iterates all versions of all keys in the range from newest to
+// oldest, which allows counting the versions newer than the current one.
+func processKeepVersions(
+	ctx context.Context,
+	desc *roachpb.RangeDescriptor,
+	snap storage.Reader,
+	threshold hlc.Timestamp,
+	keepVersions int,
+	keepThreshold hlc.Timestamp,
+	batchGCKeysBytesThreshold int64,
+	gcer GCer,
+	info *Info,
+) error {
+	if keepVersions <= 0 || keepThreshold.LessEq(threshold) {
+		return nil
+	}
+	span := desc.KeySpan().AsRawSpanWithNoLocals()
+	iter, err := snap.NewMVCCIterator(ctx, storage.MVCCKeyAndIntentsIterKind, storage.IterOptions{
+		LowerBound:   span.Key,
+		UpperBound:   span.EndKey,
+		KeyTypes:     storage.IterKeyTypePointsOnly,
+		ReadCategory: fs.MVCCGCReadCategory,
+	})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	var batch []kvpb.GCRequest_GCKey
+	var alloc bufalloc.ByteAllocator
+	var batchKeyBytes int64
+	// batchThreshold is the GC threshold which the range must have before the
+	// versions in batch are garbage collected.
+	batchThreshold := threshold
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if info.Threshold.Less(batchThreshold) {
+			if err := gcer.SetGCThreshold(ctx, Threshold{Key: batchThreshold}); err != nil {
+				return errors.Wrap(err, "failed to set GC threshold")
+			}
+			info.Threshold = batchThreshold
+		}
+		if err := gcer.GC(ctx, batch, nil, nil); err != nil {
+			return err
+		}
+		batch, alloc, batchKeyBytes = nil, nil, 0
+		return nil
+	}
+
+	var curKey roachpb.Key
+	// versions is the number of versions of curKey seen so far, and prevTS the
+	// timestamp of the oldest of them, which shadows the current version.
+	var versions int
+	var prevTS hlc.Timestamp
+	// skipKey is set if the versions of curKey are not considered, and
+	// collecting if the current version is already covered by a GC key.
+	var skipKey, collecting bool
+	for iter.SeekGE(storage.MakeMVCCMetadataKey(span.Key)); ; iter.Next() {
+		if ok, err := iter.Valid(); err != nil {
+			return err
+		} else if !ok {
+			break
+		}
+		unsafeKey := iter.UnsafeKey()
+		if !unsafeKey.Key.Equal(curKey) {
+			curKey = append(curKey[:0], unsafeKey.Key...)
+			versions, prevTS = 0, hlc.Timestamp{}
+			skipKey, collecting = false, false
+		}
+		if skipKey {
+			continue
+		}
+		if !unsafeKey.IsValue() {
+			// The key has an intent, which may be aborted, or is an inline value.
+			skipKey = true
+			continue
+		}
+		if !collecting && versions >= keepVersions && prevTS.LessEq(keepThreshold) &&
+			threshold.Less(prevTS) {
+			// The current version, and all older versions of the key, can be
+			// collected. A GC key removes all versions at or below its timestamp.
+			if batchKeyBytes >= batchGCKeysBytesThreshold {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			var key roachpb.Key
+			alloc, key = alloc.Copy(unsafeKey.Key, 0)
+			batch = append(batch, kvpb.GCRequest_GCKey{Key: key, Timestamp: unsafeKey.Timestamp})
+			batchThreshold.Forward(prevTS)
+			info.NumKeysAffected++
+			collecting = true
+		}
+		if collecting {
+			keySize := int64(unsafeKey.EncodedSize())
+			batchKeyBytes += keySize
+			// Versions shadowed at or below the GC threshold are accounted for by
+			// processReplicatedKeyRange.
+			if threshold.Less(prevTS) {
+				info.AffectedVersionsKeyBytes += keySize
+				info.AffectedVersionsValBytes += int64(iter.ValueLen())
+				info.KeepVersionsAffected++
+			}
+		}
+		versions++
+		prevTS = unsafeKey.Timestamp
+	}
+	return flush()
+}
+
 // processReplicatedLocks identifies extant replicated locks which have been
 // around longer than the supplied lockAgeThreshold and resolves them.
 func processReplicatedLocks(
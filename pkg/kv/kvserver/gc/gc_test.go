@@ -51,7 +51,13 @@ func TestCalculateThreshold(t *testing.T) {
 }
 
 type collectingGCer struct {
-	keys [][]kvpb.GCRequest_GCKey
+	keys       [][]kvpb.GCRequest_GCKey
+	thresholds []hlc.Timestamp
+}
+
+func (c *collectingGCer) SetGCThreshold(_ context.Context, t Threshold) error {
+	c.thresholds = append(c.thresholds, t.Key)
+	return nil
 }
 
 func (c *collectingGCer) GC(
@@ -2352,3 +2358,116 @@ func keySeq(keyHistory ...[]gcData) (res []gcData) {
 	}
 	return res
 }
+
+// TestKeepVersions tests that processKeepVersions collects the versions of keys
+// above the GC threshold which are shadowed by at least keepVersions newer
+// versions, as long as the version shadowing them is at or below the keep
+// threshold, and that it advances the GC threshold past the collected versions
+// first.
+func TestKeepVersions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	eng := storage.NewDefaultInMemForTesting()
+	defer eng.Close()
+
+	tablePrefix := keys.SystemSQLCodec.TablePrefix(42)
+	desc := roachpb.RangeDescriptor{
+		StartKey: roachpb.RKey(tablePrefix),
+		EndKey:   roachpb.RKey(tablePrefix.PrefixEnd()),
+	}
+	key := func(s string) roachpb.Key {
+		return append(tablePrefix[:len(tablePrefix):len(tablePrefix)], s...)
+	}
+	ts := func(wallTime int64) hlc.Timestamp {
+		return hlc.Timestamp{WallTime: wallTime}
+	}
+	put := func(k roachpb.Key, wallTimes ...int64) {
+		for _, wt := range wallTimes {
+			require.NoError(t, eng.PutMVCC(storage.MVCCKey{Key: k, Timestamp: ts(wt)},
+				storage.MVCCValue{Value: roachpb.MakeValueFromString("v")}))
+		}
+	}
+	// Collected from the third newest version, which is shadowed at 13.
+	put(key("a"), 10, 11, 12, 13, 14, 15)
+	// Not enough versions.
+	put(key("b"), 10, 11)
+	// The third newest version is shadowed above the keep threshold, and the
+	// older one at the GC threshold.
+	put(key("c"), 3, 5, 20, 21)
+	// Keys with intents are skipped.
+	put(key("d"), 10, 11, 12)
+	txn := roachpb.MakeTransaction("txn", key("d"), isolation.Serializable,
+		roachpb.NormalUserPriority, ts(16), 1000, 0, 0, false /* omitInRangefeeds */)
+	_, err := storage.MVCCPut(ctx, eng, key("d"), ts(16), roachpb.MakeValueFromString("v"),
+		storage.MVCCWriteOptions{Txn: &txn})
+	require.NoError(t, err)
+	// The oldest version is shadowed at the GC threshold.
+	put(key("e"), 4, 5, 7, 8)
+
+	snap := eng.NewSnapshot()
+	defer snap.Close()
+
+	for _, tc := range []struct {
+		keepVersions  int
+		keepThreshold hlc.Timestamp
+		exp           []kvpb.GCRequest_GCKey
+		expThreshold  hlc.Timestamp
+		expAffected   int
+	}{
+		{
+			keepVersions:  2,
+			keepThreshold: ts(13),
+			exp: []kvpb.GCRequest_GCKey{
+				{Key: key("a"), Timestamp: ts(12)},
+				{Key: key("e"), Timestamp: ts(5)},
+			},
+			expThreshold: ts(13),
+			// 12, 11 and 10 of "a", and 5 of "e". Version 4 of "e" is left to
+			// processReplicatedKeyRange.
+			expAffected: 4,
+		},
+		{
+			keepVersions:  1,
+			keepThreshold: ts(100),
+			exp: []kvpb.GCRequest_GCKey{
+				{Key: key("a"), Timestamp: ts(14)},
+				{Key: key("b"), Timestamp: ts(10)},
+				{Key: key("c"), Timestamp: ts(20)},
+				{Key: key("e"), Timestamp: ts(7)},
+			},
+			expThreshold: ts(21),
+			// 14 through 10 of "a", 10 of "b", 20 and 5 of "c", and 7 and 5 of "e".
+			expAffected: 10,
+		},
+		{
+			keepVersions:  2,
+			keepThreshold: ts(5),
+		},
+		{
+			keepVersions:  0,
+			keepThreshold: ts(100),
+		},
+	} {
+		t.Run(fmt.Sprintf("keep=%d,threshold=%s", tc.keepVersions, tc.keepThreshold), func(t *testing.T) {
+			var gcer collectingGCer
+			info := Info{Threshold: ts(5)}
+			require.NoError(t, processKeepVersions(ctx, &desc, snap, ts(5), tc.keepVersions,
+				tc.keepThreshold, 1<<20, &gcer, &info))
+			var gcKeys []kvpb.GCRequest_GCKey
+			for _, batch := range gcer.keys {
+				gcKeys = append(gcKeys, batch...)
+			}
+			require.Equal(t, tc.exp, gcKeys)
+			require.Equal(t, tc.expAffected, info.KeepVersionsAffected)
+			if tc.expThreshold.IsEmpty() {
+				require.Empty(t, gcer.thresholds)
+				require.Equal(t, ts(5), info.Threshold)
+			} else {
+				require.Equal(t, []hlc.Timestamp{tc.expThreshold}, gcer.thresholds)
+				require.Equal(t, tc.expThreshold, info.Threshold)
+			}
+		})
+	}
+}
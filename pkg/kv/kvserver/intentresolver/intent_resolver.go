@@ -354,16 +354,21 @@ func updateIntentTxnStatus(
 // push type and request header. It returns the transaction proto corresponding
 // to the pushed transaction, and in the case of an ABORTED transaction, a bool
 // indicating whether the abort was ambiguous (see
-// PushTxnResponse.AmbiguousAbort).
+// PushTxnResponse.AmbiguousAbort). lockKey is the key of the lock on which the
+// pusher is blocked, if any (see PushTxnRequest.LockKey).
 //
 // NB: ambiguousAbort may be false with nodes <24.1.
 func (ir *IntentResolver) PushTransaction(
-	ctx context.Context, pushTxn *enginepb.TxnMeta, h kvpb.Header, pushType kvpb.PushTxnType,
+	ctx context.Context,
+	pushTxn *enginepb.TxnMeta,
+	lockKey roachpb.Key,
+	h kvpb.Header,
+	pushType kvpb.PushTxnType,
 ) (_ *roachpb.Transaction, ambiguousAbort bool, _ *kvpb.Error) {
 	pushTxns := make(map[uuid.UUID]*enginepb.TxnMeta, 1)
 	pushTxns[pushTxn.ID] = pushTxn
-	pushedTxns, ambiguousAbort, pErr := ir.MaybePushTransactions(
-		ctx, pushTxns, h, pushType, false /* skipIfInFlight */)
+	pushedTxns, ambiguousAbort, pErr := ir.maybePushTransactions(
+		ctx, pushTxns, lockKey, h, pushType, false /* skipIfInFlight */)
 	if pErr != nil {
 		return nil, false, pErr
 	}
@@ -409,6 +414,19 @@ func (ir *IntentResolver) MaybePushTransactions(
 	h kvpb.Header,
 	pushType kvpb.PushTxnType,
 	skipIfInFlight bool,
+) (_ map[uuid.UUID]*roachpb.Transaction, anyAmbiguousAbort bool, _ *kvpb.Error) {
+	return ir.maybePushTransactions(ctx, pushTxns, nil /* lockKey */, h, pushType, skipIfInFlight)
+}
+
+// maybePushTransactions is like MaybePushTransactions, but also sets the
+// LockKey of the PushTxnRequests it sends.
+func (ir *IntentResolver) maybePushTransactions(
+	ctx context.Context,
+	pushTxns map[uuid.UUID]*enginepb.TxnMeta,
+	lockKey roachpb.Key,
+	h kvpb.Header,
+	pushType kvpb.PushTxnType,
+	skipIfInFlight bool,
 ) (_ map[uuid.UUID]*roachpb.Transaction, anyAmbiguousAbort bool, _ *kvpb.Error) {
 	// Decide which transactions to push and which to ignore because
 	// of other in-flight requests. For those transactions that we
@@ -461,6 +479,7 @@ func (ir *IntentResolver) MaybePushTransactions(
 			PusheeTxn: *pushTxn,
 			PushTo:    pushTo,
 			PushType:  pushType,
+			LockKey:   lockKey,
 		})
 	}
 	err := ir.db.Run(ctx, b)
@@ -6,7 +6,10 @@
 package kvserver
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
 
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rditer"
@@ -15,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -46,6 +50,16 @@ type kvBatchSnapshotStrategy struct {
 	scratch   *SSTSnapshotStorageScratch
 	st        *cluster.Settings
 	clusterID uuid.UUID
+
+	// deltaReader is a snapshot of the user keys held by the receiver of a delta
+	// snapshot, which the data of the retained spans is read from. Only used on
+	// the receiver side.
+	deltaReader storage.Reader
+	// retainedSpans are the spans of user keys, in order, which the receiver of
+	// a delta snapshot already holds. The sender skips them and the receiver
+	// writes them from its deltaReader. retainedBytes is their size.
+	retainedSpans []roachpb.Span
+	retainedBytes int64
 }
 
 // Receive implements the snapshotStrategy interface.
@@ -219,6 +233,9 @@ func (kvSS *kvBatchSnapshotStrategy) Receive(
 					}
 				}
 
+				if err := kvSS.copyRetainedSpans(ctx, msstw, ek.Key); err != nil {
+					return noSnap, err
+				}
 				if err := kvSS.readOneToBatch(ctx, ek, header.SharedReplicate, batchReader, msstw); err != nil {
 					return noSnap, err
 				}
@@ -268,6 +285,9 @@ func (kvSS *kvBatchSnapshotStrategy) Receive(
 			// we must still construct SSTs with range deletion tombstones to remove
 			// the data.
 			timingTag.start("sst")
+			if err := kvSS.copyRetainedSpans(ctx, msstw, nil /* key */); err != nil {
+				return noSnap, err
+			}
 			dataSize, err := msstw.Finish(ctx)
 			sstSize := msstw.sstSize
 			if err != nil {
@@ -312,6 +332,9 @@ func (kvSS *kvBatchSnapshotStrategy) Receive(
 			timingTag.stop("totalTime")
 
 			kvSS.status = redact.Sprintf("local ssts: %d, shared ssts: %d, external ssts: %d", len(kvSS.scratch.SSTs()), len(sharedSSTs), len(externalSSTs))
+			if kvSS.deltaReader != nil {
+				kvSS.status = redact.Sprintf("%s, retained: %s", kvSS.status, humanizeutil.IBytes(kvSS.retainedBytes))
+			}
 			return inSnap, nil
 		}
 	}
@@ -518,11 +541,32 @@ func (kvSS *kvBatchSnapshotStrategy) Send(
 		}
 		return err
 	}
+	if len(kvSS.retainedSpans) > 0 {
+		if sharedReplicate || externalReplicate {
+			return 0, errors.AssertionFailedf("delta snapshots cannot be sent with shared or external files")
+		}
+		replicatedFilter = rditer.ReplicatedSpansExcludeUser
+	}
 	err := rditer.IterateReplicaKeySpans(ctx, snap.State.Desc, snap.EngineSnap, true, /* replicatedOnly */
 		replicatedFilter, iterateRKSpansVisitor)
 	if err != nil {
 		return 0, err
 	}
+	if len(kvSS.retainedSpans) > 0 {
+		// Stream the user keys outside of the spans retained by the receiver.
+		userSpan := snap.State.Desc.KeySpan().AsRawSpanWithNoLocals()
+		start := userSpan.Key
+		for _, span := range append(kvSS.retainedSpans, roachpb.Span{Key: userSpan.EndKey}) {
+			if start.Compare(span.Key) < 0 {
+				err := iterateSnapshotSpan(ctx, snap.EngineSnap, roachpb.Span{Key: start, EndKey: span.Key},
+					storage.IterKeyTypePointsAndRanges, iterateRKSpansVisitor)
+				if err != nil {
+					return 0, err
+				}
+			}
+			start = span.EndKey
+		}
+	}
 
 	var valBuf []byte
 	if sharedReplicate || externalReplicate {
@@ -656,6 +700,10 @@ func (kvSS *kvBatchSnapshotStrategy) Send(
 	log.Eventf(ctx, "finished sending snapshot batches, sent a total of %d bytes", bytesSent)
 
 	kvSS.status = redact.Sprintf("kvs=%d rangeKVs=%d sharedSSTs=%d, externalSSTs=%d", kvs, rangeKVs, sharedSSTCount, externalSSTCount)
+	if len(kvSS.retainedSpans) > 0 {
+		kvSS.status = redact.Sprintf("%s, retainedSpans=%d (%s)",
+			kvSS.status, len(kvSS.retainedSpans), humanizeutil.IBytes(kvSS.retainedBytes))
+	}
 	return bytesSent, nil
 }
 
@@ -692,6 +740,10 @@ func (kvSS *kvBatchSnapshotStrategy) Status() redact.RedactableString {
 
 // Close implements the snapshotStrategy interface.
 func (kvSS *kvBatchSnapshotStrategy) Close(ctx context.Context) {
+	if kvSS.deltaReader != nil {
+		kvSS.deltaReader.Close()
+		kvSS.deltaReader = nil
+	}
 	if kvSS.scratch != nil {
 		// A failure to clean up the storage is benign except that it will leak
 		// disk space (which is reclaimed on node restart). It is unexpected
@@ -701,3 +753,320 @@ func (kvSS *kvBatchSnapshotStrategy) Close(ctx context.Context) {
 		}
 	}
 }
+
+// Delta snapshots
+//
+// A replica which falls behind the truncation point of the Raft log needs a
+// snapshot, even though it usually holds most of the range's data already (for
+// example after a brief network partition). When both the sender and the
+// receiver agree to it, a Raft snapshot is sent as a delta of the receiver's
+// data, in the following exchange:
+//
+// 1. The sender sets delta_requested in the snapshot header.
+// 2. The receiver accepts the snapshot with delta_accepted set, if it holds an
+//    initialized replica of the range with the same bounds.
+// 3. The sender splits the user keys of the snapshot into spans of about
+//    kv.snapshot_delta.span_size and sends the fingerprint of each of them.
+// 4. The receiver fingerprints its own data over the same spans and responds
+//    with the spans that match, which it retains. If the spans that differ
+//    exceed kv.snapshot_delta.max_divergence of the range, it retains none of
+//    them and the snapshot is a full one.
+// 5. The sender streams the snapshot without the retained spans, whose data the
+//    receiver writes to the snapshot's SSTs from its own engine.
+//
+// The snapshot applied by the receiver is thus the same as a full one, and only
+// the network transfer of the snapshot is saved. The local key spans of the
+// range are always streamed in full, they are small.
+
+// sendDeltaSpans fingerprints the user keys of the snapshot, sends the
+// fingerprints to the receiver of a delta snapshot and records the spans the
+// receiver retains, which Send skips.
+func (kvSS *kvBatchSnapshotStrategy) sendDeltaSpans(
+	ctx context.Context, stream outgoingSnapshotStream, snap *OutgoingSnapshot, spanSize int64,
+) error {
+	userSpan := snap.State.Desc.KeySpan().AsRawSpanWithNoLocals()
+	spans, err := deltaSnapshotSpans(ctx, snap.EngineSnap, userSpan, spanSize)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&kvserverpb.SnapshotRequest{DeltaSpans: spans}); err != nil {
+		return err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	switch resp.Status {
+	case kvserverpb.SnapshotResponse_ERROR:
+		return errors.Wrap(maybeHandleDeprecatedSnapErr(resp.Error()), "remote couldn't compare delta spans")
+	case kvserverpb.SnapshotResponse_ACCEPTED:
+	default:
+		return errors.Errorf("server sent an invalid status while comparing delta spans: %s", resp.Status)
+	}
+	prev := int32(-1)
+	for _, idx := range resp.RetainedDeltaSpans {
+		if idx <= prev || int(idx) >= len(spans) {
+			return errors.Errorf("server retained an invalid delta span %d of %d", idx, len(spans))
+		}
+		prev = idx
+		kvSS.retainedSpans = append(kvSS.retainedSpans, spans[idx].Span)
+		kvSS.retainedBytes += spans[idx].Size
+	}
+	log.Eventf(ctx, "receiver retained %d of %d delta spans (%s)",
+		len(kvSS.retainedSpans), len(spans), humanizeutil.IBytes(kvSS.retainedBytes))
+	return nil
+}
+
+// receiveDeltaSpans receives the fingerprints of the user keys of a delta
+// snapshot, compares them to those of the receiver's data and responds with
+// the spans which match, whose data is retained from the deltaReader rather
+// than streamed.
+func (kvSS *kvBatchSnapshotStrategy) receiveDeltaSpans(
+	ctx context.Context,
+	s *Store,
+	stream incomingSnapshotStream,
+	header kvserverpb.SnapshotRequest_Header,
+) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if req.Header != nil || req.KVBatch != nil || req.Final ||
+		len(req.SharedTables) > 0 || len(req.ExternalTables) > 0 {
+		err := errors.New("client error: expected delta spans")
+		return sendSnapshotError(ctx, s, stream, err)
+	}
+	userSpan := header.State.Desc.KeySpan().AsRawSpanWithNoLocals()
+	start := userSpan.Key
+	for _, ds := range req.DeltaSpans {
+		if !ds.Span.Valid() || ds.Span.Key.Compare(start) < 0 || !userSpan.ContainsKey(ds.Span.Key) ||
+			ds.Span.EndKey.Compare(userSpan.EndKey) > 0 {
+			err := errors.Newf("client error: invalid delta span %s", ds.Span)
+			return sendSnapshotError(ctx, s, stream, err)
+		}
+		start = ds.Span.EndKey
+	}
+
+	maxDivergence := DeltaSnapshotMaxDivergence.Get(&s.cfg.Settings.SV)
+	retained, err := retainedDeltaSpans(ctx, kvSS.deltaReader, req.DeltaSpans, maxDivergence)
+	if err != nil {
+		return sendSnapshotError(ctx, s, stream, err)
+	}
+	for _, idx := range retained {
+		kvSS.retainedSpans = append(kvSS.retainedSpans, req.DeltaSpans[idx].Span)
+	}
+	log.Eventf(ctx, "retaining %d of %d delta spans", len(retained), len(req.DeltaSpans))
+	return stream.Send(&kvserverpb.SnapshotResponse{
+		Status:             kvserverpb.SnapshotResponse_ACCEPTED,
+		RetainedDeltaSpans: retained,
+	})
+}
+
+// copyRetainedSpans writes the data of the retained spans which end at or
+// before the key given to the snapshot's SSTs, reading it from the deltaReader,
+// so that the SSTs are written in key order as the streamed keys are
+// interleaved with the retained ones. A nil key writes all remaining spans.
+func (kvSS *kvBatchSnapshotStrategy) copyRetainedSpans(
+	ctx context.Context, msstw *multiSSTWriter, key roachpb.Key,
+) error {
+	for len(kvSS.retainedSpans) > 0 && (key == nil || kvSS.retainedSpans[0].EndKey.Compare(key) <= 0) {
+		span := kvSS.retainedSpans[0]
+		kvSS.retainedSpans = kvSS.retainedSpans[1:]
+		err := iterateSnapshotSpan(ctx, kvSS.deltaReader, span, storage.IterKeyTypePointsAndRanges,
+			func(iter storage.EngineIterator, _ roachpb.Span) error {
+				var err error
+				for ok := true; ok && err == nil; ok, err = iter.NextEngineKey() {
+					hasPoint, hasRange := iter.HasPointAndRange()
+					if hasRange && iter.RangeKeyChanged() {
+						bounds, err := iter.EngineRangeBounds()
+						if err != nil {
+							return err
+						}
+						// The multiSSTWriter retains range keys until they are
+						// fragmented, so they must not alias the iterator's memory.
+						bounds = bounds.Clone()
+						for _, rkv := range iter.EngineRangeKeys() {
+							kvSS.retainedBytes += int64(len(rkv.Version) + len(rkv.Value))
+							if err := msstw.PutRangeKey(ctx, bounds.Key, bounds.EndKey,
+								bytes.Clone(rkv.Version), bytes.Clone(rkv.Value)); err != nil {
+								return errors.Wrapf(err, "writing retained range key for raft snapshot")
+							}
+						}
+					}
+					if hasPoint {
+						ek, err := iter.UnsafeEngineKey()
+						if err != nil {
+							return err
+						}
+						v, err := iter.UnsafeValue()
+						if err != nil {
+							return err
+						}
+						kvSS.retainedBytes += int64(len(ek.Key) + len(ek.Version) + len(v))
+						if err := msstw.Put(ctx, ek, v); err != nil {
+							return errors.Wrapf(err, "writing retained key for raft snapshot")
+						}
+					}
+				}
+				return err
+			})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// iterateSnapshotSpan calls the visitor with an iterator positioned at the
+// first key of the span given, if any, and bounded to the span. Range keys are
+// truncated to the span.
+func iterateSnapshotSpan(
+	ctx context.Context,
+	reader storage.Reader,
+	span roachpb.Span,
+	keyTypes storage.IterKeyType,
+	visitor func(storage.EngineIterator, roachpb.Span) error,
+) error {
+	iter, err := reader.NewEngineIterator(ctx, storage.IterOptions{
+		KeyTypes:   keyTypes,
+		LowerBound: span.Key,
+		UpperBound: span.EndKey,
+	})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	ok, err := iter.SeekEngineKeyGE(storage.EngineKey{Key: span.Key})
+	if err == nil && ok {
+		err = visitor(iter, span)
+	}
+	return err
+}
+
+// deltaSnapshotSpans splits the span of user keys given into spans of about
+// spanSize bytes each, in order, and fingerprints each of them. Spans are only
+// split between user keys, never between the versions of a key.
+func deltaSnapshotSpans(
+	ctx context.Context, reader storage.Reader, span roachpb.Span, spanSize int64,
+) ([]kvserverpb.SnapshotRequest_DeltaSpan, error) {
+	var splits []roachpb.Key
+	err := iterateSnapshotSpan(ctx, reader, span, storage.IterKeyTypePointsOnly,
+		func(iter storage.EngineIterator, _ roachpb.Span) error {
+			var size int64
+			var prevKey roachpb.Key
+			var err error
+			for ok := true; ok && err == nil; ok, err = iter.NextEngineKey() {
+				key, err := iter.UnsafeEngineKey()
+				if err != nil {
+					return err
+				}
+				if size >= spanSize && !key.Key.Equal(prevKey) {
+					splits = append(splits, key.Key.Clone())
+					size = 0
+				}
+				v, err := iter.UnsafeValue()
+				if err != nil {
+					return err
+				}
+				size += int64(len(key.Key) + len(key.Version) + len(v))
+				prevKey = append(prevKey[:0], key.Key...)
+			}
+			return err
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	spans := make([]kvserverpb.SnapshotRequest_DeltaSpan, 0, len(splits)+1)
+	start := span.Key
+	for _, end := range append(splits, span.EndKey) {
+		ds := kvserverpb.SnapshotRequest_DeltaSpan{Span: roachpb.Span{Key: start, EndKey: end}}
+		if ds.Fingerprint, ds.Size, err = fingerprintSnapshotSpan(ctx, reader, ds.Span); err != nil {
+			return nil, err
+		}
+		spans = append(spans, ds)
+		start = end
+	}
+	return spans, nil
+}
+
+// retainedDeltaSpans returns the indexes of the delta spans whose fingerprints
+// match the data of the reader given. No span is retained if the spans which
+// do not match exceed the fraction maxDivergence of the size of all spans.
+func retainedDeltaSpans(
+	ctx context.Context,
+	reader storage.Reader,
+	spans []kvserverpb.SnapshotRequest_DeltaSpan,
+	maxDivergence float64,
+) ([]int32, error) {
+	var retained []int32
+	var size, divergedSize int64
+	for i, ds := range spans {
+		fingerprint, _, err := fingerprintSnapshotSpan(ctx, reader, ds.Span)
+		if err != nil {
+			return nil, err
+		}
+		size += ds.Size
+		if bytes.Equal(fingerprint, ds.Fingerprint) {
+			retained = append(retained, int32(i))
+		} else {
+			divergedSize += ds.Size
+		}
+	}
+	if float64(divergedSize) > maxDivergence*float64(size) {
+		return nil, nil
+	}
+	return retained, nil
+}
+
+// fingerprintSnapshotSpan returns a fingerprint of the point and range keys in
+// the span given, along with their values, and their size in bytes. Range keys
+// are truncated to the span, so that the fingerprints of adjacent spans are
+// independent of each other.
+func fingerprintSnapshotSpan(
+	ctx context.Context, reader storage.Reader, span roachpb.Span,
+) (fingerprint []byte, size int64, _ error) {
+	hasher := sha256.New()
+	var intBuf [8]byte
+	write := func(kind byte, parts ...[]byte) {
+		_, _ = hasher.Write([]byte{kind})
+		for _, b := range parts {
+			binary.LittleEndian.PutUint64(intBuf[:], uint64(len(b)))
+			_, _ = hasher.Write(intBuf[:])
+			_, _ = hasher.Write(b)
+			size += int64(len(b))
+		}
+	}
+	err := iterateSnapshotSpan(ctx, reader, span, storage.IterKeyTypePointsAndRanges,
+		func(iter storage.EngineIterator, _ roachpb.Span) error {
+			var err error
+			for ok := true; ok && err == nil; ok, err = iter.NextEngineKey() {
+				hasPoint, hasRange := iter.HasPointAndRange()
+				if hasRange && iter.RangeKeyChanged() {
+					bounds, err := iter.EngineRangeBounds()
+					if err != nil {
+						return err
+					}
+					for _, rkv := range iter.EngineRangeKeys() {
+						write('r', bounds.Key, bounds.EndKey, rkv.Version, rkv.Value)
+					}
+				}
+				if hasPoint {
+					key, err := iter.UnsafeEngineKey()
+					if err != nil {
+						return err
+					}
+					v, err := iter.UnsafeValue()
+					if err != nil {
+						return err
+					}
+					write('p', key.Key, key.Version, v)
+				}
+			}
+			return err
+		})
+	if err != nil {
+		return nil, 0, err
+	}
+	return hasher.Sum(nil), size, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package kvserver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rditer"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+// TestDeltaSnapshot tests that the fingerprints of a delta snapshot identify
+// the spans of user keys that differ between the sender and the receiver, and
+// that the SSTs built by the receiver from the spans it retains and those
+// streamed by the sender contain the sender's data.
+func TestDeltaSnapshot(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	desc := roachpb.RangeDescriptor{
+		RangeID:  1,
+		StartKey: roachpb.RKey("a"),
+		EndKey:   roachpb.RKey("b"),
+	}
+	userSpan := desc.KeySpan().AsRawSpanWithNoLocals()
+
+	sender := storage.NewDefaultInMemForTesting()
+	defer sender.Close()
+	receiver := storage.NewDefaultInMemForTesting()
+	defer receiver.Close()
+
+	key := func(i int) roachpb.Key {
+		return roachpb.Key(fmt.Sprintf("a%04d", i))
+	}
+	for _, eng := range []storage.Engine{sender, receiver} {
+		for i := 0; i < 100; i++ {
+			for ts := int64(1); ts <= 2; ts++ {
+				require.NoError(t, eng.PutMVCC(
+					storage.MVCCKey{Key: key(i), Timestamp: hlc.Timestamp{WallTime: ts}},
+					storage.MVCCValue{Value: roachpb.MakeValueFromString(fmt.Sprintf("v%d", ts))}))
+			}
+		}
+		// A range key covering many of the spans of the snapshot.
+		require.NoError(t, eng.PutMVCCRangeKey(storage.MVCCRangeKey{
+			StartKey: key(10), EndKey: key(60), Timestamp: hlc.Timestamp{WallTime: 3},
+		}, storage.MVCCValue{}))
+	}
+	// The receiver is missing the latest write.
+	require.NoError(t, sender.PutMVCC(
+		storage.MVCCKey{Key: key(42), Timestamp: hlc.Timestamp{WallTime: 4}},
+		storage.MVCCValue{Value: roachpb.MakeValueFromString("v4")}))
+
+	spans, err := deltaSnapshotSpans(ctx, sender, userSpan, 200)
+	require.NoError(t, err)
+	require.Greater(t, len(spans), 10)
+	start := userSpan.Key
+	diverged := -1
+	for i, ds := range spans {
+		require.Equal(t, start, ds.Span.Key)
+		start = ds.Span.EndKey
+		if ds.Span.ContainsKey(key(42)) {
+			diverged = i
+		}
+	}
+	require.Equal(t, userSpan.EndKey, start)
+
+	// Every span but the one containing the missing write is retained, unless
+	// the divergence is beyond the maximum.
+	retained, err := retainedDeltaSpans(ctx, receiver, spans, 0.5)
+	require.NoError(t, err)
+	require.Len(t, retained, len(spans)-1)
+	require.NotContains(t, retained, int32(diverged))
+	none, err := retainedDeltaSpans(ctx, receiver, spans, 0)
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	// Build the snapshot's SSTs from the keys of the spans which are not
+	// retained, as the sender would stream them, and from the receiver's data.
+	cleanup, eng := newOnDiskEngine(ctx, t)
+	defer cleanup()
+	defer eng.Close()
+	scratch := NewSSTSnapshotStorage(eng, rate.NewLimiter(rate.Inf, 0)).NewScratchSpace(
+		desc.RangeID, uuid.MakeV4())
+	keySpans := rditer.MakeReplicatedKeySpans(&desc)
+	msstw, err := newMultiSSTWriter(ctx, cluster.MakeTestingClusterSettings(), scratch,
+		keySpans[:len(keySpans)-1], keySpans[len(keySpans)-1], 0, false, true /* rangeKeysInOrder */)
+	require.NoError(t, err)
+	defer msstw.Close()
+
+	kvSS := &kvBatchSnapshotStrategy{deltaReader: receiver}
+	streamed := make(map[int]bool, len(spans))
+	for i := range spans {
+		streamed[i] = true
+	}
+	for _, idx := range retained {
+		kvSS.retainedSpans = append(kvSS.retainedSpans, spans[idx].Span)
+		delete(streamed, int(idx))
+	}
+	for i := range spans {
+		if !streamed[i] {
+			continue
+		}
+		require.NoError(t, iterateSnapshotSpan(ctx, sender, spans[i].Span, storage.IterKeyTypePointsAndRanges,
+			func(iter storage.EngineIterator, _ roachpb.Span) error {
+				var err error
+				for ok := true; ok && err == nil; ok, err = iter.NextEngineKey() {
+					hasPoint, hasRange := iter.HasPointAndRange()
+					if hasRange && iter.RangeKeyChanged() {
+						bounds, err := iter.EngineRangeBounds()
+						require.NoError(t, err)
+						require.NoError(t, kvSS.copyRetainedSpans(ctx, msstw, bounds.Key))
+						for _, rkv := range iter.EngineRangeKeys() {
+							require.NoError(t, msstw.PutRangeKey(ctx, bounds.Key.Clone(), bounds.EndKey.Clone(),
+								append([]byte(nil), rkv.Version...), append([]byte(nil), rkv.Value...)))
+						}
+					}
+					if hasPoint {
+						ek, err := iter.UnsafeEngineKey()
+						require.NoError(t, err)
+						v, err := iter.UnsafeValue()
+						require.NoError(t, err)
+						require.NoError(t, kvSS.copyRetainedSpans(ctx, msstw, ek.Key))
+						require.NoError(t, msstw.Put(ctx, ek, v))
+					}
+				}
+				return err
+			}))
+	}
+	require.NoError(t, kvSS.copyRetainedSpans(ctx, msstw, nil /* key */))
+	require.Empty(t, kvSS.retainedSpans)
+	_, err = msstw.Finish(ctx)
+	require.NoError(t, err)
+	msstw.Close()
+
+	require.NoError(t, eng.IngestLocalFiles(ctx, scratch.SSTs()))
+	expected, _, err := fingerprintSnapshotSpan(ctx, sender, userSpan)
+	require.NoError(t, err)
+	actual, _, err := fingerprintSnapshotSpan(ctx, eng, userSpan)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
@@ -38,6 +38,46 @@ type Store interface {
 	// range ID, or nil if no replica was found. This is used for testing.
 	// Returns a syncutil.RWMutex rather than ReplicaMutex to avoid import cycles.
 	GetReplicaMutexForTesting(rangeID roachpb.RangeID) *syncutil.RWMutex
+
+	// NewSpanConfigChangePlanner returns a SpanConfigChangePlanner making
+	// decisions against the store's view of the cluster.
+	NewSpanConfigChangePlanner() (SpanConfigChangePlanner, error)
+}
+
+// SpanConfigChangePlanner plans the replication changes the allocator would
+// make to ranges for them to conform to span configs, without making them.
+type SpanConfigChangePlanner interface {
+	// PlanSpanConfigChange returns the replication changes the allocator would
+	// make to the range with the given descriptor, leaseholder and size for it
+	// to conform to the given span config. The range does not need to have a
+	// replica on the store. The changes planned for the ranges passed to
+	// previous calls are assumed to have been made, so that the planned
+	// changes take into account the load they move between stores.
+	PlanSpanConfigChange(
+		ctx context.Context,
+		desc roachpb.RangeDescriptor,
+		leaseholder roachpb.StoreID,
+		logicalBytes int64,
+		conf roachpb.SpanConfig,
+	) (SpanConfigChangePlan, error)
+}
+
+// SpanConfigChangePlan describes the replication changes the allocator would
+// make to a range for it to conform to a span config.
+type SpanConfigChangePlan struct {
+	// AddedReplicas are the stores which would receive a new replica of the
+	// range, and with it a snapshot of its data.
+	AddedReplicas []roachpb.ReplicationTarget
+	// RemovedReplicas are the stores which would lose their replica of the
+	// range.
+	RemovedReplicas []roachpb.ReplicationTarget
+	// LeaseTarget is the store the lease would be transferred to, or zero if
+	// the lease would stay in place.
+	LeaseTarget roachpb.StoreID
+	// Unsatisfiable, if set, is the reason the allocator could not make the
+	// range conform to the span config. The changes above are the ones it
+	// would make before getting stuck.
+	Unsatisfiable error
 }
 
 // UnsupportedStoresIterator is a StoresIterator that only returns "unsupported"
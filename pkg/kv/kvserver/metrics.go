@@ -1935,6 +1935,12 @@ The messages are dropped to help these replicas to recover from I/O overload.`,
 		Measurement: "Replicas",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaConsistencyQueueRepairs = metric.Metadata{
+		Name:        "queue.consistency.repairs",
+		Help:        "Number of inconsistent replicas removed by the consistency checker queue to be rebuilt from a healthy replica",
+		Measurement: "Replicas",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaConsistencyQueueFailures = metric.Metadata{
 		Name:        "queue.consistency.process.failure",
 		Help:        "Number of replicas which failed processing in the consistency checker queue",
@@ -2458,6 +2464,13 @@ Note that the measurement does not include the duration for replicating the eval
 		Unit:        metric.Unit_COUNT,
 	}
 
+	metaAppendOnlyCount = metric.Metadata{
+		Name:        "kv.loadsplitter.appendonly",
+		Help:        "Load-based splitter skipped a split of the range containing the highest keys of an index whose load-based splits repeatedly moved load to that range.",
+		Measurement: "Occurrences",
+		Unit:        metric.Unit_COUNT,
+	}
+
 	metaSplitEstimatedStats = metric.Metadata{
 		Name:        "kv.split.estimated_stats",
 		Help:        "Number of splits that computed estimated MVCC stats.",
@@ -2901,6 +2914,7 @@ type StoreMetrics struct {
 	RaftSnapshotQueueProcessingNanos          *metric.Counter
 	ConsistencyQueueSuccesses                 *metric.Counter
 	ConsistencyQueueFailures                  *metric.Counter
+	ConsistencyQueueRepairs                   *metric.Counter
 	ConsistencyQueuePending                   *metric.Gauge
 	ConsistencyQueueProcessingNanos           *metric.Counter
 	LeaseQueueSuccesses                       *metric.Counter
@@ -3320,6 +3334,7 @@ func newStoreMetrics(histogramWindow time.Duration) *StoreMetrics {
 		LoadSplitterMetrics: &split.LoadSplitterMetrics{
 			PopularKeyCount: metric.NewCounter(metaPopularKeyCount),
 			NoSplitKeyCount: metric.NewCounter(metaNoSplitKeyCount),
+			AppendOnlyCount: metric.NewCounter(metaAppendOnlyCount),
 		},
 
 		// Replica metrics.
@@ -3672,6 +3687,7 @@ func newStoreMetrics(histogramWindow time.Duration) *StoreMetrics {
 		RaftSnapshotQueueProcessingNanos:          metric.NewCounter(metaRaftSnapshotQueueProcessingNanos),
 		ConsistencyQueueSuccesses:                 metric.NewCounter(metaConsistencyQueueSuccesses),
 		ConsistencyQueueFailures:                  metric.NewCounter(metaConsistencyQueueFailures),
+		ConsistencyQueueRepairs:                   metric.NewCounter(metaConsistencyQueueRepairs),
 		ConsistencyQueuePending:                   metric.NewGauge(metaConsistencyQueuePending),
 		ConsistencyQueueProcessingNanos:           metric.NewCounter(metaConsistencyQueueProcessingNanos),
 		LeaseQueueSuccesses:                       metric.NewCounter(metaLeaseQueueSuccesses),
@@ -62,6 +62,11 @@ const (
 	// prevent continually spinning on intents that belong to active transactions,
 	// which can't be cleaned up.
 	mvccGCQueueIntentCooldownDuration = 2 * time.Hour
+	// mvccGCQueueKeepVersionsCooldownDuration is the duration to wait between
+	// MVCC GC attempts of the same range when triggered solely by keys having
+	// more versions than the GC policy keeps. The versions may be too recent to
+	// be collected, so this prevents spinning on hot keys.
+	mvccGCQueueKeepVersionsCooldownDuration = 10 * time.Minute
 	// intentAgeNormalization is the average age of outstanding intents
 	// which amount to a score of "1" added to total replica priority.
 	intentAgeNormalization = 8 * time.Hour
@@ -72,6 +77,10 @@ const (
 	mvccGCKeyScoreNoCooldownThreshold = 2
 	mvccGCIntentScoreThreshold        = 1
 	mvccGCDropRangeKeyScoreThreshold  = 1
+	// mvccGCKeepVersionsScoreThreshold is the factor by which the average number
+	// of versions per key must exceed the number of versions kept by the GC
+	// policy for the range to be queued.
+	mvccGCKeepVersionsScoreThreshold = 2
 
 	probablyLargeAbortSpanSysCountThreshold = 10000
 	largeAbortSpanBytesThreshold            = 16 * (1 << 20) // 16mb
@@ -225,6 +234,7 @@ type mvccGCQueueScore struct {
 	DeadFraction        float64
 	ValuesScalableScore float64
 	IntentScore         float64
+	VersionsScore       float64
 	FuzzFactor          float64
 	FinalScore          float64
 	ShouldQueue         bool
@@ -251,6 +261,9 @@ func (r mvccGCQueueScore) String() string {
 		r.ShouldQueue, r.FinalScore, r.FuzzFactor, r.FinalScore/r.FuzzFactor, r.ValuesScalableScore,
 		r.DeadFraction, r.IntentScore, lastGC, humanizeutil.IBytes(r.GCBytes),
 		humanizeutil.IBytes(r.GCByteAge), humanizeutil.IBytes(r.ExpMinGCByteAgeReduction))
+	if r.VersionsScore != 0 {
+		s += fmt.Sprintf("\nversions score: %.2f", r.VersionsScore)
+	}
 	if !r.Hint.IsEmpty() {
 		s += fmt.Sprintf("\nhint: %s", r.Hint)
 	}
@@ -289,7 +302,8 @@ func (mgcq *mvccGCQueue) shouldQueue(
 		return false, 0
 	}
 
-	r := makeMVCCGCQueueScore(ctx, repl, gcTimestamp, lastGC, conf.TTL(), canAdvanceGCThreshold)
+	r := makeMVCCGCQueueScore(ctx, repl, gcTimestamp, lastGC, conf.TTL(), conf.GCPolicy.KeepVersions,
+		canAdvanceGCThreshold)
 	log.VEventf(ctx, 2, "shouldQueue=%t: %s", r.ShouldQueue, r)
 	return r.ShouldQueue, r.FinalScore
 }
@@ -300,6 +314,7 @@ func makeMVCCGCQueueScore(
 	now hlc.Timestamp,
 	lastGC hlc.Timestamp,
 	gcTTL time.Duration,
+	keepVersions int32,
 	canAdvanceGCThreshold bool,
 ) mvccGCQueueScore {
 	repl.mu.RLock()
@@ -318,9 +333,25 @@ func makeMVCCGCQueueScore(
 		ctx, int64(repl.RangeID), now, ms, gcTTL, lastGC, canAdvanceGCThreshold,
 		hint, gc.TxnCleanupThreshold.Get(&repl.ClusterSettings().SV),
 	)
+	maybeQueueForKeepVersions(&r, ms, keepVersions)
 	return r
 }
 
+// maybeQueueForKeepVersions queues a replica whose keys have, on average, many
+// more versions than are kept by the GC policy. Such versions are collected
+// ahead of the GC TTL, so their GC byte age does not account for them.
+func maybeQueueForKeepVersions(r *mvccGCQueueScore, ms enginepb.MVCCStats, keepVersions int32) {
+	if keepVersions <= 0 || ms.KeyCount <= 0 {
+		return
+	}
+	r.VersionsScore = float64(ms.ValCount) / float64(int64(keepVersions)*ms.KeyCount)
+	if !r.ShouldQueue && r.FuzzFactor*r.VersionsScore > mvccGCKeepVersionsScoreThreshold &&
+		(r.LastGC == 0 || r.LastGC >= mvccGCQueueKeepVersionsCooldownDuration) {
+		r.ShouldQueue = true
+		r.FinalScore += r.FuzzFactor * r.VersionsScore
+	}
+}
+
 // makeMVCCGCQueueScoreImpl is used to compute when to trigger the MVCC GC
 // Queue. It's important that we don't queue a replica before a relevant amount
 // of data is actually deletable, or the queue might run in a tight loop. To
@@ -714,11 +745,20 @@ func (mgcq *mvccGCQueue) process(
 		lastGC = hlc.Timestamp{}
 		log.VErrEventf(ctx, 2, "failed to fetch last processed time: %v", err)
 	}
-	r := makeMVCCGCQueueScore(ctx, repl, gcTimestamp, lastGC, conf.TTL(), canAdvanceGCThreshold)
+	r := makeMVCCGCQueueScore(ctx, repl, gcTimestamp, lastGC, conf.TTL(), conf.GCPolicy.KeepVersions,
+		canAdvanceGCThreshold)
 	log.VEventf(ctx, 2, "processing replica %s with score %s", repl.String(), r)
+	// Versions collected per the KeepVersions GC policy advance the GC threshold
+	// past newThreshold, up to keepVersionsThreshold.
+	var keepVersionsThreshold hlc.Timestamp
+	pendingThreshold := newThreshold
+	if conf.GCPolicy.KeepVersions > 0 {
+		keepVersionsThreshold = makeKeepVersionsThreshold(ctx, repl, repl.store.Clock().Now())
+		pendingThreshold.Forward(keepVersionsThreshold)
+	}
 	// Synchronize the new GC threshold decision with concurrent
 	// AdminVerifyProtectedTimestamp requests.
-	if err := repl.markPendingGC(cacheTimestamp, newThreshold); err != nil {
+	if err := repl.markPendingGC(cacheTimestamp, pendingThreshold); err != nil {
 		log.VEventf(ctx, 1, "not gc'ing replica %v due to pending protection: %v", repl, err)
 		return false, nil
 	}
@@ -750,6 +790,8 @@ func (mgcq *mvccGCQueue) process(
 			MaxTxnsPerIntentCleanupBatch:         intentresolver.MaxTxnsPerIntentCleanupBatch,
 			IntentCleanupBatchTimeout:            mvccGCQueueIntentBatchTimeout,
 			ClearRangeMinKeys:                    clearRangeMinKeys,
+			KeepVersions:                         int(conf.GCPolicy.KeepVersions),
+			KeepVersionsThreshold:                keepVersionsThreshold,
 		},
 		conf.TTL(),
 		&replicaGCer{
@@ -799,8 +841,10 @@ func (mgcq *mvccGCQueue) process(
 		return false, err
 	}
 
+	// NB: the number of versions kept is not considered, as versions that are
+	// too recent to be collected are expected to remain after GC.
 	scoreAfter := makeMVCCGCQueueScore(
-		ctx, repl, repl.store.Clock().Now(), lastGC, conf.TTL(), canAdvanceGCThreshold)
+		ctx, repl, repl.store.Clock().Now(), lastGC, conf.TTL(), 0 /* keepVersions */, canAdvanceGCThreshold)
 	log.VEventf(ctx, 2, "MVCC stats after GC: %+v", repl.GetMVCCStats())
 	log.VEventf(ctx, 2, "GC score after GC: %s", scoreAfter)
 	updateStoreMetricsWithGCInfo(mgcq.store.metrics, info)
@@ -835,6 +879,22 @@ func (mgcq *mvccGCQueue) process(
 	return true, nil
 }
 
+// makeKeepVersionsThreshold returns the timestamp up to which the GC threshold
+// may be advanced to collect versions per the KeepVersions GC policy. It is at
+// or below the closed timestamp, so that no writes are rejected, and below the
+// earliest protected timestamp which applies to the range.
+func makeKeepVersionsThreshold(ctx context.Context, repl *Replica, now hlc.Timestamp) hlc.Timestamp {
+	threshold := now.Add(-gc.KeepVersionsMinAge.Get(&repl.ClusterSettings().SV).Nanoseconds(), 0)
+	threshold.Backward(repl.GetCurrentClosedTimestamp(ctx))
+	repl.mu.RLock()
+	earliestProtectionTimestamp := repl.mu.cachedProtectedTS.earliestProtectionTimestamp
+	repl.mu.RUnlock()
+	if !earliestProtectionTimestamp.IsEmpty() {
+		threshold.Backward(earliestProtectionTimestamp.Prev())
+	}
+	return threshold
+}
+
 func updateStoreMetricsWithGCInfo(metrics *StoreMetrics, info gc.Info) {
 	metrics.GCNumKeysAffected.Inc(int64(info.NumKeysAffected))
 	metrics.GCNumRangeKeysAffected.Inc(int64(info.NumRangeKeysAffected))
@@ -78,6 +78,7 @@ func newBufferedRegistration(
 	withDiff bool,
 	withFiltering bool,
 	withOmitRemote bool,
+	filter *EventFilter,
 	bufferSz int,
 	blockWhenFull bool,
 	metrics *Metrics,
@@ -92,6 +93,7 @@ func newBufferedRegistration(
 			withDiff:               withDiff,
 			withFiltering:          withFiltering,
 			withOmitRemote:         withOmitRemote,
+			filter:                 filter,
 			removeRegFromProcessor: removeRegFromProcessor,
 		},
 		metrics:       metrics,
@@ -308,7 +310,7 @@ func (br *bufferedRegistration) maybeRunCatchUpScan(ctx context.Context) error {
 		br.metrics.RangeFeedCatchUpScanNanos.Inc(timeutil.Since(start).Nanoseconds())
 	}()
 
-	return catchUpIter.CatchUpScan(ctx, br.stream.SendUnbuffered, br.withDiff, br.withFiltering, br.withOmitRemote)
+	return catchUpIter.CatchUpScan(ctx, br.stream.SendUnbuffered, br.withDiff, br.withFiltering, br.withOmitRemote, br.filter)
 }
 
 // Wait for this registration to completely process its internal
@@ -141,6 +141,7 @@ func (i *CatchUpIterator) CatchUpScan(
 	withDiff bool,
 	withFiltering bool,
 	withOmitRemote bool,
+	filter *EventFilter,
 ) error {
 	var a bufalloc.ByteAllocator
 	// MVCCIterator will encounter historical values for each key in
@@ -153,8 +154,12 @@ func (i *CatchUpIterator) CatchUpScan(
 	outputEvents := func() error {
 		for i := len(reorderBuf) - 1; i >= 0; i-- {
 			e := reorderBuf[i]
-			if err := outputFn(&e); err != nil {
-				return err
+			// The column predicates of the filter are evaluated once the previous
+			// value of the event is known.
+			if filter.matchesValue(e.Val, withDiff) {
+				if err := outputFn(&e); err != nil {
+					return err
+				}
 			}
 			reorderBuf[i] = kvpb.RangeFeedEvent{} // Drop references to values to allow GC
 		}
@@ -343,8 +348,10 @@ func (i *CatchUpIterator) CatchUpScan(
 			// of the conditions is met: 1) the value has the OmitInRangefeeds flag,
 			// and this iterator has opted into filtering; 2) the value is from a
 			// remote cluster (non zero originID), and the iterator has opted into
-			// omitting remote values.
-			if (mvccVal.OmitInRangefeeds && withFiltering) || (mvccVal.OriginID != 0 && withOmitRemote) {
+			// omitting remote values; 3) the key or the origin of the value do not
+			// match the filter.
+			if (mvccVal.OmitInRangefeeds && withFiltering) || (mvccVal.OriginID != 0 && withOmitRemote) ||
+				!filter.matchesKeyAndOrigin(key, mvccVal.OriginID) {
 				i.Next()
 				continue
 			}
@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/storageutils"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -160,7 +161,7 @@ func TestCatchupScan(t *testing.T) {
 				require.NoError(t, iter.CatchUpScan(ctx, func(e *kvpb.RangeFeedEvent) error {
 					events = append(events, *e.Val)
 					return nil
-				}, withDiff, withFiltering, false /* withOmitRemote */))
+				}, withDiff, withFiltering, false /* withOmitRemote */, nil /* filter */))
 				if !(withFiltering && omitInRangefeeds) {
 					require.Equal(t, 7, len(events))
 				} else {
@@ -238,7 +239,7 @@ func TestCatchupScanOriginID(t *testing.T) {
 		require.NoError(t, iter.CatchUpScan(ctx, func(e *kvpb.RangeFeedEvent) error {
 			events = append(events, *e.Val)
 			return nil
-		}, false /* withDiff */, false /* withFiltering */, omitRemote))
+		}, false /* withDiff */, false /* withFiltering */, omitRemote, nil /* filter */))
 		if omitRemote {
 			require.Equal(t, 1, len(events))
 		} else {
@@ -251,6 +252,154 @@ func TestCatchupScanOriginID(t *testing.T) {
 	})
 }
 
+// TestCatchupScanFilter tests that the catch-up scan applies the event filter
+// of the registration: versions of keys and origins which do not match are not
+// emitted but still serve as the previous value of the next version with diff,
+// and column predicates are evaluated on both the value and the previous value
+// of an event with diff.
+func TestCatchupScanFilter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	eng := storage.NewDefaultInMemForTesting(storage.If(smallEngineBlocks, storage.BlockSize(1)))
+	defer eng.Close()
+
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+	row1Fam0, row1Fam1, row2Fam0 := makeRowKey(1, 0), makeRowKey(1, 1), makeRowKey(2, 0)
+	put := func(key roachpb.Key, wallTime int64, originID uint32, v roachpb.Value) {
+		_, err := storage.MVCCPut(ctx, eng, key, ts(wallTime), v, storage.MVCCWriteOptions{OriginID: originID})
+		require.NoError(t, err)
+	}
+	// The versions at ts 1 precede the start of the catch-up scan, and are only
+	// read as previous values. The version of row 1 at ts 2 is written by a
+	// remote origin.
+	put(row1Fam0, 1, 0, makeTupleValue(2, 1))
+	put(row1Fam0, 2, 1, makeTupleValue(2, 2))
+	put(row1Fam0, 3, 0, makeTupleValue(2, 3))
+	put(row1Fam1, 2, 0, makeTupleValue(3, 5))
+	put(row2Fam0, 2, 0, makeTupleValue(2, 1))
+	_, _, err := storage.MVCCDelete(ctx, eng, row2Fam0, ts(3), storage.MVCCWriteOptions{})
+	require.NoError(t, err)
+	put(row2Fam0, 4, 0, makeTupleValue(2, 2))
+
+	col2Is2 := kvpb.RangeFeedFilter_ColumnPredicate{
+		ColumnID: 2,
+		Op:       kvpb.RangeFeedFilter_ColumnPredicate_EQ,
+		Value:    encoding.EncodeIntValue(nil, encoding.NoColumnID, 2),
+	}
+	type event struct {
+		key      roachpb.Key
+		wallTime int64
+		// prev is the previous value of the event, which is only checked with
+		// diff.
+		prev roachpb.Value
+	}
+	for _, tc := range []struct {
+		name     string
+		filter   kvpb.RangeFeedFilter
+		withDiff bool
+		exp      []event
+	}{
+		{
+			name:   "family",
+			filter: kvpb.RangeFeedFilter{ColumnFamilies: []uint32{0}},
+			exp: []event{
+				{key: row1Fam0, wallTime: 2},
+				{key: row1Fam0, wallTime: 3},
+				{key: row2Fam0, wallTime: 2},
+				{key: row2Fam0, wallTime: 3},
+				{key: row2Fam0, wallTime: 4},
+			},
+		},
+		{
+			name:     "origin with diff",
+			filter:   kvpb.RangeFeedFilter{OriginIDs: []uint32{0}},
+			withDiff: true,
+			exp: []event{
+				// The version written by the remote origin is the previous value.
+				{key: row1Fam0, wallTime: 3, prev: makeTupleValue(2, 2)},
+				{key: row1Fam1, wallTime: 2},
+				{key: row2Fam0, wallTime: 2},
+				{key: row2Fam0, wallTime: 3, prev: makeTupleValue(2, 1)},
+				{key: row2Fam0, wallTime: 4},
+			},
+		},
+		{
+			name: "predicate",
+			filter: kvpb.RangeFeedFilter{
+				ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{col2Is2},
+			},
+			exp: []event{
+				{key: row1Fam0, wallTime: 2},
+				// The predicate is on another family.
+				{key: row1Fam1, wallTime: 2},
+				// The previous value of a deletion is unknown without diff.
+				{key: row2Fam0, wallTime: 3},
+				{key: row2Fam0, wallTime: 4},
+			},
+		},
+		{
+			name: "predicate with diff",
+			filter: kvpb.RangeFeedFilter{
+				ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{col2Is2},
+			},
+			withDiff: true,
+			exp: []event{
+				{key: row1Fam0, wallTime: 2, prev: makeTupleValue(2, 1)},
+				// The previous value matches.
+				{key: row1Fam0, wallTime: 3, prev: makeTupleValue(2, 2)},
+				{key: row1Fam1, wallTime: 2},
+				{key: row2Fam0, wallTime: 4},
+			},
+		},
+		{
+			name: "predicate and origin with diff",
+			filter: kvpb.RangeFeedFilter{
+				OriginIDs:        []uint32{0},
+				ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{col2Is2},
+			},
+			withDiff: true,
+			exp: []event{
+				// The previous value, written by the remote origin, matches.
+				{key: row1Fam0, wallTime: 3, prev: makeTupleValue(2, 2)},
+				{key: row1Fam1, wallTime: 2},
+				{key: row2Fam0, wallTime: 4},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := NewEventFilter(&tc.filter)
+			require.NoError(t, err)
+			span := roachpb.Span{Key: keys.LocalMax, EndKey: keys.MaxKey}
+			iter, err := NewCatchUpIterator(ctx, eng, span, ts(1), nil, nil)
+			require.NoError(t, err)
+			defer iter.Close()
+			var events []kvpb.RangeFeedValue
+			require.NoError(t, iter.CatchUpScan(ctx, func(e *kvpb.RangeFeedEvent) error {
+				events = append(events, *e.Val)
+				return nil
+			}, tc.withDiff, false /* withFiltering */, false /* withOmitRemote */, filter))
+
+			require.Len(t, events, len(tc.exp))
+			for i, exp := range tc.exp {
+				ev := events[i]
+				require.Equal(t, exp.key, ev.Key, "event %d", i)
+				require.Equal(t, ts(exp.wallTime), ev.Value.Timestamp, "event %d", i)
+				if !tc.withDiff || !exp.prev.IsPresent() {
+					require.False(t, ev.PrevValue.IsPresent(), "event %d", i)
+					continue
+				}
+				expTuple, err := exp.prev.GetTuple()
+				require.NoError(t, err)
+				prevTuple, err := ev.PrevValue.GetTuple()
+				require.NoError(t, err)
+				require.Equal(t, expTuple, prevTuple, "event %d", i)
+			}
+		})
+	}
+}
+
 func TestCatchupScanInlineError(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -269,7 +418,7 @@ func TestCatchupScanInlineError(t *testing.T) {
 	require.NoError(t, err)
 	defer iter.Close()
 
-	err = iter.CatchUpScan(ctx, nil, false /* withDiff */, false /* withFiltering */, false /* withOmitRemote */)
+	err = iter.CatchUpScan(ctx, nil, false /* withDiff */, false /* withFiltering */, false /* withOmitRemote */, nil /* filter */)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "unexpected inline value")
 }
@@ -317,7 +466,7 @@ func TestCatchupScanSeesOldIntent(t *testing.T) {
 	require.NoError(t, iter.CatchUpScan(ctx, func(e *kvpb.RangeFeedEvent) error {
 		keys[string(e.Val.Key)] = struct{}{}
 		return nil
-	}, true /* withDiff */, false /* withFiltering */, false /* withOmitRemote */))
+	}, true /* withDiff */, false /* withFiltering */, false /* withOmitRemote */, nil /* filter */))
 	require.Equal(t, map[string]struct{}{
 		"b": {},
 		"e": {},
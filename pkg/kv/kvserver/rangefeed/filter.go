@@ -6,8 +6,14 @@
 package rangefeed
 
 import (
+	"bytes"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/interval"
+	"github.com/cockroachdb/errors"
 )
 
 // Filter informs the producer of logical operations of the information that a
@@ -48,3 +54,197 @@ func (r *Filter) NeedPrevVal(s roachpb.Span) bool {
 func (r *Filter) NeedVal(s roachpb.Span) bool {
 	return r.needVals.Overlaps(s.AsRange())
 }
+
+// EventFilter filters the value events of a rangefeed registration, per the
+// kvpb.RangeFeedFilter of its request. Like the request's filter, it only
+// rejects the events it can determine do not match. A nil *EventFilter matches
+// every event.
+type EventFilter struct {
+	families   map[uint32]struct{}
+	originIDs  map[uint32]struct{}
+	predicates []columnPredicate
+}
+
+// columnPredicate is a validated kvpb.RangeFeedFilter_ColumnPredicate, with
+// the type and data of its value decoded.
+type columnPredicate struct {
+	kvpb.RangeFeedFilter_ColumnPredicate
+	typ  encoding.Type
+	data []byte
+}
+
+// NewEventFilter returns the EventFilter of the rangefeed filter given, which
+// may be nil.
+func NewEventFilter(f *kvpb.RangeFeedFilter) (*EventFilter, error) {
+	if f == nil ||
+		(len(f.ColumnFamilies) == 0 && len(f.OriginIDs) == 0 && len(f.ColumnPredicates) == 0) {
+		return nil, nil
+	}
+	ef := &EventFilter{}
+	if len(f.ColumnFamilies) > 0 {
+		ef.families = make(map[uint32]struct{}, len(f.ColumnFamilies))
+		for _, id := range f.ColumnFamilies {
+			ef.families[id] = struct{}{}
+		}
+	}
+	if len(f.OriginIDs) > 0 {
+		ef.originIDs = make(map[uint32]struct{}, len(f.OriginIDs))
+		for _, id := range f.OriginIDs {
+			ef.originIDs[id] = struct{}{}
+		}
+	}
+	for _, p := range f.ColumnPredicates {
+		cp := columnPredicate{RangeFeedFilter_ColumnPredicate: p}
+		switch p.Op {
+		case kvpb.RangeFeedFilter_ColumnPredicate_EQ, kvpb.RangeFeedFilter_ColumnPredicate_NE:
+			var err error
+			if cp.typ, cp.data, err = decodeColumnValue(p.Value); err != nil {
+				return nil, errors.Wrapf(err, "invalid value of predicate on column %d", p.ColumnID)
+			}
+		case kvpb.RangeFeedFilter_ColumnPredicate_IS_NULL, kvpb.RangeFeedFilter_ColumnPredicate_IS_NOT_NULL:
+		default:
+			return nil, errors.Errorf("unknown operator %s of predicate on column %d", p.Op, p.ColumnID)
+		}
+		ef.predicates = append(ef.predicates, cp)
+	}
+	return ef, nil
+}
+
+// matches returns whether the event, of a value written by the origin given,
+// matches the filter. The previous value of the event is only considered if
+// withDiff is true.
+func (f *EventFilter) matches(event *kvpb.RangeFeedEvent, originID uint32, withDiff bool) bool {
+	if f == nil {
+		return true
+	}
+	v, ok := event.GetValue().(*kvpb.RangeFeedValue)
+	if !ok {
+		return true
+	}
+	return f.matchesKeyAndOrigin(v.Key, originID) && f.matchesValue(v, withDiff)
+}
+
+// matchesKeyAndOrigin returns whether a value of the key given, written by the
+// origin given, may match the filter.
+func (f *EventFilter) matchesKeyAndOrigin(key roachpb.Key, originID uint32) bool {
+	if f == nil {
+		return true
+	}
+	if f.originIDs != nil {
+		if _, ok := f.originIDs[originID]; !ok {
+			return false
+		}
+	}
+	if f.families != nil {
+		// Keys which are not SQL row keys are never filtered by family.
+		if familyID, err := keys.DecodeFamilyKey(key); err == nil {
+			if _, ok := f.families[familyID]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesValue returns whether the value of the event, or its previous value
+// if withDiff is true, may match the column predicates of the filter. A nil
+// value, of an event which is not a value event, matches.
+func (f *EventFilter) matchesValue(v *kvpb.RangeFeedValue, withDiff bool) bool {
+	if f == nil || len(f.predicates) == 0 || v == nil {
+		return true
+	}
+	familyID, err := keys.DecodeFamilyKey(v.Key)
+	if err != nil {
+		return true
+	}
+	if !v.Value.IsPresent() && !withDiff {
+		// A deletion, whose previous value is unknown.
+		return true
+	}
+	return f.matchesPredicates(familyID, v.Value) ||
+		(withDiff && f.matchesPredicates(familyID, v.PrevValue))
+}
+
+// matchesPredicates returns whether the value, of the column family given, may
+// match all of the column predicates of the filter. A value which is not
+// present does not match.
+func (f *EventFilter) matchesPredicates(familyID uint32, value roachpb.Value) bool {
+	if !value.IsPresent() {
+		return false
+	}
+	if value.GetTag() != roachpb.ValueType_TUPLE {
+		// The value of a column family with a single column does not contain the
+		// column's ID, so the predicates cannot be evaluated on it.
+		return true
+	}
+	tuple, err := value.GetTuple()
+	if err != nil {
+		return true
+	}
+	for i := range f.predicates {
+		p := &f.predicates[i]
+		if p.FamilyID != familyID {
+			continue
+		}
+		typ, data, found, err := findTupleColumn(tuple, p.ColumnID)
+		if err != nil {
+			return true
+		}
+		var ok bool
+		switch p.Op {
+		case kvpb.RangeFeedFilter_ColumnPredicate_EQ:
+			ok = found && typ == p.typ && bytes.Equal(data, p.data)
+		case kvpb.RangeFeedFilter_ColumnPredicate_NE:
+			ok = found && (typ != p.typ || !bytes.Equal(data, p.data))
+		case kvpb.RangeFeedFilter_ColumnPredicate_IS_NULL:
+			ok = !found
+		case kvpb.RangeFeedFilter_ColumnPredicate_IS_NOT_NULL:
+			ok = found
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeColumnValue returns the type and data of a value-encoded datum.
+func decodeColumnValue(b []byte) (encoding.Type, []byte, error) {
+	_, dataOffset, _, typ, err := encoding.DecodeValueTag(b)
+	if err != nil {
+		return encoding.Unknown, nil, err
+	}
+	length, err := encoding.PeekValueLengthWithOffsetsAndType(b, dataOffset, typ)
+	if err != nil {
+		return encoding.Unknown, nil, err
+	}
+	return typ, b[dataOffset:length], nil
+}
+
+// findTupleColumn returns the type and data of the column with the ID given in
+// a tuple of value-encoded columns, if the column is present. NULL columns are
+// not present in tuples.
+func findTupleColumn(
+	tuple []byte, columnID uint32,
+) (typ encoding.Type, data []byte, found bool, _ error) {
+	var colID uint32
+	for len(tuple) > 0 {
+		_, dataOffset, colIDDelta, typ, err := encoding.DecodeValueTag(tuple)
+		if err != nil {
+			return encoding.Unknown, nil, false, err
+		}
+		length, err := encoding.PeekValueLengthWithOffsetsAndType(tuple, dataOffset, typ)
+		if err != nil {
+			return encoding.Unknown, nil, false, err
+		}
+		colID += colIDDelta
+		if colID == columnID {
+			return typ, tuple[dataOffset:length], true, nil
+		} else if colID > columnID {
+			// Columns are encoded in increasing order of their IDs.
+			break
+		}
+		tuple = tuple[length:]
+	}
+	return encoding.Unknown, nil, false, nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package rangefeed
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+// makeRowKey returns the key of the column family given of a row of a table
+// with an integer primary key.
+func makeRowKey(pk int64, familyID uint32) roachpb.Key {
+	key := keys.SystemSQLCodec.IndexPrefix(104, 1)
+	key = encoding.EncodeVarintAscending(key, pk)
+	return keys.MakeFamilyKey(key, familyID)
+}
+
+// makeTupleValue returns a tuple value of integer columns, given as pairs of
+// column ID and value in increasing order of column ID. Columns which are not
+// given are NULL.
+func makeTupleValue(cols ...int64) roachpb.Value {
+	var tuple []byte
+	var prevID uint32
+	for i := 0; i < len(cols); i += 2 {
+		colID := uint32(cols[i])
+		tuple = encoding.EncodeIntValue(tuple, colID-prevID, cols[i+1])
+		prevID = colID
+	}
+	var v roachpb.Value
+	v.SetTuple(tuple)
+	return v
+}
+
+func TestEventFilter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	intValue := func(i int64) []byte {
+		return encoding.EncodeIntValue(nil, encoding.NoColumnID, i)
+	}
+	pred := func(
+		op kvpb.RangeFeedFilter_ColumnPredicate_Op, colID uint32, value []byte,
+	) kvpb.RangeFeedFilter_ColumnPredicate {
+		return kvpb.RangeFeedFilter_ColumnPredicate{ColumnID: colID, Op: op, Value: value}
+	}
+	valueEvent := func(key roachpb.Key, value, prevValue roachpb.Value) *kvpb.RangeFeedEvent {
+		var ev kvpb.RangeFeedEvent
+		ev.MustSetValue(&kvpb.RangeFeedValue{Key: key, Value: value, PrevValue: prevValue})
+		return &ev
+	}
+
+	ef, err := NewEventFilter(nil)
+	require.NoError(t, err)
+	require.Nil(t, ef)
+	ef, err = NewEventFilter(&kvpb.RangeFeedFilter{})
+	require.NoError(t, err)
+	require.Nil(t, ef)
+	_, err = NewEventFilter(&kvpb.RangeFeedFilter{ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{
+		pred(kvpb.RangeFeedFilter_ColumnPredicate_EQ, 1, nil),
+	}})
+	require.ErrorContains(t, err, "invalid value of predicate on column 1")
+
+	for _, tc := range []struct {
+		name     string
+		filter   kvpb.RangeFeedFilter
+		event    *kvpb.RangeFeedEvent
+		originID uint32
+		withDiff bool
+		exp      bool
+	}{
+		{
+			name:   "family matches",
+			filter: kvpb.RangeFeedFilter{ColumnFamilies: []uint32{0, 2}},
+			event:  valueEvent(makeRowKey(1, 2), makeTupleValue(1, 1), roachpb.Value{}),
+			exp:    true,
+		},
+		{
+			name:   "family does not match",
+			filter: kvpb.RangeFeedFilter{ColumnFamilies: []uint32{0, 2}},
+			event:  valueEvent(makeRowKey(1, 1), makeTupleValue(1, 1), roachpb.Value{}),
+			exp:    false,
+		},
+		{
+			name:   "non-row key is not filtered by family",
+			filter: kvpb.RangeFeedFilter{ColumnFamilies: []uint32{0}},
+			event:  valueEvent(roachpb.Key("a"), roachpb.MakeValueFromString("a"), roachpb.Value{}),
+			exp:    true,
+		},
+		{
+			name:     "origin matches",
+			filter:   kvpb.RangeFeedFilter{OriginIDs: []uint32{0, 2}},
+			event:    valueEvent(makeRowKey(1, 0), makeTupleValue(1, 1), roachpb.Value{}),
+			originID: 2,
+			exp:      true,
+		},
+		{
+			name:     "origin does not match",
+			filter:   kvpb.RangeFeedFilter{OriginIDs: []uint32{0, 2}},
+			event:    valueEvent(makeRowKey(1, 0), makeTupleValue(1, 1), roachpb.Value{}),
+			originID: 1,
+			exp:      false,
+		},
+		{
+			name: "equality matches",
+			filter: kvpb.RangeFeedFilter{ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{
+				pred(kvpb.RangeFeedFilter_ColumnPredicate_EQ, 2, intValue(7)),
+			}},
+			event: valueEvent(makeRowKey(1, 0), makeTupleValue(1, 1, 2, 7), roachpb.Value{}),
+			exp:   true,
+		},
+		{
+			name: "equality does not match",
+			filter: kvpb.RangeFeedFilter{ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{
+				pred(kvpb.RangeFeedFilter_ColumnPredicate_EQ, 2, intValue(7)),
+			}},
+			event: valueEvent(makeRowKey(1, 0), makeTupleValue(1, 1, 2, 8), roachpb.Value{}),
+			exp:   false,
+		},
+		{
+			name: "equality does not match NULL",
+			filter: kvpb.RangeFeedFilter{ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{
+				pred(kvpb.RangeFeedFilter_ColumnPredicate_EQ, 2, intValue(7)),
+			}},
+			event: valueEvent(makeRowKey(1, 0), makeTupleValue(1, 1, 3, 7), roachpb.Value{}),
+			exp:   false,
+		},
+		{
+			name: "inequality matches",
+			filter: kvpb.RangeFeedFilter{ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{
+				pred(kvpb.RangeFeedFilter_ColumnPredicate_NE, 1, intValue(7)),
+			}},
+			event: valueEvent(makeRowKey(1, 0), makeTupleValue(1, 1, 2, 7), roachpb.Value{}),
+			exp:   true,
+		},
+		{
+			name: "IS NULL matches",
+			filter: kvpb.RangeFeedFilter{ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{
+				pred(kvpb.RangeFeedFilter_ColumnPredicate_IS_NULL, 2, nil),
+			}},
+			event: valueEvent(makeRowKey(1, 0), makeTupleValue(1, 1, 3, 7), roachpb.Value{}),
+			exp:   true,
+		},
+		{
+			name: "IS NOT NULL does not match",
+			filter: kvpb.RangeFeedFilter{ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{
+				pred(kvpb.RangeFeedFilter_ColumnPredicate_IS_NOT_NULL, 2, nil),
+			}},
+			event: valueEvent(makeRowKey(1, 0), makeTupleValue(1, 1, 3, 7), roachpb.Value{}),
+			exp:   false,
+		},
+		{
+			name: "predicate on another family is ignored",
+			filter: kvpb.RangeFeedFilter{ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{
+				{FamilyID: 1, ColumnID: 2, Op: kvpb.RangeFeedFilter_ColumnPredicate_IS_NULL},
+			}},
+			event: valueEvent(makeRowKey(1, 0), makeTupleValue(1, 1, 2, 7), roachpb.Value{}),
+			exp:   true,
+		},
+		{
+			name: "previous value matches with diff",
+			filter: kvpb.RangeFeedFilter{ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{
+				pred(kvpb.RangeFeedFilter_ColumnPredicate_EQ, 2, intValue(7)),
+			}},
+			event:    valueEvent(makeRowKey(1, 0), makeTupleValue(1, 1, 2, 8), makeTupleValue(1, 1, 2, 7)),
+			withDiff: true,
+			exp:      true,
+		},
+		{
+			name: "previous value is ignored without diff",
+			filter: kvpb.RangeFeedFilter{ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{
+				pred(kvpb.RangeFeedFilter_ColumnPredicate_EQ, 2, intValue(7)),
+			}},
+			event: valueEvent(makeRowKey(1, 0), makeTupleValue(1, 1, 2, 8), makeTupleValue(1, 1, 2, 7)),
+			exp:   false,
+		},
+		{
+			name: "deletion matches without diff",
+			filter: kvpb.RangeFeedFilter{ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{
+				pred(kvpb.RangeFeedFilter_ColumnPredicate_EQ, 2, intValue(7)),
+			}},
+			event: valueEvent(makeRowKey(1, 0), roachpb.Value{}, roachpb.Value{}),
+			exp:   true,
+		},
+		{
+			name: "deletion is filtered by previous value with diff",
+			filter: kvpb.RangeFeedFilter{ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{
+				pred(kvpb.RangeFeedFilter_ColumnPredicate_EQ, 2, intValue(7)),
+			}},
+			event:    valueEvent(makeRowKey(1, 0), roachpb.Value{}, makeTupleValue(1, 1, 2, 8)),
+			withDiff: true,
+			exp:      false,
+		},
+		{
+			name: "non-tuple value matches",
+			filter: kvpb.RangeFeedFilter{ColumnPredicates: []kvpb.RangeFeedFilter_ColumnPredicate{
+				pred(kvpb.RangeFeedFilter_ColumnPredicate_EQ, 2, intValue(7)),
+			}},
+			event: valueEvent(makeRowKey(1, 0), roachpb.MakeValueFromString("a"), roachpb.Value{}),
+			exp:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ef, err := NewEventFilter(&tc.filter)
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, ef.matches(tc.event, tc.originID, tc.withDiff))
+		})
+	}
+}
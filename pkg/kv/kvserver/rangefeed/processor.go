@@ -195,6 +195,7 @@ type Processor interface {
 		withDiff bool,
 		withFiltering bool,
 		withOmitRemote bool,
+		eventFilter *EventFilter,
 		stream Stream,
 	) (bool, Disconnector, *Filter)
 
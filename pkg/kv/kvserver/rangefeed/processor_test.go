@@ -69,6 +69,7 @@ func TestProcessorBasic(t *testing.T) {
 			false, /* withDiff */
 			false, /* withFiltering */
 			false, /* withOmitRemote */
+			nil,   /* eventFilter */
 			h.toBufferedStreamIfNeeded(r1Stream),
 		)
 		require.True(t, r1OK)
@@ -203,6 +204,7 @@ func TestProcessorBasic(t *testing.T) {
 			true,  /* withDiff */
 			true,  /* withFiltering */
 			false, /* withOmitRemote */
+			nil,   /* eventFilter */
 			h.toBufferedStreamIfNeeded(r2Stream),
 		)
 		require.True(t, r2OK)
@@ -315,6 +317,7 @@ func TestProcessorBasic(t *testing.T) {
 			false, /* withDiff */
 			false, /* withFiltering */
 			false, /* withOmitRemote */
+			nil,   /* eventFilter */
 			h.toBufferedStreamIfNeeded(r3Stream),
 		)
 		require.True(t, r30K)
@@ -336,6 +339,7 @@ func TestProcessorBasic(t *testing.T) {
 			false, /* withDiff */
 			false, /* withFiltering */
 			false, /* withOmitRemote */
+			nil,   /* eventFilter */
 			h.toBufferedStreamIfNeeded(r4Stream),
 		)
 		require.False(t, r4OK)
@@ -361,6 +365,7 @@ func TestProcessorOmitRemote(t *testing.T) {
 			false, /* withDiff */
 			false, /* withFiltering */
 			false, /* withOmitRemote */
+			nil,   /* eventFilter */
 			h.toBufferedStreamIfNeeded(r1Stream),
 		)
 		require.True(t, r1OK)
@@ -386,6 +391,7 @@ func TestProcessorOmitRemote(t *testing.T) {
 			false, /* withDiff */
 			false, /* withFiltering */
 			true,  /* withOmitRemote */
+			nil,   /* eventFilter */
 			h.toBufferedStreamIfNeeded(r2Stream),
 		)
 		require.True(t, r2OK)
@@ -443,6 +449,7 @@ func TestProcessorSlowConsumer(t *testing.T) {
 				false, /* withDiff */
 				false, /* withFiltering */
 				false, /* withOmitRemote */
+				nil,   /* eventFilter */
 				h.toBufferedStreamIfNeeded(r1Stream),
 			)
 			r2Stream := newTestStream()
@@ -454,6 +461,7 @@ func TestProcessorSlowConsumer(t *testing.T) {
 				false, /* withDiff */
 				false, /* withFiltering */
 				false, /* withOmitRemote */
+				nil,   /* eventFilter */
 				h.toBufferedStreamIfNeeded(r2Stream),
 			)
 			h.syncEventAndRegistrations()
@@ -549,6 +557,7 @@ func TestProcessorMemoryBudgetExceeded(t *testing.T) {
 			false, /* withDiff */
 			false, /* withFiltering */
 			false, /* withOmitRemote */
+			nil,   /* eventFilter */
 			h.toBufferedStreamIfNeeded(r1Stream),
 		)
 		h.syncEventAndRegistrations()
@@ -604,6 +613,7 @@ func TestProcessorMemoryBudgetReleased(t *testing.T) {
 			false, /* withDiff */
 			false, /* withFiltering */
 			false, /* withOmitRemote */
+			nil,   /* eventFilter */
 			h.toBufferedStreamIfNeeded(r1Stream),
 		)
 		h.syncEventAndRegistrations()
@@ -685,6 +695,7 @@ func TestProcessorInitializeResolvedTimestamp(t *testing.T) {
 			false, /* withDiff */
 			false, /* withFiltering */
 			false, /* withOmitRemote */
+			nil,   /* eventFilter */
 			h.toBufferedStreamIfNeeded(r1Stream),
 		)
 		h.syncEventAndRegistrations()
@@ -992,7 +1003,7 @@ func TestProcessorConcurrentStop(t *testing.T) {
 				runtime.Gosched()
 				s := newTestStream()
 				p.Register(s.ctx, h.span, hlc.Timestamp{}, nil, /* catchUpIter */
-					false /* withDiff */, false /* withFiltering */, false, /* withOmitRemote */
+					false /* withDiff */, false /* withFiltering */, false /* withOmitRemote */, nil, /* eventFilter */
 					h.toBufferedStreamIfNeeded(s))
 			}()
 			go func() {
@@ -1065,7 +1076,7 @@ func TestProcessorRegistrationObservesOnlyNewEvents(t *testing.T) {
 				s := newTestStream()
 				regs[s] = firstIdx
 				p.Register(s.ctx, h.span, hlc.Timestamp{}, nil, /* catchUpIter */
-					false /* withDiff */, false /* withFiltering */, false, /* withOmitRemote */
+					false /* withDiff */, false /* withFiltering */, false /* withOmitRemote */, nil, /* eventFilter */
 					h.toBufferedStreamIfNeeded(s))
 				regDone <- struct{}{}
 			}
@@ -1126,6 +1137,7 @@ func TestBudgetReleaseOnProcessorStop(t *testing.T) {
 			false, /* withDiff */
 			false, /* withFiltering */
 			false, /* withOmitRemote */
+			nil,   /* eventFilter */
 			h.toBufferedStreamIfNeeded(rStream),
 		)
 		h.syncEventAndRegistrations()
@@ -1206,6 +1218,7 @@ func TestBudgetReleaseOnLastStreamError(t *testing.T) {
 			false, /* withDiff */
 			false, /* withFiltering */
 			false, /* withOmitRemote */
+			nil,   /* eventFilter */
 			h.toBufferedStreamIfNeeded(rStream),
 		)
 		h.syncEventAndRegistrations()
@@ -1276,6 +1289,7 @@ func TestBudgetReleaseOnOneStreamError(t *testing.T) {
 			false, /* withDiff */
 			false, /* withFiltering */
 			false, /* withOmitRemote */
+			nil,   /* eventFilter */
 			h.toBufferedStreamIfNeeded(r1Stream),
 		)
 
@@ -1289,6 +1303,7 @@ func TestBudgetReleaseOnOneStreamError(t *testing.T) {
 			false, /* withDiff */
 			false, /* withFiltering */
 			false, /* withOmitRemote */
+			nil,   /* eventFilter */
 			h.toBufferedStreamIfNeeded(r2Stream),
 		)
 		h.syncEventAndRegistrations()
@@ -1457,7 +1472,7 @@ func TestProcessorBackpressure(t *testing.T) {
 		// Add a registration.
 		stream := newTestStream()
 		ok, _, _ := p.Register(stream.ctx, span, hlc.MinTimestamp, nil, /* catchUpIter */
-			false /* withDiff */, false /* withFiltering */, false, /* withOmitRemote */
+			false /* withDiff */, false /* withFiltering */, false /* withOmitRemote */, nil, /* eventFilter */
 			h.toBufferedStreamIfNeeded(stream))
 		require.True(t, ok)
 
@@ -294,6 +294,12 @@ func withOmitRemote(opt bool) registrationOption {
 	}
 }
 
+func withEventFilter(filter *EventFilter) registrationOption {
+	return func(cfg *testRegistrationConfig) {
+		cfg.filter = filter
+	}
+}
+
 func withRegistrationType(regType registrationType) registrationOption {
 	return func(cfg *testRegistrationConfig) {
 		cfg.withRegistrationTestTypes = regType
@@ -338,6 +344,7 @@ type testRegistrationConfig struct {
 	withDiff                  bool
 	withFiltering             bool
 	withOmitRemote            bool
+	filter                    *EventFilter
 	withRegistrationTestTypes registrationType
 	metrics                   *Metrics
 }
@@ -361,6 +368,7 @@ func newTestRegistration(s *testStream, opts ...registrationOption) testRegistra
 			cfg.withDiff,
 			cfg.withFiltering,
 			cfg.withOmitRemote,
+			cfg.filter,
 			5,
 			false, /* blockWhenFull */
 			cfg.metrics,
@@ -376,6 +384,7 @@ func newTestRegistration(s *testStream, opts ...registrationOption) testRegistra
 			cfg.withDiff,
 			cfg.withFiltering,
 			cfg.withOmitRemote,
+			cfg.filter,
 			5,
 			cfg.metrics,
 			&testBufferedStream{Stream: s},
@@ -231,6 +231,35 @@ func TestRegistryWithOmitOrigin(t *testing.T) {
 	})
 }
 
+func TestRegistryWithEventFilter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+
+	testutils.RunValues(t, "registration type=", registrationTestTypes, func(t *testing.T, rt registrationType) {
+		val := roachpb.Value{RawBytes: []byte("val"), Timestamp: hlc.Timestamp{WallTime: 1}}
+		ev1, ev2 := new(kvpb.RangeFeedEvent), new(kvpb.RangeFeedEvent)
+		ev1.MustSetValue(&kvpb.RangeFeedValue{Key: keyA, Value: val})
+		ev2.MustSetValue(&kvpb.RangeFeedValue{Key: keyB, Value: val})
+
+		ef, err := NewEventFilter(&kvpb.RangeFeedFilter{OriginIDs: []uint32{1}})
+		require.NoError(t, err)
+
+		reg := makeRegistry(NewMetrics())
+		s := newTestStream()
+		r := newTestRegistration(s, withRSpan(spAC), withEventFilter(ef), withRegistrationType(rt))
+		go r.runOutputLoop(ctx, 0)
+		defer r.Disconnect(nil)
+		reg.Register(ctx, r)
+
+		reg.PublishToOverlapping(ctx, spAC, ev1, logicalOpMetadata{}, nil /* alloc */)
+		reg.PublishToOverlapping(ctx, spAC, ev2, logicalOpMetadata{originID: 1}, nil /* alloc */)
+
+		require.NoError(t, reg.waitForCaughtUp(ctx, all))
+		require.Equal(t, []*kvpb.RangeFeedEvent{ev2}, s.GetAndClearEvents())
+		require.Nil(t, s.Error())
+	})
+}
+
 func TestRegistryBasic(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	ctx := context.Background()
@@ -98,6 +98,7 @@ func newUnbufferedRegistration(
 	withDiff bool,
 	withFiltering bool,
 	withOmitRemote bool,
+	filter *EventFilter,
 	bufferSz int,
 	metrics *Metrics,
 	stream BufferedStream,
@@ -111,6 +112,7 @@ func newUnbufferedRegistration(
 			withDiff:               withDiff,
 			withFiltering:          withFiltering,
 			withOmitRemote:         withOmitRemote,
+			filter:                 filter,
 			removeRegFromProcessor: removeRegFromProcessor,
 		},
 		metrics: metrics,
@@ -364,7 +366,7 @@ func (ubr *unbufferedRegistration) maybeRunCatchUpScan(ctx context.Context) erro
 	}()
 
 	return catchUpIter.CatchUpScan(ctx, ubr.stream.SendUnbuffered, ubr.withDiff, ubr.withFiltering,
-		ubr.withOmitRemote)
+		ubr.withOmitRemote, ubr.filter)
 }
 
 // Used for testing only.
@@ -3355,6 +3355,11 @@ func (r *Replica) followerSendSnapshot(
 		SharedReplicate:     sharedReplicate,
 		ExternalReplicate:   externalReplicate,
 		RangeKeysInOrder:    true,
+		// Raft snapshots are sent to replicas which fell behind the truncation
+		// point of the log, which usually still hold most of the range's data.
+		DeltaRequested: req.SenderQueueName == kvserverpb.SnapshotRequest_RAFT_SNAPSHOT_QUEUE &&
+			!sharedReplicate && !externalReplicate &&
+			DeltaSnapshotsEnabled.Get(&r.store.ClusterSettings().SV),
 	}
 	newBatchFn := func() storage.WriteBatch {
 		return r.store.TODOEngine().NewWriteBatch()
@@ -6,17 +6,21 @@
 package kvserver
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"crypto/sha512"
 	"encoding/binary"
 	"fmt"
 	"os"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/allocator/allocatorimpl"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
@@ -32,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
@@ -58,8 +63,9 @@ type replicaChecksum struct {
 //
 // When req.Mode is CHECK_VIA_QUEUE and an inconsistency is detected, the
 // consistency check will be re-run to save storage engine checkpoints and
-// terminate suspicious nodes. This behavior should be lifted to the consistency
-// checker queue in the future.
+// terminate suspicious nodes, or, if server.consistency_check.auto_repair.enabled
+// is set, to repair the inconsistent replicas. This behavior should be lifted to
+// the consistency checker queue in the future.
 func (r *Replica) CheckConsistency(
 	ctx context.Context, req kvpb.CheckConsistencyRequest,
 ) (kvpb.CheckConsistencyResponse, *kvpb.Error) {
@@ -225,6 +231,16 @@ func (r *Replica) checkConsistencyImpl(
 		return resp, nil
 	}
 
+	// If auto repair is enabled and the leaseholder is part of a healthy quorum,
+	// the minority is removed and rebuilt instead of terminated.
+	if consistencyCheckAutoRepairEnabled.Get(&r.ClusterSettings().SV) {
+		if minority, ok := findRepairableMinority(r.Desc(), r.replicaID, results); ok {
+			r.repairInconsistency(ctx, args, minority, res.Detail)
+			return resp, nil
+		}
+		log.Errorf(ctx, "consistency check failed; the inconsistency cannot be repaired automatically")
+	}
+
 	// No checkpoint was requested, so we want to re-run the check with
 	// checkpoints and termination of suspicious nodes. Note that this recursive
 	// call will be terminated in the `args.Checkpoint` branch above.
@@ -259,6 +275,124 @@ func (r *Replica) checkConsistencyImpl(
 	return resp, nil
 }
 
+// findRepairableMinority returns the replicas whose checksum differs from the
+// leaseholder's, if the inconsistency can be repaired by removing them and
+// rebuilding them from a snapshot. This requires that all replicas returned a
+// checksum, that the range is not in a joint configuration, that the replicas
+// agreeing with the leaseholder form a quorum of the voters, and that all the
+// other replicas are either full voters or non-voters. The returned replicas
+// are sorted by replica ID.
+func findRepairableMinority(
+	desc *roachpb.RangeDescriptor, leaseholder roachpb.ReplicaID, results []ConsistencyCheckResult,
+) ([]roachpb.ReplicaDescriptor, bool) {
+	if desc.Replicas().InAtomicReplicationChange() ||
+		len(results) != len(desc.Replicas().Descriptors()) {
+		return nil, false
+	}
+	var healthySHA []byte
+	for _, result := range results {
+		if result.Err != nil {
+			return nil, false
+		}
+		if result.Replica.ReplicaID == leaseholder {
+			healthySHA = result.Response.Checksum
+		}
+	}
+	if healthySHA == nil {
+		return nil, false
+	}
+	var minority []roachpb.ReplicaDescriptor
+	var healthyVoters int
+	for _, result := range results {
+		if bytes.Equal(result.Response.Checksum, healthySHA) {
+			if result.Replica.Type == roachpb.VOTER_FULL {
+				healthyVoters++
+			}
+			continue
+		}
+		if typ := result.Replica.Type; typ != roachpb.VOTER_FULL && typ != roachpb.NON_VOTER {
+			return nil, false
+		}
+		minority = append(minority, result.Replica)
+	}
+	if len(minority) == 0 || healthyVoters <= len(desc.Replicas().VoterDescriptors())/2 {
+		return nil, false
+	}
+	slices.SortFunc(minority, func(a, b roachpb.ReplicaDescriptor) int {
+		return cmp.Compare(a.ReplicaID, b.ReplicaID)
+	})
+	return minority, true
+}
+
+// repairInconsistency re-runs the consistency check with checkpoints to
+// confirm that the given minority of replicas is inconsistent with the
+// leaseholder, and then removes them through the replicate queue, which
+// rebuilds them from a snapshot of a healthy replica. The removals are
+// recorded in the system event log along with the diff summary of the first
+// round.
+//
+// If the inconsistency is not confirmed, nothing is done, and the next
+// consistency check of the range will try again.
+func (r *Replica) repairInconsistency(
+	ctx context.Context,
+	args kvpb.ComputeChecksumRequest,
+	minority []roachpb.ReplicaDescriptor,
+	detail string,
+) {
+	minoritySet := roachpb.MakeReplicaSet(minority)
+	log.Errorf(ctx, "consistency check failed; fetching details and repairing minority %v", minoritySet)
+
+	args.Checkpoint = true
+	results, err := r.runConsistencyCheck(ctx, args)
+	if err != nil {
+		log.Errorf(ctx, "replica inconsistency detected; second round failed: %s", err)
+		return
+	}
+	confirmed, ok := findRepairableMinority(r.Desc(), r.replicaID, results)
+	if !ok || !slices.EqualFunc(minority, confirmed, func(a, b roachpb.ReplicaDescriptor) bool {
+		return a.ReplicaID == b.ReplicaID
+	}) {
+		log.Errorf(ctx, "replica inconsistency detected; second round did not confirm minority %v, not repairing",
+			minoritySet)
+		return
+	}
+
+	ev := &eventpb.RangeInconsistencyRepaired{
+		NodeID:  int32(r.store.NodeID()),
+		StoreID: int32(r.store.StoreID()),
+		RangeID: int64(r.RangeID),
+		Detail:  redact.RedactableString(detail),
+	}
+	defer func() {
+		if len(ev.RemovedReplicas) > 0 {
+			ev.CommonDetails().Timestamp = timeutil.Now().UnixNano()
+			r.store.logStructuredEvent(ctx, ev)
+		}
+	}()
+	for _, rDesc := range minority {
+		changeType := roachpb.REMOVE_VOTER
+		if rDesc.Type == roachpb.NON_VOTER {
+			changeType = roachpb.REMOVE_NON_VOTER
+		}
+		chgs := kvpb.MakeReplicationChanges(changeType, roachpb.ReplicationTarget{
+			NodeID: rDesc.NodeID, StoreID: rDesc.StoreID,
+		})
+		if err := r.store.replicateQueue.changeReplicas(ctx, r, chgs, r.Desc(),
+			allocatorimpl.AllocatorRemoveVoter.Priority(), kvserverpb.ReasonUnknown,
+			"replica inconsistent with the leaseholder",
+		); err != nil {
+			log.Errorf(ctx, "unable to remove inconsistent replica %s: %v", rDesc, err)
+			return
+		}
+		log.Warningf(ctx, "removed inconsistent replica %s", rDesc)
+		ev.RemovedReplicas = append(ev.RemovedReplicas, rDesc.String())
+		r.store.metrics.ConsistencyQueueRepairs.Inc(1)
+	}
+	// Rebuild the removed replicas, from a snapshot of a healthy replica, without
+	// waiting for the next scan of the replicate queue.
+	r.store.replicateQueue.AddAsync(ctx, r, allocatorimpl.AllocatorAddVoter.Priority())
+}
+
 // A ConsistencyCheckResult contains the outcome of a CollectChecksum call.
 type ConsistencyCheckResult struct {
 	Replica  roachpb.ReplicaDescriptor
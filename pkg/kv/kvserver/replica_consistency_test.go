@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
 )
@@ -79,6 +80,71 @@ func TestReplicaChecksumVersion(t *testing.T) {
 	})
 }
 
+func TestFindRepairableMinority(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	makeDesc := func(types ...roachpb.ReplicaType) *roachpb.RangeDescriptor {
+		desc := &roachpb.RangeDescriptor{RangeID: 1}
+		for i, typ := range types {
+			id := i + 1
+			desc.InternalReplicas = append(desc.InternalReplicas, roachpb.ReplicaDescriptor{
+				NodeID: roachpb.NodeID(id), StoreID: roachpb.StoreID(id),
+				ReplicaID: roachpb.ReplicaID(id), Type: typ,
+			})
+		}
+		return desc
+	}
+	// makeResults returns the results of a consistency check of the replicas of
+	// the descriptor, with the given checksums. An empty checksum is an error.
+	makeResults := func(desc *roachpb.RangeDescriptor, checksums ...string) []ConsistencyCheckResult {
+		var results []ConsistencyCheckResult
+		for i, rDesc := range desc.Replicas().Descriptors() {
+			res := ConsistencyCheckResult{Replica: rDesc}
+			if checksums[i] == "" {
+				res.Err = errors.New("boom")
+			} else {
+				res.Response.Checksum = []byte(checksums[i])
+			}
+			results = append(results, res)
+		}
+		return results
+	}
+	voters := makeDesc(roachpb.VOTER_FULL, roachpb.VOTER_FULL, roachpb.VOTER_FULL)
+	withNonVoter := makeDesc(roachpb.VOTER_FULL, roachpb.VOTER_FULL, roachpb.VOTER_FULL,
+		roachpb.NON_VOTER)
+	fiveVoters := makeDesc(roachpb.VOTER_FULL, roachpb.VOTER_FULL, roachpb.VOTER_FULL,
+		roachpb.VOTER_FULL, roachpb.VOTER_FULL)
+	joint := makeDesc(roachpb.VOTER_FULL, roachpb.VOTER_FULL, roachpb.VOTER_OUTGOING)
+
+	for _, tc := range []struct {
+		name        string
+		desc        *roachpb.RangeDescriptor
+		leaseholder roachpb.ReplicaID
+		checksums   []string
+		exp         []roachpb.ReplicaID
+	}{
+		{name: "consistent", desc: voters, leaseholder: 1, checksums: []string{"a", "a", "a"}},
+		{name: "voter", desc: voters, leaseholder: 1, checksums: []string{"a", "a", "b"}, exp: []roachpb.ReplicaID{3}},
+		{name: "leaseholder in minority", desc: voters, leaseholder: 3, checksums: []string{"a", "a", "b"}},
+		{name: "no quorum", desc: voters, leaseholder: 1, checksums: []string{"a", "b", "c"}},
+		{name: "missing checksum", desc: voters, leaseholder: 1, checksums: []string{"a", "a", ""}},
+		{name: "non-voter", desc: withNonVoter, leaseholder: 2, checksums: []string{"a", "a", "a", "b"}, exp: []roachpb.ReplicaID{4}},
+		{name: "non-voters are not a quorum", desc: withNonVoter, leaseholder: 1, checksums: []string{"a", "b", "c", "a"}},
+		{name: "two voters", desc: fiveVoters, leaseholder: 2, checksums: []string{"b", "a", "a", "c", "a"}, exp: []roachpb.ReplicaID{1, 4}},
+		{name: "joint config", desc: joint, leaseholder: 1, checksums: []string{"a", "a", "b"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			minority, ok := findRepairableMinority(tc.desc, tc.leaseholder, makeResults(tc.desc, tc.checksums...))
+			require.Equal(t, tc.exp != nil, ok)
+			var ids []roachpb.ReplicaID
+			for _, rDesc := range minority {
+				ids = append(ids, rDesc.ReplicaID)
+			}
+			require.Equal(t, tc.exp, ids)
+		})
+	}
+}
+
 func TestStoreCheckpointSpans(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
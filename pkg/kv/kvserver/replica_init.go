@@ -138,6 +138,7 @@ func newUninitializedReplicaWithoutRaftGroup(
 			Stopper:            store.Stopper(),
 			IntentResolver:     store.intentResolver,
 			TxnWaitMetrics:     store.txnWaitMetrics,
+			TxnWaitDeadlocks:   store.txnWaitDeadlocks,
 			SlowLatchGauge:     store.metrics.SlowLatchRequests,
 			LatchWaitDurations: store.metrics.LatchWaitDurations,
 			DisableTxnPushing:  store.TestingKnobs().DontPushOnLockConflictError,
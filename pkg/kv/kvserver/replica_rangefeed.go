@@ -251,6 +251,10 @@ func (r *Replica) RangeFeed(
 	} else if len(args.WithMatchingOriginIDs) > 0 {
 		return nil, errors.Errorf("multiple origin IDs and OriginID != 0 not supported yet")
 	}
+	eventFilter, err := rangefeed.NewEventFilter(args.Filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid rangefeed filter")
+	}
 
 	// If the RangeFeed is performing a catch-up scan then it will observe all
 	// values above args.Timestamp. If the RangeFeed is requesting previous
@@ -336,7 +340,7 @@ func (r *Replica) RangeFeed(
 	}
 
 	p, disconnector, err := r.registerWithRangefeedRaftMuLocked(
-		streamCtx, rSpan, args.Timestamp, catchUpIter, args.WithDiff, args.WithFiltering, omitRemote, stream,
+		streamCtx, rSpan, args.Timestamp, catchUpIter, args.WithDiff, args.WithFiltering, omitRemote, eventFilter, stream,
 	)
 	r.raftMu.Unlock()
 
@@ -433,6 +437,7 @@ func (r *Replica) registerWithRangefeedRaftMuLocked(
 	withDiff bool,
 	withFiltering bool,
 	withOmitRemote bool,
+	eventFilter *rangefeed.EventFilter,
 	stream rangefeed.Stream,
 ) (rangefeed.Processor, rangefeed.Disconnector, error) {
 	defer logSlowRangefeedRegistration(streamCtx)()
@@ -454,7 +459,7 @@ func (r *Replica) registerWithRangefeedRaftMuLocked(
 
 	if p != nil {
 		reg, disconnector, filter := p.Register(streamCtx, span, startTS, catchUpIter, withDiff, withFiltering, withOmitRemote,
-			stream)
+			eventFilter, stream)
 		if reg {
 			// Registered successfully with an existing processor.
 			// Update the rangefeed filter to avoid filtering ops
@@ -536,7 +541,7 @@ func (r *Replica) registerWithRangefeedRaftMuLocked(
 	// this ensures that the only time the registration fails is during
 	// server shutdown.
 	reg, disconnector, filter := p.Register(streamCtx, span, startTS, catchUpIter, withDiff,
-		withFiltering, withOmitRemote, stream)
+		withFiltering, withOmitRemote, eventFilter, stream)
 	if !reg {
 		select {
 		case <-r.store.Stopper().ShouldQuiesce():
@@ -30,6 +30,17 @@ var SplitByLoadEnabled = settings.RegisterBoolSetting(
 	settings.WithName("kv.range_split.by_load.enabled"),
 	settings.WithPublic)
 
+// SplitByLoadAppendOnlyDetectionEnabled wraps
+// "kv.range_split.by_load.append_only_detection.enabled".
+var SplitByLoadAppendOnlyDetectionEnabled = settings.RegisterBoolSetting(
+	settings.SystemOnly,
+	"kv.range_split.by_load.append_only_detection.enabled",
+	"detect indexes whose load-based splits repeatedly move load to the range "+
+		"containing their highest keys, as with sequential writes, and stop "+
+		"splitting these ranges based on load",
+	true,
+)
+
 // SplitByLoadQPSThreshold wraps "kv.range_split.load_qps_threshold".
 var SplitByLoadQPSThreshold = settings.RegisterIntSetting(
 	settings.SystemOnly,
@@ -129,6 +140,30 @@ func (r *Replica) SplitByLoadEnabled() bool {
 		!r.store.TestingKnobs().DisableLoadBasedSplitting
 }
 
+// makeAppendOnlyLoadSplit returns a description of a load-based split of the
+// range at splitKey for the append-only hotspot detection, or false if the key
+// is not in a SQL index.
+func makeAppendOnlyLoadSplit(
+	desc *roachpb.RangeDescriptor, splitKey roachpb.Key, snap split.LoadSplitSnapshot, writesPerSecond float64,
+) (split.LoadSplit, bool) {
+	rest, tenantID, err := keys.DecodeTenantPrefix(splitKey)
+	if err != nil || keys.TableDataMin.Compare(rest) > 0 {
+		return split.LoadSplit{}, false
+	}
+	_, tableID, indexID, err := keys.DecodeTableIDIndexID(rest)
+	if err != nil {
+		return split.LoadSplit{}, false
+	}
+	prefix := keys.MakeSQLCodec(tenantID).IndexPrefix(tableID, indexID)
+	return split.LoadSplit{
+		Prefix:          prefix,
+		Key:             splitKey,
+		Tail:            desc.EndKey.AsRawKey().Compare(prefix.PrefixEnd()) >= 0,
+		AccessDirection: snap.AccessDirection,
+		WritesPerSecond: writesPerSecond,
+	}, true
+}
+
 // getResponseBoundarySpan computes the union span of the true spans that were
 // iterated over using the request span and the response's resumeSpan.
 //
@@ -15440,3 +15440,56 @@ func TestLeaderlessWatcherInit(t *testing.T) {
 		t.Fatalf("expected LeaderlessWatcher channel to be closed")
 	}
 }
+
+// TestReplicaReadOnlySpan verifies that a replica rejects writes to a span
+// made read-only through its span config, while still serving reads and
+// writes outside of the span.
+func TestReplicaReadOnlySpan(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc := testContext{}
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	tc.Start(ctx, t, stopper)
+
+	pArgs := putArgs(roachpb.Key("b"), []byte("value"))
+	_, pErr := tc.SendWrapped(&pArgs)
+	require.NoError(t, pErr.GoError())
+
+	conf := roachpb.TestingDefaultSpanConfig()
+	conf.ReadOnly = true
+	sp := roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("c")}
+	tc.repl.SetSpanConfig(conf, sp)
+
+	dArgs := deleteArgs(roachpb.Key("b"))
+	drArgs := deleteRangeArgs(roachpb.Key("a"), roachpb.Key("z"))
+	for _, req := range []kvpb.Request{
+		&pArgs, incrementArgs(roachpb.Key("b"), 1), &dArgs, &drArgs,
+	} {
+		_, pErr := tc.SendWrapped(req)
+		require.True(t, errors.HasType(pErr.GoError(), (*kvpb.ReadOnlySpanError)(nil)),
+			"expected ReadOnlySpanError for %s, got %v", req.Method(), pErr)
+	}
+
+	// Reads are served.
+	gArgs := getArgs(roachpb.Key("b"))
+	resp, pErr := tc.SendWrapped(&gArgs)
+	require.NoError(t, pErr.GoError())
+	val, err := resp.(*kvpb.GetResponse).Value.GetBytes()
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), val)
+
+	// Writes outside of the span are allowed.
+	pArgs = putArgs(roachpb.Key("d"), []byte("value"))
+	_, pErr = tc.SendWrapped(&pArgs)
+	require.NoError(t, pErr.GoError())
+
+	// Writes are allowed again once the span is no longer read-only.
+	conf.ReadOnly = false
+	tc.repl.SetSpanConfig(conf, sp)
+	pArgs = putArgs(roachpb.Key("b"), []byte("value2"))
+	_, pErr = tc.SendWrapped(&pArgs)
+	require.NoError(t, pErr.GoError())
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval"
@@ -126,6 +127,11 @@ func (r *Replica) executeWriteBatch(
 		return nil, g, nil, kvpb.NewError(err)
 	}
 
+	// Reject writes to a span made read-only through its span config.
+	if err := r.checkWriteToReadOnlySpan(ba); err != nil {
+		return nil, g, nil, kvpb.NewError(err)
+	}
+
 	// Compute the transaction's local uncertainty limit using observed
 	// timestamps, which can help avoid uncertainty restarts.
 	ui := uncertainty.ComputeInterval(&ba.Header, st, r.Clock().MaxOffset())
@@ -360,6 +366,49 @@ func (r *Replica) executeWriteBatch(
 	}
 }
 
+// checkWriteToReadOnlySpan returns a ReadOnlySpanError if the batch writes to
+// the data of a span which has been made read-only through its span config.
+// Writes to range-local keys, such as transaction records and range
+// descriptors, are allowed so that transactions can complete and the range can
+// be split, merged and rebalanced. Intent resolution and GC are allowed too, as
+// they do not change the data visible to readers.
+func (r *Replica) checkWriteToReadOnlySpan(ba *kvpb.BatchRequest) error {
+	r.mu.RLock()
+	readOnly, confSpan := r.mu.conf.ReadOnly, r.mu.confSpan
+	r.mu.RUnlock()
+	if !readOnly {
+		return nil
+	}
+	for _, union := range ba.Requests {
+		req := union.GetInner()
+		if !isReadOnlySpanWrite(req) {
+			continue
+		}
+		span := req.Header().Span()
+		if keys.IsLocal(span.Key) {
+			continue
+		}
+		// The span config may not cover the entire range until the range is split
+		// at its bounds.
+		if confSpan.Overlaps(span) {
+			return kvpb.NewReadOnlySpanError(confSpan)
+		}
+	}
+	return nil
+}
+
+// isReadOnlySpanWrite returns whether the request writes data and is rejected
+// on read-only spans.
+func isReadOnlySpanWrite(req kvpb.Request) bool {
+	switch req.(type) {
+	case *kvpb.AddSSTableRequest, *kvpb.LinkExternalSSTableRequest, *kvpb.ClearRangeRequest,
+		*kvpb.RevertRangeRequest, *kvpb.DeleteRangeRequest, *kvpb.MergeRequest:
+		return true
+	default:
+		return kvpb.IsIntentWrite(req)
+	}
+}
+
 // canAttempt1PCEvaluation looks at the batch and decides whether it can be
 // executed as 1PC.
 //
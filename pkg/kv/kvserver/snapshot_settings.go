@@ -163,3 +163,38 @@ var snapshotChecksumVerification = settings.RegisterBoolSetting(
 	"verify value checksums on receiving a raft snapshot",
 	true,
 )
+
+// DeltaSnapshotsEnabled enables delta snapshots for Raft snapshots, where the
+// sender skips the spans of user keys which the receiver already holds.
+var DeltaSnapshotsEnabled = settings.RegisterBoolSetting(
+	settings.SystemOnly,
+	"kv.snapshot_delta.enabled",
+	"if enabled, Raft snapshots sent to a replica which holds data of the range "+
+		"only stream the spans of user keys whose fingerprints differ from its own",
+	false,
+)
+
+// DeltaSnapshotSpanSize is the approximate size of the spans of user keys that
+// the fingerprints of a delta snapshot are computed over. Smaller spans allow
+// more of the receiver's data to be retained, at the cost of more fingerprints.
+var DeltaSnapshotSpanSize = settings.RegisterByteSizeSetting(
+	settings.SystemOnly,
+	"kv.snapshot_delta.span_size",
+	"the approximate size of the spans of user keys fingerprinted in a delta snapshot",
+	4<<20, // 4 MiB
+	settings.ByteSizeWithMinimum(64<<10),
+)
+
+// DeltaSnapshotMaxDivergence is the fraction of the user keys of a range, by
+// size, that may differ between the sender and the receiver for a delta
+// snapshot to be sent. Beyond it, the receiver retains none of its data and the
+// whole range is streamed, since the fingerprints are then unlikely to save
+// much.
+var DeltaSnapshotMaxDivergence = settings.RegisterFloatSetting(
+	settings.SystemOnly,
+	"kv.snapshot_delta.max_divergence",
+	"the fraction of the user keys of a range, by size, which may differ from "+
+		"those held by the receiver of a delta snapshot before a full snapshot is sent",
+	0.5,
+	settings.Fraction,
+)
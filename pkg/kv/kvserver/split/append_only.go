@@ -0,0 +1,176 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package split
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// appendOnlyAccessDirectionThreshold is the minimum access direction of the
+// load sampled before a split (see LoadBasedSplitter.AccessDirection) for the
+// split to be considered to move load rightward.
+const appendOnlyAccessDirectionThreshold = 0.5
+
+// appendOnlyMinSplits is the number of consecutive load-based splits of an
+// index moving load rightward after which the index is considered to have an
+// append-only hotspot.
+const appendOnlyMinSplits = 3
+
+// appendOnlyRetention is the duration after which the state of an index is
+// discarded if no load-based split was suggested for it. If an index stops
+// being append-only, this is the longest load-based splitting of it is held
+// back.
+const appendOnlyRetention = 10 * time.Minute
+
+// AppendOnlyHotspot describes an index whose load-based splits repeatedly
+// moved load rightward, i.e. to the range containing its highest keys. This is
+// the signature of writes with sequential keys, for which load-based splitting
+// is futile, as the right-hand side of each split becomes the hotspot again.
+type AppendOnlyHotspot struct {
+	// Prefix is the prefix of the keys of the index.
+	Prefix roachpb.Key
+	// Splits is the number of consecutive load-based splits of the index which
+	// moved load rightward.
+	Splits int
+	// LastSplitKey is the key of the last of these splits.
+	LastSplitKey roachpb.Key
+	// WritesPerSecond is the rate of writes to the hot range of the index, as of
+	// LastSeen.
+	WritesPerSecond float64
+	// Detected is the time at which the index was detected as append-only, and
+	// LastSeen the last time a load-based split of the index was suggested.
+	Detected, LastSeen time.Time
+}
+
+// A LoadSplit describes a load-based split of a range of an index.
+type LoadSplit struct {
+	// Prefix is the prefix of the keys of the index, and Key the split key.
+	Prefix, Key roachpb.Key
+	// Tail is true if the range contains the highest keys of the index.
+	Tail bool
+	// AccessDirection is the access direction of the load sampled to find the
+	// split key, and WritesPerSecond the rate of writes to the range.
+	AccessDirection, WritesPerSecond float64
+}
+
+// An AppendOnlyDetector tracks the load-based splits of the ranges of each
+// index in order to detect append-only hotspots, and tells whether the
+// load-based splits suggested by a Decider are worth carrying out.
+//
+// The zero value of an AppendOnlyDetector is ready for use. It is safe for
+// concurrent use.
+type AppendOnlyDetector struct {
+	mu struct {
+		syncutil.Mutex
+		indexes map[string]*AppendOnlyHotspot
+	}
+}
+
+// isRightward returns whether a load-based split of the range containing the
+// highest keys of the index moves load rightward, i.e. is to the right of the
+// previous split of the index if any, while the load moves rightward.
+func (h *AppendOnlyHotspot) isRightward(s LoadSplit) bool {
+	return s.AccessDirection >= appendOnlyAccessDirectionThreshold &&
+		(h == nil || bytes.Compare(h.LastSplitKey, s.Key) < 0)
+}
+
+func (h *AppendOnlyHotspot) detected() bool {
+	return h != nil && h.Splits >= appendOnlyMinSplits
+}
+
+// ShouldSplit returns whether a suggested load-based split should be carried
+// out. It returns false if the index has an append-only hotspot which the split
+// would not relieve, in which case the hotspot is refreshed. A split of the
+// tail of the index which does not move load rightward ends the hotspot, while
+// splits of other ranges of the index are unaffected.
+func (d *AppendOnlyDetector) ShouldSplit(now time.Time, s LoadSplit) bool {
+	if !s.Tail {
+		return true
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	h := d.getLocked(now, s.Prefix)
+	if !h.detected() {
+		return true
+	}
+	if !h.isRightward(s) {
+		delete(d.mu.indexes, string(s.Prefix))
+		return true
+	}
+	h.WritesPerSecond = s.WritesPerSecond
+	h.LastSeen = now
+	return false
+}
+
+// RecordSplit records a load-based split which was carried out. It returns true
+// if the split makes the index an append-only hotspot. Splits of ranges other
+// than the tail of the index are ignored.
+func (d *AppendOnlyDetector) RecordSplit(now time.Time, s LoadSplit) bool {
+	if !s.Tail {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	h := d.getLocked(now, s.Prefix)
+	if !h.isRightward(s) {
+		delete(d.mu.indexes, string(s.Prefix))
+		return false
+	}
+	if h == nil {
+		if d.mu.indexes == nil {
+			d.mu.indexes = make(map[string]*AppendOnlyHotspot)
+		}
+		h = &AppendOnlyHotspot{Prefix: s.Prefix.Clone()}
+		d.mu.indexes[string(s.Prefix)] = h
+	}
+	h.Splits++
+	h.LastSplitKey = s.Key.Clone()
+	h.WritesPerSecond = s.WritesPerSecond
+	h.LastSeen = now
+	if h.Splits == appendOnlyMinSplits {
+		h.Detected = now
+		return true
+	}
+	return false
+}
+
+// Hotspots returns the append-only hotspots detected, ordered by prefix.
+func (d *AppendOnlyDetector) Hotspots(now time.Time) []AppendOnlyHotspot {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var hotspots []AppendOnlyHotspot
+	for prefix := range d.mu.indexes {
+		if h := d.getLocked(now, roachpb.Key(prefix)); h.detected() {
+			hotspots = append(hotspots, *h)
+		}
+	}
+	sort.Slice(hotspots, func(i, j int) bool {
+		return hotspots[i].Prefix.Compare(hotspots[j].Prefix) < 0
+	})
+	return hotspots
+}
+
+// getLocked returns the state of the index with the given prefix, or nil if
+// there is none or it expired, in which case it is discarded.
+func (d *AppendOnlyDetector) getLocked(now time.Time, prefix roachpb.Key) *AppendOnlyHotspot {
+	h, ok := d.mu.indexes[string(prefix)]
+	if !ok {
+		return nil
+	}
+	if now.Sub(h.LastSeen) > appendOnlyRetention {
+		delete(d.mu.indexes, string(prefix))
+		return nil
+	}
+	return h
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package split
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSequentialAccessDirection tests that the access direction of the load
+// based splitters exceeds the append-only threshold for sequential writes, and
+// not for uniformly distributed writes.
+func TestSequentialAccessDirection(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	start := timeutil.Unix(0, 0)
+	for _, weighted := range []bool{false, true} {
+		for _, sequential := range []bool{false, true} {
+			t.Run(fmt.Sprintf("weighted=%t/sequential=%t", weighted, sequential), func(t *testing.T) {
+				randSource := rand.New(rand.NewSource(1))
+				var finder LoadBasedSplitter
+				if weighted {
+					finder = NewWeightedFinder(start, randSource)
+				} else {
+					finder = NewUnweightedFinder(start, randSource)
+				}
+				for i := 0; i < 10000; i++ {
+					k := i
+					if !sequential {
+						k = randSource.Intn(10000)
+					}
+					key := keys.SystemSQLCodec.TablePrefix(uint32(k))
+					finder.Record(roachpb.Span{Key: key}, 1)
+				}
+				if sequential {
+					require.GreaterOrEqual(t, finder.AccessDirection(), appendOnlyAccessDirectionThreshold)
+				} else {
+					require.Less(t, finder.AccessDirection(), appendOnlyAccessDirectionThreshold)
+				}
+			})
+		}
+	}
+}
+
+func TestAppendOnlyDetector(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	prefix := keys.SystemSQLCodec.IndexPrefix(104, 1)
+	other := keys.SystemSQLCodec.IndexPrefix(104, 2)
+	key := func(i int) roachpb.Key {
+		return append(prefix[:len(prefix):len(prefix)], byte(i))
+	}
+	// tailSplit returns a split of the range containing the highest keys of the
+	// index at key i.
+	tailSplit := func(i int, accessDirection, writesPerSecond float64) LoadSplit {
+		return LoadSplit{
+			Prefix: prefix, Key: key(i), Tail: true,
+			AccessDirection: accessDirection, WritesPerSecond: writesPerSecond,
+		}
+	}
+	now := timeutil.Unix(0, 0)
+
+	var d AppendOnlyDetector
+	require.True(t, d.ShouldSplit(now, tailSplit(1, 1, 100)))
+
+	// Splits which move load rightward make the index append-only after
+	// appendOnlyMinSplits of them.
+	for i := 1; i < appendOnlyMinSplits; i++ {
+		require.False(t, d.RecordSplit(now, tailSplit(i, 0.9, 100)))
+		require.True(t, d.ShouldSplit(now, tailSplit(i+1, 0.9, 100)))
+	}
+	require.Empty(t, d.Hotspots(now))
+	// Splits of other ranges of the index are ignored.
+	nonTail := tailSplit(0, 0, 100)
+	nonTail.Tail = false
+	require.False(t, d.RecordSplit(now, nonTail))
+	now = now.Add(time.Minute)
+	require.True(t, d.RecordSplit(now, tailSplit(appendOnlyMinSplits, 0.9, 200)))
+	exp := AppendOnlyHotspot{
+		Prefix:          prefix,
+		Splits:          appendOnlyMinSplits,
+		LastSplitKey:    key(appendOnlyMinSplits),
+		WritesPerSecond: 200,
+		Detected:        now,
+		LastSeen:        now,
+	}
+	require.Equal(t, []AppendOnlyHotspot{exp}, d.Hotspots(now))
+
+	// Further splits continuing the pattern are not carried out, and refresh
+	// the hotspot. Other ranges and indexes are unaffected.
+	now = now.Add(time.Minute)
+	require.False(t, d.ShouldSplit(now, tailSplit(10, 0.95, 300)))
+	require.True(t, d.ShouldSplit(now, nonTail))
+	otherSplit := tailSplit(10, 0.95, 300)
+	otherSplit.Prefix = other
+	require.True(t, d.ShouldSplit(now, otherSplit))
+	exp.WritesPerSecond, exp.LastSeen = 300, now
+	require.Equal(t, []AppendOnlyHotspot{exp}, d.Hotspots(now))
+
+	// The hotspot expires if no split is suggested for the retention period.
+	require.Equal(t, []AppendOnlyHotspot{exp}, d.Hotspots(now.Add(appendOnlyRetention)))
+	now = now.Add(appendOnlyRetention + time.Second)
+	require.Empty(t, d.Hotspots(now))
+	require.True(t, d.ShouldSplit(now, tailSplit(11, 0.95, 300)))
+
+	// A split which doesn't move load rightward ends the hotspot.
+	for i := 1; i <= appendOnlyMinSplits; i++ {
+		d.RecordSplit(now, tailSplit(i, 0.9, 100))
+	}
+	require.Len(t, d.Hotspots(now), 1)
+	require.True(t, d.ShouldSplit(now, tailSplit(20, 0.1, 100)))
+	require.Empty(t, d.Hotspots(now))
+
+	// So does a split to the left of the previous one.
+	for i := 1; i < appendOnlyMinSplits; i++ {
+		d.RecordSplit(now, tailSplit(i, 0.9, 100))
+	}
+	require.False(t, d.RecordSplit(now, tailSplit(0, 0.9, 100)))
+	require.Empty(t, d.Hotspots(now))
+}
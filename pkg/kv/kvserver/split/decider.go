@@ -137,6 +137,7 @@ func GlobalRandSource() RandSource {
 type LoadSplitterMetrics struct {
 	PopularKeyCount *metric.Counter
 	NoSplitKeyCount *metric.Counter
+	AppendOnlyCount *metric.Counter
 }
 
 // Decider tracks the latest load and if certain conditions are met, records
@@ -404,6 +405,9 @@ type LoadSplitSnapshot struct {
 	SplitObjective       SplitObjective
 	Max, Last, Threshold float64
 	Ok                   bool
+	// AccessDirection is the access direction of the load sampled to find a
+	// split key, or zero if no split key is being searched for.
+	AccessDirection float64
 }
 
 // Snapshot returns a consistent snapshot of the decider state.
@@ -414,13 +418,18 @@ func (d *Decider) Snapshot(ctx context.Context, now time.Time) LoadSplitSnapshot
 	maxStat, ok := d.maxStatLocked(ctx, now)
 	lastStat := d.lastStatLocked(ctx, now)
 	threshold := d.config.StatThreshold(d.mu.objective)
+	var accessDirection float64
+	if d.mu.splitFinder != nil {
+		accessDirection = d.mu.splitFinder.AccessDirection()
+	}
 
 	return LoadSplitSnapshot{
-		SplitObjective: d.mu.objective,
-		Max:            maxStat,
-		Last:           lastStat,
-		Ok:             ok,
-		Threshold:      threshold,
+		SplitObjective:  d.mu.objective,
+		Max:             maxStat,
+		Last:            lastStat,
+		Ok:              ok,
+		Threshold:       threshold,
+		AccessDirection: accessDirection,
 	}
 }
 
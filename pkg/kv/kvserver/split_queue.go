@@ -338,6 +338,21 @@ func (sq *splitQueue) processAttempt(
 		lbSplitSnap := r.loadBasedSplitter.Snapshot(ctx, now)
 		splitObj := lbSplitSnap.SplitObjective
 
+		// Splitting the range containing the highest keys of an index with
+		// sequential writes is futile, as the right-hand side of the split becomes
+		// the hotspot again. Skip such splits once the pattern has been detected.
+		appendOnlySplit, trackAppendOnly := makeAppendOnlyLoadSplit(
+			desc, splitByLoadKey, lbSplitSnap, raftAppliedQPS)
+		trackAppendOnly = trackAppendOnly &&
+			SplitByLoadAppendOnlyDetectionEnabled.Get(&sq.store.cfg.Settings.SV)
+		if trackAppendOnly && !sq.store.appendOnlyDetector.ShouldSplit(now, appendOnlySplit) {
+			log.KvDistribution.VEventf(ctx, 2,
+				"skipping load-based split at key %s of index %s with an append-only hotspot",
+				splitByLoadKey, appendOnlySplit.Prefix)
+			sq.store.metrics.LoadSplitterMetrics.AppendOnlyCount.Inc(1)
+			return false, nil
+		}
+
 		reason := redact.Sprintf(
 			"load at key %s (%s %s, %.2f batches/sec, %.2f raft mutations/sec)",
 			splitByLoadKey,
@@ -381,6 +396,13 @@ func (sq *splitQueue) processAttempt(
 
 		telemetry.Inc(sq.loadBasedCount)
 		sq.metrics.LoadBasedSplitCount.Inc(1)
+		if trackAppendOnly && sq.store.appendOnlyDetector.RecordSplit(now, appendOnlySplit) {
+			log.KvDistribution.Warningf(ctx,
+				"load-based splits of index %s repeatedly moved load to the range containing its "+
+					"highest keys (%.2f writes/sec), which suggests sequential writes; further "+
+					"load-based splits of this range are skipped, consider using a hash-sharded index",
+				appendOnlySplit.Prefix, raftAppliedQPS)
+		}
 
 		// Reset the splitter now that the bounds of the range changed.
 		r.loadBasedSplitter.Reset(sq.store.Clock().PhysicalTime())
@@ -51,6 +51,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/raftentry"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rangefeed"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rditer"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/split"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/storeliveness"
 	slpb "github.com/cockroachdb/cockroach/pkg/kv/kvserver/storeliveness/storelivenesspb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantrate"
@@ -84,6 +85,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/limit"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/logcrash"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logpb"
 	"github.com/cockroachdb/cockroach/pkg/util/log/severity"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
@@ -911,6 +913,7 @@ type Store struct {
 	raftEntryCache      *raftentry.Cache
 	limiters            batcheval.Limiters
 	txnWaitMetrics      *txnwait.Metrics
+	txnWaitDeadlocks    *txnwait.DeadlockHistory
 	raftMetrics         *raft.Metrics
 	sstSnapshotStorage  SSTSnapshotStorage
 	protectedtsReader   spanconfig.ProtectedTSReader
@@ -927,6 +930,10 @@ type Store struct {
 	// replicas.
 	kvflowRangeControllerFactory replica_rac2.RangeControllerFactory
 
+	// appendOnlyDetector detects the indexes of the store's ranges for which
+	// load-based splitting is futile, as their writes have sequential keys.
+	appendOnlyDetector split.AppendOnlyDetector
+
 	// metricsMu protects the collection and update of engine metrics.
 	metricsMu syncutil.Mutex
 
@@ -1328,6 +1335,10 @@ type StoreConfig struct {
 	// RangeLogWriter is used to write entries to the system.rangelog table.
 	RangeLogWriter RangeLogWriter
 
+	// EventLogger, if set, is used to log structured events and to record them
+	// in the system.eventlog table.
+	EventLogger func(context.Context, logpb.EventPayload)
+
 	// RangeFeedSchedulerConcurrency specifies number of rangefeed scheduler
 	// workers for the store.
 	RangeFeedSchedulerConcurrency int
@@ -1621,6 +1632,7 @@ func NewStore(
 
 	s.txnWaitMetrics = txnwait.NewMetrics(cfg.HistogramWindowInterval)
 	s.metrics.registry.AddMetricStruct(s.txnWaitMetrics)
+	s.txnWaitDeadlocks = txnwait.NewDeadlockHistory()
 
 	s.raftMetrics = raft.NewMetrics()
 	s.metrics.registry.AddMetricStruct(s.raftMetrics)
@@ -3800,6 +3812,16 @@ func (s *Store) ComputeMetricsPeriodically(
 	return m, nil
 }
 
+// logStructuredEvent logs the given structured event through the configured
+// EventLogger, or only to the logging channel of the event if there is none.
+func (s *Store) logStructuredEvent(ctx context.Context, event logpb.EventPayload) {
+	if s.cfg.EventLogger == nil {
+		log.StructuredEvent(ctx, severity.INFO, event)
+		return
+	}
+	s.cfg.EventLogger(ctx, event)
+}
+
 // ComputeMetrics immediately computes the current value of store metrics which
 // cannot be computed incrementally. This method should be invoked periodically
 // by a higher-level system which records store metrics.
@@ -558,14 +558,25 @@ func (s *Store) receiveSnapshot(
 		st:           s.ClusterSettings(),
 		clusterID:    s.ClusterID(),
 	}
+	if header.DeltaRequested && DeltaSnapshotsEnabled.Get(&s.cfg.Settings.SV) {
+		ss.deltaReader = s.deltaSnapshotReader(header.State.Desc)
+	}
 	defer ss.Close(ctx)
 
-	if err := stream.Send(&kvserverpb.SnapshotResponse{Status: kvserverpb.SnapshotResponse_ACCEPTED}); err != nil {
+	if err := stream.Send(&kvserverpb.SnapshotResponse{
+		Status:        kvserverpb.SnapshotResponse_ACCEPTED,
+		DeltaAccepted: ss.deltaReader != nil,
+	}); err != nil {
 		return err
 	}
 	if log.V(2) {
 		log.Infof(ctx, "accepted snapshot reservation for r%d", header.State.Desc.RangeID)
 	}
+	if ss.deltaReader != nil {
+		if err := ss.receiveDeltaSpans(ctx, s, stream, *header); err != nil {
+			return err
+		}
+	}
 
 	comparisonResult := s.getLocalityComparison(header.RaftMessageRequest.FromReplica.NodeID,
 		header.RaftMessageRequest.ToReplica.NodeID)
@@ -616,6 +627,18 @@ func (s *Store) receiveSnapshot(
 	})
 }
 
+// deltaSnapshotReader returns a snapshot of the user keys of the range with the
+// descriptor given, if the store holds an initialized replica of the range with
+// the same bounds, which an incoming snapshot can be a delta of. The caller
+// must close the returned reader.
+func (s *Store) deltaSnapshotReader(desc *roachpb.RangeDescriptor) storage.Reader {
+	r := s.GetReplicaIfExists(desc.RangeID)
+	if r == nil || !r.IsInitialized() || !r.Desc().RSpan().Equal(desc.RSpan()) {
+		return nil
+	}
+	return s.TODOEngine().NewSnapshot(desc.KeySpan().AsRawSpanWithNoLocals())
+}
+
 // sendSnapshotError sends an error response back to the sender of this snapshot
 // to signify that it can not accept this snapshot. Internally it increments the
 // statistic tracking how many invalid snapshots it received.
@@ -849,6 +872,9 @@ func sendSnapshot(
 	case kvserverpb.SnapshotResponse_ACCEPTED:
 		// This is the response we're expecting. Continue with snapshot sending.
 		log.Event(ctx, "received SnapshotResponse_ACCEPTED message from server")
+		if resp.DeltaAccepted && !header.DeltaRequested {
+			return nil, errors.Errorf("%s: server accepted a delta of %s, which was not requested", to, snap)
+		}
 	default:
 		err := errors.Errorf("%s: server sent an invalid status while negotiating %s: %s",
 			to, snap, resp.Status)
@@ -880,6 +906,13 @@ func sendSnapshot(
 		clusterID:     clusterID,
 	}
 
+	if resp.DeltaAccepted {
+		if err := ss.sendDeltaSpans(ctx, stream, snap, DeltaSnapshotSpanSize.Get(&st.SV)); err != nil {
+			storePool.Throttle(storepool.ThrottleFailed, err.Error(), to.StoreID)
+			return nil, errors.Wrapf(err, "%s: negotiating delta of %s", to, snap)
+		}
+	}
+
 	// Record timings for snapshot send if kv.trace.snapshot.enable_threshold is enabled
 	numBytesSent, err := ss.Send(ctx, stream, header, snap, recordBytesSent)
 	if err != nil {
@@ -0,0 +1,293 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package kvserver
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/allocator"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/allocator/allocatorimpl"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/allocator/plan"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/allocator/storepool"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/constraint"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/errors"
+)
+
+// maxSpanConfigChangePlanSteps bounds the number of allocator decisions
+// simulated for a single range by PlanSpanConfigChange. A range conforming to a
+// span config takes at most a couple of decisions per replica, so this only
+// protects against the allocator going back and forth between decisions.
+const maxSpanConfigChangePlanSteps = 32
+
+// spanConfigChangePlanner implements kvserverbase.SpanConfigChangePlanner. It
+// makes decisions against a copy of the store's store pool, to which the
+// simulated replica movements and lease transfers are applied, like the
+// replicate queue does to the local store estimates after making changes.
+type spanConfigChangePlanner struct {
+	s         *Store
+	storePool *storepool.StorePool
+}
+
+var _ kvserverbase.SpanConfigChangePlanner = &spanConfigChangePlanner{}
+
+// NewSpanConfigChangePlanner returns a planner simulating allocator decisions
+// against the store's view of the cluster.
+func (s *Store) NewSpanConfigChangePlanner() (kvserverbase.SpanConfigChangePlanner, error) {
+	if s.cfg.StorePool == nil {
+		return nil, errors.AssertionFailedf("store pool unavailable on s%d", s.StoreID())
+	}
+	return &spanConfigChangePlanner{s: s, storePool: s.cfg.StorePool.Clone()}, nil
+}
+
+// PlanSpanConfigChange simulates the decisions the allocator would make for
+// the range with the given descriptor, leaseholder and size were the given
+// span config to apply to it, and returns the replica and lease movements
+// they amount to. Nothing is actually moved.
+//
+// Only the decisions needed for the range to conform to the span config are
+// simulated, i.e. reaching the configured number of voters and non-voters,
+// satisfying the constraints and the lease preferences. The rebalancing the
+// allocator would additionally carry out to even out load across stores is
+// not, as it depends on the state of the cluster as the changes are made.
+//
+// Like AllocatorCheckRange, the range does not need to have a replica on the
+// store. The movements are applied to the planner's store pool, so that the
+// decisions made for subsequent ranges account for them.
+func (p *spanConfigChangePlanner) PlanSpanConfigChange(
+	ctx context.Context,
+	desc roachpb.RangeDescriptor,
+	leaseholder roachpb.StoreID,
+	logicalBytes int64,
+	conf roachpb.SpanConfig,
+) (kvserverbase.SpanConfigChangePlan, error) {
+	var res kvserverbase.SpanConfigChangePlan
+	s, storePool := p.s, p.storePool
+
+	// The descriptor is modified as changes are simulated, so work on a copy
+	// of its replicas.
+	desc.InternalReplicas = append([]roachpb.ReplicaDescriptor(nil), desc.InternalReplicas...)
+	usage := allocator.RangeUsageInfo{LogicalBytes: logicalBytes}
+	apply := func(chgs kvpb.ReplicationChanges) {
+		applySimulatedReplicationChanges(&desc, chgs, &res)
+		for _, chg := range chgs {
+			storePool.UpdateLocalStoreAfterRebalance(chg.Target.StoreID, usage, chg.ChangeType)
+		}
+	}
+
+	done := false
+	for i := 0; i < maxSpanConfigChangePlanSteps && !done; i++ {
+		action, _ := s.allocator.ComputeAction(ctx, storePool, &conf, &desc)
+		switch {
+		case action.Add() || action.Replace():
+			voters, nonVoters, replacing, nothingToDo, err :=
+				allocatorimpl.FilterReplicasForAction(storePool, &desc, action)
+			if err != nil {
+				return res, err
+			}
+			if nothingToDo {
+				done = true
+				break
+			}
+			targetType := action.TargetReplicaType()
+			target, _, err := s.allocator.AllocateTarget(ctx, storePool, &conf,
+				voters, nonVoters, replacing, action.ReplicaStatus(), targetType,
+			)
+			if err == nil {
+				err = s.allocator.CheckAvoidsFragileQuorum(ctx, storePool, &conf,
+					desc.Replicas().VoterDescriptors(), nonVoters, action.ReplicaStatus(),
+					targetType, target, replacing != nil,
+				)
+			}
+			if err != nil {
+				res.Unsatisfiable = err
+				done = true
+				break
+			}
+			var chgs kvpb.ReplicationChanges
+			if repl, ok := desc.GetReplicaDescriptor(target.StoreID); ok &&
+				repl.Type == roachpb.NON_VOTER && targetType == allocatorimpl.VoterTarget {
+				chgs = kvpb.ReplicationChangesForPromotion(target)
+			} else {
+				chgs = kvpb.MakeReplicationChanges(targetType.AddChangeType(), target)
+			}
+			if replacing != nil {
+				chgs = append(chgs, kvpb.MakeReplicationChanges(
+					targetType.RemoveChangeType(),
+					roachpb.ReplicationTarget{NodeID: replacing.NodeID, StoreID: replacing.StoreID},
+				)...)
+			}
+			apply(chgs)
+
+		case action == allocatorimpl.AllocatorRemoveVoter,
+			action == allocatorimpl.AllocatorRemoveNonVoter:
+			voters := desc.Replicas().VoterDescriptors()
+			nonVoters := desc.Replicas().NonVoterDescriptors()
+			var target roachpb.ReplicationTarget
+			var err error
+			if action == allocatorimpl.AllocatorRemoveVoter {
+				target, _, err = s.allocator.RemoveVoter(ctx, storePool, &conf,
+					voters, voters, nonVoters, s.allocator.ScorerOptions(ctx))
+			} else {
+				target, _, err = s.allocator.RemoveNonVoter(ctx, storePool, &conf,
+					nonVoters, voters, nonVoters, s.allocator.ScorerOptions(ctx))
+			}
+			if err != nil {
+				return res, err
+			}
+			apply(kvpb.MakeReplicationChanges(action.TargetReplicaType().RemoveChangeType(), target))
+
+		case action == allocatorimpl.AllocatorConsiderRebalance:
+			if !violatesConstraints(storePool, &desc, &conf) {
+				done = true
+				break
+			}
+			// The replica counts are right, but the replicas are in the wrong
+			// places. The allocator moves them through rebalancing.
+			voters := desc.Replicas().VoterDescriptors()
+			nonVoters := desc.Replicas().NonVoterDescriptors()
+			targetType := allocatorimpl.VoterTarget
+			add, remove, _, ok := s.allocator.RebalanceVoter(ctx, storePool, &conf,
+				nil /* raftStatus */, voters, nonVoters, usage, storepool.StoreFilterThrottled,
+				s.allocator.ScorerOptions(ctx),
+			)
+			if !ok {
+				targetType = allocatorimpl.NonVoterTarget
+				add, remove, _, ok = s.allocator.RebalanceNonVoter(ctx, storePool, &conf,
+					nil /* raftStatus */, voters, nonVoters, usage, storepool.StoreFilterThrottled,
+					s.allocator.ScorerOptions(ctx),
+				)
+			}
+			if !ok {
+				res.Unsatisfiable = errors.New("no store satisfies the constraints of a misplaced replica")
+				done = true
+				break
+			}
+			chgs, _, err := plan.ReplicationChangesForRebalance(ctx, &desc, len(voters), add, remove, targetType)
+			if err != nil {
+				return res, err
+			}
+			apply(chgs)
+
+		default:
+			// The remaining actions either don't depend on the span config, like
+			// removing dead or decommissioning replicas, or indicate the range
+			// can't be changed at all right now.
+			done = true
+		}
+	}
+	if !done {
+		res.Unsatisfiable = errors.Newf(
+			"the range does not conform after %d replication changes", maxSpanConfigChangePlanSteps)
+	}
+
+	res.LeaseTarget = s.planLeaseTransfer(storePool, &desc, leaseholder, &conf)
+	if res.LeaseTarget != 0 {
+		storePool.UpdateLocalStoresAfterLeaseTransfer(leaseholder, res.LeaseTarget, usage)
+	}
+	return res, nil
+}
+
+// planLeaseTransfer returns the store the lease of the range would be
+// transferred to, given its simulated descriptor, for the lease to be held by
+// a voter satisfying the lease preferences, or zero if it would stay in place.
+func (s *Store) planLeaseTransfer(
+	storePool storepool.AllocatorStorePool,
+	desc *roachpb.RangeDescriptor,
+	leaseholder roachpb.StoreID,
+	conf *roachpb.SpanConfig,
+) roachpb.StoreID {
+	if leaseholder == 0 {
+		// The leaseholder is unknown.
+		return 0
+	}
+	voters := desc.Replicas().VoterDescriptors()
+	candidates := s.allocator.PreferredLeaseholders(storePool, conf, voters)
+	if len(candidates) == 0 {
+		candidates = voters
+	}
+	var target roachpb.StoreID
+	var targetLeases int32
+	for _, repl := range candidates {
+		if repl.StoreID == leaseholder {
+			// The lease is already where it should be.
+			return 0
+		}
+		// Of the candidates, favor the one holding the fewest leases.
+		leases := int32(-1)
+		if storeDesc, ok := storePool.GetStoreDescriptor(repl.StoreID); ok {
+			leases = storeDesc.Capacity.LeaseCount
+		}
+		if target == 0 || leases < targetLeases {
+			target, targetLeases = repl.StoreID, leases
+		}
+	}
+	return target
+}
+
+// violatesConstraints returns whether the replicas of the range fail to
+// satisfy the constraints or voter constraints of the span config.
+func violatesConstraints(
+	storePool storepool.AllocatorStorePool, desc *roachpb.RangeDescriptor, conf *roachpb.SpanConfig,
+) bool {
+	violates := func(replicas []roachpb.ReplicaDescriptor, numReplicas int32, constraints []roachpb.ConstraintsConjunction) bool {
+		analyzed := constraint.AnalyzeConstraints(storePool, replicas, numReplicas, constraints)
+		for i, c := range analyzed.Constraints {
+			// A conjunction without a number of replicas applies to all of them.
+			n := c.NumReplicas
+			if n == 0 {
+				n = numReplicas
+			}
+			if len(analyzed.SatisfiedBy[i]) < int(n) {
+				return true
+			}
+		}
+		return false
+	}
+	return violates(desc.Replicas().Descriptors(), conf.NumReplicas, conf.Constraints) ||
+		violates(desc.Replicas().VoterDescriptors(), conf.GetNumVoters(), conf.VoterConstraints)
+}
+
+// applySimulatedReplicationChanges applies the given changes to the range
+// descriptor and records the replicas they add and remove. Promotions and
+// demotions only change the type of an existing replica, without moving it.
+func applySimulatedReplicationChanges(
+	desc *roachpb.RangeDescriptor, chgs kvpb.ReplicationChanges, res *kvserverbase.SpanConfigChangePlan,
+) {
+	typeChanged := func(target roachpb.ReplicationTarget) bool {
+		var added, removed bool
+		for _, chg := range chgs {
+			if chg.Target == target {
+				added = added || chg.ChangeType.IsAddition()
+				removed = removed || chg.ChangeType.IsRemoval()
+			}
+		}
+		return added && removed
+	}
+	for _, chg := range chgs {
+		switch {
+		case typeChanged(chg.Target):
+			if chg.ChangeType.IsAddition() {
+				typ := roachpb.VOTER_FULL
+				if chg.ChangeType == roachpb.ADD_NON_VOTER {
+					typ = roachpb.NON_VOTER
+				}
+				desc.SetReplicaType(chg.Target.NodeID, chg.Target.StoreID, typ)
+			}
+		case chg.ChangeType == roachpb.ADD_VOTER:
+			desc.AddReplica(chg.Target.NodeID, chg.Target.StoreID, roachpb.VOTER_FULL)
+			res.AddedReplicas = append(res.AddedReplicas, chg.Target)
+		case chg.ChangeType == roachpb.ADD_NON_VOTER:
+			desc.AddReplica(chg.Target.NodeID, chg.Target.StoreID, roachpb.NON_VOTER)
+			res.AddedReplicas = append(res.AddedReplicas, chg.Target)
+		case chg.ChangeType.IsRemoval():
+			desc.RemoveReplica(chg.Target.NodeID, chg.Target.StoreID)
+			res.RemovedReplicas = append(res.RemovedReplicas, chg.Target)
+		}
+	}
+}
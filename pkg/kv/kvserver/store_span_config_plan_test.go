@@ -0,0 +1,144 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package kvserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/allocator/allocatorimpl"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/testutils/gossiputil"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorePlanSpanConfigChange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	stopper, g, sp, a, _ := allocatorimpl.CreateTestAllocator(ctx, 5, true /* deterministic */)
+	defer stopper.Stop(ctx)
+
+	// Stores 1-3 are in region a, stores 4 and 5 in region b.
+	var stores []*roachpb.StoreDescriptor
+	for i, region := range []string{"a", "a", "a", "b", "b"} {
+		id := i + 1
+		stores = append(stores, &roachpb.StoreDescriptor{
+			StoreID: roachpb.StoreID(id),
+			Node: roachpb.NodeDescriptor{
+				NodeID:   roachpb.NodeID(id),
+				Locality: roachpb.Locality{Tiers: []roachpb.Tier{{Key: "region", Value: region}}},
+			},
+			Capacity: roachpb.StoreCapacity{
+				Capacity:   200,
+				Available:  100,
+				RangeCount: 10,
+				LeaseCount: int32(10 - id),
+			},
+		})
+	}
+	gossiputil.NewStoreGossiper(g).GossipStores(stores, t)
+
+	s := &Store{allocator: a}
+	s.cfg.StorePool = sp
+	newPlanner := func(t *testing.T) kvserverbase.SpanConfigChangePlanner {
+		p, err := s.NewSpanConfigChangePlanner()
+		require.NoError(t, err)
+		return p
+	}
+
+	makeDesc := func(storeIDs ...roachpb.StoreID) roachpb.RangeDescriptor {
+		desc := roachpb.RangeDescriptor{RangeID: 1, NextReplicaID: 1}
+		for _, storeID := range storeIDs {
+			desc.AddReplica(roachpb.NodeID(storeID), storeID, roachpb.VOTER_FULL)
+		}
+		return desc
+	}
+	storeIDs := func(targets []roachpb.ReplicationTarget) []roachpb.StoreID {
+		var ids []roachpb.StoreID
+		for _, target := range targets {
+			ids = append(ids, target.StoreID)
+		}
+		return ids
+	}
+	regionConstraint := func(region string) []roachpb.Constraint {
+		return []roachpb.Constraint{{Type: roachpb.Constraint_REQUIRED, Key: "region", Value: region}}
+	}
+
+	t.Run("conforming", func(t *testing.T) {
+		res, err := newPlanner(t).PlanSpanConfigChange(ctx, makeDesc(1, 2, 3), 1, 100,
+			roachpb.SpanConfig{NumReplicas: 3})
+		require.NoError(t, err)
+		require.Empty(t, res.AddedReplicas)
+		require.Empty(t, res.RemovedReplicas)
+		require.Zero(t, res.LeaseTarget)
+		require.NoError(t, res.Unsatisfiable)
+	})
+
+	t.Run("up-replicate", func(t *testing.T) {
+		desc := makeDesc(1, 2, 3)
+		res, err := newPlanner(t).PlanSpanConfigChange(ctx, desc, 1, 100, roachpb.SpanConfig{NumReplicas: 5})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []roachpb.StoreID{4, 5}, storeIDs(res.AddedReplicas))
+		require.Empty(t, res.RemovedReplicas)
+		require.Zero(t, res.LeaseTarget)
+		require.NoError(t, res.Unsatisfiable)
+		// The descriptor passed in is left untouched.
+		require.Len(t, desc.Replicas().Descriptors(), 3)
+	})
+
+	t.Run("lease preferences", func(t *testing.T) {
+		// Of the voters in region a, store 2 holds the fewest leases.
+		res, err := newPlanner(t).PlanSpanConfigChange(ctx, makeDesc(1, 2, 4), 4, 100, roachpb.SpanConfig{
+			NumReplicas:      3,
+			LeasePreferences: []roachpb.LeasePreference{{Constraints: regionConstraint("a")}},
+		})
+		require.NoError(t, err)
+		require.Empty(t, res.AddedReplicas)
+		require.Empty(t, res.RemovedReplicas)
+		require.Equal(t, roachpb.StoreID(2), res.LeaseTarget)
+		require.NoError(t, res.Unsatisfiable)
+	})
+
+	t.Run("unsatisfiable", func(t *testing.T) {
+		// Only two stores are in region b, so the third replica can't be placed.
+		res, err := newPlanner(t).PlanSpanConfigChange(ctx, makeDesc(1, 2, 3), 1, 100, roachpb.SpanConfig{
+			NumReplicas: 3,
+			Constraints: []roachpb.ConstraintsConjunction{{Constraints: regionConstraint("b")}},
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []roachpb.StoreID{4, 5}, storeIDs(res.AddedReplicas))
+		require.Len(t, res.RemovedReplicas, 2)
+		require.Error(t, res.Unsatisfiable)
+	})
+
+	t.Run("accumulated moves", func(t *testing.T) {
+		// Each range up-replicates to region b. Once the first range is planned
+		// to move to a store there, the second one favors the other store, as
+		// the first now holds one more range.
+		p := newPlanner(t)
+		conf := roachpb.SpanConfig{NumReplicas: 3}
+		first, err := p.PlanSpanConfigChange(ctx, makeDesc(1, 2), 1, 100, conf)
+		require.NoError(t, err)
+		require.Len(t, first.AddedReplicas, 1)
+		second, err := p.PlanSpanConfigChange(ctx, makeDesc(1, 2), 1, 100, conf)
+		require.NoError(t, err)
+		require.Len(t, second.AddedReplicas, 1)
+		require.ElementsMatch(t, []roachpb.StoreID{4, 5},
+			storeIDs(append(first.AddedReplicas, second.AddedReplicas...)))
+
+		// The store pool of the store is left untouched.
+		for _, storeID := range []roachpb.StoreID{4, 5} {
+			desc, ok := sp.GetStoreDescriptor(storeID)
+			require.True(t, ok)
+			require.Equal(t, int32(10), desc.Capacity.RangeCount)
+		}
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package kvserver
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/split"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+)
+
+// StoreAppendOnlyHotspot is an append-only hotspot detected by a store.
+type StoreAppendOnlyHotspot struct {
+	NodeID  roachpb.NodeID
+	StoreID roachpb.StoreID
+	split.AppendOnlyHotspot
+}
+
+// InspectAllAppendOnlyHotspots is an interface that allows for the per-store
+// append-only hotspots detected by load-based splitting to be combined into a
+// per-node view. It powers the inspectz append-only hotspots functionality.
+type InspectAllAppendOnlyHotspots interface {
+	InspectAllAppendOnlyHotspots() ([]StoreAppendOnlyHotspot, error)
+}
+
+var _ InspectAllAppendOnlyHotspots = (*Stores)(nil)
+
+// InspectAllAppendOnlyHotspots implements the InspectAllAppendOnlyHotspots
+// interface. It iterates over all stores and aggregates their append-only
+// hotspots.
+func (ls *Stores) InspectAllAppendOnlyHotspots() ([]StoreAppendOnlyHotspot, error) {
+	var hotspots []StoreAppendOnlyHotspot
+	err := ls.VisitStores(func(s *Store) error {
+		now := s.Clock().PhysicalTime()
+		for _, h := range s.appendOnlyDetector.Hotspots(now) {
+			hotspots = append(hotspots, StoreAppendOnlyHotspot{
+				NodeID:            s.NodeID(),
+				StoreID:           s.StoreID(),
+				AppendOnlyHotspot: h,
+			})
+		}
+		return nil
+	})
+	return hotspots, err
+}
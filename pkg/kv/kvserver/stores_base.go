@@ -94,3 +94,9 @@ func (s *baseStore) GetReplicaMutexForTesting(rangeID roachpb.RangeID) *syncutil
 	}
 	return nil
 }
+
+// NewSpanConfigChangePlanner is part of kvserverbase.Store.
+func (s *baseStore) NewSpanConfigChangePlanner() (kvserverbase.SpanConfigChangePlanner, error) {
+	store := (*Store)(s)
+	return store.NewSpanConfigChangePlanner()
+}
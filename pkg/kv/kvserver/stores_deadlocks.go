@@ -0,0 +1,36 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package kvserver
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/txnwait"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+)
+
+// StoreDeadlock is a transaction deadlock detected by a store's wait queues.
+type StoreDeadlock struct {
+	NodeID  roachpb.NodeID
+	StoreID roachpb.StoreID
+	txnwait.Deadlock
+}
+
+// DeadlocksSince returns the deadlocks detected by each store on this node
+// with a sequence number greater than the one provided for the store in after.
+// Stores missing from after return all of their retained deadlocks.
+func (ls *Stores) DeadlocksSince(after map[roachpb.StoreID]uint64) ([]StoreDeadlock, error) {
+	var deadlocks []StoreDeadlock
+	err := ls.VisitStores(func(s *Store) error {
+		for _, d := range s.txnWaitDeadlocks.Since(after[s.StoreID()]) {
+			deadlocks = append(deadlocks, StoreDeadlock{
+				NodeID:   s.NodeID(),
+				StoreID:  s.StoreID(),
+				Deadlock: d,
+			})
+		}
+		return nil
+	})
+	return deadlocks, err
+}
@@ -717,6 +717,7 @@ func TestTxnWaitQueueDependencyCycle(t *testing.T) {
 		PushType:  kvpb.PUSH_ABORT,
 		PusherTxn: *txnA,
 		PusheeTxn: txnB.TxnMeta,
+		LockKey:   roachpb.Key("lock-a"),
 	}
 	reqB := &kvpb.PushTxnRequest{
 		RequestHeader: kvpb.RequestHeader{
@@ -725,6 +726,7 @@ func TestTxnWaitQueueDependencyCycle(t *testing.T) {
 		PushType:  kvpb.PUSH_ABORT,
 		PusherTxn: *txnB,
 		PusheeTxn: txnC.TxnMeta,
+		LockKey:   roachpb.Key("lock-b"),
 	}
 	reqC := &kvpb.PushTxnRequest{
 		RequestHeader: kvpb.RequestHeader{
@@ -733,6 +735,7 @@ func TestTxnWaitQueueDependencyCycle(t *testing.T) {
 		PushType:  kvpb.PUSH_ABORT,
 		PusherTxn: *txnC,
 		PusheeTxn: txnA.TxnMeta,
+		LockKey:   roachpb.Key("lock-c"),
 	}
 	wp := lock.WaitPolicy_Block
 
@@ -779,6 +782,23 @@ func TestTxnWaitQueueDependencyCycle(t *testing.T) {
 	}
 	require.True(t, pushed)
 	require.GreaterOrEqual(t, m.DeadlocksTotal.Count(), int64(1))
+
+	// The deadlock is recorded with every member of the cycle, along with the
+	// lock it was blocked on.
+	deadlocks := tc.store.txnWaitDeadlocks.Since(0)
+	require.NotEmpty(t, deadlocks)
+	members := deadlocks[0].Members
+	require.Len(t, members, 3)
+	lockKeys := map[uuid.UUID]roachpb.Key{}
+	for i, member := range members {
+		require.Equal(t, members[(i+1)%len(members)].Txn.ID, member.WaitingOn)
+		lockKeys[member.Txn.ID] = member.LockKey
+	}
+	require.Equal(t, map[uuid.UUID]roachpb.Key{
+		txnA.ID: roachpb.Key("lock-a"),
+		txnB.ID: roachpb.Key("lock-b"),
+		txnC.ID: roachpb.Key("lock-c"),
+	}, lockKeys)
 }
 
 // TestTxnWaitQueueDependencyCycleWithPriorityInversion verifies that
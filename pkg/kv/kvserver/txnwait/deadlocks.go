@@ -0,0 +1,173 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package txnwait
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+)
+
+// defaultDeadlockHistorySize is the number of deadlocks retained by a
+// DeadlockHistory before the oldest entries are evicted.
+const defaultDeadlockHistorySize = 128
+
+// Deadlock describes a dependency cycle between transactions that was detected
+// and broken by a Queue.
+type Deadlock struct {
+	// Seq is a sequence number assigned by the DeadlockHistory that recorded
+	// the deadlock. It is strictly increasing across the deadlocks recorded by
+	// a single history.
+	Seq uint64
+	// DetectedAt is the time at which the cycle was detected.
+	DetectedAt time.Time
+	// RangeID is the range whose wait queue detected the cycle.
+	RangeID roachpb.RangeID
+	// Members are the transactions of the cycle, in order: each member was
+	// waiting on the next one, and the last one on the first one. The first
+	// member is the transaction which detected the cycle, and the second one
+	// the transaction which was aborted to break it.
+	//
+	// The members between the aborted transaction and the detecting one are
+	// known from the wait queues they were waiting in. If some of these queues
+	// are on nodes which predate the tracking of waiting transactions, only the
+	// first two members are known.
+	Members []DeadlockMember
+}
+
+// DeadlockMember is a transaction of a deadlock cycle.
+type DeadlockMember struct {
+	// Txn is the transaction.
+	Txn enginepb.TxnMeta
+	// WaitingOn is the ID of the transaction it was waiting on, or nil if
+	// unknown.
+	WaitingOn uuid.UUID
+	// LockKey is the key of the lock on which it was blocked, if known. See
+	// kvpb.PushTxnRequest.LockKey.
+	LockKey roachpb.Key
+	// WaitDuration is the time it had spent waiting on WaitingOn when the
+	// cycle was detected.
+	WaitDuration time.Duration
+}
+
+// makeDeadlock describes the deadlock detected by the pusher of a push, given
+// the transactions known to be transitively waiting on the pusher.
+func makeDeadlock(
+	now hlc.Timestamp,
+	rangeID roachpb.RangeID,
+	pusher kvpb.WaitingTxn,
+	pushee enginepb.TxnMeta,
+	waitingTxns []kvpb.WaitingTxn,
+) Deadlock {
+	member := func(w kvpb.WaitingTxn) DeadlockMember {
+		m := DeadlockMember{Txn: w.Txn, WaitingOn: w.PusheeTxnID, LockKey: w.LockKey}
+		if w.WaitStart.IsSet() && w.WaitStart.Less(now) {
+			m.WaitDuration = now.GoTime().Sub(w.WaitStart.GoTime())
+		}
+		return m
+	}
+	d := Deadlock{
+		DetectedAt: now.GoTime(),
+		RangeID:    rangeID,
+		Members:    []DeadlockMember{member(pusher)},
+	}
+
+	// Search for the path from the pushee to the pusher through the waiting
+	// transactions, breadth first.
+	waitsOf := make(map[uuid.UUID][]int, len(waitingTxns))
+	for i, w := range waitingTxns {
+		waitsOf[w.Txn.ID] = append(waitsOf[w.Txn.ID], i)
+	}
+	// via is the index of the wait through which each visited transaction was
+	// reached, or -1 for the pushee.
+	via := map[uuid.UUID]int{pushee.ID: -1}
+	queue := []uuid.UUID{pushee.ID}
+	for len(queue) > 0 {
+		txnID := queue[0]
+		queue = queue[1:]
+		for _, i := range waitsOf[txnID] {
+			next := waitingTxns[i].PusheeTxnID
+			if next == pusher.Txn.ID {
+				// Walk back from the last wait of the cycle to the pushee.
+				var path []DeadlockMember
+				for j := i; j != -1; j = via[waitingTxns[j].Txn.ID] {
+					path = append(path, member(waitingTxns[j]))
+				}
+				for k := len(path) - 1; k >= 0; k-- {
+					d.Members = append(d.Members, path[k])
+				}
+				return d
+			}
+			if _, ok := via[next]; !ok {
+				via[next] = i
+				queue = append(queue, next)
+			}
+		}
+	}
+	d.Members = append(d.Members, DeadlockMember{Txn: pushee})
+	return d
+}
+
+// DeadlockHistory is a bounded, in-memory record of the deadlocks detected by
+// the wait queues of a store. It is safe for concurrent use. A nil
+// DeadlockHistory discards all deadlocks recorded to it.
+type DeadlockHistory struct {
+	mu struct {
+		syncutil.Mutex
+		seq uint64
+		// buf is a ring buffer of the most recently recorded deadlocks, in
+		// order of increasing sequence number starting at start.
+		buf   []Deadlock
+		start int
+	}
+	size int
+}
+
+// NewDeadlockHistory creates a new DeadlockHistory.
+func NewDeadlockHistory() *DeadlockHistory {
+	return &DeadlockHistory{size: defaultDeadlockHistorySize}
+}
+
+// Record adds the deadlock to the history, evicting the oldest deadlock if the
+// history is full. The deadlock's Seq is assigned by the history.
+func (h *DeadlockHistory) Record(d Deadlock) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.mu.seq++
+	d.Seq = h.mu.seq
+	if len(h.mu.buf) < h.size {
+		h.mu.buf = append(h.mu.buf, d)
+		return
+	}
+	h.mu.buf[h.mu.start] = d
+	h.mu.start = (h.mu.start + 1) % len(h.mu.buf)
+}
+
+// Since returns the retained deadlocks with a sequence number greater than
+// seq, in order of increasing sequence number.
+func (h *DeadlockHistory) Since(seq uint64) []Deadlock {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var res []Deadlock
+	for i := range h.mu.buf {
+		d := h.mu.buf[(h.mu.start+i)%len(h.mu.buf)]
+		if d.Seq > seq {
+			res = append(res, d)
+		}
+	}
+	return res
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package txnwait
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadlockHistory(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	seqs := func(ds []Deadlock) []uint64 {
+		var res []uint64
+		for _, d := range ds {
+			res = append(res, d.Seq)
+		}
+		return res
+	}
+
+	// A nil history discards deadlocks.
+	var nilHistory *DeadlockHistory
+	nilHistory.Record(Deadlock{})
+	require.Empty(t, nilHistory.Since(0))
+
+	h := NewDeadlockHistory()
+	h.size = 3
+	require.Empty(t, h.Since(0))
+
+	members := []DeadlockMember{{LockKey: roachpb.Key("a")}}
+	h.Record(Deadlock{RangeID: 1, Members: members})
+	h.Record(Deadlock{RangeID: 2})
+	ds := h.Since(0)
+	require.Equal(t, []uint64{1, 2}, seqs(ds))
+	require.Equal(t, roachpb.RangeID(1), ds[0].RangeID)
+	require.Equal(t, members, ds[0].Members)
+	require.Equal(t, []uint64{2}, seqs(h.Since(1)))
+	require.Empty(t, h.Since(2))
+
+	// Filling the history evicts the oldest deadlocks.
+	h.Record(Deadlock{RangeID: 3})
+	h.Record(Deadlock{RangeID: 4})
+	h.Record(Deadlock{RangeID: 5})
+	require.Equal(t, []uint64{3, 4, 5}, seqs(h.Since(0)))
+	require.Equal(t, []uint64{5}, seqs(h.Since(4)))
+}
+
+func TestMakeDeadlock(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	txn := func() enginepb.TxnMeta { return enginepb.TxnMeta{ID: uuid.MakeV4()} }
+	a, b, c, d := txn(), txn(), txn(), txn()
+	now := hlc.Timestamp{WallTime: 10 * time.Second.Nanoseconds()}
+	waiting := func(txn, pushee enginepb.TxnMeta, key string, wait time.Duration) kvpb.WaitingTxn {
+		return kvpb.WaitingTxn{
+			Txn:         txn,
+			PusheeTxnID: pushee.ID,
+			LockKey:     roachpb.Key(key),
+			WaitStart:   now.Add(-wait.Nanoseconds(), 0),
+		}
+	}
+	member := func(txn, waitingOn enginepb.TxnMeta, key string, wait time.Duration) DeadlockMember {
+		return DeadlockMember{
+			Txn:          txn,
+			WaitingOn:    waitingOn.ID,
+			LockKey:      roachpb.Key(key),
+			WaitDuration: wait,
+		}
+	}
+
+	// a waits on b, which waits on c, which waits on a. d waits on a and c, but
+	// is not part of the cycle.
+	deadlock := makeDeadlock(now, 5, waiting(a, b, "a", time.Second), b, []kvpb.WaitingTxn{
+		waiting(d, a, "d", time.Minute),
+		waiting(c, a, "c", 3*time.Second),
+		waiting(d, c, "d", time.Minute),
+		waiting(b, c, "b", 2*time.Second),
+	})
+	require.Equal(t, Deadlock{
+		DetectedAt: now.GoTime(),
+		RangeID:    5,
+		Members: []DeadlockMember{
+			member(a, b, "a", time.Second),
+			member(b, c, "b", 2*time.Second),
+			member(c, a, "c", 3*time.Second),
+		},
+	}, deadlock)
+
+	// If the path from the pushee to the pusher is unknown, only the pusher and
+	// the pushee are recorded.
+	deadlock = makeDeadlock(now, 5, waiting(a, b, "a", time.Second), b, []kvpb.WaitingTxn{
+		waiting(c, a, "c", 3*time.Second),
+	})
+	require.Equal(t, []DeadlockMember{member(a, b, "a", time.Second), {Txn: b}}, deadlock.Members)
+}
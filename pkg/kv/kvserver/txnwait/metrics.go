@@ -20,6 +20,7 @@ type Metrics struct {
 	PusherWaitTime metric.IHistogram
 	QueryWaitTime  metric.IHistogram
 	DeadlocksTotal *metric.Counter
+	YieldsTotal    *metric.Counter
 }
 
 // NewMetrics creates a new Metrics instance with all related metric fields.
@@ -95,5 +96,14 @@ func NewMetrics(histogramWindowInterval time.Duration) *Metrics {
 				Unit:        metric.Unit_COUNT,
 			},
 		),
+
+		YieldsTotal: metric.NewCounter(
+			metric.Metadata{
+				Name:        "txnwaitqueue.yields_total",
+				Help:        "Number of batch transactions pushed to yield to interactive transactions waiting on them",
+				Measurement: "Yields",
+				Unit:        metric.Unit_COUNT,
+			},
+		),
 	}
 }
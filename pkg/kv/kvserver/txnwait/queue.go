@@ -20,6 +20,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -62,6 +64,19 @@ func TestingOverrideTxnLivenessThreshold(t time.Duration) func() {
 	}
 }
 
+// BatchYieldThreshold is the duration that an interactive transaction waits on
+// a batch transaction in the txn wait queue before asking it to yield. See
+// roachpb.TransactionClass.
+var BatchYieldThreshold = settings.RegisterDurationSetting(
+	settings.SystemOnly,
+	"kv.txn_wait_queue.batch_yield_threshold",
+	"the duration after which an interactive transaction waiting on a lock "+
+		"held by a batch transaction pushes the batch transaction regardless of "+
+		"priorities; set to 0 to disable",
+	5*time.Second,
+	settings.NonNegativeDuration,
+)
+
 // ShouldPushImmediately returns whether the PushTxn request should proceed
 // without queueing. This is true for pushes which are neither ABORT nor
 // TIMESTAMP, but also for ABORT and TIMESTAMP pushes with WaitPolicy_Error or
@@ -69,7 +84,7 @@ func TestingOverrideTxnLivenessThreshold(t time.Duration) func() {
 func ShouldPushImmediately(
 	req *kvpb.PushTxnRequest, pusheeStatus roachpb.TransactionStatus, wp lock.WaitPolicy,
 ) bool {
-	if req.Force || wp == lock.WaitPolicy_Error {
+	if req.Force || req.Yield || wp == lock.WaitPolicy_Error {
 		return true
 	}
 	return CanPushWithPriority(
@@ -154,6 +169,22 @@ func CanPushWithPriority(
 	}
 }
 
+// CanPushWithYield returns true if a push issued by a pusher of the given
+// transaction class is allowed to override the priority checks against a
+// pushee of the given class and status because the pushee is expected to
+// yield. Only batch transactions yield, and only to interactive transactions.
+func CanPushWithYield(
+	pusherClass, pusheeClass roachpb.TransactionClass, pusheeStatus roachpb.TransactionStatus,
+) bool {
+	// A prepared transaction must be guaranteed to succeed if it decides to
+	// commit, so it never yields.
+	if pusheeStatus == roachpb.PREPARED {
+		return false
+	}
+	return pusherClass == roachpb.TransactionClass_INTERACTIVE &&
+		pusheeClass == roachpb.TransactionClass_BATCH
+}
+
 // isPushed returns whether the PushTxn request has already been
 // fulfilled by the current transaction state. This may be true
 // for transactions with pushed timestamps.
@@ -190,11 +221,42 @@ func createPushTxnResponse(txn *roachpb.Transaction) *kvpb.PushTxnResponse {
 // dependency cycles.
 type waitingPush struct {
 	req *kvpb.PushTxnRequest
+	// waitStart is the time at which the push started waiting in the queue.
+	waitStart hlc.Timestamp
 	// pending channel receives updated, pushed txn or nil if queue is cleared.
 	pending chan *roachpb.Transaction
 	mu      struct {
 		syncutil.Mutex
 		dependents map[uuid.UUID]struct{} // transitive set of txns waiting on this txn
+		// waitingTxns describes what the dependents which are known to be
+		// waiting in a txn wait queue are waiting on. It is used to describe the
+		// members of a deadlock, once detected.
+		waitingTxns map[waitingTxnKey]kvpb.WaitingTxn
+	}
+}
+
+// waitingTxnKey identifies a kvpb.WaitingTxn. A transaction may be waiting on
+// multiple transactions at once, through concurrent requests.
+type waitingTxnKey struct {
+	txnID, pusheeTxnID uuid.UUID
+}
+
+// waitingTxn returns the description of the push, whose pusher must be a
+// transaction.
+func (push *waitingPush) waitingTxn() kvpb.WaitingTxn {
+	return kvpb.WaitingTxn{
+		Txn:         push.req.PusherTxn.TxnMeta,
+		PusheeTxnID: push.req.PusheeTxn.ID,
+		LockKey:     push.req.LockKey,
+		WaitStart:   push.waitStart,
+	}
+}
+
+// addWaitingTxnsLocked adds the descriptions of the transactions waiting on
+// the pusher, which are transitively waiting on the push, to the map.
+func (push *waitingPush) addWaitingTxnsLocked(m map[waitingTxnKey]kvpb.WaitingTxn) {
+	for k, w := range push.mu.waitingTxns {
+		m[k] = w
 	}
 }
 
@@ -238,6 +300,26 @@ func (pt *pendingTxn) takeWaitingPushes() *list.List {
 	return wp
 }
 
+// getWaitingTxns returns the descriptions of the transactions waiting on the
+// pending transaction, either directly or indirectly.
+func (pt *pendingTxn) getWaitingTxns() []kvpb.WaitingTxn {
+	m := map[waitingTxnKey]kvpb.WaitingTxn{}
+	for e := pt.waitingPushes.Front(); e != nil; e = e.Next() {
+		push := e.Value.(*waitingPush)
+		if id := push.req.PusherTxn.ID; id != (uuid.UUID{}) {
+			m[waitingTxnKey{txnID: id, pusheeTxnID: push.req.PusheeTxn.ID}] = push.waitingTxn()
+			push.mu.Lock()
+			push.addWaitingTxnsLocked(m)
+			push.mu.Unlock()
+		}
+	}
+	waitingTxns := make([]kvpb.WaitingTxn, 0, len(m))
+	for _, w := range m {
+		waitingTxns = append(waitingTxns, w)
+	}
+	return waitingTxns
+}
+
 func (pt *pendingTxn) getDependentsSet() map[uuid.UUID]struct{} {
 	set := map[uuid.UUID]struct{}{}
 	for e := pt.waitingPushes.Front(); e != nil; e = e.Next() {
@@ -260,7 +342,10 @@ type Config struct {
 	DB        *kv.DB
 	Clock     *hlc.Clock
 	Stopper   *stop.Stopper
+	Settings  *cluster.Settings
 	Metrics   *Metrics
+	// Deadlocks, if set, records the deadlocks detected by the queue.
+	Deadlocks *DeadlockHistory
 	Knobs     TestingKnobs
 }
 
@@ -532,6 +617,22 @@ func (q *Queue) GetDependents(txnID uuid.UUID) []uuid.UUID {
 	return nil
 }
 
+// GetWaitingTxns returns the descriptions of the transactions waiting on the
+// specified txn either directly or indirectly, for those known to be waiting
+// in a txn wait queue.
+func (q *Queue) GetWaitingTxns(txnID uuid.UUID) []kvpb.WaitingTxn {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.mu.txns == nil {
+		// Not enabled; do nothing.
+		return nil
+	}
+	if pending, ok := q.mu.txns[txnID]; ok {
+		return pending.getWaitingTxns()
+	}
+	return nil
+}
+
 // isTxnUpdated returns whether the transaction specified in
 // the QueryTxnRequest has had its status or priority updated
 // or whether the known set of dependent transactions has
@@ -601,8 +702,9 @@ func (q *Queue) MaybeWaitForPush(
 	}
 
 	push := &waitingPush{
-		req:     req,
-		pending: make(chan *roachpb.Transaction, 1),
+		req:       req,
+		waitStart: q.cfg.Clock.Now(),
+		pending:   make(chan *roachpb.Transaction, 1),
 	}
 	pushElem := pending.waitingPushes.PushBack(push)
 	waitingPushesCount := pending.waitingPushes.Len()
@@ -694,6 +796,16 @@ func (q *Queue) waitForPush(
 	// itself up after the pusher saw an intent but before it entered this
 	// queue.
 	pusheeTxnTimer.Reset(0)
+
+	// Interactive pushers ask batch pushees to yield once they have waited for
+	// longer than the configured threshold.
+	var yieldTimer timeutil.Timer
+	defer yieldTimer.Stop()
+	if req.PusherTxn.Class == roachpb.TransactionClass_INTERACTIVE {
+		if threshold := BatchYieldThreshold.Get(&q.cfg.Settings.SV); threshold > 0 {
+			yieldTimer.Reset(threshold)
+		}
+	}
 	for {
 		select {
 		case <-slowTimer.C:
@@ -743,7 +855,7 @@ func (q *Queue) waitForPush(
 			log.VEvent(ctx, 2, "querying pushee")
 			pusheeTxnTimer.Read = true
 			// Periodically check whether the pushee txn has been abandoned.
-			updatedPushee, _, pErr := q.queryTxnStatus(
+			updatedPushee, _, _, pErr := q.queryTxnStatus(
 				ctx, req.PusheeTxn, false, nil,
 			)
 			if pErr != nil {
@@ -791,6 +903,33 @@ func (q *Queue) waitForPush(
 			now := q.cfg.Clock.Now().GoTime()
 			pusheeTxnTimer.Reset(expiration.Sub(now))
 
+		case <-yieldTimer.C:
+			yieldTimer.Read = true
+			// Only ask the pushee to yield if its transaction record indicates
+			// that it is a batch transaction. The class of a pushee without a
+			// transaction record is unknown, so it is left alone.
+			pushee := pending.getTxn()
+			if !CanPushWithYield(req.PusherTxn.Class, pushee.Class, pushee.Status) {
+				continue
+			}
+			log.VEventf(ctx, 1, "%s waited %.2fs on batch txn %s; asking it to yield",
+				req.PusherTxn.ID.Short(),
+				timeutil.Since(tBegin).Seconds(),
+				req.PusheeTxn.ID.Short(),
+			)
+			resp, pErr := q.yieldPush(ctx, req)
+			if pErr != nil {
+				if _, ok := pErr.GetDetail().(*kvpb.TransactionPushError); ok {
+					// The pushee could not be pushed (e.g. because it has been
+					// prepared in the meantime). Continue waiting.
+					log.VEventf(ctx, 2, "batch txn %s did not yield: %v", req.PusheeTxn.ID.Short(), pErr)
+					continue
+				}
+				return nil, pErr
+			}
+			metrics.YieldsTotal.Inc(1)
+			return resp, nil
+
 		case updatedPusher := <-queryPusherCh:
 			switch updatedPusher.Status {
 			case roachpb.COMMITTED:
@@ -853,6 +992,17 @@ func (q *Queue) waitForPush(
 						dependents,
 					)
 					metrics.DeadlocksTotal.Inc(1)
+					if q.cfg.Deadlocks != nil {
+						push.mu.Lock()
+						waitingTxns := make([]kvpb.WaitingTxn, 0, len(push.mu.waitingTxns))
+						for _, w := range push.mu.waitingTxns {
+							waitingTxns = append(waitingTxns, w)
+						}
+						push.mu.Unlock()
+						q.cfg.Deadlocks.Record(makeDeadlock(
+							q.cfg.Clock.Now(), q.cfg.RangeDesc.RangeID, push.waitingTxn(), req.PusheeTxn, waitingTxns,
+						))
+					}
 					return q.forcePushAbort(ctx, req)
 				}
 			}
@@ -985,7 +1135,8 @@ func (q *Queue) startQueryPusherTxn(
 			for r := retry.StartWithCtx(ctx, base.DefaultRetryOptions()); r.Next(); {
 				var pErr *kvpb.Error
 				var updatedPusher *roachpb.Transaction
-				updatedPusher, waitingTxns, pErr = q.queryTxnStatus(
+				var waitingTxnDetails []kvpb.WaitingTxn
+				updatedPusher, waitingTxns, waitingTxnDetails, pErr = q.queryTxnStatus(
 					ctx, pusher.TxnMeta, true, waitingTxns,
 				)
 				if pErr != nil {
@@ -1013,6 +1164,12 @@ func (q *Queue) startQueryPusherTxn(
 				for _, txnID := range waitingTxns {
 					push.mu.dependents[txnID] = struct{}{}
 				}
+				if len(waitingTxnDetails) > 0 && push.mu.waitingTxns == nil {
+					push.mu.waitingTxns = map[waitingTxnKey]kvpb.WaitingTxn{}
+				}
+				for _, w := range waitingTxnDetails {
+					push.mu.waitingTxns[waitingTxnKey{txnID: w.Txn.ID, pusheeTxnID: w.PusheeTxnID}] = w
+				}
 				push.mu.Unlock()
 
 				// Send an update of the pusher txn.
@@ -1045,10 +1202,11 @@ func (q *Queue) startQueryPusherTxn(
 // information about their own txns.
 //
 // Returns the updated transaction (or nil if not updated) as well as
-// the list of transactions which are waiting on the updated txn.
+// the list of transactions which are waiting on the updated txn and the
+// descriptions of those known to be waiting in a txn wait queue.
 func (q *Queue) queryTxnStatus(
 	ctx context.Context, txnMeta enginepb.TxnMeta, wait bool, dependents []uuid.UUID,
-) (*roachpb.Transaction, []uuid.UUID, *kvpb.Error) {
+) (*roachpb.Transaction, []uuid.UUID, []kvpb.WaitingTxn, *kvpb.Error) {
 	b := &kv.Batch{}
 	b.Header.Timestamp = q.cfg.Clock.Now()
 	b.AddRawRequest(&kvpb.QueryTxnRequest{
@@ -1081,11 +1239,11 @@ func (q *Queue) queryTxnStatus(
 		//
 		// so something is sketchy here, but it should all resolve nicely when we
 		// don't use store.db for these internal requests any more.
-		return nil, nil, kvpb.NewError(err)
+		return nil, nil, nil, kvpb.NewError(err)
 	}
 	br := b.RawResponse()
 	resp := br.Responses[0].GetInner().(*kvpb.QueryTxnResponse)
-	return &resp.QueriedTxn, resp.WaitingTxns, nil
+	return &resp.QueriedTxn, resp.WaitingTxns, resp.WaitingTxnDetails, nil
 }
 
 // forcePushAbort upgrades the PushTxn request to a "forced" push abort, which
@@ -1109,6 +1267,26 @@ func (q *Queue) forcePushAbort(
 	return b.RawResponse().Responses[0].GetPushTxn(), nil
 }
 
+// yieldPush resends the PushTxn request with the Yield flag set, which
+// overrides the normal priority checks if the pushee is a batch transaction and
+// the pusher is an interactive one. Unlike forcePushAbort, the push type of the
+// request is kept, so a pusher which only needs to push the pushee's timestamp
+// does not abort it.
+func (q *Queue) yieldPush(
+	ctx context.Context, req *kvpb.PushTxnRequest,
+) (*kvpb.PushTxnResponse, *kvpb.Error) {
+	yieldPush := *req
+	yieldPush.Yield = true
+	b := &kv.Batch{}
+	b.Header.Timestamp = q.cfg.Clock.Now()
+	b.Header.Timestamp.Forward(req.PushTo)
+	b.AddRawRequest(&yieldPush)
+	if err := q.cfg.DB.Run(ctx, b); err != nil {
+		return nil, b.MustPErr()
+	}
+	return b.RawResponse().Responses[0].GetPushTxn(), nil
+}
+
 // TrackedTxns returns a (newly minted) set containing the transaction IDs which
 // are being tracked (i.e. waited on).
 //
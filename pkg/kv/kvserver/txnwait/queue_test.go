@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -471,6 +472,33 @@ func testCanPushWithPriorityPushTouch(t *testing.T) {
 	}
 }
 
+func TestCanPushWithYield(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	interactive := roachpb.TransactionClass_INTERACTIVE
+	batch := roachpb.TransactionClass_BATCH
+	testCases := []struct {
+		pusherClass  roachpb.TransactionClass
+		pusheeClass  roachpb.TransactionClass
+		pusheeStatus roachpb.TransactionStatus
+		exp          bool
+	}{
+		{interactive, interactive, roachpb.PENDING, false},
+		{interactive, batch, roachpb.PENDING, true},
+		{interactive, batch, roachpb.STAGING, true},
+		{interactive, batch, roachpb.PREPARED, false},
+		{batch, interactive, roachpb.PENDING, false},
+		{batch, batch, roachpb.PENDING, false},
+	}
+	for _, test := range testCases {
+		name := fmt.Sprintf("pusher=%s/pushee=%s/status=%s",
+			test.pusherClass, test.pusheeClass, test.pusheeStatus)
+		t.Run(name, func(t *testing.T) {
+			canPush := CanPushWithYield(test.pusherClass, test.pusheeClass, test.pusheeStatus)
+			require.Equal(t, test.exp, canPush)
+		})
+	}
+}
+
 func makeTS(w int64, l int32) hlc.Timestamp {
 	return hlc.Timestamp{WallTime: w, Logical: l}
 }
@@ -524,6 +552,7 @@ func makeConfig(s kv.SenderFunc, stopper *stop.Stopper) Config {
 	}
 	cfg.Clock = hlc.NewClockForTesting(timeutil.NewManualTime(timeutil.Unix(0, 123)))
 	cfg.Stopper = stopper
+	cfg.Settings = cluster.MakeTestingClusterSettings()
 	cfg.Metrics = NewMetrics(time.Minute)
 	if s != nil {
 		factory := kv.NonTransactionalFactoryFunc(s)
@@ -679,3 +708,79 @@ func TestPushersReleasedAfterAnyQueryTxnFindsAbortedTxn(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+// TestMaybeWaitForPushYieldsToInteractiveTxn verifies that an interactive
+// pusher waiting on a batch pushee for longer than the batch yield threshold
+// reissues its push with the Yield flag set and its original push type, and
+// that this is reflected in the metrics.
+func TestMaybeWaitForPushYieldsToInteractiveTxn(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	testutils.RunValues(t, "push-type", []kvpb.PushTxnType{kvpb.PUSH_ABORT, kvpb.PUSH_TIMESTAMP},
+		func(t *testing.T, pushType kvpb.PushTxnType) {
+			testMaybeWaitForPushYieldsToInteractiveTxn(t, pushType)
+		})
+}
+
+func testMaybeWaitForPushYieldsToInteractiveTxn(t *testing.T, pushType kvpb.PushTxnType) {
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	var mockSender kv.SenderFunc
+	cfg := makeConfig(func(
+		ctx context.Context, ba *kvpb.BatchRequest,
+	) (*kvpb.BatchResponse, *kvpb.Error) {
+		return mockSender(ctx, ba)
+	}, stopper)
+	BatchYieldThreshold.Override(ctx, &cfg.Settings.SV, time.Millisecond)
+	q := NewQueue(cfg)
+	q.Enable(1 /* leaseSeq */)
+
+	// Set an extremely high transaction liveness threshold so that the pushee
+	// is not considered expired while the pusher waits.
+	defer TestingOverrideTxnLivenessThreshold(time.Hour)()
+
+	// Enqueue the batch pushee transaction in the queue.
+	txn := roachpb.MakeTransaction("test", nil, 0, 0, cfg.Clock.Now(), 0, 0, 0, false /* omitInRangefeeds */)
+	txn.Class = roachpb.TransactionClass_BATCH
+	q.EnqueueTxn(&txn)
+
+	var yieldPushes int32
+	mockSender = func(
+		ctx context.Context, ba *kvpb.BatchRequest,
+	) (*kvpb.BatchResponse, *kvpb.Error) {
+		br := ba.CreateReply()
+		switch req := ba.Requests[0].GetInner().(type) {
+		case *kvpb.QueryTxnRequest:
+			br.Responses[0].GetQueryTxn().QueriedTxn = txn
+		case *kvpb.PushTxnRequest:
+			require.True(t, req.Yield)
+			require.Equal(t, pushType, req.PushType)
+			atomic.AddInt32(&yieldPushes, 1)
+			resp := br.Responses[0].GetPushTxn()
+			resp.PusheeTxn = txn
+			if pushType == kvpb.PUSH_ABORT {
+				resp.PusheeTxn.Status = roachpb.ABORTED
+			} else {
+				resp.PusheeTxn.WriteTimestamp = req.PushTo.Next()
+			}
+		default:
+			t.Errorf("unexpected request: %v", req)
+		}
+		return br, nil
+	}
+
+	req := kvpb.PushTxnRequest{
+		PusheeTxn: txn.TxnMeta, PushTo: cfg.Clock.Now(), PushType: pushType,
+	}
+	res, pErr := q.MaybeWaitForPush(ctx, &req, lock.WaitPolicy_Block)
+	require.Nil(t, pErr)
+	require.NotNil(t, res)
+	if pushType == kvpb.PUSH_ABORT {
+		require.Equal(t, roachpb.ABORTED, res.PusheeTxn.Status)
+	} else {
+		require.Equal(t, roachpb.PENDING, res.PusheeTxn.Status)
+		require.True(t, req.PushTo.Less(res.PusheeTxn.WriteTimestamp))
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&yieldPushes))
+	require.Equal(t, int64(1), cfg.Metrics.YieldsTotal.Count())
+}
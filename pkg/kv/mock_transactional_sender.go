@@ -108,6 +108,16 @@ func (m *MockTransactionalSender) SetOmitInRangefeeds() {
 	m.txn.OmitInRangefeeds = true
 }
 
+// GetTransactionClass is part of the TxnSender interface.
+func (m *MockTransactionalSender) GetTransactionClass() roachpb.TransactionClass {
+	return m.txn.Class
+}
+
+// SetTransactionClass is part of the TxnSender interface.
+func (m *MockTransactionalSender) SetTransactionClass(class roachpb.TransactionClass) {
+	m.txn.Class = class
+}
+
 // SetBufferedWritesEnabled is part of the TxnSender interface.
 func (m *MockTransactionalSender) SetBufferedWritesEnabled(enabled bool) {}
 
@@ -127,6 +127,13 @@ type TxnSender interface {
 	// Transaction proto.
 	SetOmitInRangefeeds()
 
+	// GetTransactionClass returns the value of the Class attribute of the
+	// Transaction proto.
+	GetTransactionClass() roachpb.TransactionClass
+
+	// SetTransactionClass sets the Class attribute in the Transaction proto.
+	SetTransactionClass(roachpb.TransactionClass)
+
 	// SetBufferedWritesEnabled toggles whether the writes are buffered on the
 	// gateway node until the commit time. Only allowed on the RootTxn. Buffered
 	// writes cannot be enabled on a txn that performed any requests. When
@@ -546,6 +546,26 @@ func (txn *Txn) SetOmitInRangefeeds() {
 	txn.mu.sender.SetOmitInRangefeeds()
 }
 
+// GetTransactionClass returns the value of the Class attribute of the
+// Transaction proto of the sender.
+func (txn *Txn) GetTransactionClass() roachpb.TransactionClass {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.GetTransactionClass()
+}
+
+// SetTransactionClass sets the Class attribute in the Transaction proto of the
+// sender. Batch transactions yield their locks to interactive transactions that
+// have been waiting on them for too long.
+//
+// SetTransactionClass must be called before any operations are performed on
+// the transaction.
+func (txn *Txn) SetTransactionClass(class roachpb.TransactionClass) {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	txn.mu.sender.SetTransactionClass(class)
+}
+
 // NewBatch creates and returns a new empty batch object for use with the Txn.
 func (txn *Txn) NewBatch() *Batch {
 	return &Batch{txn: txn, AdmissionHeader: txn.AdmissionHeader()}
@@ -1498,6 +1498,12 @@ func (t *Transaction) Update(o *Transaction) {
 	// Ratchet the transaction priority.
 	t.UpgradePriority(o.Priority)
 
+	// The transaction class doesn't change after the transaction is created, so
+	// we don't ever expect to change it back to the default class.
+	if o.Class != TransactionClass_INTERACTIVE {
+		t.Class = o.Class
+	}
+
 	// The following fields are not present in TransactionRecord, so we need to be
 	// careful when updating them since Transaction o might be coming from a
 	// TransactionRecord. If the fields were previously set, do not overwrite them
@@ -1620,6 +1626,7 @@ func (t *Transaction) AsRecord() TransactionRecord {
 	tr.LockSpans = t.LockSpans
 	tr.InFlightWrites = t.InFlightWrites
 	tr.IgnoredSeqNums = t.IgnoredSeqNums
+	tr.Class = t.Class
 	return tr
 }
 
@@ -1634,6 +1641,7 @@ func (tr *TransactionRecord) AsTransaction() Transaction {
 	t.LockSpans = tr.LockSpans
 	t.InFlightWrites = tr.InFlightWrites
 	t.IgnoredSeqNums = tr.IgnoredSeqNums
+	t.Class = tr.Class
 	return t
 }
 
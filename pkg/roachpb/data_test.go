@@ -611,6 +611,7 @@ var nonZeroTxn = Transaction{
 	IgnoredSeqNums:     []enginepb.IgnoredSeqNumRange{{Start: 888, End: 999}},
 	AdmissionPriority:  1,
 	OmitInRangefeeds:   true,
+	Class:              TransactionClass_BATCH,
 }
 
 func TestTransactionUpdate(t *testing.T) {
@@ -939,6 +940,9 @@ func TestTransactionRecordRoundtrips(t *testing.T) {
 	if !reflect.DeepEqual(txnRecord.IgnoredSeqNums, txn.IgnoredSeqNums) {
 		t.Errorf("txnRecord.IgnoredSeqNums = %v, txn.IgnoredSeqNums = %v", txnRecord.IgnoredSeqNums, txn.IgnoredSeqNums)
 	}
+	if !reflect.DeepEqual(txnRecord.Class, txn.Class) {
+		t.Errorf("txnRecord.Class = %v, txn.Class = %v", txnRecord.Class, txn.Class)
+	}
 
 	// Verify that converting through a Transaction message and back
 	// to a TransactionRecord is a lossless round trip.
@@ -98,6 +98,9 @@ func (s *SpanConfig) ValidateSystemTargetSpanConfig() error {
 	if s.ExcludeDataFromBackup {
 		return errors.AssertionFailedf("ExcludeDataFromBackup set on system span config")
 	}
+	if s.ReadOnly {
+		return errors.AssertionFailedf("ReadOnly set on system span config")
+	}
 	return nil
 }
 
@@ -593,6 +593,7 @@ func NewNode(
 	n.diskSlowCoalescerMu.lastDiskSlow = make(map[roachpb.StoreID]time.Time)
 	n.versionUpdateMu.updateCh = make(chan struct{})
 	n.perReplicaServer = kvserver.MakeServer(&n.Descriptor, n.stores)
+	n.storeCfg.EventLogger = n.logStructuredEvent
 	return n
 }
 
@@ -0,0 +1,336 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats/persistedsqlstats/sqlstatsutil"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+)
+
+// deadlockReportInterval is how often the deadlocks detected by the
+// transaction wait queues of a node's stores are recorded in
+// system.transaction_deadlocks.
+var deadlockReportInterval = settings.RegisterDurationSetting(
+	settings.SystemOnly,
+	"kv.txn_wait_queue.deadlock_report.interval",
+	"the interval at which deadlocks detected by the transaction wait queues are "+
+		"recorded in system.transaction_deadlocks; set to 0 to disable",
+	10*time.Second,
+	settings.NonNegativeDuration,
+)
+
+// deadlockReportRetention is how long the deadlocks recorded in
+// system.transaction_deadlocks are retained.
+var deadlockReportRetention = settings.RegisterDurationSetting(
+	settings.SystemOnly,
+	"kv.txn_wait_queue.deadlock_report.retention",
+	"the amount of time deadlocks recorded in system.transaction_deadlocks are retained",
+	7*24*time.Hour,
+	settings.PositiveDuration,
+)
+
+// deadlockReportDisabledInterval is how often the reporting loop checks whether
+// deadlock reporting has been re-enabled.
+const deadlockReportDisabledInterval = time.Minute
+
+// deadlockGCInterval is how often each node deletes the deadlocks recorded in
+// system.transaction_deadlocks which are older than the retention.
+const deadlockGCInterval = time.Hour
+
+// deadlockGCBatchSize is the maximum number of rows deleted by a single
+// statement when deleting expired deadlocks.
+const deadlockGCBatchSize = 1000
+
+// maxDeadlockResolutionAttempts is the number of reporting rounds during which
+// the reporter attempts to resolve the fingerprint IDs of the transactions
+// involved in a deadlock before reporting it without them. Fingerprint IDs only
+// become available once the transactions finish, which for the transaction
+// that detected the deadlock may take a while.
+const maxDeadlockResolutionAttempts = 3
+
+// deadlockMemberRow is a row of system.transaction_deadlocks, describing a
+// member of a deadlock cycle.
+type deadlockMemberRow struct {
+	detectedAt time.Time
+	deadlockID uuid.UUID
+	position   int
+	nodeID     roachpb.NodeID
+	storeID    roachpb.StoreID
+	rangeID    roachpb.RangeID
+	txnID      uuid.UUID
+	// fingerprintID is the encoded fingerprint ID of the transaction, or nil if
+	// it was not resolved.
+	fingerprintID []byte
+	// waitingOn is the ID of the member the transaction waits on, or nil if not
+	// known.
+	waitingOn    uuid.UUID
+	lockKey      roachpb.Key
+	waitDuration time.Duration
+}
+
+// deadlockReporter records the deadlocks detected by the transaction wait
+// queues of a node's stores in system.transaction_deadlocks, after resolving
+// the fingerprint IDs of the transactions that formed the cycle.
+type deadlockReporter struct {
+	deadlocksSince func(map[roachpb.StoreID]uint64) ([]kvserver.StoreDeadlock, error)
+	resolve        func(context.Context, []enginepb.TxnMeta) (map[uuid.UUID]appstatspb.TransactionFingerprintID, []enginepb.TxnMeta, error)
+	write          func(context.Context, []deadlockMemberRow) error
+
+	// lastSeq is the sequence number of the last deadlock collected from each
+	// store.
+	lastSeq map[roachpb.StoreID]uint64
+	// pending are the collected deadlocks that have not been reported yet.
+	pending []pendingDeadlock
+	// fingerprints are the fingerprint IDs resolved for the transactions of
+	// pending deadlocks.
+	fingerprints map[uuid.UUID]appstatspb.TransactionFingerprintID
+}
+
+type pendingDeadlock struct {
+	kvserver.StoreDeadlock
+	attempts int
+}
+
+// resolved returns whether the fingerprint IDs of all the members of the
+// deadlock have been resolved.
+func (r *deadlockReporter) resolved(d pendingDeadlock) bool {
+	for _, m := range d.Members {
+		if _, ok := r.fingerprints[m.Txn.ID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// report collects the deadlocks detected since the last call, attempts to
+// resolve the fingerprint IDs of the transactions involved in the pending
+// deadlocks and records the deadlocks that are either fully resolved or out of
+// resolution attempts.
+func (r *deadlockReporter) report(ctx context.Context) {
+	deadlocks, err := r.deadlocksSince(r.lastSeq)
+	if err != nil {
+		log.Warningf(ctx, "failed to collect deadlocks: %v", err)
+	}
+	for _, d := range deadlocks {
+		if d.Seq > r.lastSeq[d.StoreID] {
+			r.lastSeq[d.StoreID] = d.Seq
+		}
+		r.pending = append(r.pending, pendingDeadlock{StoreDeadlock: d})
+	}
+	if len(r.pending) == 0 {
+		return
+	}
+
+	var toResolve []enginepb.TxnMeta
+	seen := make(map[uuid.UUID]struct{})
+	for _, d := range r.pending {
+		for _, m := range d.Members {
+			if _, ok := r.fingerprints[m.Txn.ID]; ok {
+				continue
+			}
+			if _, ok := seen[m.Txn.ID]; ok {
+				continue
+			}
+			seen[m.Txn.ID] = struct{}{}
+			toResolve = append(toResolve, m.Txn)
+		}
+	}
+	if len(toResolve) > 0 {
+		resolved, _, err := r.resolve(ctx, toResolve)
+		if err != nil {
+			log.VEventf(ctx, 2, "failed to resolve deadlocked transactions: %v", err)
+		}
+		for txnID, fingerprintID := range resolved {
+			r.fingerprints[txnID] = fingerprintID
+		}
+	}
+
+	var rows []deadlockMemberRow
+	remaining := r.pending[:0]
+	for _, d := range r.pending {
+		d.attempts++
+		if !r.resolved(d) && d.attempts < maxDeadlockResolutionAttempts {
+			remaining = append(remaining, d)
+			continue
+		}
+		rows = append(rows, r.makeRows(d.StoreDeadlock)...)
+	}
+	r.pending = remaining
+	if len(rows) > 0 {
+		if err := r.write(ctx, rows); err != nil {
+			log.Warningf(ctx, "failed to record deadlocks: %v", err)
+		}
+	}
+
+	// Forget the fingerprints that are no longer needed by pending deadlocks.
+	needed := make(map[uuid.UUID]struct{})
+	for _, d := range r.pending {
+		for _, m := range d.Members {
+			needed[m.Txn.ID] = struct{}{}
+		}
+	}
+	for txnID := range r.fingerprints {
+		if _, ok := needed[txnID]; !ok {
+			delete(r.fingerprints, txnID)
+		}
+	}
+}
+
+// makeRows returns the rows of system.transaction_deadlocks describing the
+// members of the deadlock.
+func (r *deadlockReporter) makeRows(d kvserver.StoreDeadlock) []deadlockMemberRow {
+	deadlockID := uuid.MakeV4()
+	rows := make([]deadlockMemberRow, 0, len(d.Members))
+	for i, m := range d.Members {
+		rows = append(rows, deadlockMemberRow{
+			detectedAt:    d.DetectedAt,
+			deadlockID:    deadlockID,
+			position:      i,
+			nodeID:        d.NodeID,
+			storeID:       d.StoreID,
+			rangeID:       d.RangeID,
+			txnID:         m.Txn.ID,
+			fingerprintID: r.encodeFingerprintID(m.Txn.ID),
+			waitingOn:     m.WaitingOn,
+			lockKey:       m.LockKey,
+			waitDuration:  m.WaitDuration,
+		})
+	}
+	return rows
+}
+
+// encodeFingerprintID returns the encoded fingerprint ID of the transaction, in
+// the same format used by the SQL statistics tables, or nil if it was not
+// resolved.
+func (r *deadlockReporter) encodeFingerprintID(txnID uuid.UUID) []byte {
+	fingerprintID, ok := r.fingerprints[txnID]
+	if !ok || fingerprintID == appstatspb.InvalidTransactionFingerprintID {
+		return nil
+	}
+	return sqlstatsutil.EncodeUint64ToBytes(uint64(fingerprintID))
+}
+
+// writeDeadlocks inserts the rows in system.transaction_deadlocks. The rows are
+// dropped if the table may not exist yet.
+func (n *Node) writeDeadlocks(ctx context.Context, rows []deadlockMemberRow) error {
+	if !n.storeCfg.Settings.Version.IsActive(ctx, clusterversion.V25_2_TransactionDeadlocksTable) {
+		log.VEventf(ctx, 2, "dropping %d deadlock members until system.transaction_deadlocks exists", len(rows))
+		return nil
+	}
+	const numCols = 11
+	var b strings.Builder
+	b.WriteString(`INSERT INTO system.transaction_deadlocks (
+  detected_at, deadlock_id, cycle_position, node_id, store_id, range_id,
+  txn_id, txn_fingerprint_id, waiting_on_txn_id, lock_key, wait_duration
+) VALUES `)
+	args := make([]interface{}, 0, numCols*len(rows))
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("(")
+		for j := 1; j <= numCols; j++ {
+			if j > 1 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "$%d", i*numCols+j)
+		}
+		b.WriteString(")")
+		// The member a transaction waits on, and for how long, are only known
+		// if the wait queue it waited in tracked it.
+		var waitingOn, waitDuration interface{}
+		if row.waitingOn != uuid.Nil {
+			waitingOn, waitDuration = row.waitingOn, row.waitDuration
+		}
+		args = append(args,
+			row.detectedAt, row.deadlockID, row.position, row.nodeID, row.storeID, row.rangeID,
+			row.txnID, row.fingerprintID, waitingOn, row.lockKey, waitDuration,
+		)
+	}
+	_, err := n.execCfg.InternalDB.Executor().ExecEx(
+		ctx, "insert-transaction-deadlocks", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		b.String(), args...,
+	)
+	return err
+}
+
+// deleteExpiredDeadlocks deletes the deadlocks recorded in
+// system.transaction_deadlocks which are older than the retention.
+func (n *Node) deleteExpiredDeadlocks(ctx context.Context) error {
+	if !n.storeCfg.Settings.Version.IsActive(ctx, clusterversion.V25_2_TransactionDeadlocksTable) {
+		return nil
+	}
+	before := timeutil.Now().Add(-deadlockReportRetention.Get(&n.storeCfg.Settings.SV))
+	for {
+		deleted, err := n.execCfg.InternalDB.Executor().ExecEx(
+			ctx, "delete-expired-transaction-deadlocks", nil, /* txn */
+			sessiondata.NodeUserSessionDataOverride,
+			`DELETE FROM system.transaction_deadlocks WHERE detected_at < $1 LIMIT $2`,
+			before, deadlockGCBatchSize,
+		)
+		if err != nil || deleted < deadlockGCBatchSize {
+			return err
+		}
+	}
+}
+
+// startReportingDeadlocks starts a loop which periodically records the
+// deadlocks detected by the node's stores in system.transaction_deadlocks, and
+// deletes the expired ones.
+func (n *Node) startReportingDeadlocks(stopper *stop.Stopper) {
+	ctx := n.AnnotateCtx(context.Background())
+	r := &deadlockReporter{
+		deadlocksSince: n.stores.DeadlocksSince,
+		resolve:        n.execCfg.ContentionRegistry.ResolveTxnFingerprintIDs,
+		write:          n.writeDeadlocks,
+		lastSeq:        make(map[roachpb.StoreID]uint64),
+		fingerprints:   make(map[uuid.UUID]appstatspb.TransactionFingerprintID),
+	}
+	_ = stopper.RunAsyncTask(ctx, "report-deadlocks", func(ctx context.Context) {
+		var timer timeutil.Timer
+		defer timer.Stop()
+		var lastGC time.Time
+		for {
+			interval := deadlockReportInterval.Get(&n.storeCfg.Settings.SV)
+			enabled := interval > 0
+			if !enabled {
+				interval = deadlockReportDisabledInterval
+			}
+			timer.Reset(interval)
+			select {
+			case <-timer.C:
+				timer.Read = true
+				if enabled {
+					r.report(ctx)
+				}
+				if timeutil.Since(lastGC) >= deadlockGCInterval {
+					lastGC = timeutil.Now()
+					if err := n.deleteExpiredDeadlocks(ctx); err != nil {
+						log.Warningf(ctx, "failed to delete expired deadlocks: %v", err)
+					}
+				}
+			case <-stopper.ShouldQuiesce():
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/txnwait"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadlockReporter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	pusher := enginepb.TxnMeta{ID: uuid.MakeV4(), CoordinatorNodeID: 1}
+	pushee := enginepb.TxnMeta{ID: uuid.MakeV4(), CoordinatorNodeID: 2}
+	other := enginepb.TxnMeta{ID: uuid.MakeV4(), CoordinatorNodeID: 2}
+	detectedAt := time.Unix(100, 0)
+
+	var deadlocks []kvserver.StoreDeadlock
+	fingerprints := map[uuid.UUID]appstatspb.TransactionFingerprintID{}
+	var rows []deadlockMemberRow
+	r := &deadlockReporter{
+		deadlocksSince: func(after map[roachpb.StoreID]uint64) ([]kvserver.StoreDeadlock, error) {
+			var res []kvserver.StoreDeadlock
+			for _, d := range deadlocks {
+				if d.Seq > after[d.StoreID] {
+					res = append(res, d)
+				}
+			}
+			return res, nil
+		},
+		resolve: func(
+			_ context.Context, txns []enginepb.TxnMeta,
+		) (map[uuid.UUID]appstatspb.TransactionFingerprintID, []enginepb.TxnMeta, error) {
+			resolved := map[uuid.UUID]appstatspb.TransactionFingerprintID{}
+			var unresolved []enginepb.TxnMeta
+			for _, txn := range txns {
+				if id, ok := fingerprints[txn.ID]; ok {
+					resolved[txn.ID] = id
+				} else {
+					unresolved = append(unresolved, txn)
+				}
+			}
+			return resolved, unresolved, nil
+		},
+		write: func(_ context.Context, written []deadlockMemberRow) error {
+			rows = append(rows, written...)
+			return nil
+		},
+		lastSeq:      make(map[roachpb.StoreID]uint64),
+		fingerprints: make(map[uuid.UUID]appstatspb.TransactionFingerprintID),
+	}
+
+	// A deadlock whose transactions are resolved is recorded right away, one
+	// row per member.
+	fingerprints[pusher.ID] = 0x1234
+	fingerprints[pushee.ID] = 0xabcd
+	fingerprints[other.ID] = 0x5678
+	deadlocks = append(deadlocks, kvserver.StoreDeadlock{
+		NodeID:  1,
+		StoreID: 2,
+		Deadlock: txnwait.Deadlock{
+			Seq:        1,
+			DetectedAt: detectedAt,
+			RangeID:    3,
+			Members: []txnwait.DeadlockMember{
+				{Txn: pusher, WaitingOn: pushee.ID, LockKey: roachpb.Key("a"), WaitDuration: time.Second},
+				{Txn: pushee, WaitingOn: other.ID, LockKey: roachpb.Key("b"), WaitDuration: 2 * time.Second},
+				{Txn: other, WaitingOn: pusher.ID, LockKey: roachpb.Key("c"), WaitDuration: 3 * time.Second},
+			},
+		},
+	})
+	r.report(ctx)
+	require.Len(t, rows, 3)
+	deadlockID := rows[0].deadlockID
+	require.NotEqual(t, uuid.Nil, deadlockID)
+	require.Equal(t, []deadlockMemberRow{
+		{
+			detectedAt: detectedAt, deadlockID: deadlockID, position: 0, nodeID: 1, storeID: 2, rangeID: 3,
+			txnID: pusher.ID, fingerprintID: []byte{0, 0, 0, 0, 0, 0, 0x12, 0x34},
+			waitingOn: pushee.ID, lockKey: roachpb.Key("a"), waitDuration: time.Second,
+		},
+		{
+			detectedAt: detectedAt, deadlockID: deadlockID, position: 1, nodeID: 1, storeID: 2, rangeID: 3,
+			txnID: pushee.ID, fingerprintID: []byte{0, 0, 0, 0, 0, 0, 0xab, 0xcd},
+			waitingOn: other.ID, lockKey: roachpb.Key("b"), waitDuration: 2 * time.Second,
+		},
+		{
+			detectedAt: detectedAt, deadlockID: deadlockID, position: 2, nodeID: 1, storeID: 2, rangeID: 3,
+			txnID: other.ID, fingerprintID: []byte{0, 0, 0, 0, 0, 0, 0x56, 0x78},
+			waitingOn: pusher.ID, lockKey: roachpb.Key("c"), waitDuration: 3 * time.Second,
+		},
+	}, rows)
+
+	// The same deadlock is not recorded twice.
+	r.report(ctx)
+	require.Len(t, rows, 3)
+
+	// A deadlock whose transactions are not resolved is retried, and recorded
+	// once they are.
+	deadlocks = append(deadlocks, kvserver.StoreDeadlock{
+		NodeID:  1,
+		StoreID: 2,
+		Deadlock: txnwait.Deadlock{Seq: 2, Members: []txnwait.DeadlockMember{
+			{Txn: other, WaitingOn: pusher.ID}, {Txn: pusher},
+		}},
+	})
+	delete(fingerprints, pusher.ID)
+	r.report(ctx)
+	require.Len(t, rows, 3)
+	fingerprints[pusher.ID] = 0x1234
+	r.report(ctx)
+	require.Len(t, rows, 5)
+	require.NotEqual(t, deadlockID, rows[3].deadlockID)
+	require.Equal(t, rows[3].deadlockID, rows[4].deadlockID)
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0x56, 0x78}, rows[3].fingerprintID)
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0x12, 0x34}, rows[4].fingerprintID)
+	require.Equal(t, uuid.Nil, rows[4].waitingOn)
+
+	// A deadlock whose transactions are never resolved is recorded without
+	// fingerprints once it runs out of attempts.
+	deadlocks = append(deadlocks, kvserver.StoreDeadlock{
+		NodeID:  1,
+		StoreID: 4,
+		Deadlock: txnwait.Deadlock{Seq: 1, Members: []txnwait.DeadlockMember{
+			{Txn: pushee, WaitingOn: other.ID}, {Txn: other},
+		}},
+	})
+	delete(fingerprints, pushee.ID)
+	delete(fingerprints, other.ID)
+	for i := 0; i < maxDeadlockResolutionAttempts-1; i++ {
+		r.report(ctx)
+		require.Len(t, rows, 5)
+	}
+	r.report(ctx)
+	require.Len(t, rows, 7)
+	for _, row := range rows[5:] {
+		require.Equal(t, roachpb.StoreID(4), row.storeID)
+		require.Nil(t, row.fingerprintID)
+	}
+	require.Empty(t, r.pending)
+	require.Empty(t, r.fingerprints)
+}
@@ -1110,6 +1110,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (serverctl.ServerStartupInterf
 		node.storeCfg.KVFlowController,
 		node.storeCfg.KVFlowStreamTokenProvider,
 		kvserver.MakeStoresForStoreLiveness(stores),
+		stores,
 	)
 	if err = cfg.CidrLookup.Start(ctx, stopper); err != nil {
 		return nil, err
@@ -2008,6 +2009,9 @@ func (s *topLevelServer) PreStart(ctx context.Context) error {
 		return err
 	}
 
+	// Begin recording the deadlocks detected by the transaction wait queues.
+	s.node.startReportingDeadlocks(s.stopper)
+
 	if subscriber, ok := s.spanConfigSubscriber.(*spanconfigkvsubscriber.KVSubscriber); ok {
 		if err := subscriber.Start(workersCtx, s.stopper); err != nil {
 			return err
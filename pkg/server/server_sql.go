@@ -1342,6 +1342,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 	)
 
 	execCfg.SpanConfigReconciler = spanConfigReconciler
+	execCfg.SpanConfigSQLTranslatorFactory = spanConfig.sqlTranslatorFactory
 	execCfg.SpanConfigKVAccessor = cfg.spanConfigAccessor
 	execCfg.SpanConfigLimiter = spanConfig.limiter
 	execCfg.SpanConfigSplitter = spanConfig.splitter
@@ -48,6 +48,7 @@ var fields = [config.NumFields]Field{
 	constraints,
 	voterConstraints,
 	leasePreferences,
+	gcKeepVersions,
 }
 
 const (
@@ -60,4 +61,5 @@ const (
 	constraints      = constraintsConjunctionField(config.Constraints)
 	voterConstraints = constraintsConjunctionField(config.VoterConstraints)
 	leasePreferences = leasePreferencesField(config.LeasePreferences)
+	gcKeepVersions   = int32Field(config.GCKeepVersions)
 )
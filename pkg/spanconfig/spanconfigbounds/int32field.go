@@ -32,6 +32,9 @@ func (f int32Field) FieldBound(b *Bounds) ValueBounds {
 			return b.NumVoters
 		case gcTTLSeconds:
 			return b.GCTTLSeconds
+		case gcKeepVersions:
+			// The number of versions kept is not bounded for tenants.
+			return nil
 		default:
 			// This is safe because we test that all the fields in the proto have
 			// a corresponding field, and we call this for each of them, and the user
@@ -57,6 +60,8 @@ func (f int32Field) fieldValue(c *roachpb.SpanConfig) *int32 {
 		return &c.NumVoters
 	case gcTTLSeconds:
 		return &c.GCPolicy.TTLSeconds
+	case gcKeepVersions:
+		return &c.GCPolicy.KeepVersions
 	default:
 		// This is safe because we test that all the fields in the proto have
 		// a corresponding field, and we call this for each of them, and the user
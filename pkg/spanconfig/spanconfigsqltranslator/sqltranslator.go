@@ -42,6 +42,8 @@ type Factory struct {
 	knobs       *spanconfig.TestingKnobs
 }
 
+var _ sql.SpanConfigSQLTranslatorFactory = &Factory{}
+
 // NewFactory constructs and returns a Factory.
 func NewFactory(
 	ptsProvider protectedts.Provider, codec keys.SQLCodec, knobs *spanconfig.TestingKnobs,
@@ -59,7 +61,7 @@ func NewFactory(
 // NewSQLTranslator constructs and returns a transaction-scoped
 // spanconfig.SQLTranslator. The caller must ensure that the collection and
 // internal executor and the transaction are associated with each other.
-func (f *Factory) NewSQLTranslator(txn descs.Txn) *SQLTranslator {
+func (f *Factory) NewSQLTranslator(txn descs.Txn) spanconfig.SQLTranslator {
 	return &SQLTranslator{
 		codec: f.codec,
 		knobs: f.knobs,
@@ -347,6 +349,10 @@ func (s *SQLTranslator) generateSpanConfigurationsForTable(
 	// backups.
 	tableSpanConfig.ExcludeDataFromBackup = table.GetExcludeDataFromBackup()
 
+	// Set whether the table's data has been made read-only. Dropped tables are
+	// not, so that their data can be cleared once their GC TTL expires.
+	tableSpanConfig.ReadOnly = table.IsKVReadOnly() && !table.Dropped()
+
 	records := make([]spanconfig.Record, 0)
 	if table.GetID() == keys.DescriptorTableID {
 		// We have named ranges preceding `system.descriptor`.
@@ -443,6 +449,7 @@ func (s *SQLTranslator) generateSpanConfigurationsForTable(
 		// SubzoneSpanConfig.
 		subzoneSpanConfig.GCPolicy.ProtectionPolicies = tableSpanConfig.GCPolicy.ProtectionPolicies[:]
 		subzoneSpanConfig.ExcludeDataFromBackup = tableSpanConfig.ExcludeDataFromBackup
+		subzoneSpanConfig.ReadOnly = tableSpanConfig.ReadOnly
 		if isSystemDesc { // same as above
 			subzoneSpanConfig.RangefeedEnabled = true
 			subzoneSpanConfig.GCPolicy.IgnoreStrictEnforcement = true
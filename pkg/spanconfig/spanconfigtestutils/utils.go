@@ -625,6 +625,9 @@ func PrintSpanConfigDiffedAgainstDefaults(conf roachpb.SpanConfig) string {
 	if conf.GCPolicy.TTLSeconds != defaultConf.GCPolicy.TTLSeconds {
 		diffs = append(diffs, fmt.Sprintf("ttl_seconds=%d", conf.GCPolicy.TTLSeconds))
 	}
+	if conf.GCPolicy.KeepVersions != defaultConf.GCPolicy.KeepVersions {
+		diffs = append(diffs, fmt.Sprintf("keep_versions=%d", conf.GCPolicy.KeepVersions))
+	}
 	if conf.GCPolicy.IgnoreStrictEnforcement != defaultConf.GCPolicy.IgnoreStrictEnforcement {
 		diffs = append(diffs, fmt.Sprintf("ignore_strict_gc=%t", conf.GCPolicy.IgnoreStrictEnforcement))
 	}
@@ -664,6 +667,9 @@ func PrintSpanConfigDiffedAgainstDefaults(conf roachpb.SpanConfig) string {
 	if conf.ExcludeDataFromBackup != defaultConf.ExcludeDataFromBackup {
 		diffs = append(diffs, fmt.Sprintf("exclude_data_from_backup=%v", conf.ExcludeDataFromBackup))
 	}
+	if conf.ReadOnly != defaultConf.ReadOnly {
+		diffs = append(diffs, fmt.Sprintf("read_only=%v", conf.ReadOnly))
+	}
 
 	return strings.Join(diffs, " ")
 }
@@ -121,6 +121,15 @@ func (p *planner) addColumnImpl(
 		return err
 	}
 
+	// The backfill of the new column or its index could never write to a
+	// read-only table.
+	if n.tableDesc.IsKVReadOnly() &&
+		(idx != nil || (!col.Virtual && (col.HasDefault() || col.IsComputed()))) {
+		return sqlerrors.NewSchemaChangeOnKVReadOnlyTableErr(
+			"ADD COLUMN requiring a backfill", n.tableDesc.GetName(),
+		)
+	}
+
 	n.tableDesc.AddColumnMutation(col, descpb.DescriptorMutation_ADD)
 	if idx != nil {
 		if err := n.tableDesc.AddIndexMutationMaybeWithTempIndex(idx, descpb.DescriptorMutation_ADD); err != nil {
@@ -79,6 +79,8 @@ var (
 		catconstants.JobsProgressHistoryTableName,
 		catconstants.JobsStatusTableName,
 		catconstants.JobsMessageTableName,
+		catconstants.BackupCatalogTableName,
+		catconstants.TransactionDeadlocksTableName,
 	}
 
 	readWriteSystemSequences = []catconstants.SystemTableName{
@@ -785,6 +785,9 @@ type TableDescriptor interface {
 	// GetExcludeDataFromBackup returns true if the table's row data is configured
 	// to be excluded during backup.
 	GetExcludeDataFromBackup() bool
+	// IsKVReadOnly returns true if the table's data has been made read-only at
+	// the KV level through the read_only storage parameter.
+	IsKVReadOnly() bool
 	// GetStorageParams returns a list of storage parameters for the table.
 	GetStorageParams(spaceBetweenEqual bool) []string
 	// NoAutoStatsSettingsOverrides is true if no auto stats related settings are
@@ -1323,6 +1323,43 @@ CREATE TABLE system.prepared_transactions (
   CONSTRAINT "primary" PRIMARY KEY (global_id),
   FAMILY "primary" (global_id, transaction_id, transaction_key, prepared, owner, database, heuristic)
 );`
+
+	// BackupCatalogTableSchema stores the backup catalog: one row per backup,
+	// keyed by the redacted URI of the backup, holding the encoded
+	// jobspb.BackupCatalogEntry describing it.
+	BackupCatalogTableSchema = `
+CREATE TABLE system.backup_catalog (
+  uri     STRING NOT NULL,
+  -- Null if the backup was found by listing its collection.
+  job_id  INT8   NULL,
+  entry   BYTES  NOT NULL,
+  CONSTRAINT "primary" PRIMARY KEY (uri),
+  FAMILY "primary" (uri, job_id, entry)
+);`
+
+	// TransactionDeadlocksTableSchema stores the deadlocks detected by the txn
+	// wait queues: one row per member of each dependency cycle, in cycle order.
+	// The member at cycle_position 0 detected the deadlock, and the member at
+	// cycle_position 1 was aborted to break it; each member waits on the next
+	// one, and the last one on the first.
+	TransactionDeadlocksTableSchema = `
+CREATE TABLE system.transaction_deadlocks (
+  detected_at         TIMESTAMPTZ NOT NULL,
+  deadlock_id         UUID        NOT NULL,
+  cycle_position      INT8        NOT NULL,
+  node_id             INT8        NOT NULL,
+  store_id            INT8        NOT NULL,
+  range_id            INT8        NOT NULL,
+  txn_id              UUID        NOT NULL,
+  -- Null if the fingerprint of the transaction could not be resolved.
+  txn_fingerprint_id  BYTES       NULL,
+  -- Null if the member the transaction waits on is not known.
+  waiting_on_txn_id   UUID        NULL,
+  lock_key            BYTES       NULL,
+  wait_duration       INTERVAL    NULL,
+  CONSTRAINT "primary" PRIMARY KEY (detected_at, deadlock_id, cycle_position),
+  FAMILY "primary" (detected_at, deadlock_id, cycle_position, node_id, store_id, range_id, txn_id, txn_fingerprint_id, waiting_on_txn_id, lock_key, wait_duration)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1375,7 +1412,7 @@ const SystemDatabaseName = catconstants.SystemDatabaseName
 // release version).
 //
 // NB: Don't set this to clusterversion.Latest; use a specific version instead.
-var SystemDatabaseSchemaBootstrapVersion = clusterversion.V25_2_AddSqlActivityFlushJob.Version()
+var SystemDatabaseSchemaBootstrapVersion = clusterversion.V25_2_TransactionDeadlocksTable.Version()
 
 // MakeSystemDatabaseDesc constructs a copy of the system database
 // descriptor.
@@ -1572,6 +1609,8 @@ func MakeSystemTables() []SystemTable {
 		SystemJobStatusTable,
 		SystemJobMessageTable,
 		PreparedTransactionsTable,
+		BackupCatalogTable,
+		TransactionDeadlocksTable,
 	}
 }
 
@@ -5199,6 +5238,68 @@ var (
 			pk("global_id"),
 		),
 	)
+
+	BackupCatalogTable = makeSystemTable(
+		BackupCatalogTableSchema,
+		systemTable(
+			catconstants.BackupCatalogTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "uri", ID: 1, Type: types.String},
+				{Name: "job_id", ID: 2, Type: types.Int, Nullable: true},
+				{Name: "entry", ID: 3, Type: types.Bytes},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ColumnNames: []string{"uri", "job_id", "entry"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3},
+				},
+			},
+			pk("uri"),
+		),
+	)
+
+	TransactionDeadlocksTable = makeSystemTable(
+		TransactionDeadlocksTableSchema,
+		systemTable(
+			catconstants.TransactionDeadlocksTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "detected_at", ID: 1, Type: types.TimestampTZ},
+				{Name: "deadlock_id", ID: 2, Type: types.Uuid},
+				{Name: "cycle_position", ID: 3, Type: types.Int},
+				{Name: "node_id", ID: 4, Type: types.Int},
+				{Name: "store_id", ID: 5, Type: types.Int},
+				{Name: "range_id", ID: 6, Type: types.Int},
+				{Name: "txn_id", ID: 7, Type: types.Uuid},
+				{Name: "txn_fingerprint_id", ID: 8, Type: types.Bytes, Nullable: true},
+				{Name: "waiting_on_txn_id", ID: 9, Type: types.Uuid, Nullable: true},
+				{Name: "lock_key", ID: 10, Type: types.Bytes, Nullable: true},
+				{Name: "wait_duration", ID: 11, Type: types.Interval, Nullable: true},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name: "primary",
+					ColumnNames: []string{
+						"detected_at", "deadlock_id", "cycle_position", "node_id", "store_id", "range_id",
+						"txn_id", "txn_fingerprint_id", "waiting_on_txn_id", "lock_key", "wait_duration",
+					},
+					ColumnIDs: []descpb.ColumnID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:           tabledesc.LegacyPrimaryKeyIndexName,
+				ID:             1,
+				Unique:         true,
+				KeyColumnNames: []string{"detected_at", "deadlock_id", "cycle_position"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{
+					catenumpb.IndexColumn_ASC, catenumpb.IndexColumn_ASC, catenumpb.IndexColumn_ASC,
+				},
+				KeyColumnIDs: []descpb.ColumnID{1, 2, 3},
+			},
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
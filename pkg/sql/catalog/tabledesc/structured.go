@@ -2447,6 +2447,11 @@ func (desc *wrapper) GetExcludeDataFromBackup() bool {
 	return desc.ExcludeDataFromBackup
 }
 
+// IsKVReadOnly implements the TableDescriptor interface.
+func (desc *wrapper) IsKVReadOnly() bool {
+	return desc.KVReadOnly
+}
+
 // GetStorageParams implements the TableDescriptor interface.
 func (desc *wrapper) GetStorageParams(spaceBetweenEqual bool) []string {
 	var storageParams []string
@@ -2498,6 +2503,9 @@ func (desc *wrapper) GetStorageParams(spaceBetweenEqual bool) []string {
 	if exclude := desc.GetExcludeDataFromBackup(); exclude {
 		appendStorageParam(`exclude_data_from_backup`, `true`)
 	}
+	if desc.IsKVReadOnly() {
+		appendStorageParam(`read_only`, `true`)
+	}
 	if settings := desc.AutoStatsSettings; settings != nil {
 		if settings.Enabled != nil {
 			value := *settings.Enabled
@@ -93,7 +93,23 @@ func init() {
 			Field:        config.GCTTL,
 			RequiredType: types.Int,
 			Setter: func(c *zonepb.ZoneConfig, d tree.Datum) {
-				c.GC = &zonepb.GCPolicy{TTLSeconds: int32(tree.MustBeDInt(d))}
+				gc := zonepb.GCPolicy{TTLSeconds: int32(tree.MustBeDInt(d))}
+				if c.GC != nil {
+					gc.KeepVersions = c.GC.KeepVersions
+				}
+				c.GC = &gc
+			},
+		},
+		{
+			Field:        config.GCKeepVersions,
+			RequiredType: types.Int,
+			Setter: func(c *zonepb.ZoneConfig, d tree.Datum) {
+				// If the GC policy is otherwise inherited, the TTL is left unset here
+				// and is inherited from the parent zone.
+				if c.GC == nil {
+					c.GC = &zonepb.GCPolicy{}
+				}
+				c.GC.KeepVersions = proto.Int32(int32(tree.MustBeDInt(d)))
 			},
 		},
 		{
@@ -228,6 +228,7 @@ var (
 	errChangeFrontierWrap             = errors.New("core.ChangeFrontier is not supported")
 	errReadImportWrap                 = errors.New("core.ReadImport is not supported")
 	errBackupDataWrap                 = errors.New("core.BackupData is not supported")
+	errExportBackupDataWrap           = errors.New("core.ExportBackupData is not supported")
 	errBackfillerWrap                 = errors.New("core.Backfiller is not supported (not an execinfra.RowSource)")
 	errExporterWrap                   = errors.New("core.Exporter is not supported (not an execinfra.RowSource)")
 	errSamplerWrap                    = errors.New("core.Sampler is not supported (not an execinfra.RowSource)")
@@ -302,6 +303,8 @@ func canWrap(mode sessiondatapb.VectorizeExecMode, core *execinfrapb.ProcessorCo
 	case core.BackupData != nil:
 		return errBackupDataWrap
 	case core.RestoreData != nil:
+	case core.ExportBackupData != nil:
+		return errExportBackupDataWrap
 	case core.Filterer != nil:
 	case core.StreamIngestionData != nil:
 		return errStreamIngestionWrap
@@ -3783,6 +3783,16 @@ func (ex *connExecutor) omitInRangefeeds() bool {
 	return ex.sessionData().DisableChangefeedReplication
 }
 
+// txnClass returns the roachpb.TransactionClass of the KV transactions
+// originating from this session, which is configured with the
+// transaction_class session variable.
+func (ex *connExecutor) txnClass() roachpb.TransactionClass {
+	if ex.sessionData() == nil {
+		return roachpb.TransactionClass_INTERACTIVE
+	}
+	return roachpb.TransactionClass(ex.sessionData().TransactionClass)
+}
+
 func (ex *connExecutor) bufferedWritesEnabled(ctx context.Context) bool {
 	if ex.sessionData() == nil {
 		return false
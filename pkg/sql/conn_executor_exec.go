@@ -2405,6 +2405,7 @@ func (ex *connExecutor) resetTransactionOnSchemaChangeRetry(ctx context.Context)
 	defer ex.state.mu.Unlock()
 	userPriority := ex.state.mu.txn.UserPriority()
 	omitInRangefeeds := ex.state.mu.txn.GetOmitInRangefeeds()
+	txnClass := ex.state.mu.txn.GetTransactionClass()
 	newTxn := kv.NewTxnWithSteppingEnabled(ctx, ex.transitionCtx.db,
 		ex.transitionCtx.nodeIDOrZero, ex.QualityOfService())
 	if err := newTxn.SetUserPriority(userPriority); err != nil {
@@ -2413,6 +2414,7 @@ func (ex *connExecutor) resetTransactionOnSchemaChangeRetry(ctx context.Context)
 	if omitInRangefeeds {
 		newTxn.SetOmitInRangefeeds()
 	}
+	newTxn.SetTransactionClass(txnClass)
 	if buildutil.CrdbTestBuild {
 		// For now, we explicitly disable buffered writes before executing DDLs.
 		// TODO(#140695): we should consider allowing this in the future.
@@ -3587,6 +3589,7 @@ func (ex *connExecutor) execStmtInNoTxnState(
 				ex.QualityOfService(),
 				ex.txnIsolationLevelToKV(ctx, s.Modes.Isolation),
 				ex.omitInRangefeeds(),
+				ex.txnClass(),
 				ex.bufferedWritesEnabled(ctx),
 			)
 	case *tree.ShowCommitTimestamp:
@@ -3621,6 +3624,7 @@ func (ex *connExecutor) execStmtInNoTxnState(
 				ex.QualityOfService(),
 				ex.txnIsolationLevelToKV(ctx, tree.UnspecifiedIsolation),
 				ex.omitInRangefeeds(),
+				ex.txnClass(),
 				ex.bufferedWritesEnabled(ctx),
 			)
 	}
@@ -3655,6 +3659,7 @@ func (ex *connExecutor) beginImplicitTxn(
 			qos,
 			ex.txnIsolationLevelToKV(ctx, tree.UnspecifiedIsolation),
 			ex.omitInRangefeeds(),
+			ex.txnClass(),
 			ex.bufferedWritesEnabled(ctx),
 		)
 }
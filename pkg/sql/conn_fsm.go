@@ -120,6 +120,7 @@ type eventTxnStartPayload struct {
 	qualityOfService      sessiondatapb.QoSLevel
 	isoLevel              isolation.Level
 	omitInRangefeeds      bool
+	txnClass              roachpb.TransactionClass
 	bufferedWritesEnabled bool
 }
 
@@ -133,6 +134,7 @@ func makeEventTxnStartPayload(
 	qualityOfService sessiondatapb.QoSLevel,
 	isoLevel isolation.Level,
 	omitInRangefeeds bool,
+	txnClass roachpb.TransactionClass,
 	bufferedWritesEnabled bool,
 ) eventTxnStartPayload {
 	return eventTxnStartPayload{
@@ -144,6 +146,7 @@ func makeEventTxnStartPayload(
 		qualityOfService:      qualityOfService,
 		isoLevel:              isoLevel,
 		omitInRangefeeds:      omitInRangefeeds,
+		txnClass:              txnClass,
 		bufferedWritesEnabled: bufferedWritesEnabled,
 	}
 }
@@ -600,6 +603,7 @@ func noTxnToOpen(args fsm.Args) error {
 		payload.qualityOfService,
 		payload.isoLevel,
 		payload.omitInRangefeeds,
+		payload.txnClass,
 		payload.bufferedWritesEnabled,
 	)
 	ts.setAdvanceInfo(
@@ -18,8 +18,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/contentionpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/cache"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
@@ -59,6 +61,10 @@ type Registry struct {
 	// transactions in the contention events to their corresponding transaction
 	// fingerprint ID.
 	eventStore *eventStore
+
+	// resolverEndpoint is used to resolve transaction IDs into transaction
+	// fingerprint IDs on demand.
+	resolverEndpoint ResolverEndpoint
 }
 
 var (
@@ -241,9 +247,10 @@ func newNonSQLKeysMap() *nonSQLKeysMap {
 // NewRegistry creates a new Registry.
 func NewRegistry(st *cluster.Settings, endpoint ResolverEndpoint, metrics *Metrics) *Registry {
 	return &Registry{
-		indexMap:      newIndexMap(),
-		nonSQLKeysMap: newNonSQLKeysMap(),
-		eventStore:    newEventStore(st, endpoint, timeutil.Now, metrics),
+		indexMap:         newIndexMap(),
+		nonSQLKeysMap:    newNonSQLKeysMap(),
+		eventStore:       newEventStore(st, endpoint, timeutil.Now, metrics),
+		resolverEndpoint: endpoint,
 	}
 }
 
@@ -293,6 +300,20 @@ func (r *Registry) ForEachEvent(op func(event *contentionpb.ExtendedContentionEv
 	return r.eventStore.ForEachEvent(op)
 }
 
+// ResolveTxnFingerprintIDs maps the IDs of the given transactions into their
+// transaction fingerprint IDs by querying the transaction ID caches of their
+// coordinator nodes. Transactions that are still in progress or that could not
+// be resolved are returned in unresolved.
+func (r *Registry) ResolveTxnFingerprintIDs(
+	ctx context.Context, txns []enginepb.TxnMeta,
+) (
+	resolved map[uuid.UUID]appstatspb.TransactionFingerprintID,
+	unresolved []enginepb.TxnMeta,
+	err error,
+) {
+	return resolveTxnFingerprintIDs(ctx, r.resolverEndpoint, txns)
+}
+
 // FlushEventsForTest flushes contention events in the write-buffer into the in-memory
 // store.
 func (r *Registry) FlushEventsForTest(ctx context.Context) error {
@@ -14,6 +14,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/contentionpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
@@ -365,3 +366,53 @@ func makeRPCRequestsFromBatch(
 
 	return blockingTxnIDReq, waitingTxnIDReq
 }
+
+// resolveTxnFingerprintIDs maps the IDs of the given transactions into their
+// transaction fingerprint IDs, issuing one RPC request per coordinator node.
+// Transactions that are still in progress or that could not be resolved are
+// returned in unresolved so that the caller can retry them later.
+func resolveTxnFingerprintIDs(
+	ctx context.Context, endpoint ResolverEndpoint, txns []enginepb.TxnMeta,
+) (
+	resolved map[uuid.UUID]appstatspb.TransactionFingerprintID,
+	unresolved []enginepb.TxnMeta,
+	err error,
+) {
+	byCoordinator := make(map[int32][]enginepb.TxnMeta)
+	for _, txn := range txns {
+		if uuid.Nil.Equal(txn.ID) {
+			continue
+		}
+		byCoordinator[txn.CoordinatorNodeID] = append(byCoordinator[txn.CoordinatorNodeID], txn)
+	}
+	coordinators := make([]int32, 0, len(byCoordinator))
+	for coordinatorID := range byCoordinator {
+		coordinators = append(coordinators, coordinatorID)
+	}
+	sort.Slice(coordinators, func(i, j int) bool { return coordinators[i] < coordinators[j] })
+
+	resolved = make(map[uuid.UUID]appstatspb.TransactionFingerprintID, len(txns))
+	for _, coordinatorID := range coordinators {
+		batch := byCoordinator[coordinatorID]
+		req := &serverpb.TxnIDResolutionRequest{
+			CoordinatorID: strconv.Itoa(int(coordinatorID)),
+			TxnIDs:        make([]uuid.UUID, 0, len(batch)),
+		}
+		for _, txn := range batch {
+			req.TxnIDs = append(req.TxnIDs, txn.ID)
+		}
+		resp, rpcErr := endpoint(ctx, req)
+		if rpcErr != nil {
+			err = errors.CombineErrors(err, rpcErr)
+		}
+		resolvedTxnIDs, _ := extractResolvedAndInProgressTxnIDs(resp)
+		for _, txn := range batch {
+			if txnFingerprintID, ok := resolvedTxnIDs[txn.ID]; ok {
+				resolved[txn.ID] = txnFingerprintID
+			} else {
+				unresolved = append(unresolved, txn)
+			}
+		}
+	}
+	return resolved, unresolved, err
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/contentionpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
@@ -525,6 +526,41 @@ func TestResolver(t *testing.T) {
 	})
 }
 
+func TestResolveTxnFingerprintIDs(t *testing.T) {
+	statusServer := newFakeStatusServerCluster()
+	ctx := context.Background()
+
+	resolvedOnNode1 := enginepb.TxnMeta{ID: uuid.MakeV4(), CoordinatorNodeID: 1}
+	resolvedOnNode2 := enginepb.TxnMeta{ID: uuid.MakeV4(), CoordinatorNodeID: 2}
+	missingOnNode2 := enginepb.TxnMeta{ID: uuid.MakeV4(), CoordinatorNodeID: 2}
+	failedOnNode3 := enginepb.TxnMeta{ID: uuid.MakeV4(), CoordinatorNodeID: 3}
+	statusServer.setTxnIDEntry("1", resolvedOnNode1.ID, 100)
+	statusServer.setTxnIDEntry("2", resolvedOnNode2.ID, 200)
+	statusServer.setTxnIDEntry("3", failedOnNode3.ID, 300)
+	statusServer.setStatusServerError("3", errors.New("injected"))
+
+	resolved, unresolved, err := resolveTxnFingerprintIDs(
+		ctx,
+		statusServer.txnIDResolution,
+		[]enginepb.TxnMeta{failedOnNode3, missingOnNode2, resolvedOnNode1, resolvedOnNode2, {}},
+	)
+	require.ErrorContains(t, err, "injected")
+	require.Equal(t, map[uuid.UUID]appstatspb.TransactionFingerprintID{
+		resolvedOnNode1.ID: 100,
+		resolvedOnNode2.ID: 200,
+	}, resolved)
+	require.Equal(t, []enginepb.TxnMeta{missingOnNode2, failedOnNode3}, unresolved)
+
+	// Once the coordinator recovers, the remaining transactions are resolved.
+	statusServer.clearErrors()
+	resolved, unresolved, err = resolveTxnFingerprintIDs(ctx, statusServer.txnIDResolution, unresolved)
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID]appstatspb.TransactionFingerprintID{
+		failedOnNode3.ID: 300,
+	}, resolved)
+	require.Equal(t, []enginepb.TxnMeta{missingOnNode2}, unresolved)
+}
+
 func sortResolvedContentionEvents(
 	events []contentionpb.ExtendedContentionEvent,
 ) []contentionpb.ExtendedContentionEvent {
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
 	"github.com/cockroachdb/cockroach/pkg/gossip"
+	"github.com/cockroachdb/cockroach/pkg/inspectz/inspectzpb"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobsauth"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -232,6 +233,10 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalFullyQualifiedNamesViewID:          crdbInternalFullyQualifiedNamesView,
 		catconstants.CrdbInternalStoreLivenessSupportFrom:           crdbInternalStoreLivenessSupportFromTable,
 		catconstants.CrdbInternalStoreLivenessSupportFor:            crdbInternalStoreLivenessSupportForTable,
+		catconstants.CrdbInternalBackupCatalogTableID:               crdbInternalBackupCatalogTable,
+		catconstants.CrdbInternalBackupCatalogTablesTableID:         crdbInternalBackupCatalogTablesTable,
+		catconstants.CrdbInternalNodeAppendOnlyHotspotsTableID:      crdbInternalNodeAppendOnlyHotspotsTable,
+		catconstants.CrdbInternalTransactionDeadlocksTableID:        crdbInternalTransactionDeadlocksTable,
 	},
 	validWithNoDatabaseContext: true,
 }
@@ -7913,8 +7918,9 @@ CREATE TABLE crdb_internal.transaction_contention_events (
 	generator: func(ctx context.Context, p *planner, db catalog.DatabaseDescriptor, stopper *stop.Stopper) (virtualTableGenerator, cleanupFunc, error) {
 		// Check permission first before making RPC fanout.
 		// If a user has VIEWACTIVITYREDACTED role option but the user does not
-		// have the ADMIN role option, then the contending key should be redacted.
-		hasPermission, shouldRedactContendingKey, err := p.HasViewActivityOrViewActivityRedactedRole(ctx)
+		// have the ADMIN role option, then the transaction record key should be
+		// redacted.
+		hasPermission, shouldRedactKey, err := p.HasViewActivityOrViewActivityRedactedRole(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -9924,3 +9930,480 @@ func populateStoreLivenessSupportResponse(
 	}
 	return nil
 }
+
+var crdbInternalNodeAppendOnlyHotspotsTable = virtualSchemaTable{
+	comment: `node-level view of the indexes for which load-based splitting is futile because of writes with sequential keys`,
+	schema: `
+CREATE TABLE crdb_internal.node_append_only_hotspots (
+  node_id           INT NOT NULL,
+  store_id          INT NOT NULL,
+  tenant_id         INT NOT NULL,
+  table_id          INT NOT NULL,
+  index_id          INT NOT NULL,
+  database_name     STRING,
+  table_name        STRING,
+  index_name        STRING,
+  splits            INT NOT NULL,
+  writes_per_second FLOAT NOT NULL,
+  last_split_key    STRING NOT NULL,
+  detected          TIMESTAMPTZ NOT NULL,
+  last_seen         TIMESTAMPTZ NOT NULL,
+  recommendation    STRING
+);`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		hasRoleOption, _, err := p.HasViewActivityOrViewActivityRedactedRole(ctx)
+		if err != nil {
+			return err
+		}
+		if !hasRoleOption {
+			return noViewActivityOrViewActivityRedactedRoleError(p.User())
+		}
+
+		resp, err := p.extendedEvalCtx.ExecCfg.InspectzServer.AppendOnlyHotspots(ctx, &inspectzpb.AppendOnlyHotspotsRequest{})
+		if err != nil {
+			return err
+		}
+		for _, h := range resp.Hotspots {
+			rest, tenantID, err := keys.DecodeTenantPrefix(h.Prefix)
+			if err != nil {
+				return err
+			}
+			_, tableID, indexID, err := keys.DecodeTableIDIndexID(rest)
+			if err != nil {
+				return err
+			}
+			dbName, tableName, indexName, recommendation := tree.DNull, tree.DNull, tree.DNull, tree.DNull
+			// Only the indexes of the system tenant can be resolved; the hotspots of
+			// other tenants are listed by ID.
+			if tenantID.IsSystem() && p.ExecCfg().Codec.ForSystemTenant() {
+				if info, ok := getAppendOnlyHotspotIndexInfo(ctx, p, descpb.ID(tableID), descpb.IndexID(indexID)); ok {
+					dbName = tree.NewDString(info.dbName)
+					tableName = tree.NewDString(info.tableName)
+					indexName = tree.NewDString(info.indexName)
+					if info.recommendation != "" {
+						recommendation = tree.NewDString(info.recommendation)
+					}
+				}
+			}
+			detected, err := tree.MakeDTimestampTZ(h.Detected, time.Microsecond)
+			if err != nil {
+				return err
+			}
+			lastSeen, err := tree.MakeDTimestampTZ(h.LastSeen, time.Microsecond)
+			if err != nil {
+				return err
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(h.NodeID)),
+				tree.NewDInt(tree.DInt(h.StoreID)),
+				tree.NewDInt(tree.DInt(tenantID.ToUint64())),
+				tree.NewDInt(tree.DInt(tableID)),
+				tree.NewDInt(tree.DInt(indexID)),
+				dbName,
+				tableName,
+				indexName,
+				tree.NewDInt(tree.DInt(h.Splits)),
+				tree.NewDFloat(tree.DFloat(h.WritesPerSecond)),
+				tree.NewDString(h.LastSplitKey.String()),
+				detected,
+				lastSeen,
+				recommendation,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// appendOnlyHotspotIndexInfo describes the index of an append-only hotspot.
+type appendOnlyHotspotIndexInfo struct {
+	dbName, tableName, indexName string
+	// recommendation is a statement which replaces the index with an equivalent
+	// hash-sharded index, spreading its sequential writes across ranges.
+	recommendation string
+}
+
+// getAppendOnlyHotspotIndexInfo performs a best-effort lookup of the index of
+// an append-only hotspot. It returns false if the index or its table were
+// dropped.
+func getAppendOnlyHotspotIndexInfo(
+	ctx context.Context, p *planner, tableID descpb.ID, indexID descpb.IndexID,
+) (info appendOnlyHotspotIndexInfo, ok bool) {
+	desc := p.Descriptors()
+	tableDesc, err := desc.ByIDWithLeased(p.txn).WithoutNonPublic().Get().Table(ctx, tableID)
+	if err != nil {
+		return info, false
+	}
+	idx, err := catalog.MustFindIndexByID(tableDesc, indexID)
+	if err != nil {
+		return info, false
+	}
+	dbDesc, err := desc.ByIDWithLeased(p.txn).WithoutNonPublic().Get().Database(ctx, tableDesc.GetParentID())
+	if err != nil {
+		return info, false
+	}
+	schemaDesc, err := desc.ByIDWithLeased(p.txn).WithoutNonPublic().Get().Schema(ctx, tableDesc.GetParentSchemaID())
+	if err != nil {
+		return info, false
+	}
+	info.dbName = dbDesc.GetName()
+	info.tableName = tableDesc.GetName()
+	info.indexName = idx.GetName()
+
+	tn := tree.MakeTableNameWithSchema(
+		tree.Name(dbDesc.GetName()), tree.Name(schemaDesc.GetName()), tree.Name(tableDesc.GetName()),
+	)
+	// The recommendation is left empty if the index definition can't be
+	// formatted.
+	if recommendation, err := formatAppendOnlyHotspotRecommendation(
+		ctx, p, tableDesc, &tn, idx,
+	); err == nil {
+		info.recommendation = recommendation
+	}
+	return info, true
+}
+
+// formatAppendOnlyHotspotRecommendation returns a statement which replaces the
+// given index with a hash-sharded index with the same key columns and
+// directions, stored columns and partial index predicate.
+func formatAppendOnlyHotspotRecommendation(
+	ctx context.Context,
+	p *planner,
+	tableDesc catalog.TableDescriptor,
+	tn *tree.TableName,
+	idx catalog.Index,
+) (string, error) {
+	f := tree.NewFmtCtx(tree.FmtParsable)
+	if idx.Primary() {
+		f.WriteString("ALTER TABLE ")
+		f.FormatNode(tn)
+		f.WriteString(" ALTER PRIMARY KEY USING COLUMNS (")
+	} else {
+		f.WriteString("CREATE ")
+		if idx.IsUnique() {
+			f.WriteString("UNIQUE ")
+		}
+		f.WriteString("INDEX ON ")
+		f.FormatNode(tn)
+		f.WriteString(" (")
+	}
+	if err := catformat.FormatIndexElements(
+		ctx, tableDesc, idx.IndexDesc(), f, p.EvalContext(), &p.semaCtx, p.SessionData(),
+	); err != nil {
+		return "", err
+	}
+	f.WriteString(") USING HASH")
+	if idx.Primary() {
+		return f.CloseAndGetString(), nil
+	}
+
+	if idx.NumSecondaryStoredColumns() > 0 {
+		f.WriteString(" STORING (")
+		for i := 0; i < idx.NumSecondaryStoredColumns(); i++ {
+			if i > 0 {
+				f.WriteString(", ")
+			}
+			name := tree.Name(idx.GetStoredColumnName(i))
+			f.FormatNode(&name)
+		}
+		f.WriteByte(')')
+	}
+	if idx.IsPartial() {
+		pred, err := schemaexpr.FormatExprForDisplay(
+			ctx, tableDesc, idx.GetPredicate(), p.EvalContext(), &p.semaCtx, p.SessionData(), tree.FmtParsable,
+		)
+		if err != nil {
+			return "", err
+		}
+		f.WriteString(" WHERE ")
+		f.WriteString(pred)
+	}
+	f.WriteString("; DROP INDEX ")
+	f.FormatNode(tn)
+	f.WriteByte('@')
+	name := tree.Name(idx.GetName())
+	f.FormatNode(&name)
+	// A unique index which was created as a constraint can only be dropped with
+	// CASCADE.
+	if idx.IsUnique() && !idx.IsCreatedExplicitly() {
+		f.WriteString(" CASCADE")
+	}
+	return f.CloseAndGetString(), nil
+}
+
+var crdbInternalBackupCatalogTable = virtualSchemaTable{
+	comment: `backups written or compacted by jobs in this cluster, or found in their collections, as recorded in system.backup_catalog`,
+	schema: `
+CREATE TABLE crdb_internal.backup_catalog (
+  job_id           INT,
+  collection       STRING,
+  subdir           STRING,
+  uri              STRING NOT NULL,
+  backup_type      STRING NOT NULL,
+  start_time       TIMESTAMPTZ,
+  end_time         TIMESTAMPTZ NOT NULL,
+  revision_history BOOL NOT NULL,
+  full_cluster     BOOL NOT NULL,
+  is_encrypted     BOOL NOT NULL,
+  size_bytes       INT NOT NULL,
+  rows             INT NOT NULL,
+  file_count       INT NOT NULL,
+  table_count      INT NOT NULL,
+  compacted_by     INT,
+  missing          BOOL NOT NULL,
+  scan_error       STRING,
+  scanned_at       TIMESTAMPTZ NOT NULL
+);`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		entries, err := loadBackupCatalog(ctx, p)
+		if err != nil {
+			return err
+		}
+		compactedBy := backupCatalogCompactedBy(entries)
+		for _, e := range entries {
+			startTime := tree.DNull
+			if !e.StartTime.IsEmpty() {
+				startTime = backupCatalogTimestamp(e.StartTime)
+			}
+			compactedByJob := tree.DNull
+			if id, ok := compactedBy[e.URI]; ok && id != jobspb.InvalidJobID {
+				compactedByJob = tree.NewDInt(tree.DInt(id))
+			}
+			if err := addRow(
+				backupCatalogJobID(e.jobID),
+				dStringOrNull(e.CollectionURI),
+				dStringOrNull(e.Subdir),
+				tree.NewDString(e.URI),
+				tree.NewDString(strings.ToLower(e.Type.String())),
+				startTime,
+				backupCatalogTimestamp(e.EndTime),
+				tree.MakeDBool(tree.DBool(e.RevisionHistory)),
+				tree.MakeDBool(tree.DBool(e.FullCluster)),
+				tree.MakeDBool(tree.DBool(e.Encrypted)),
+				tree.NewDInt(tree.DInt(e.DataSize)),
+				tree.NewDInt(tree.DInt(e.Rows)),
+				tree.NewDInt(tree.DInt(e.FileCount)),
+				tree.NewDInt(tree.DInt(len(e.Tables))),
+				compactedByJob,
+				tree.MakeDBool(tree.DBool(e.Missing)),
+				dStringOrNull(e.ScanError),
+				backupCatalogTimestamp(e.ScannedAt),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var crdbInternalBackupCatalogTablesTable = virtualSchemaTable{
+	comment: `tables contained in the backups recorded in the backup catalog`,
+	schema: `
+CREATE TABLE crdb_internal.backup_catalog_tables (
+  uri           STRING NOT NULL,
+  job_id        INT,
+  database_name STRING,
+  schema_name   STRING,
+  table_name    STRING NOT NULL,
+  table_id      INT NOT NULL
+);`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		entries, err := loadBackupCatalog(ctx, p)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			for _, table := range e.Tables {
+				if err := addRow(
+					tree.NewDString(e.URI),
+					backupCatalogJobID(e.jobID),
+					dStringOrNull(table.DatabaseName),
+					dStringOrNull(table.SchemaName),
+					tree.NewDString(table.Name),
+					tree.NewDInt(tree.DInt(table.ID)),
+				); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
+
+// backupCatalogEntry is an entry of the backup catalog along with the ID of
+// the job which wrote the backup it describes, which is zero if the backup was
+// found by listing its collection.
+type backupCatalogEntry struct {
+	jobID jobspb.JobID
+	jobspb.BackupCatalogEntry
+}
+
+// loadBackupCatalog reads the entries of the backup catalog from
+// system.backup_catalog, ordered by the URI of the backups they describe.
+func loadBackupCatalog(ctx context.Context, p *planner) (_ []backupCatalogEntry, retErr error) {
+	if err := p.CheckPrivilege(ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.VIEWCLUSTERMETADATA); err != nil {
+		return nil, err
+	}
+	if !p.IsActive(ctx, clusterversion.V25_2_BackupCatalogTable) {
+		return nil, nil
+	}
+	it, err := p.InternalSQLTxn().QueryIteratorEx(
+		ctx, "backup-catalog", p.txn, sessiondata.NodeUserSessionDataOverride,
+		`SELECT job_id, entry FROM system.backup_catalog ORDER BY uri`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := it.Close(); err != nil {
+			retErr = errors.CombineErrors(retErr, err)
+		}
+	}()
+
+	var entries []backupCatalogEntry
+	for {
+		ok, err := it.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		row := it.Cur()
+		var e backupCatalogEntry
+		if row[0] != tree.DNull {
+			e.jobID = jobspb.JobID(tree.MustBeDInt(row[0]))
+		}
+		if err := protoutil.Unmarshal([]byte(tree.MustBeDBytes(row[1])), &e.BackupCatalogEntry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// backupCatalogCompactedBy returns, for the URI of each backup of the backup
+// catalog which was compacted, the ID of the job which compacted it. A backup
+// is compacted by a compacted backup of the same chain whose time range
+// contains its own; if there are several, the narrowest one compacted it
+// directly. The ID is zero if the compacted backup was found by listing its
+// collection.
+func backupCatalogCompactedBy(entries []backupCatalogEntry) map[string]jobspb.JobID {
+	compactedBy := make(map[string]jobspb.JobID)
+	for _, e := range entries {
+		if e.Type == jobspb.BackupCatalogEntry_FULL {
+			continue
+		}
+		var compaction *backupCatalogEntry
+		for i := range entries {
+			c := &entries[i]
+			if c.Type != jobspb.BackupCatalogEntry_COMPACTED || c.URI == e.URI ||
+				c.CollectionURI != e.CollectionURI || c.Subdir != e.Subdir {
+				continue
+			}
+			if e.StartTime.Less(c.StartTime) || c.EndTime.Less(e.EndTime) ||
+				(c.StartTime.Equal(e.StartTime) && c.EndTime.Equal(e.EndTime)) {
+				continue
+			}
+			if compaction == nil || c.EndTime.WallTime-c.StartTime.WallTime <
+				compaction.EndTime.WallTime-compaction.StartTime.WallTime {
+				compaction = c
+			}
+		}
+		if compaction != nil {
+			compactedBy[e.URI] = compaction.jobID
+		}
+	}
+	return compactedBy
+}
+
+func backupCatalogJobID(jobID jobspb.JobID) tree.Datum {
+	if jobID == jobspb.InvalidJobID {
+		return tree.DNull
+	}
+	return tree.NewDInt(tree.DInt(jobID))
+}
+
+func backupCatalogTimestamp(ts hlc.Timestamp) tree.Datum {
+	return tree.MustMakeDTimestampTZ(timeutil.Unix(0, ts.WallTime), time.Microsecond)
+}
+
+func dStringOrNull(s string) tree.Datum {
+	if s == "" {
+		return tree.DNull
+	}
+	return tree.NewDString(s)
+}
+
+var crdbInternalTransactionDeadlocksTable = virtualSchemaTable{
+	comment: `members of the transaction deadlocks detected and broken by the transaction wait queues, as recorded in system.transaction_deadlocks`,
+	schema: `
+CREATE TABLE crdb_internal.transaction_deadlocks (
+  detected           TIMESTAMPTZ NOT NULL,
+  deadlock_id        UUID NOT NULL,
+  node_id            INT NOT NULL,
+  store_id           INT NOT NULL,
+  range_id           INT NOT NULL,
+  cycle_position     INT NOT NULL,
+  txn_id             UUID NOT NULL,
+  txn_fingerprint_id BYTES,
+  aborted            BOOL NOT NULL,
+  waiting_on_txn_id  UUID,
+  lock_key           STRING,
+  wait_duration      INTERVAL
+);`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) (retErr error) {
+		// If a user has VIEWACTIVITYREDACTED role option but the user does not
+		// have the ADMIN role option, then the lock key should be redacted.
+		hasPermission, shouldRedactKey, err := p.HasViewActivityOrViewActivityRedactedRole(ctx)
+		if err != nil {
+			return err
+		}
+		if !hasPermission {
+			return noViewActivityOrViewActivityRedactedRoleError(p.User())
+		}
+		if !p.IsActive(ctx, clusterversion.V25_2_TransactionDeadlocksTable) {
+			return nil
+		}
+
+		// Deadlocks are recorded by the KV nodes, so they are only present in
+		// the system tenant's table.
+		it, err := p.InternalSQLTxn().QueryIteratorEx(
+			ctx,
+			"crdb-internal-transaction-deadlocks",
+			p.Txn(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT detected_at, deadlock_id, node_id, store_id, range_id, cycle_position,
+       txn_id, txn_fingerprint_id, cycle_position = 1, waiting_on_txn_id, lock_key, wait_duration
+FROM system.transaction_deadlocks
+ORDER BY detected_at, deadlock_id, cycle_position`,
+		)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			retErr = errors.CombineErrors(retErr, it.Close())
+		}()
+
+		for {
+			ok, err := it.Next(ctx)
+			if err != nil || !ok {
+				return err
+			}
+			row := it.Cur()
+			// The lock key is stored in its raw form; show it pretty-printed.
+			const lockKeyIdx = 10
+			if key, ok := row[lockKeyIdx].(*tree.DBytes); ok {
+				if shouldRedactKey {
+					row[lockKeyIdx] = tree.NewDString("")
+				} else {
+					row[lockKeyIdx] = tree.NewDString(roachpb.Key(*key).String())
+				}
+			}
+			if err := addRow(row...); err != nil {
+				return err
+			}
+		}
+	},
+}
@@ -94,6 +94,11 @@ func (p *planner) CreateIndex(ctx context.Context, n *tree.CreateIndex) (planNod
 		return nil, err
 	}
 
+	// The backfill of the new index could never write to a read-only table.
+	if tableDesc.IsKVReadOnly() {
+		return nil, sqlerrors.NewSchemaChangeOnKVReadOnlyTableErr("CREATE INDEX", tableDesc.GetName())
+	}
+
 	return &createIndexNode{tableDesc: tableDesc, n: n}, nil
 }
 
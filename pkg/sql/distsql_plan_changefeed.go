@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -48,11 +49,23 @@ func WithExtraColumn(c catalog.Column) CDCOption {
 	})
 }
 
+// WithIndex returns an option to make the specified secondary index available
+// to the optimizer, in addition to the primary index. The caller is expected
+// to force the use of this index via index flags in the CDC expression. This
+// is used to determine the set of secondary index spans that have to be
+// scanned in order to locate the rows matching the expression predicate.
+func WithIndex(idx catalog.Index) CDCOption {
+	return funcOpt(func(config *cdcConfig) {
+		config.index = idx
+	})
+}
+
 // CDCExpressionPlan encapsulates execution plan for evaluation of CDC expressions.
 type CDCExpressionPlan struct {
 	Plan         planMaybePhysical     // Underlying plan...
 	PlanCtx      *PlanningCtx          // ... and plan context
 	Spans        roachpb.Spans         // Set of spans for rangefeed.
+	IndexID      descpb.IndexID        // Index scanned by the plan.
 	Presentation colinfo.ResultColumns // List of result columns.
 }
 
@@ -133,21 +146,27 @@ func PlanCDCExpression(
 
 	// Walk the plan, perform sanity checks and extract information we need.
 	var spans roachpb.Spans
+	var indexID descpb.IndexID
 	var validatePlanAndCollectSpans func(p planNode) error
 	validatePlanAndCollectSpans = func(p planNode) error {
 		switch n := p.(type) {
 		case *scanNode:
 			// Collect spans we wanted to scan. The select statement used for
 			// this plan should result in a single table scan of primary index
-			// span.
+			// span, or of the secondary index requested via WithIndex option.
 			if len(spans) > 0 {
 				return errors.AssertionFailedf("unexpected multiple primary index scan operations")
 			}
-			if n.index.GetID() != n.desc.GetPrimaryIndexID() {
+			expectedIndexID := n.desc.GetPrimaryIndexID()
+			if cfg.index != nil {
+				expectedIndexID = cfg.index.GetID()
+			}
+			if n.index.GetID() != expectedIndexID {
 				return errors.AssertionFailedf(
-					"expect scan of primary index, found scan of %d", n.index.GetID())
+					"expect scan of index %d, found scan of %d", expectedIndexID, n.index.GetID())
 			}
 			spans = n.spans
+			indexID = n.index.GetID()
 		case *zeroNode:
 			return errors.Newf(
 				"changefeed expression %s does not match any rows", tree.AsString(cdcExpr))
@@ -185,6 +204,7 @@ func PlanCDCExpression(
 		Plan:         p.curPlan.main,
 		PlanCtx:      planCtx,
 		Spans:        spans,
+		IndexID:      indexID,
 		Presentation: presentation,
 	}, nil
 }
@@ -443,7 +463,7 @@ func (c *cdcOptCatalog) ResolveFunction(
 func (c *cdcOptCatalog) newCDCDataSource(
 	ctx context.Context, original catalog.TableDescriptor, familyID catid.FamilyID,
 ) (cat.DataSource, error) {
-	d, err := newFamilyTableDescriptor(original, familyID, c.extraColumns)
+	d, err := newFamilyTableDescriptor(original, familyID, c.extraColumns, c.index)
 	if err != nil {
 		return nil, err
 	}
@@ -456,10 +476,15 @@ type familyTableDescriptor struct {
 	catalog.TableDescriptor
 	includeSet catalog.TableColSet
 	extraCols  []catalog.Column
+	// index, if set, is the only secondary index exposed by this descriptor.
+	index catalog.Index
 }
 
 func newFamilyTableDescriptor(
-	original catalog.TableDescriptor, familyID catid.FamilyID, extraCols []catalog.Column,
+	original catalog.TableDescriptor,
+	familyID catid.FamilyID,
+	extraCols []catalog.Column,
+	index catalog.Index,
 ) (catalog.TableDescriptor, error) {
 	// Build the set of columns in the family, along with the primary
 	// key columns.
@@ -478,24 +503,51 @@ func newFamilyTableDescriptor(
 		includeSet.Add(col.ID)
 	}
 
+	if index != nil {
+		if index.Primary() || !index.Public() {
+			return nil, errors.AssertionFailedf(
+				"expected public secondary index, found index %d", index.GetID())
+		}
+		if index.GetType() != idxtype.FORWARD {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"index %q of type %s cannot be used by changefeed expression",
+				index.GetName(), index.GetType())
+		}
+		// Key columns of the index must be available to the optimizer in
+		// order for it to constrain the index scan.
+		for i := 0; i < index.NumKeyColumns(); i++ {
+			if !includeSet.Contains(index.GetKeyColumnID(i)) {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"index %q references column %q which is not in the target column family",
+					index.GetName(), index.GetKeyColumnName(i))
+			}
+		}
+	}
+
 	return &familyTableDescriptor{
 		TableDescriptor: original,
 		includeSet:      includeSet,
 		extraCols:       extraCols,
+		index:           index,
 	}, nil
 }
 
 // DeletableNonPrimaryIndexes implements catalog.TableDescriptor interface.
-// CDC currently supports primary index only.
-// TODO(yevgeniy): We should be able to use secondary indexes provided
-// the CDC expression access only the columns available in that secondary index.
+// CDC supports primary index, and optionally, a single secondary index
+// specified via WithIndex option.
 func (d *familyTableDescriptor) DeletableNonPrimaryIndexes() []catalog.Index {
+	if d.index != nil {
+		return []catalog.Index{d.index}
+	}
 	return nil
 }
 
 // ActiveIndexes implements catalog.TableDescriptor.
-// Only primary index supported for now.
+// Only primary index, and optionally a single secondary index, are supported.
 func (d *familyTableDescriptor) ActiveIndexes() []catalog.Index {
+	if d.index != nil {
+		return []catalog.Index{d.TableDescriptor.GetPrimaryIndex(), d.index}
+	}
 	return d.TableDescriptor.ActiveIndexes()[:1]
 }
 
@@ -596,6 +648,7 @@ func (c *remappedOrdinalColumn) Ordinal() int {
 
 type cdcConfig struct {
 	extraColumns []catalog.Column
+	index        catalog.Index
 }
 
 type funcOpt func(config *cdcConfig)
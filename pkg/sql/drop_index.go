@@ -70,6 +70,12 @@ func (p *planner) DropIndex(ctx context.Context, n *tree.DropIndex) (planNode, e
 			return nil, err
 		}
 
+		// The data of the dropped index could never be cleared if the table is
+		// read-only.
+		if tableDesc.IsKVReadOnly() {
+			return nil, sqlerrors.NewSchemaChangeOnKVReadOnlyTableErr("DROP INDEX", tableDesc.GetName())
+		}
+
 		idxNames = append(idxNames, fullIndexName{tn: tn, idxName: index.Index})
 	}
 	return &dropIndexNode{n: n, idxNames: idxNames}, nil
@@ -1534,6 +1534,10 @@ type ExecutorConfig struct {
 	// records.
 	SpanConfigKVAccessor spanconfig.KVAccessor
 
+	// SpanConfigSQLTranslatorFactory is used to translate zone configs into
+	// span configs, to plan zone config changes.
+	SpanConfigSQLTranslatorFactory SpanConfigSQLTranslatorFactory
+
 	// InternalDB is used to create an isql.Executor bound with SessionData and
 	// other ExtraTxnState.
 	InternalDB *InternalDB
@@ -1910,6 +1914,10 @@ type BackupRestoreTestingKnobs struct {
 	RunAfterRetryIteration func(err error) error
 
 	RunAfterRestoreProcDrains func()
+
+	// RunAfterWritingExportBackupFile allows blocking the EXPORT BACKUP job
+	// after a file has been written.
+	RunAfterWritingExportBackupFile func(ctx context.Context, name string) error
 }
 
 var _ base.ModuleTestingKnobs = &BackupRestoreTestingKnobs{}
@@ -4101,6 +4109,10 @@ func (m *sessionDataMutator) SetRegisterLatchWaitContentionEvents(val bool) {
 	m.data.RegisterLatchWaitContentionEvents = val
 }
 
+func (m *sessionDataMutator) SetTransactionClass(val roachpb.TransactionClass) {
+	m.data.TransactionClass = int32(val)
+}
+
 // Utility functions related to scrubbing sensitive information on SQL Stats.
 
 // quantizeCounts ensures that the Count field in the
@@ -64,6 +64,11 @@ func (m *ExportSpec) User() username.SQLUsername {
 	return m.UserProto.Decode()
 }
 
+// User accesses the user field.
+func (m *ExportBackupDataSpec) User() username.SQLUsername {
+	return m.UserProto.Decode()
+}
+
 // User accesses the user field.
 func (m *ReadImportDataSpec) User() username.SQLUsername {
 	return m.UserProto.Decode()
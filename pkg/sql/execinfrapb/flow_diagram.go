@@ -541,6 +541,11 @@ func (c *RestoreDataSpec) summary() (string, []string) {
 	return "RestoreDataSpec", []string{}
 }
 
+// summary implements the diagramCellType interface.
+func (c *ExportBackupDataSpec) summary() (string, []string) {
+	return "ExportBackupDataSpec", []string{fmt.Sprintf("Entries: %d", len(c.Entries))}
+}
+
 // summary implements the diagramCellType interface.
 func (c *CloudStorageTestSpec) summary() (string, []string) {
 	return "CloudStorageTestSpec", []string{}
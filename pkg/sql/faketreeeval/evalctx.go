@@ -536,6 +536,13 @@ func (ep *DummyEvalPlanner) GetRangeDescByID(
 	return
 }
 
+// SpanConfigsForZoneConfigChange is part of the eval.Planner interface.
+func (ep *DummyEvalPlanner) SpanConfigsForZoneConfigChange(
+	context.Context, string,
+) ([]roachpb.SpanConfigEntry, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
 // SpanStats is part of the eval.Planner interface.
 func (ep *DummyEvalPlanner) SpanStats(
 	context.Context, roachpb.Spans,
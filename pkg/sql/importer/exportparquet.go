@@ -37,15 +37,39 @@ func fileName(spec execinfrapb.ExportSpec, part string) string {
 	}
 
 	fileName := strings.Replace(pattern, exportFilePatternPart, part, -1)
-	suffix := ""
-	switch spec.Format.Compression {
+	return fileName + ParquetCompressionSuffix(spec.Format.Compression)
+}
+
+// ParquetCompressionSuffix returns the suffix appended to the name of a Parquet
+// file written with the given compression.
+func ParquetCompressionSuffix(compression roachpb.IOFileFormat_Compression) string {
+	switch compression {
 	case roachpb.IOFileFormat_Gzip:
-		suffix = ".gz"
+		return ".gz"
 	case roachpb.IOFileFormat_Snappy:
-		suffix = ".snappy"
+		return ".snappy"
+	}
+	return ""
+}
+
+// ParquetCompressionCodec returns the codec used to write a Parquet file with
+// the given compression.
+func ParquetCompressionCodec(
+	compression roachpb.IOFileFormat_Compression,
+) (parquet.CompressionCodec, error) {
+	// TODO: util/parquet supports more compression formats. The
+	// exporter can be updated to supported these too.
+	switch compression {
+	case roachpb.IOFileFormat_Snappy:
+		return parquet.CompressionSnappy, nil
+	case roachpb.IOFileFormat_Gzip:
+		return parquet.CompressionGZIP, nil
+	case roachpb.IOFileFormat_Auto, roachpb.IOFileFormat_None:
+		return parquet.CompressionNone, nil
+	default:
+		return 0, pgerror.Newf(pgcode.FeatureNotSupported,
+			"parquet writer does not support compression format %s", compression)
 	}
-	fileName += suffix
-	return fileName
 }
 
 func newParquetWriterProcessor(
@@ -117,19 +141,9 @@ func (sp *parquetWriterProcessor) Run(ctx context.Context, output execinfra.RowR
 			return err
 		}
 
-		// TODO: util/parquet supports more compression formats. The
-		// exporter can be updated to supported these too.
-		var compression parquet.CompressionCodec
-		switch sp.spec.Format.Compression {
-		case roachpb.IOFileFormat_Snappy:
-			compression = parquet.CompressionSnappy
-		case roachpb.IOFileFormat_Gzip:
-			compression = parquet.CompressionGZIP
-		case roachpb.IOFileFormat_Auto, roachpb.IOFileFormat_None:
-			compression = parquet.CompressionNone
-		default:
-			return pgerror.Newf(pgcode.FeatureNotSupported,
-				"parquet writer does not support compression format %s", sp.spec.Format.Compression)
+		compression, err := ParquetCompressionCodec(sp.spec.Format.Compression)
+		if err != nil {
+			return err
 		}
 
 		chunk := 0
@@ -10,6 +10,8 @@ import (
 	gojson "encoding/json"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
@@ -19,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -38,6 +41,8 @@ func (p *planner) GenerateExecutionDetailsJSON(
 	switch payload.Type() {
 	case jobspb.TypeBackup:
 		executionDetailsJSON, err = constructBackupExecutionDetails(ctx, jobID, execCfg.InternalDB)
+	case jobspb.TypeRestore:
+		executionDetailsJSON, err = constructRestoreExecutionDetails(ctx, jobID, execCfg.InternalDB)
 	default:
 		executionDetailsJSON, err = constructDefaultExecutionDetails(ctx, jobID, execCfg.InternalDB)
 	}
@@ -76,6 +81,95 @@ func constructDefaultExecutionDetails(
 	return j, err
 }
 
+// progressEstimateDetails is a JSON serializable struct that captures the
+// latest jobspb.BulkProgressEstimate persisted by a BACKUP or RESTORE.
+type progressEstimateDetails struct {
+	TotalBytes     int64   `json:"total_bytes"`
+	CompletedBytes int64   `json:"completed_bytes"`
+	RemainingBytes int64   `json:"remaining_bytes"`
+	BytesPerSecond float64 `json:"bytes_per_second"`
+	// ETANanos is the estimated time until the job finishes, or zero if the
+	// job has not made enough progress to produce an estimate.
+	ETANanos int64 `json:"eta_nanos"`
+	// Bottleneck is the phase of the job that most of its time is currently
+	// spent in, e.g. "download", "ingestion" or "admission_control".
+	Bottleneck        string                  `json:"bottleneck"`
+	PerNodeThroughput []nodeThroughputDetails `json:"per_node_throughput"`
+	UpdatedAt         time.Time               `json:"updated_at"`
+}
+
+// nodeThroughputDetails is the throughput of a single node participating in a
+// BACKUP or RESTORE.
+type nodeThroughputDetails struct {
+	SQLInstanceID  base.SQLInstanceID `json:"sql_instance_id"`
+	CompletedBytes int64              `json:"completed_bytes"`
+	BytesPerSecond float64            `json:"bytes_per_second"`
+}
+
+// readProgressEstimate reads the latest progress estimate persisted by the
+// job, if any.
+func readProgressEstimate(
+	ctx context.Context, infoStorage jobs.InfoStorage,
+) (*progressEstimateDetails, error) {
+	value, exists, err := infoStorage.Get(ctx, "read-progress-estimate", profilerconstants.ProgressEstimateInfoKey)
+	if err != nil || !exists {
+		return nil, err
+	}
+	var estimate jobspb.BulkProgressEstimate
+	if err := protoutil.Unmarshal(value, &estimate); err != nil {
+		return nil, err
+	}
+	details := &progressEstimateDetails{
+		TotalBytes:        estimate.TotalBytes,
+		CompletedBytes:    estimate.CompletedBytes,
+		RemainingBytes:    estimate.RemainingBytes,
+		BytesPerSecond:    estimate.BytesPerSecond,
+		ETANanos:          estimate.ETA.Nanoseconds(),
+		Bottleneck:        strings.ToLower(estimate.Bottleneck.String()),
+		PerNodeThroughput: make([]nodeThroughputDetails, 0, len(estimate.Nodes)),
+		UpdatedAt:         estimate.UpdatedAt,
+	}
+	for _, n := range estimate.Nodes {
+		details.PerNodeThroughput = append(details.PerNodeThroughput, nodeThroughputDetails{
+			SQLInstanceID:  n.SQLInstanceID,
+			CompletedBytes: n.CompletedBytes,
+			BytesPerSecond: n.BytesPerSecond,
+		})
+	}
+	return details, nil
+}
+
+// restoreExecutionDetails is a JSON serializable struct that captures the
+// execution details that are specific to RESTOREs.
+type restoreExecutionDetails struct {
+	defaultExecutionDetails
+
+	// ProgressEstimate is the latest estimate of the restore's remaining work.
+	ProgressEstimate *progressEstimateDetails `json:"progress_estimate,omitempty"`
+}
+
+func constructRestoreExecutionDetails(
+	ctx context.Context, jobID jobspb.JobID, db isql.DB,
+) ([]byte, error) {
+	executionDetails := &restoreExecutionDetails{}
+	if err := db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		infoStorage := jobs.InfoStorageForJob(txn, jobID)
+		if err := infoStorage.GetLast(ctx, profilerconstants.DSPDiagramInfoKeyPrefix, func(infoKey string, value []byte) error {
+			executionDetails.PlanDiagram = string(value)
+			return nil
+		}); err != nil {
+			return err
+		}
+		var err error
+		executionDetails.ProgressEstimate, err = readProgressEstimate(ctx, infoStorage)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	j, err := gojson.Marshal(executionDetails)
+	return j, err
+}
+
 // backupExecutionDetails is a JSON serializable struct that captures the
 // execution details that are specific to BACKUPs.
 type backupExecutionDetails struct {
@@ -85,12 +179,16 @@ type backupExecutionDetails struct {
 	// execinfra.ComponentID to the progress fraction reported by the executing
 	// job for that component.
 	PerComponentFractionProgressed map[string]float32 `json:"per_component_fraction_progressed"`
+
+	// ProgressEstimate is the latest estimate of the backup's remaining work.
+	ProgressEstimate *progressEstimateDetails `json:"progress_estimate,omitempty"`
 }
 
 func constructBackupExecutionDetails(
 	ctx context.Context, jobID jobspb.JobID, db isql.DB,
 ) ([]byte, error) {
 	var annotatedURL url.URL
+	var progressEstimate *progressEstimateDetails
 	marshallablePerComponentProgress := make(map[string]float32)
 	if err := db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
 		// Read the latest DistSQL diagram.
@@ -150,7 +248,10 @@ func constructBackupExecutionDetails(
 		for component, progress := range perComponentProgress {
 			marshallablePerComponentProgress[component.String()] = progress
 		}
-		return nil
+
+		var err error
+		progressEstimate, err = readProgressEstimate(ctx, infoStorage)
+		return err
 	}); err != nil {
 		return nil, err
 	}
@@ -158,6 +259,7 @@ func constructBackupExecutionDetails(
 	executionDetails := backupExecutionDetails{
 		defaultExecutionDetails:        defaultExecutionDetails{PlanDiagram: annotatedURL.String()},
 		PerComponentFractionProgressed: marshallablePerComponentProgress,
+		ProgressEstimate:               progressEstimate,
 	}
 	j, err := gojson.Marshal(executionDetails)
 	return j, err
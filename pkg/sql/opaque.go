@@ -441,6 +441,8 @@ func init() {
 		&tree.AlterTenantReplication{},
 		&tree.AlterTenantReset{},
 		&tree.Backup{},
+		&tree.BackupCopy{},
+		&tree.ExportBackup{},
 		&tree.ShowBackup{},
 		&tree.Restore{},
 		&tree.CreateChangefeed{},
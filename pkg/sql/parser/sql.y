@@ -978,11 +978,11 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> BOOLEAN BOTH BOX2D BUNDLE BY BYPASSRLS
 
 %token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHANGEFEED CHAR
-%token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CLOSE
+%token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CHECK_FINGERPRINTS CLOSE
 %token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
-%token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
+%token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIG CONFIGURATION CONFIGURATIONS CONFIGURE
 %token <str> CONFLICT CONNECTION CONNECTIONS CONSTRAINT CONSTRAINTS CONTAINS CONTROLCHANGEFEED CONTROLJOB
-%token <str> CONVERSION CONVERT COPY COS_DISTANCE COST COVERING CREATE CREATEDB CREATELOGIN CREATEROLE
+%token <str> CONVERSION CONVERT COPY COPY_TO COS_DISTANCE COST COVERING CREATE CREATEDB CREATELOGIN CREATEROLE
 %token <str> CROSS CSV CUBE CURRENT CURRENT_CATALOG CURRENT_DATE CURRENT_SCHEMA
 %token <str> CURRENT_ROLE CURRENT_TIME CURRENT_TIMESTAMP
 %token <str> CURRENT_USER CURSOR CYCLE
@@ -1016,7 +1016,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITIALLY
 %token <str> INDEX_BEFORE_PAREN INDEX_BEFORE_NAME_THEN_PAREN INDEX_AFTER_ORDER_BY_BEFORE_AT
 %token <str> INNER INOUT INPUT INSENSITIVE INSERT INSTEAD INT INTEGER
-%token <str> INTERSECT INTERVAL INTO INTO_DB INVERTED INVOKER IS ISERROR ISNULL ISOLATION
+%token <str> INTERSECT INTERVAL INTO INTO_DB INTO_TABLE INVERTED INVOKER IS ISERROR ISNULL ISOLATION
 
 %token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
 
@@ -1039,7 +1039,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
 
-%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD OLD_KMS ON ONLY OPT OPTION OPTIONS OR
+%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD OLD_KMS ON ON_CONFLICT ONLY OPT OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
 %token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PER PERMISSIVE PHYSICAL PLACEMENT PLACING
@@ -1052,7 +1052,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFERENCING REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH REMOVE_REGIONS RENAME REPEATABLE REPLACE REPLICATED REPLICATION
-%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESTRICTIVE RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
+%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESTRICTIVE RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY REVISIONS
 %token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROUTINES ROW ROWS RSHIFT RULE RUNNING
 
 %token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
@@ -1733,6 +1733,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 
 %type <tree.ChangefeedTargets> changefeed_targets
 %type <tree.ChangefeedTarget> changefeed_target
+%type <tree.ChangefeedTarget> changefeed_table_target
 %type <tree.BackupTargetList> backup_targets
 %type <*tree.BackupTargetList> opt_backup_targets
 
@@ -3407,6 +3408,7 @@ opt_clear_data:
 //    Empty targets list: backup full cluster.
 //    TABLE <pattern> [, ...]
 //    DATABASE <databasename> [, ...]
+//    SYSTEM CONFIG [( <category> [, ...] )]
 //
 // Destination:
 //    "[scheme]://[host]/[path to backup]?[parameters]"
@@ -3418,6 +3420,10 @@ opt_clear_data:
 //    detached: execute backup job asynchronously, without waiting for its completion
 //    incremental_location: specify a different path to store the incremental backup
 //    include_all_virtual_clusters: enable backups of all virtual clusters during a cluster backup
+//    copy_to: copy each completed backup to a second collection
+//
+// Copy a backup chain from one collection to another:
+// BACKUP COPY FROM <subdir|LATEST> IN <collection> TO <collection>
 //
 // %SeeAlso: RESTORE, WEBDOCS/backup.html
 backup_stmt:
@@ -3451,6 +3457,14 @@ backup_stmt:
       Options: *$8.backupOptions(),
     }
   }
+| BACKUP COPY FROM string_or_placeholder IN string_or_placeholder TO string_or_placeholder
+  {
+    $$.val = &tree.BackupCopy{
+      Subdir: $4.expr(),
+      From: $6.expr(),
+      To: $8.expr(),
+    }
+  }
 | BACKUP opt_backup_targets TO error
   {
     setErr(sqllex, errors.New("The `BACKUP TO` syntax is no longer supported. Please use `BACKUP INTO` to create a backup collection."))
@@ -3552,6 +3566,10 @@ backup_options:
   {
     $$.val = &tree.BackupOptions{UpdatesClusterMonitoringMetrics: $3.expr()}
   }
+| COPY_TO '=' string_or_placeholder
+  {
+    $$.val = &tree.BackupOptions{CopyTo: $3.expr()}
+  }
 
 include_all_clusters:
   INCLUDE_ALL_SECONDARY_TENANTS { /* SKIP DOC */ }
@@ -3917,6 +3935,7 @@ drop_external_connection_stmt:
 // Targets:
 //    TABLE <pattern> [, ...]
 //    DATABASE <databasename> [, ...]
+//    SYSTEM CONFIG [( <category> [, ...] )]
 //
 // Locations:
 //    "[scheme]://[host]/[path to backup]?[parameters]"
@@ -3933,6 +3952,9 @@ drop_external_connection_stmt:
 //    detached: execute restore job asynchronously, without waiting for its completion
 //    skip_localities_check: ignore difference of zone configuration between restore cluster and backup cluster
 //    new_db_name: renames the restored database. only applies to database restores
+//    where: only restore the rows of a single table matching a predicate on its primary key
+//    into_table: write the rows of a single table into an existing table instead of creating it
+//    on_conflict: whether into_table or SYSTEM CONFIG overwrites or skips existing rows
 //    include_all_virtual_clusters: enable backups of all virtual clusters during a cluster backup
 // %SeeAlso: BACKUP, WEBDOCS/restore.html
 restore_stmt:
@@ -3958,8 +3980,14 @@ restore_stmt:
   }
 | RESTORE backup_targets FROM string_or_placeholder IN string_or_placeholder_opt_list opt_as_of_clause opt_with_restore_options
   {
+    targets := $2.backupTargetList()
+    coverage := tree.RequestedDescriptors
+    if targets.SystemConfig {
+      coverage = tree.SystemConfig
+    }
     $$.val = &tree.Restore{
-      Targets: $2.backupTargetList(),
+      Targets: targets,
+      DescriptorCoverage: coverage,
       Subdir: $4.expr(),
       From: $6.stringOrPlaceholderOptList(),
       AsOf: $7.asOfClause(),
@@ -4103,6 +4131,18 @@ restore_options:
   {
     $$.val = &tree.RestoreOptions{RemoveRegions: true, SkipLocalitiesCheck: true}
   }
+| WHERE '=' string_or_placeholder
+  {
+    $$.val = &tree.RestoreOptions{Where: $3.expr()}
+  }
+| INTO_TABLE '=' string_or_placeholder
+  {
+    $$.val = &tree.RestoreOptions{IntoTable: $3.expr()}
+  }
+| ON_CONFLICT '=' string_or_placeholder
+  {
+    $$.val = &tree.RestoreOptions{OnConflict: $3.expr()}
+  }
 
 virtual_cluster_opt:
   TENANT  { /* SKIP DOC */ }
@@ -4194,12 +4234,49 @@ import_stmt:
 // Options:
 //    delimiter = '...'   [CSV-specific]
 //
-// %SeeAlso: SELECT
+// Export the tables of a backup without restoring it:
+// EXPORT BACKUP [<targets...>] FROM <subdir|LATEST> IN <collection>
+//        INTO PARQUET <destination>
+//        [ AS OF SYSTEM TIME <expr> ]
+//        [ WITH <option> [= <value>] [, ...] ]
+//
+// Options:
+//    encryption_passphrase="secret": decrypt the backup
+//    kms="[kms_provider]://[kms_host]/[master_key_identifier]?[parameters]" : decrypt the backup using KMS
+//    compression = 'gzip'|'snappy'
+//    chunk_rows = '...'
+//    detached: execute the export job asynchronously
+//
+// %SeeAlso: SELECT, RESTORE
 export_stmt:
   EXPORT INTO import_format string_or_placeholder opt_with_options FROM select_stmt
   {
     $$.val = &tree.Export{Query: $7.slct(), FileFormat: $3, File: $4.expr(), Options: $5.kvOptions()}
   }
+| EXPORT BACKUP FROM string_or_placeholder IN string_or_placeholder INTO import_format string_or_placeholder opt_as_of_clause opt_with_options
+  {
+    $$.val = &tree.ExportBackup{
+      Subdir: $4.expr(),
+      From: $6.expr(),
+      FileFormat: $8,
+      File: $9.expr(),
+      AsOf: $10.asOfClause(),
+      Options: $11.kvOptions(),
+    }
+  }
+| EXPORT BACKUP backup_targets FROM string_or_placeholder IN string_or_placeholder INTO import_format string_or_placeholder opt_as_of_clause opt_with_options
+  {
+    targets := $3.backupTargetList()
+    $$.val = &tree.ExportBackup{
+      Targets: &targets,
+      Subdir: $5.expr(),
+      From: $7.expr(),
+      FileFormat: $9,
+      File: $10.expr(),
+      AsOf: $11.asOfClause(),
+      Options: $12.kvOptions(),
+    }
+  }
 | EXPORT error // SHOW HELP: EXPORT
 
 string_or_placeholder:
@@ -6342,21 +6419,36 @@ changefeed_targets:
   }
 
 changefeed_target:
-  opt_table_prefix table_name opt_changefeed_family
+  changefeed_table_target
+| TABLE changefeed_table_target
+  {
+    $$.val = $2.changefeedTarget()
+  }
+| MATERIALIZED VIEW table_name
   {
     $$.val = tree.ChangefeedTarget{
-      TableName:  $2.unresolvedObjectName().ToUnresolvedName(),
-      FamilyName: tree.Name($3),
+      TableName:        $3.unresolvedObjectName().ToUnresolvedName(),
+      MaterializedView: true,
     }
   }
 
-changefeed_target_expr: insert_target
+changefeed_table_target:
+  table_name opt_changefeed_family
+  {
+    $$.val = tree.ChangefeedTarget{
+      TableName:  $1.unresolvedObjectName().ToUnresolvedName(),
+      FamilyName: tree.Name($2),
+    }
+  }
+| table_name '@' index_name
+  {
+    $$.val = tree.ChangefeedTarget{
+      TableName: $1.unresolvedObjectName().ToUnresolvedName(),
+      IndexName: tree.UnrestrictedName($3),
+    }
+  }
 
-opt_table_prefix:
-  TABLE
-  {}
-| /* EMPTY */
-  {}
+changefeed_target_expr: insert_target
 
 opt_changefeed_family:
   FAMILY family_name
@@ -6928,7 +7020,7 @@ alter_job_stmt:
 // %Help: ALTER CHANGEFEED - alter an existing changefeed
 // %Category: CCL
 // %Text:
-// ALTER CHANGEFEED <job_id> {{ADD|DROP <targets...>} | SET <options...>}...
+// ALTER CHANGEFEED <job_id> {{ADD|DROP|PAUSE|RESUME <targets...>} | SET <options...>}...
 alter_changefeed_stmt:
   ALTER CHANGEFEED a_expr alter_changefeed_cmds
   {
@@ -6977,6 +7069,20 @@ alter_changefeed_cmd:
       Options: $2.nameList(),
     }
   }
+  // ALTER CHANGEFEED <job_id> PAUSE [TABLE] ...
+| PAUSE changefeed_targets
+  {
+    $$.val = &tree.AlterChangefeedPauseTarget{
+      Targets: $2.changefeedTargets(),
+    }
+  }
+  // ALTER CHANGEFEED <job_id> RESUME [TABLE] ...
+| RESUME changefeed_targets
+  {
+    $$.val = &tree.AlterChangefeedResumeTarget{
+      Targets: $2.changefeedTargets(),
+    }
+  }
 
 // %Help: ALTER BACKUP - alter an existing backup's encryption keys
 // %Category: CCL
@@ -8723,7 +8829,7 @@ show_histogram_stmt:
 
 // %Help: SHOW BACKUP - list backup contents
 // %Category: CCL
-// %Text: SHOW BACKUP [SCHEMAS|FILES|RANGES] <location>
+// %Text: SHOW BACKUP [SCHEMAS|FILES|RANGES|REVISIONS] <location>
 // %SeeAlso: WEBDOCS/show-backup.html
 show_backup_stmt:
   SHOW BACKUPS IN string_or_placeholder_opt_list
@@ -8806,6 +8912,10 @@ show_backup_details:
     /* SKIP DOC */
 	$$.val = tree.BackupRangeDetails
 	}
+| REVISIONS
+	{
+	$$.val = tree.BackupRevisionDetails
+	}
 | VALIDATE
 	{
     /* SKIP DOC */
@@ -8848,6 +8958,10 @@ show_backup_options:
  {
  $$.val = &tree.ShowBackupOptions{CheckFiles: true}
  }
+ | CHECK_FINGERPRINTS
+ {
+ $$.val = &tree.ShowBackupOptions{CheckFingerprints: true}
+ }
  | SKIP SIZE
  {
  $$.val = &tree.ShowBackupOptions{SkipSize: true}
@@ -10411,6 +10525,14 @@ backup_targets:
   {
     $$.val = tree.BackupTargetList{Databases: $2.nameList()}
   }
+| SYSTEM CONFIG
+  {
+    $$.val = tree.BackupTargetList{SystemConfig: true}
+  }
+| SYSTEM CONFIG '(' name_list ')'
+  {
+    $$.val = tree.BackupTargetList{SystemConfig: true, ConfigCategories: $4.nameList()}
+  }
 
 // target_roles is the variant of targets which recognizes ON ROLES
 // with a name list. This cannot be included in targets directly
@@ -18099,6 +18221,7 @@ unreserved_keyword:
 | CASCADE
 | CHANGEFEED
 | CHECK_FILES
+| CHECK_FINGERPRINTS
 | CLOSE
 | CLUSTER
 | CLUSTERS
@@ -18111,6 +18234,7 @@ unreserved_keyword:
 | COMPLETE
 | COMPLETIONS
 | CONFLICT
+| CONFIG
 | CONFIGURATION
 | CONFIGURATIONS
 | CONFIGURE
@@ -18122,6 +18246,7 @@ unreserved_keyword:
 | CONVERSION
 | CONVERT
 | COPY
+| COPY_TO
 | COST
 | COVERING
 | CREATEDB
@@ -18233,6 +18358,7 @@ unreserved_keyword:
 | INSERT
 | INSTEAD
 | INTO_DB
+| INTO_TABLE
 | INVERTED
 | INVISIBLE
 | ISOLATION
@@ -18329,6 +18455,7 @@ unreserved_keyword:
 | OIDS
 | OLD
 | OLD_KMS
+| ON_CONFLICT
 | OPERATOR
 | OPT
 | OPTION
@@ -18410,6 +18537,7 @@ unreserved_keyword:
 | RETURN
 | RETURNS
 | REVISION_HISTORY
+| REVISIONS
 | REVOKE
 | ROLE
 | ROLES
@@ -18613,6 +18741,7 @@ bare_label_keywords:
 | CHARACTERISTICS
 | CHECK
 | CHECK_FILES
+| CHECK_FINGERPRINTS
 | CLOSE
 | CLUSTER
 | CLUSTERS
@@ -18628,6 +18757,7 @@ bare_label_keywords:
 | COMPLETE
 | COMPLETIONS
 | CONCURRENTLY
+| CONFIG
 | CONFIGURATION
 | CONFIGURATIONS
 | CONFIGURE
@@ -18641,6 +18771,7 @@ bare_label_keywords:
 | CONVERSION
 | CONVERT
 | COPY
+| COPY_TO
 | COST
 | COVERING
 | CREATEDB
@@ -18794,6 +18925,7 @@ bare_label_keywords:
 | INTEGER
 | INTERVAL
 | INTO_DB
+| INTO_TABLE
 | INVERTED
 | INVISIBLE
 | INVOKER
@@ -18903,6 +19035,7 @@ bare_label_keywords:
 | OIDS
 | OLD
 | OLD_KMS
+| ON_CONFLICT
 | ONLY
 | OPERATOR
 | OPT
@@ -18995,6 +19128,7 @@ bare_label_keywords:
 | RETURN
 | RETURNS
 | REVISION_HISTORY
+| REVISIONS
 | REVOKE
 | RIGHT
 | ROLE
@@ -40,6 +40,11 @@ func ConvertBatchError(ctx context.Context, tableDesc catalog.TableDescriptor, b
 			pgcode.UnsatisfiableBoundedStaleness,
 		)
 
+	case *kvpb.ReadOnlySpanError:
+		return pgerror.Wrapf(
+			origPErr.GoError(), pgcode.ReadOnlySQLTransaction, "table %q is read-only", tableDesc.GetName(),
+		)
+
 	case *kvpb.ConditionFailedError:
 		if !v.OriginTimestampOlderThan.IsEmpty() {
 			// NOTE: we return the go error here because this error should never be
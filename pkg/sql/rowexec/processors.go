@@ -263,6 +263,15 @@ func NewProcessor(
 		}
 		return NewRestoreDataProcessor(ctx, flowCtx, processorID, *core.RestoreData, post, inputs[0])
 	}
+	if core.ExportBackupData != nil {
+		if err := checkNumIn(inputs, 0); err != nil {
+			return nil, err
+		}
+		if NewExportBackupDataProcessor == nil {
+			return nil, errors.New("ExportBackupData processor unimplemented")
+		}
+		return NewExportBackupDataProcessor(ctx, flowCtx, processorID, *core.ExportBackupData, post)
+	}
 	if core.StreamIngestionData != nil {
 		if err := checkNumIn(inputs, 0); err != nil {
 			return nil, err
@@ -423,6 +432,9 @@ var NewBackupDataProcessor func(context.Context, *execinfra.FlowCtx, int32, exec
 // NewRestoreDataProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
 var NewRestoreDataProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.RestoreDataSpec, *execinfrapb.PostProcessSpec, execinfra.RowSource) (execinfra.Processor, error)
 
+// NewExportBackupDataProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
+var NewExportBackupDataProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.ExportBackupDataSpec, *execinfrapb.PostProcessSpec) (execinfra.Processor, error)
+
 // NewStreamIngestionDataProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
 var NewStreamIngestionDataProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.StreamIngestionDataSpec, *execinfrapb.PostProcessSpec) (execinfra.Processor, error)
 
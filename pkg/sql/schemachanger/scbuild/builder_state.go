@@ -605,6 +605,13 @@ func (b *builderState) IsTableEmpty(table *scpb.Table) bool {
 	return b.tr.IsTableEmpty(b.ctx, table.TableID, index.IndexID)
 }
 
+// IsTableKVReadOnly implements the scbuildstmt.TableHelpers interface.
+func (b *builderState) IsTableKVReadOnly(tableID catid.DescID) bool {
+	b.ensureDescriptor(tableID)
+	tbl, ok := b.descCache[tableID].desc.(catalog.TableDescriptor)
+	return ok && tbl.IsKVReadOnly()
+}
+
 func (b *builderState) nextIndexID(id catid.DescID) (ret catid.IndexID) {
 	{
 		b.ensureDescriptor(id)
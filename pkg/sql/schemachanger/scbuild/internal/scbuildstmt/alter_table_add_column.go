@@ -278,6 +278,12 @@ func alterTableAddColumn(
 		}
 		b.IncrementSchemaChangeAddColumnQualificationCounter("on_update")
 	}
+	// The backfill of the new column or its index could never write to a
+	// read-only table.
+	if idx != nil || (!spec.colType.IsVirtual && (spec.def != nil || spec.colType.ComputeExpr != nil ||
+		spec.compute != nil || spec.transientCompute != nil)) {
+		panicIfTableIsKVReadOnly(b, tbl.TableID, "ADD COLUMN requiring a backfill")
+	}
 	// Add secondary indexes for this column.
 	backing := addColumn(b, spec, t)
 	if idx != nil {
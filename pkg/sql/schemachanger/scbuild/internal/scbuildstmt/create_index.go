@@ -145,6 +145,8 @@ func CreateIndex(b BuildCtx, n *tree.CreateIndex) {
 		))
 	}
 	panicIfSchemaChangeIsDisallowed(relationElements, n)
+	// The backfill of the new index could never write to a read-only table.
+	panicIfTableIsKVReadOnly(b, idxSpec.secondary.TableID, "CREATE INDEX")
 
 	if !n.Type.SupportsSharding() && n.Sharded != nil {
 		panic(pgerror.Newf(pgcode.InvalidSQLStatementName,
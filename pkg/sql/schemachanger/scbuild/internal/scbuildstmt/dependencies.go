@@ -318,6 +318,10 @@ type TableHelpers interface {
 
 	// IsTableEmpty returns if the table is empty or not.
 	IsTableEmpty(tbl *scpb.Table) bool
+
+	// IsTableKVReadOnly returns if the table's data has been made read-only
+	// through the read_only storage parameter.
+	IsTableKVReadOnly(tableID catid.DescID) bool
 }
 
 type FunctionHelpers interface {
@@ -96,6 +96,9 @@ func maybeDropIndex(
 	}
 	panicIfRegionChangeUnderwayOnRBRTable(b, "DROP INDEX", sie.TableID)
 	panicIfSchemaChangeIsDisallowed(b.QueryByID(sie.TableID), n)
+	// The data of the dropped index could never be cleared if the table is
+	// read-only.
+	panicIfTableIsKVReadOnly(b, sie.TableID, "DROP INDEX")
 	// Cannot drop the index if not CASCADE and a unique constraint depends on it.
 	if n.DropBehavior != tree.DropCascade && sie.IsUnique && !sie.IsCreatedExplicitly {
 		panic(errors.WithHint(
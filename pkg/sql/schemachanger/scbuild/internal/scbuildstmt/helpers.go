@@ -972,6 +972,20 @@ func shouldSkipValidatingConstraint(
 	return skip, err
 }
 
+// panicIfTableIsKVReadOnly panics if the table's data has been made read-only
+// through the read_only storage parameter, as the schema change would need to
+// write to or clear that data.
+func panicIfTableIsKVReadOnly(b BuildCtx, tableID catid.DescID, op string) {
+	if !b.IsTableKVReadOnly(tableID) {
+		return
+	}
+	_, _, ns := scpb.FindNamespace(b.QueryByID(tableID))
+	if ns == nil {
+		panic(errors.AssertionFailedf("programming error: Namespace element not found"))
+	}
+	panic(sqlerrors.NewSchemaChangeOnKVReadOnlyTableErr(op, ns.Name))
+}
+
 // panicIfSchemaChangeIsDisallowed panics if a schema change is not allowed on
 // this table. A schema change is disallowed if one of the following is true:
 //   - The schema_locked table storage parameter is true, and this statement is
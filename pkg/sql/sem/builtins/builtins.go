@@ -210,6 +210,8 @@ var StartCompactionJob func(
 	start, end hlc.Timestamp,
 ) (jobspb.JobID, error)
 
+var RescanBackupCatalog func(ctx context.Context, planner interface{}, collectionURIs []string) (int, error)
+
 // builtins contains the built-in functions indexed by name.
 //
 // For use in other packages, see AllBuiltinNames and GetBuiltinProperties().
@@ -9203,6 +9205,45 @@ WHERE object_id = table_descriptor_id
 			},
 		},
 	),
+	"crdb_internal.rescan_backup_catalog": makeBuiltin(
+		tree.FunctionProperties{
+			Undocumented: true,
+			ReturnLabels: []string{"backups"},
+		},
+		tree.Overload{
+			Types:      tree.ParamTypes{},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Info:       "Refreshes the backup catalog from the manifests of the backups written by jobs in this cluster and of the backups in their collections, and returns the number of backups scanned.",
+			Volatility: volatility.Volatile,
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if RescanBackupCatalog == nil {
+					return nil, errors.Newf("missing RescanBackupCatalog")
+				}
+				n, err := RescanBackupCatalog(ctx, evalCtx.Planner, nil /* collectionURIs */)
+				return tree.NewDInt(tree.DInt(n)), err
+			},
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "collection_uris", Typ: types.StringArray},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Info:       "Refreshes the backup catalog from the manifests of the backups written by jobs in this cluster and of the backups in their collections and in the given ones, and returns the number of backups scanned.",
+			Volatility: volatility.Volatile,
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if RescanBackupCatalog == nil {
+					return nil, errors.Newf("missing RescanBackupCatalog")
+				}
+				ary := *tree.MustBeDArray(args[0])
+				collectionURIs, ok := darrayToStringSlice(ary)
+				if !ok {
+					return nil, errors.Newf("expected array value, got %T", args[0])
+				}
+				n, err := RescanBackupCatalog(ctx, evalCtx.Planner, collectionURIs)
+				return tree.NewDInt(tree.DInt(n)), err
+			},
+		},
+	),
 }
 
 var lengthImpls = func(incBitOverload bool) builtinDefinition {
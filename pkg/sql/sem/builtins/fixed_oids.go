@@ -2652,6 +2652,9 @@ var builtinOidsArray = []string{
 	2689: `jsonb_path_exists(target: jsonb, path: jsonpath) -> bool`,
 	2690: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
 	2691: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2692: `crdb_internal.rescan_backup_catalog() -> int`,
+	2693: `crdb_internal.plan_zone_config_change(stmt: string) -> tuple{int AS range_id, string AS change, int AS store_id, int AS bytes, string AS detail}`,
+	2694: `crdb_internal.rescan_backup_catalog(collection_uris: string[]) -> int`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
@@ -0,0 +1,237 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	for k, v := range planZoneConfigChangeGenerators {
+		const enforceClass = true
+		registerBuiltin(k, v, tree.GeneratorClass, enforceClass)
+	}
+}
+
+var planZoneConfigChangeGenerators = map[string]builtinDefinition{
+	"crdb_internal.plan_zone_config_change": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true, // uses the planner
+			Undocumented:     true,
+		},
+		makeGeneratorOverload(
+			tree.ParamTypes{
+				{Name: "stmt", Typ: types.String},
+			},
+			planZoneConfigChangeGeneratorType,
+			makePlanZoneConfigChangeGenerator,
+			`Returns the replica and lease movements the given ALTER ... CONFIGURE ZONE
+			statement would cause, without applying it.
+			Parameters
+				stmt: the ALTER ... CONFIGURE ZONE statement to plan.
+			Returned rows
+				Each row describes a change to a range affected by the statement. change is
+				one of 'add replica', 'remove replica', 'transfer lease' or 'unsatisfiable'.
+				store_id is the store the replica is added to or removed from, or the store
+				the lease is transferred to. bytes is the logical size of the range, i.e. the
+				number of bytes sent to or freed on the store by the replica movement.
+				detail explains why the range can't conform to the new zone config.
+			Example usage
+				bytes to be sent to each store: select store_id, sum(bytes) from crdb_internal.plan_zone_config_change('ALTER TABLE t CONFIGURE ZONE USING num_replicas = 5') where change = 'add replica' group by store_id;
+			Notes
+				The movements are simulated against the view of the cluster of the node
+				executing the function, and only include those needed for the ranges to
+				conform to the new zone config, not any subsequent load-based rebalancing.
+			`,
+			volatility.Volatile,
+		),
+	),
+}
+
+var planZoneConfigChangeGeneratorType = types.MakeLabeledTuple(
+	[]*types.T{types.Int, types.String, types.Int, types.Int, types.String},
+	[]string{"range_id", "change", "store_id", "bytes", "detail"},
+)
+
+// planZoneConfigChangeGenerator is a generator for the
+// crdb_internal.plan_zone_config_change builtin.
+type planZoneConfigChangeGenerator struct {
+	evalCtx *eval.Context
+	stmt    string
+	// rows are the rows yet to be emitted, computed in Start.
+	rows []tree.Datums
+	// cur is the current row, emitted by Values().
+	cur tree.Datums
+}
+
+var _ eval.ValueGenerator = &planZoneConfigChangeGenerator{}
+
+func makePlanZoneConfigChangeGenerator(
+	ctx context.Context, evalCtx *eval.Context, args tree.Datums,
+) (eval.ValueGenerator, error) {
+	if err := evalCtx.SessionAccessor.CheckPrivilege(
+		ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.VIEWCLUSTERMETADATA,
+	); err != nil {
+		return nil, err
+	}
+	return &planZoneConfigChangeGenerator{
+		evalCtx: evalCtx,
+		stmt:    string(tree.MustBeDString(args[0])),
+	}, nil
+}
+
+// ResolvedType is part of the eval.ValueGenerator interface.
+func (*planZoneConfigChangeGenerator) ResolvedType() *types.T {
+	return planZoneConfigChangeGeneratorType
+}
+
+// Start is part of the eval.ValueGenerator interface.
+func (p *planZoneConfigChangeGenerator) Start(ctx context.Context, _ *kv.Txn) error {
+	entries, err := p.evalCtx.Planner.SpanConfigsForZoneConfigChange(ctx, p.stmt)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	// The changes are planned by the allocator of any of the local stores.
+	var store kvserverbase.Store
+	if err := p.evalCtx.KVStoresIterator.ForEachStore(func(s kvserverbase.Store) error {
+		if store == nil {
+			store = s
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if store == nil {
+		// Only nodes of the system tenant have stores.
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"crdb_internal.plan_zone_config_change requires a node with local stores")
+	}
+	// The planner accounts for the changes planned for each range when planning
+	// the next ones, so the same one is used for all ranges.
+	planner, err := store.NewSpanConfigChangePlanner()
+	if err != nil {
+		return err
+	}
+
+	// A range straddling the spans of several span configs is planned against
+	// the config of the first of them, which is the config it takes after
+	// being split along the span boundaries.
+	seen := make(map[roachpb.RangeID]struct{})
+	for _, entry := range entries {
+		span := entry.Target.GetSpan()
+		if span == nil {
+			continue
+		}
+		it, err := p.evalCtx.Planner.GetRangeDescIterator(ctx, *span)
+		if err != nil {
+			return err
+		}
+		var descs []roachpb.RangeDescriptor
+		var startKeys []roachpb.Key
+		for ; it.Valid(); it.Next() {
+			desc := it.CurRangeDescriptor()
+			if _, ok := seen[desc.RangeID]; ok {
+				continue
+			}
+			seen[desc.RangeID] = struct{}{}
+			if len(desc.StartKey) == 0 {
+				desc.StartKey = keys.MustAddr(keys.LocalMax)
+			}
+			descs = append(descs, desc)
+			startKeys = append(startKeys, desc.StartKey.AsRawKey())
+		}
+		if len(descs) == 0 {
+			continue
+		}
+		stats, err := p.evalCtx.RangeStatsFetcher.RangeStats(ctx, startKeys...)
+		if err != nil {
+			return err
+		}
+		if len(stats) != len(descs) {
+			return errors.AssertionFailedf(
+				"expected stats for %d ranges, got %d", len(descs), len(stats))
+		}
+		for i := range descs {
+			bytes := stats[i].MVCCStats.Total()
+			res, err := planner.PlanSpanConfigChange(ctx, descs[i],
+				stats[i].RangeInfo.Lease.Replica.StoreID, bytes, entry.Config)
+			if err != nil {
+				return err
+			}
+			p.addRows(descs[i].RangeID, bytes, res)
+		}
+	}
+	return nil
+}
+
+// addRows adds the rows describing the planned changes for the range.
+func (p *planZoneConfigChangeGenerator) addRows(
+	rangeID roachpb.RangeID, bytes int64, res kvserverbase.SpanConfigChangePlan,
+) {
+	addRow := func(change string, storeID roachpb.StoreID, bytes tree.Datum, detail tree.Datum) {
+		storeIDDatum := tree.DNull
+		if storeID != 0 {
+			storeIDDatum = tree.NewDInt(tree.DInt(storeID))
+		}
+		p.rows = append(p.rows, tree.Datums{
+			tree.NewDInt(tree.DInt(rangeID)),
+			tree.NewDString(change),
+			storeIDDatum,
+			bytes,
+			detail,
+		})
+	}
+	bytesDatum := tree.NewDInt(tree.DInt(bytes))
+	for _, target := range res.AddedReplicas {
+		addRow("add replica", target.StoreID, bytesDatum, tree.DNull)
+	}
+	for _, target := range res.RemovedReplicas {
+		addRow("remove replica", target.StoreID, bytesDatum, tree.DNull)
+	}
+	if res.LeaseTarget != 0 {
+		addRow("transfer lease", res.LeaseTarget, tree.DNull, tree.DNull)
+	}
+	if res.Unsatisfiable != nil {
+		addRow("unsatisfiable", 0, tree.DNull, tree.NewDString(res.Unsatisfiable.Error()))
+	}
+}
+
+// Next is part of the eval.ValueGenerator interface.
+func (p *planZoneConfigChangeGenerator) Next(_ context.Context) (bool, error) {
+	if len(p.rows) == 0 {
+		return false, nil
+	}
+	p.cur, p.rows = p.rows[0], p.rows[1:]
+	return true, nil
+}
+
+// Values is part of the eval.ValueGenerator interface.
+func (p *planZoneConfigChangeGenerator) Values() (tree.Datums, error) {
+	return p.cur, nil
+}
+
+// Close is part of the eval.ValueGenerator interface.
+func (p *planZoneConfigChangeGenerator) Close(_ context.Context) {}
@@ -107,6 +107,8 @@ const (
 	TxnExecInsightsTableName               SystemTableName = "transaction_execution_insights"
 	TableMetadata                          SystemTableName = "table_metadata"
 	PreparedTransactionsTableName          SystemTableName = "prepared_transactions"
+	BackupCatalogTableName                 SystemTableName = "backup_catalog"
+	TransactionDeadlocksTableName          SystemTableName = "transaction_deadlocks"
 )
 
 // Oid for virtual database and table.
@@ -223,6 +225,10 @@ const (
 	CrdbInternalFullyQualifiedNamesViewID
 	CrdbInternalStoreLivenessSupportFrom
 	CrdbInternalStoreLivenessSupportFor
+	CrdbInternalBackupCatalogTableID
+	CrdbInternalBackupCatalogTablesTableID
+	CrdbInternalNodeAppendOnlyHotspotsTableID
+	CrdbInternalTransactionDeadlocksTableID
 	// CrdbInternalTestID is reserved for tests that need to inject virtual tables
 	// into crdb_internal.
 	CrdbInternalTestID
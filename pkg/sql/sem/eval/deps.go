@@ -397,6 +397,12 @@ type Planner interface {
 	// GetRangeDescByID gets the RangeDescriptor by the specified RangeID.
 	GetRangeDescByID(context.Context, roachpb.RangeID) (roachpb.RangeDescriptor, error)
 
+	// SpanConfigsForZoneConfigChange returns the span configs that executing
+	// the given ALTER ... CONFIGURE ZONE statement would install, for the spans
+	// whose span configs it would change. The statement is executed in a
+	// separate transaction which is rolled back.
+	SpanConfigsForZoneConfigChange(ctx context.Context, stmt string) ([]roachpb.SpanConfigEntry, error)
+
 	SpanStats(context.Context, roachpb.Spans) (*roachpb.SpanStatsResponse, error)
 
 	GetDetailsForSpanStats(ctx context.Context, dbId int, tableId int) (InternalRows, error)
@@ -45,11 +45,15 @@ func (*AlterChangefeedAddTarget) alterChangefeedCmd()    {}
 func (*AlterChangefeedDropTarget) alterChangefeedCmd()   {}
 func (*AlterChangefeedSetOptions) alterChangefeedCmd()   {}
 func (*AlterChangefeedUnsetOptions) alterChangefeedCmd() {}
+func (*AlterChangefeedPauseTarget) alterChangefeedCmd()  {}
+func (*AlterChangefeedResumeTarget) alterChangefeedCmd() {}
 
 var _ AlterChangefeedCmd = &AlterChangefeedAddTarget{}
 var _ AlterChangefeedCmd = &AlterChangefeedDropTarget{}
 var _ AlterChangefeedCmd = &AlterChangefeedSetOptions{}
 var _ AlterChangefeedCmd = &AlterChangefeedUnsetOptions{}
+var _ AlterChangefeedCmd = &AlterChangefeedPauseTarget{}
+var _ AlterChangefeedCmd = &AlterChangefeedResumeTarget{}
 
 // AlterChangefeedAddTarget represents an ADD <targets> command
 type AlterChangefeedAddTarget struct {
@@ -107,3 +111,25 @@ func (node *AlterChangefeedUnsetOptions) Format(ctx *FmtCtx) {
 	ctx.WriteString(" UNSET ")
 	ctx.FormatNode(&node.Options)
 }
+
+// AlterChangefeedPauseTarget represents a PAUSE <targets> command
+type AlterChangefeedPauseTarget struct {
+	Targets ChangefeedTargets
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterChangefeedPauseTarget) Format(ctx *FmtCtx) {
+	ctx.WriteString(" PAUSE ")
+	ctx.FormatNode(&node.Targets)
+}
+
+// AlterChangefeedResumeTarget represents a RESUME <targets> command
+type AlterChangefeedResumeTarget struct {
+	Targets ChangefeedTargets
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterChangefeedResumeTarget) Format(ctx *FmtCtx) {
+	ctx.WriteString(" RESUME ")
+	ctx.FormatNode(&node.Targets)
+}
@@ -31,6 +31,11 @@ const (
 	// SystemUsers coverage indicates that only the system.users
 	// table will be restored from the backup.
 	SystemUsers
+
+	// SystemConfig coverage indicates that only the system tables holding the
+	// cluster configuration, e.g. cluster settings and roles, will be restored
+	// from the backup.
+	SystemConfig
 )
 
 // BackupOptions describes options for the BACKUP execution.
@@ -43,6 +48,7 @@ type BackupOptions struct {
 	IncrementalStorage              StringOrPlaceholderOptList
 	ExecutionLocality               Expr
 	UpdatesClusterMonitoringMetrics Expr
+	CopyTo                          Expr
 }
 
 var _ NodeFormatter = &BackupOptions{}
@@ -106,6 +112,30 @@ func (node Backup) Coverage() DescriptorCoverage {
 	return RequestedDescriptors
 }
 
+// BackupCopy represents a BACKUP COPY statement, which copies a backup chain
+// from one collection to another.
+type BackupCopy struct {
+	// Subdir is the subdirectory of the chain in the source collection, or
+	// LATEST.
+	Subdir Expr
+	// From is the URI of the source collection.
+	From Expr
+	// To is the URI of the destination collection.
+	To Expr
+}
+
+var _ Statement = &BackupCopy{}
+
+// Format implements the NodeFormatter interface.
+func (node *BackupCopy) Format(ctx *FmtCtx) {
+	ctx.WriteString("BACKUP COPY FROM ")
+	ctx.FormatNode(node.Subdir)
+	ctx.WriteString(" IN ")
+	ctx.FormatURI(node.From)
+	ctx.WriteString(" TO ")
+	ctx.FormatURI(node.To)
+}
+
 // RestoreOptions describes options for the RESTORE execution.
 type RestoreOptions struct {
 	EncryptionPassphrase             Expr
@@ -128,6 +158,9 @@ type RestoreOptions struct {
 	ExecutionLocality                Expr
 	ExperimentalOnline               bool
 	RemoveRegions                    bool
+	Where                            Expr
+	IntoTable                        Expr
+	OnConflict                       Expr
 }
 
 var _ NodeFormatter = &RestoreOptions{}
@@ -155,7 +188,7 @@ var _ Statement = &Restore{}
 // Format implements the NodeFormatter interface.
 func (node *Restore) Format(ctx *FmtCtx) {
 	ctx.WriteString("RESTORE ")
-	if node.DescriptorCoverage == RequestedDescriptors {
+	if node.DescriptorCoverage == RequestedDescriptors || node.DescriptorCoverage == SystemConfig {
 		ctx.FormatNode(&node.Targets)
 		ctx.WriteString(" ")
 	}
@@ -297,6 +330,12 @@ func (o *BackupOptions) Format(ctx *FmtCtx) {
 		ctx.WriteString("updates_cluster_monitoring_metrics = ")
 		ctx.FormatNode(o.UpdatesClusterMonitoringMetrics)
 	}
+
+	if o.CopyTo != nil {
+		maybeAddSep()
+		ctx.WriteString("copy_to = ")
+		ctx.FormatURI(o.CopyTo)
+	}
 }
 
 // CombineWith merges other backup options into this backup options struct.
@@ -357,6 +396,12 @@ func (o *BackupOptions) CombineWith(other *BackupOptions) error {
 	} else {
 		o.UpdatesClusterMonitoringMetrics = other.UpdatesClusterMonitoringMetrics
 	}
+
+	if o.CopyTo == nil {
+		o.CopyTo = other.CopyTo
+	} else if other.CopyTo != nil {
+		return errors.New("copy_to option specified multiple times")
+	}
 	return nil
 }
 
@@ -370,7 +415,8 @@ func (o BackupOptions) IsDefault() bool {
 		cmp.Equal(o.IncrementalStorage, options.IncrementalStorage) &&
 		o.ExecutionLocality == options.ExecutionLocality &&
 		o.IncludeAllSecondaryTenants == options.IncludeAllSecondaryTenants &&
-		o.UpdatesClusterMonitoringMetrics == options.UpdatesClusterMonitoringMetrics
+		o.UpdatesClusterMonitoringMetrics == options.UpdatesClusterMonitoringMetrics &&
+		o.CopyTo == options.CopyTo
 }
 
 // Format implements the NodeFormatter interface.
@@ -492,6 +538,24 @@ func (o *RestoreOptions) Format(ctx *FmtCtx) {
 		maybeAddSep()
 		ctx.WriteString("remove_regions")
 	}
+
+	if o.Where != nil {
+		maybeAddSep()
+		ctx.WriteString("where = ")
+		ctx.FormatNode(o.Where)
+	}
+
+	if o.IntoTable != nil {
+		maybeAddSep()
+		ctx.WriteString("into_table = ")
+		ctx.FormatNode(o.IntoTable)
+	}
+
+	if o.OnConflict != nil {
+		maybeAddSep()
+		ctx.WriteString("on_conflict = ")
+		ctx.FormatNode(o.OnConflict)
+	}
 }
 
 // CombineWith merges other backup options into this backup options struct.
@@ -641,6 +705,24 @@ func (o *RestoreOptions) CombineWith(other *RestoreOptions) error {
 		o.RemoveRegions = other.RemoveRegions
 	}
 
+	if o.Where == nil {
+		o.Where = other.Where
+	} else if other.Where != nil {
+		return errors.New("where option specified multiple times")
+	}
+
+	if o.IntoTable == nil {
+		o.IntoTable = other.IntoTable
+	} else if other.IntoTable != nil {
+		return errors.New("into_table option specified multiple times")
+	}
+
+	if o.OnConflict == nil {
+		o.OnConflict = other.OnConflict
+	} else if other.OnConflict != nil {
+		return errors.New("on_conflict option specified multiple times")
+	}
+
 	return nil
 }
 
@@ -666,7 +748,10 @@ func (o RestoreOptions) IsDefault() bool {
 		o.UnsafeRestoreIncompatibleVersion == options.UnsafeRestoreIncompatibleVersion &&
 		o.ExecutionLocality == options.ExecutionLocality &&
 		o.ExperimentalOnline == options.ExperimentalOnline &&
-		o.RemoveRegions == options.RemoveRegions
+		o.RemoveRegions == options.RemoveRegions &&
+		o.Where == options.Where &&
+		o.IntoTable == options.IntoTable &&
+		o.OnConflict == options.OnConflict
 }
 
 // BackupTargetList represents a list of targets.
@@ -676,6 +761,12 @@ type BackupTargetList struct {
 	Schemas   ObjectNamePrefixList
 	Tables    TableAttrs
 	TenantID  TenantID
+
+	// SystemConfig is set for the SYSTEM CONFIG target, which covers the system
+	// tables holding the cluster configuration. ConfigCategories, if set,
+	// restricts it to the named categories of configuration.
+	SystemConfig     bool
+	ConfigCategories NameList
 }
 
 // Format implements the NodeFormatter interface.
@@ -689,6 +780,13 @@ func (tl *BackupTargetList) Format(ctx *FmtCtx) {
 	} else if tl.TenantID.Specified {
 		ctx.WriteString("VIRTUAL CLUSTER ")
 		ctx.FormatNode(&tl.TenantID)
+	} else if tl.SystemConfig {
+		ctx.WriteString("SYSTEM CONFIG")
+		if tl.ConfigCategories != nil {
+			ctx.WriteString(" (")
+			ctx.FormatNode(&tl.ConfigCategories)
+			ctx.WriteString(")")
+		}
 	} else {
 		if tl.Tables.SequenceOnly {
 			ctx.WriteString("SEQUENCE ")
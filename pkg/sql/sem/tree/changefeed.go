@@ -69,12 +69,25 @@ func (node *CreateChangefeed) formatWithPredicates(ctx *FmtCtx) {
 type ChangefeedTarget struct {
 	TableName  TablePattern
 	FamilyName Name
+	// IndexName, if set, is the secondary index of the table to watch instead
+	// of the primary index.
+	IndexName UnrestrictedName
+	// MaterializedView is true if the target is a materialized view.
+	MaterializedView bool
 }
 
 // Format implements the NodeFormatter interface.
 func (ct *ChangefeedTarget) Format(ctx *FmtCtx) {
-	ctx.WriteString("TABLE ")
+	if ct.MaterializedView {
+		ctx.WriteString("MATERIALIZED VIEW ")
+	} else {
+		ctx.WriteString("TABLE ")
+	}
 	ctx.FormatNode(ct.TableName)
+	if ct.IndexName != "" {
+		ctx.WriteByte('@')
+		ctx.FormatNode(&ct.IndexName)
+	}
 	if ct.FamilyName != "" {
 		ctx.WriteString(" FAMILY ")
 		ctx.FormatNode(&ct.FamilyName)
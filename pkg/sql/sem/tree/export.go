@@ -29,3 +29,62 @@ func (node *Export) Format(ctx *FmtCtx) {
 	ctx.WriteString(" FROM ")
 	ctx.FormatNode(node.Query)
 }
+
+// ExportBackup represents an EXPORT BACKUP statement, which exports the rows
+// of the tables in a backup to files in external storage.
+type ExportBackup struct {
+	// Targets are the tables to export. If nil, every table in the backup is
+	// exported.
+	Targets *BackupTargetList
+	// Subdir is the subdirectory of the backup in the collection, or LATEST.
+	Subdir Expr
+	// From is the URI of the collection.
+	From       Expr
+	FileFormat string
+	File       Expr
+	AsOf       AsOfClause
+	Options    KVOptions
+}
+
+var _ Statement = &ExportBackup{}
+
+// Format implements the NodeFormatter interface.
+func (node *ExportBackup) Format(ctx *FmtCtx) {
+	ctx.WriteString("EXPORT BACKUP ")
+	if node.Targets != nil {
+		ctx.FormatNode(node.Targets)
+		ctx.WriteString(" ")
+	}
+	ctx.WriteString("FROM ")
+	ctx.FormatNode(node.Subdir)
+	ctx.WriteString(" IN ")
+	ctx.FormatURI(node.From)
+	ctx.WriteString(" INTO ")
+	ctx.WriteString(node.FileFormat)
+	ctx.WriteString(" ")
+	ctx.FormatURI(node.File)
+	if node.AsOf.Expr != nil {
+		ctx.WriteString(" ")
+		ctx.FormatNode(&node.AsOf)
+	}
+	if node.Options != nil {
+		ctx.WriteString(" WITH OPTIONS (")
+		node.Options.formatEach(ctx, func(n *KVOption, ctx *FmtCtx) {
+			// The decryption options are secrets. (Use literals here to avoid
+			// pulling in the backup package as a dependency.)
+			switch string(n.Key) {
+			case "encryption_passphrase":
+				if ctx.flags.HasFlags(FmtShowPasswords) {
+					ctx.FormatNode(n.Value)
+				} else {
+					ctx.WriteString(PasswordSubstitution)
+				}
+			case "kms":
+				ctx.FormatURI(n.Value)
+			default:
+				ctx.FormatNode(n.Value)
+			}
+		})
+		ctx.WriteString(")")
+	}
+}
@@ -2184,7 +2184,7 @@ func (node *Restore) doc(p *PrettyCfg) pretty.Doc {
 	items := make([]pretty.TableRow, 0, 6)
 
 	items = append(items, p.row("RESTORE", pretty.Nil))
-	if node.DescriptorCoverage == RequestedDescriptors {
+	if node.DescriptorCoverage == RequestedDescriptors || node.DescriptorCoverage == SystemConfig {
 		items = append(items, node.Targets.docRow(p))
 	}
 	from := p.Doc(&node.From)
@@ -2211,6 +2211,12 @@ func (node *BackupTargetList) docRow(p *PrettyCfg) pretty.TableRow {
 	if node.TenantID.Specified {
 		return p.row("TENANT", p.Doc(&node.TenantID))
 	}
+	if node.SystemConfig {
+		if node.ConfigCategories == nil {
+			return p.row("SYSTEM CONFIG", pretty.Nil)
+		}
+		return p.row("SYSTEM CONFIG", p.bracket("(", p.Doc(&node.ConfigCategories), ")"))
+	}
 	if node.Tables.SequenceOnly {
 		return p.row("SEQUENCE", p.Doc(&node.Tables.TablePatterns))
 	}
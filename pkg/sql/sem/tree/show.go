@@ -86,6 +86,8 @@ const (
 	// BackupValidateDetails identifies a SHOW BACKUP VALIDATION
 	// statement.
 	BackupValidateDetails
+	// BackupRevisionDetails identifies a SHOW BACKUP REVISIONS statement.
+	BackupRevisionDetails
 )
 
 // TODO (msbutler): 22.2 after removing old style show backup syntax, rename
@@ -118,6 +120,8 @@ func (node *ShowBackup) Format(ctx *FmtCtx) {
 		ctx.WriteString("FILES ")
 	case BackupSchemaDetails:
 		ctx.WriteString("SCHEMAS ")
+	case BackupRevisionDetails:
+		ctx.WriteString("REVISIONS ")
 	}
 
 	if node.From {
@@ -138,6 +142,7 @@ func (node *ShowBackup) Format(ctx *FmtCtx) {
 type ShowBackupOptions struct {
 	AsJson               bool
 	CheckFiles           bool
+	CheckFingerprints    bool
 	DebugIDs             bool
 	IncrementalStorage   StringOrPlaceholderOptList
 	DecryptionKMSURI     StringOrPlaceholderOptList
@@ -178,6 +183,10 @@ func (o *ShowBackupOptions) Format(ctx *FmtCtx) {
 		maybeAddSep()
 		ctx.WriteString("check_files")
 	}
+	if o.CheckFingerprints {
+		maybeAddSep()
+		ctx.WriteString("check_fingerprints")
+	}
 	if o.DebugIDs {
 		maybeAddSep()
 		ctx.WriteString("debug_ids")
@@ -239,6 +248,7 @@ func (o ShowBackupOptions) IsDefault() bool {
 	options := ShowBackupOptions{}
 	return o.AsJson == options.AsJson &&
 		o.CheckFiles == options.CheckFiles &&
+		o.CheckFingerprints == options.CheckFingerprints &&
 		o.DebugIDs == options.DebugIDs &&
 		cmp.Equal(o.IncrementalStorage, options.IncrementalStorage) &&
 		cmp.Equal(o.DecryptionKMSURI, options.DecryptionKMSURI) &&
@@ -290,6 +300,11 @@ func (o *ShowBackupOptions) CombineWith(other *ShowBackupOptions) error {
 	if err != nil {
 		return err
 	}
+	o.CheckFingerprints, err = combineBools(o.CheckFingerprints, other.CheckFingerprints,
+		"check_fingerprints")
+	if err != nil {
+		return err
+	}
 	o.DebugIDs, err = combineBools(o.DebugIDs, other.DebugIDs, "debug_ids")
 	if err != nil {
 		return err
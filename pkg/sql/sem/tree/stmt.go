@@ -243,6 +243,8 @@ type CCLOnlyStatement interface {
 var _ CCLOnlyStatement = &AlterBackup{}
 var _ CCLOnlyStatement = &AlterBackupSchedule{}
 var _ CCLOnlyStatement = &Backup{}
+var _ CCLOnlyStatement = &BackupCopy{}
+var _ CCLOnlyStatement = &ExportBackup{}
 var _ CCLOnlyStatement = &ShowBackup{}
 var _ CCLOnlyStatement = &Restore{}
 var _ CCLOnlyStatement = &CreateChangefeed{}
@@ -649,6 +651,17 @@ func (*Backup) cclOnlyStatement() {}
 
 func (*Backup) hiddenFromShowQueries() {}
 
+// StatementReturnType implements the Statement interface.
+func (*BackupCopy) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*BackupCopy) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*BackupCopy) StatementTag() string { return "BACKUP COPY" }
+
+func (*BackupCopy) cclOnlyStatement() {}
+
 // StatementReturnType implements the Statement interface.
 func (*ScheduledBackup) StatementReturnType() StatementReturnType { return Rows }
 
@@ -1288,6 +1301,17 @@ func (*Export) cclOnlyStatement() {}
 // StatementTag returns a short string identifying the type of statement.
 func (*Export) StatementTag() string { return "EXPORT" }
 
+// StatementReturnType implements the Statement interface.
+func (*ExportBackup) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ExportBackup) StatementType() StatementType { return TypeDML }
+
+func (*ExportBackup) cclOnlyStatement() {}
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ExportBackup) StatementTag() string { return "EXPORT BACKUP" }
+
 // StatementReturnType implements the Statement interface.
 func (*Grant) StatementReturnType() StatementReturnType { return DDL }
 
@@ -2492,6 +2516,7 @@ func (n *AlterRoleSet) String() string                        { return AsString(
 func (n *AlterSequence) String() string                       { return AsString(n) }
 func (n *Analyze) String() string                             { return AsString(n) }
 func (n *Backup) String() string                              { return AsString(n) }
+func (n *BackupCopy) String() string                          { return AsString(n) }
 func (n *BeginTransaction) String() string                    { return AsString(n) }
 func (n *Call) String() string                                { return AsString(n) }
 func (n *ControlJobs) String() string                         { return AsString(n) }
@@ -2550,6 +2575,7 @@ func (n *Execute) String() string                             { return AsString(
 func (n *Explain) String() string                             { return AsString(n) }
 func (n *ExplainAnalyze) String() string                      { return AsString(n) }
 func (n *Export) String() string                              { return AsString(n) }
+func (n *ExportBackup) String() string                        { return AsString(n) }
 func (n *CreateExternalConnection) String() string            { return AsString(n) }
 func (n *CheckExternalConnection) String() string             { return AsString(n) }
 func (n *DropExternalConnection) String() string              { return AsString(n) }
@@ -205,6 +205,10 @@ func zoneConfigToSQL(zs *tree.ZoneSpecifier, zone *zonepb.ZoneConfig) (tree.Datu
 		maybeWriteComma(f)
 		f.Printf("\tgc.ttlseconds = %d", zone.GC.TTLSeconds)
 	}
+	if zone.GC != nil && zone.GC.KeepVersions != nil && *zone.GC.KeepVersions > 0 {
+		maybeWriteComma(f)
+		f.Printf("\tgc.keep_versions = %d", *zone.GC.KeepVersions)
+	}
 	if zone.GlobalReads != nil {
 		maybeWriteComma(f)
 		f.Printf("\tglobal_reads = %t", *zone.GlobalReads)
@@ -51,6 +51,15 @@ func NewSchemaChangeOnLockedTableErr(tableName string) error {
 			"\"ALTER TABLE %v SET (schema_locked = true);\"", tableName, tableName)
 }
 
+// NewSchemaChangeOnKVReadOnlyTableErr creates an error signaling that a schema
+// change statement would need to write to or clear the data of a table made
+// read-only through the read_only storage parameter.
+func NewSchemaChangeOnKVReadOnlyTableErr(op, tableName string) error {
+	return errors.WithHintf(pgerror.Newf(pgcode.ReadOnlySQLTransaction,
+		`%s is disallowed on table %q because it is read-only`, op, tableName),
+		"To make the table writable, try \"ALTER TABLE %v RESET (read_only);\"", tableName)
+}
+
 // NewDisallowedSchemaChangeOnLDRTableErr creates an error that indicates that
 // the schema change is disallowed because the table is being used by a
 // logical data replication job.
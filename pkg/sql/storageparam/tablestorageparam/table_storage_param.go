@@ -12,6 +12,7 @@ import (
 	"math"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
@@ -485,6 +486,29 @@ var tableParams = map[string]tableParam{
 			return nil
 		},
 	},
+	`read_only`: {
+		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext,
+			evalCtx *eval.Context, key string, datum tree.Datum) error {
+			if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V25_2_ReadOnlyTables) {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"read_only is not supported until version 25.2")
+			}
+			if po.TableDesc.Temporary {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"cannot make a temporary table read-only")
+			}
+			readOnly, err := boolFromDatum(ctx, evalCtx, key, datum)
+			if err != nil {
+				return err
+			}
+			po.TableDesc.KVReadOnly = readOnly
+			return nil
+		},
+		onReset: func(_ context.Context, po *Setter, evalCtx *eval.Context, key string) error {
+			po.TableDesc.KVReadOnly = false
+			return nil
+		},
+	},
 	catpb.AutoStatsEnabledTableSettingName: {
 		onSet:   autoStatsEnabledSettingFunc,
 		onReset: autoStatsTableSettingResetFunc,
@@ -26,15 +26,19 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltestutils"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq"
 )
 
 func TestMakeTableDescColumns(t *testing.T) {
@@ -699,3 +703,61 @@ ALTER TABLE t1 ADD COLUMN z INT;
 			"but a job was found")
 	}
 }
+
+// TestReadOnlyTableRejectsWrites verifies that writes to a table made read-only
+// through the read_only storage parameter are rejected by its replicas, and
+// that the rejection reaches the client as a ReadOnlySQLTransaction error.
+func TestReadOnlyTableRejectsWrites(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v INT)`)
+	sqlDB.Exec(t, `INSERT INTO t VALUES (1, 1)`)
+	sqlDB.Exec(t, `CREATE INDEX idx ON t (v)`)
+	sqlDB.Exec(t, `ALTER TABLE t SET (read_only = true)`)
+
+	// The span config of the table reaches its replicas asynchronously.
+	testutils.SucceedsSoon(t, func() error {
+		_, err := db.Exec(`UPSERT INTO t VALUES (2, 2)`)
+		if err == nil {
+			return errors.New("write to read-only table succeeded")
+		}
+		return nil
+	})
+	_, err := db.Exec(`UPSERT INTO t VALUES (2, 2)`)
+	if !testutils.IsError(err, `table "t" is read-only`) {
+		t.Fatalf("expected read-only error, got %v", err)
+	}
+	if pqErr := (*pq.Error)(nil); !errors.As(err, &pqErr) ||
+		pgcode.MakeCode(string(pqErr.Code)) != pgcode.ReadOnlySQLTransaction {
+		t.Fatalf("expected code %q, got %v", pgcode.ReadOnlySQLTransaction, err)
+	}
+
+	// Reads are still served.
+	sqlDB.CheckQueryResults(t, `SELECT v FROM t WHERE k = 1`, [][]string{{"1"}})
+
+	// Schema changes which would need to write to or clear the table's data are
+	// rejected up front.
+	sqlDB.ExpectErr(t, `TRUNCATE is disallowed on table "t" because it is read-only`,
+		`TRUNCATE t`)
+	sqlDB.ExpectErr(t, `DROP INDEX is disallowed on table "t" because it is read-only`,
+		`DROP INDEX t@idx`)
+	sqlDB.ExpectErr(t, `ADD COLUMN requiring a backfill is disallowed on table "t" because it is read-only`,
+		`ALTER TABLE t ADD COLUMN w INT DEFAULT 1`)
+	sqlDB.ExpectErr(t, `CREATE INDEX is disallowed on table "t" because it is read-only`,
+		`CREATE INDEX ON t (k, v)`)
+	// Adding a nullable column without a default needs no backfill.
+	sqlDB.Exec(t, `ALTER TABLE t ADD COLUMN w INT`)
+
+	// Writes are accepted again once the table is made writable.
+	sqlDB.Exec(t, `ALTER TABLE t RESET (read_only)`)
+	testutils.SucceedsSoon(t, func() error {
+		_, err := db.Exec(`UPSERT INTO t VALUES (2, 2)`)
+		return err
+	})
+}
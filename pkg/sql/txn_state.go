@@ -192,6 +192,7 @@ func (ts *txnState) resetForNewSQLTxn(
 	qualityOfService sessiondatapb.QoSLevel,
 	isoLevel isolation.Level,
 	omitInRangefeeds bool,
+	txnClass roachpb.TransactionClass,
 	bufferedWritesEnabled bool,
 ) (txnID uuid.UUID) {
 	// Reset state vars to defaults.
@@ -237,6 +238,7 @@ func (ts *txnState) resetForNewSQLTxn(
 			if omitInRangefeeds {
 				ts.mu.txn.SetOmitInRangefeeds()
 			}
+			ts.mu.txn.SetTransactionClass(txnClass)
 			if err := ts.setPriorityLocked(priority); err != nil {
 				panic(err)
 			}
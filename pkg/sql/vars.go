@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/build"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -3857,6 +3858,25 @@ var varGen = map[string]sessionVar{
 		},
 		GlobalDefault: globalFalse,
 	},
+
+	// CockroachDB extension.
+	`transaction_class`: {
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			class, ok := roachpb.TransactionClass_value[strings.ToUpper(s)]
+			if !ok {
+				return newVarValueError(`transaction_class`, s, "interactive", "batch")
+			}
+			m.SetTransactionClass(roachpb.TransactionClass(class))
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			class := roachpb.TransactionClass(evalCtx.SessionData().TransactionClass)
+			return strings.ToLower(class.String()), nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return strings.ToLower(roachpb.TransactionClass_INTERACTIVE.String())
+		},
+	},
 }
 
 func ReplicationModeFromString(s string) (sessiondatapb.ReplicationMode, error) {
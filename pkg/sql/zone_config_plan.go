@@ -0,0 +1,115 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/spanconfig"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/errors"
+)
+
+// SpanConfigSQLTranslatorFactory constructs transaction-scoped
+// spanconfig.SQLTranslators. It is implemented by the spanconfigsqltranslator
+// package, which depends on this one.
+type SpanConfigSQLTranslatorFactory interface {
+	NewSQLTranslator(txn descs.Txn) spanconfig.SQLTranslator
+}
+
+// errZoneConfigChangePlanned is returned to roll back the transaction in
+// which a zone config change is executed to be planned.
+var errZoneConfigChangePlanned = errors.New("zone config change planned")
+
+// SpanConfigsForZoneConfigChange is part of the eval.Planner interface.
+func (p *planner) SpanConfigsForZoneConfigChange(
+	ctx context.Context, sql string,
+) ([]roachpb.SpanConfigEntry, error) {
+	stmt, err := parser.ParseOne(sql)
+	if err != nil {
+		return nil, err
+	}
+	n, ok := stmt.AST.(*tree.SetZoneConfig)
+	if !ok {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"expected an ALTER ... CONFIGURE ZONE statement, got %s", stmt.AST.StatementTag())
+	}
+	translatorFactory := p.ExecCfg().SpanConfigSQLTranslatorFactory
+	if translatorFactory == nil {
+		return nil, errors.AssertionFailedf("span config translation is unavailable")
+	}
+
+	// Qualify the target of the statement and resolve it to the ID from which
+	// the zone config it changes is inherited.
+	if _, err := p.resolveTableForZone(ctx, &n.ZoneSpecifier); err != nil {
+		return nil, err
+	}
+	targetID, err := resolveZone(
+		ctx, p.txn, p.Descriptors(), &n.ZoneSpecifier, p.ExecCfg().Settings.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	type spanKey struct{ key, endKey string }
+	var entries []roachpb.SpanConfigEntry
+	if err := p.ExecCfg().InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		entries = nil
+		translate := func() ([]spanconfig.Record, error) {
+			return translatorFactory.NewSQLTranslator(txn).Translate(
+				ctx, descpb.IDs{targetID}, false, /* generateSystemSpanConfigurations */
+			)
+		}
+		before, err := translate()
+		if err != nil {
+			return err
+		}
+		// Execute the statement as the user, so that it's subject to the same
+		// privilege checks and validation as when executed directly.
+		if _, err := txn.ExecParsed(ctx, "plan-zone-config-change", txn.KV(),
+			sessiondata.InternalExecutorOverride{User: p.User(), Database: p.CurrentDatabase()},
+			stmt,
+		); err != nil {
+			return err
+		}
+		after, err := translate()
+		if err != nil {
+			return err
+		}
+
+		current := make(map[spanKey]roachpb.SpanConfig, len(before))
+		for i := range before {
+			if target := before[i].GetTarget(); target.IsSpanTarget() {
+				span := target.GetSpan()
+				current[spanKey{string(span.Key), string(span.EndKey)}] = before[i].GetConfig()
+			}
+		}
+		for i := range after {
+			target := after[i].GetTarget()
+			if !target.IsSpanTarget() {
+				continue
+			}
+			span := target.GetSpan()
+			conf := after[i].GetConfig()
+			if prev, ok := current[spanKey{string(span.Key), string(span.EndKey)}]; ok && prev.Equal(&conf) {
+				continue
+			}
+			entries = append(entries, roachpb.SpanConfigEntry{Target: target.ToProto(), Config: conf})
+		}
+		// Never commit the zone config change.
+		return errZoneConfigChangePlanned
+	}); !errors.Is(err, errZoneConfigChangePlanned) {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPlanZoneConfigChange tests that crdb_internal.plan_zone_config_change
+// returns the replica movements a zone config change would cause, subject to
+// the privilege checks of the change, without applying it.
+func TestPlanZoneConfigChange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	// Each node gets its own locality, so that constraints can target the
+	// store of any single replica.
+	const numNodes = 5
+	serverArgs := make(map[int]base.TestServerArgs, numNodes)
+	for i := 0; i < numNodes; i++ {
+		serverArgs[i] = base.TestServerArgs{
+			Locality: roachpb.Locality{Tiers: []roachpb.Tier{{Key: "node", Value: strconv.Itoa(i + 1)}}},
+		}
+	}
+	tc := serverutils.StartCluster(t, numNodes, base.TestClusterArgs{
+		ServerArgsPerNode: serverArgs,
+	})
+	defer tc.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `ALTER TABLE t CONFIGURE ZONE USING num_replicas = 3`)
+	require.NoError(t, tc.WaitForFullReplication())
+
+	var rangeID int
+	sqlDB.QueryRow(t, `SELECT range_id FROM [SHOW RANGES FROM TABLE t]`).Scan(&rangeID)
+
+	const plan = `SELECT range_id, change, store_id
+FROM crdb_internal.plan_zone_config_change($1)
+ORDER BY change, store_id`
+	const alter = `ALTER TABLE t CONFIGURE ZONE USING num_replicas = 5`
+
+	t.Run("rows", func(t *testing.T) {
+		// The range gets a replica on each of the two stores without one.
+		var replicas []string
+		for _, row := range sqlDB.QueryStr(t,
+			`SELECT unnest(replicas) FROM [SHOW RANGES FROM TABLE t]`) {
+			replicas = append(replicas, row[0])
+		}
+		require.Len(t, replicas, 3)
+		rows := sqlDB.QueryStr(t, plan, alter)
+		require.Len(t, rows, 2)
+		require.NotEqual(t, rows[0][2], rows[1][2])
+		for _, row := range rows {
+			require.Equal(t, []string{strconv.Itoa(rangeID), "add replica"}, row[:2])
+			require.NotContains(t, replicas, row[2])
+		}
+
+		// Changing the zone config to what it already is plans nothing.
+		require.Empty(t, sqlDB.QueryStr(t, plan, `ALTER TABLE t CONFIGURE ZONE USING num_replicas = 3`))
+	})
+
+	t.Run("constraints", func(t *testing.T) {
+		// Constraining the range away from the store of one of its replicas
+		// that isn't the leaseholder moves that replica to a store without one,
+		// and leaves the lease in place.
+		var leaseholder int
+		var replicas []int
+		sqlDB.QueryRow(t,
+			`SELECT lease_holder FROM [SHOW RANGES FROM TABLE t WITH DETAILS]`).Scan(&leaseholder)
+		for _, row := range sqlDB.QueryStr(t,
+			`SELECT unnest(replicas) FROM [SHOW RANGES FROM TABLE t]`) {
+			storeID, err := strconv.Atoi(row[0])
+			require.NoError(t, err)
+			replicas = append(replicas, storeID)
+		}
+		require.Len(t, replicas, 3)
+		var excluded int
+		for _, storeID := range replicas {
+			if storeID != leaseholder {
+				excluded = storeID
+				break
+			}
+		}
+
+		rows := sqlDB.QueryStr(t, plan, fmt.Sprintf(
+			`ALTER TABLE t CONFIGURE ZONE USING constraints = '[-node=%d]'`, excluded))
+		require.Len(t, rows, 2)
+		require.Equal(t, []string{strconv.Itoa(rangeID), "add replica"}, rows[0][:2])
+		added, err := strconv.Atoi(rows[0][2])
+		require.NoError(t, err)
+		require.NotContains(t, replicas, added)
+		require.Equal(t,
+			[]string{strconv.Itoa(rangeID), "remove replica", strconv.Itoa(excluded)}, rows[1])
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		sqlDB.QueryStr(t, plan, alter)
+		var rawConfigSQL string
+		sqlDB.QueryRow(t,
+			`SELECT raw_config_sql FROM [SHOW ZONE CONFIGURATION FOR TABLE t]`).Scan(&rawConfigSQL)
+		require.Contains(t, rawConfigSQL, "num_replicas = 3")
+	})
+
+	t.Run("not a zone config change", func(t *testing.T) {
+		sqlDB.ExpectErr(t, "expected an ALTER ... CONFIGURE ZONE statement",
+			plan, `DROP TABLE t`)
+		sqlDB.CheckQueryResults(t, `SELECT count(*) FROM [SHOW TABLES] WHERE table_name = 't'`,
+			[][]string{{"1"}})
+	})
+
+	t.Run("privileges", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE USER testuser`)
+		userDB := sqlutils.MakeSQLRunner(tc.Server(0).ApplicationLayer().SQLConn(
+			t, serverutils.User("testuser"), serverutils.DBName("defaultdb")))
+
+		userDB.ExpectErr(t, "VIEWCLUSTERMETADATA", plan, alter)
+
+		// The change is executed as the user, so the user also needs to be able
+		// to make it.
+		sqlDB.Exec(t, `GRANT SYSTEM VIEWCLUSTERMETADATA TO testuser`)
+		userDB.ExpectErr(t, "does not have CREATE or ZONECONFIG privilege on relation t",
+			plan, alter)
+
+		sqlDB.Exec(t, `GRANT ZONECONFIG ON TABLE t TO testuser`)
+		require.Len(t, userDB.QueryStr(t, plan, alter), 2)
+	})
+}
@@ -214,6 +214,37 @@ func (f *fingerprintWriter) stripIndexPrefix(key []byte) []byte {
 	return remainder
 }
 
+// PointKeyFingerprinter computes the fingerprint that MVCCExportFingerprint
+// would compute for a set of point keys, for point keys that are read from
+// somewhere other than a Reader, e.g. from the SSTs of a backup. Like
+// MVCCExportFingerprint, it combines the hash of each key/timestamp and value
+// via a XOR, so keys may be added in any order.
+type PointKeyFingerprinter struct {
+	w fingerprintWriter
+}
+
+// MakePointKeyFingerprinter returns a PointKeyFingerprinter that uses the
+// given fingerprinting options.
+func MakePointKeyFingerprinter(opts MVCCExportFingerprintOptions) PointKeyFingerprinter {
+	return PointKeyFingerprinter{w: fingerprintWriter{
+		hasher:  fnv.New64(),
+		xorAgg:  &uintXorAggregate{},
+		options: opts,
+	}}
+}
+
+// Add adds a point key and its value to the fingerprint. The value is the
+// encoded roachpb.Value of the key, i.e. without an MVCCValueHeader, as it would
+// be exported by MVCCExportFingerprint.
+func (f *PointKeyFingerprinter) Add(key MVCCKey, value []byte) error {
+	return f.w.PutRawMVCC(key, value)
+}
+
+// Fingerprint returns the fingerprint of the point keys added so far.
+func (f *PointKeyFingerprinter) Fingerprint() uint64 {
+	return f.w.xorAgg.result()
+}
+
 // FingerprintRangekeys iterates over the provided SSTs, that are expected to
 // contain only rangekeys, and maintains a XOR aggregate of each rangekey's
 // fingerprint.
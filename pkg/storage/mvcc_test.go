@@ -7351,6 +7351,66 @@ func TestMVCCExportFingerprint(t *testing.T) {
 	})
 }
 
+// TestPointKeyFingerprinter checks that a PointKeyFingerprinter computes the
+// same fingerprint as MVCCExportFingerprint for the exported point keys.
+func TestPointKeyFingerprinter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+
+	engine := createTestPebbleEngine()
+	defer engine.Close()
+
+	require.NoError(t, fillInData(ctx, engine, []testValue{
+		value(key(1), "value1", ts(1000)),
+		value(key(2), "value2", ts(1000)),
+		value(key(2), "value3", ts(2000)),
+		value(key(3), "value4", ts(2000)),
+	}))
+
+	testutils.RunTrueAndFalse(t, "allRevisions", func(t *testing.T, allRevisions bool) {
+		opts := MVCCExportOptions{
+			StartKey:           MVCCKey{Key: key(1)},
+			EndKey:             keys.MaxKey,
+			EndTS:              hlc.Timestamp{WallTime: 9999},
+			ExportAllRevisions: allRevisions,
+			FingerprintOptions: MVCCExportFingerprintOptions{
+				StripTenantPrefix:  true,
+				StripValueChecksum: true,
+			},
+		}
+		var dest bytes.Buffer
+		_, _, expected, _, err := MVCCExportFingerprint(ctx, st, engine, opts, &dest)
+		require.NoError(t, err)
+
+		dest.Reset()
+		_, _, err = MVCCExportToSST(ctx, st, engine, opts, &dest)
+		require.NoError(t, err)
+		iter, err := NewMemSSTIterator(dest.Bytes(), false, IterOptions{
+			KeyTypes:   IterKeyTypePointsOnly,
+			LowerBound: keys.LocalMax,
+			UpperBound: keys.MaxKey,
+		})
+		require.NoError(t, err)
+		defer iter.Close()
+
+		f := MakePointKeyFingerprinter(opts.FingerprintOptions)
+		for iter.SeekGE(MVCCKey{Key: keys.MinKey}); ; iter.Next() {
+			ok, err := iter.Valid()
+			require.NoError(t, err)
+			if !ok {
+				break
+			}
+			v, err := iter.UnsafeValue()
+			require.NoError(t, err)
+			require.NoError(t, f.Add(iter.UnsafeKey(), v))
+		}
+		require.NotZero(t, expected)
+		require.Equal(t, expected, f.Fingerprint())
+	})
+}
+
 type fingerprintOracle struct {
 	st     *cluster.Settings
 	engine Engine
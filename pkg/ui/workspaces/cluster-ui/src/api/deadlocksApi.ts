@@ -0,0 +1,108 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+import moment from "moment-timezone";
+
+import { TxnDeadlock } from "src/insights/types";
+
+import { FixFingerprintHexValue } from "../util";
+
+import {
+  executeInternalSql,
+  LARGE_RESULT_SIZE,
+  LONG_TIMEOUT,
+  sqlApiErrorMessage,
+  SqlExecutionRequest,
+  sqlResultsAreEmpty,
+} from "./sqlApi";
+
+type TxnDeadlockColumns = {
+  deadlock_id: string;
+  detected: string;
+  txn_id: string;
+  txn_fingerprint_id: string;
+  aborted: boolean;
+  waiting_on_txn_id: string;
+  lock_key: string;
+  wait_duration: string;
+};
+
+/**
+ * getTxnDeadlocksApi returns the deadlocks from
+ * crdb_internal.transaction_deadlocks in which the transaction execution
+ * took part, with all the transactions of their cycles.
+ */
+export async function getTxnDeadlocksApi(
+  txnExecutionID: string,
+): Promise<TxnDeadlock[]> {
+  const request: SqlExecutionRequest = {
+    statements: [
+      {
+        sql: `
+SELECT
+  deadlock_id,
+  detected,
+  txn_id,
+  encode(txn_fingerprint_id, 'hex') AS txn_fingerprint_id,
+  aborted,
+  waiting_on_txn_id,
+  lock_key,
+  wait_duration
+FROM
+  crdb_internal.transaction_deadlocks
+WHERE
+  deadlock_id IN (
+    SELECT deadlock_id
+    FROM crdb_internal.transaction_deadlocks
+    WHERE txn_id = $1::UUID
+  )
+ORDER BY
+  detected DESC, deadlock_id, cycle_position
+`,
+        arguments: [txnExecutionID],
+      },
+    ],
+    execute: true,
+    max_result_size: LARGE_RESULT_SIZE,
+    timeout: LONG_TIMEOUT,
+  };
+
+  const result = await executeInternalSql<TxnDeadlockColumns>(request);
+  if (result.error) {
+    throw new Error(
+      `Error while retrieving deadlock information: ${sqlApiErrorMessage(
+        result.error.message,
+      )}`,
+    );
+  }
+  if (sqlResultsAreEmpty(result)) {
+    return [];
+  }
+
+  // The rows of a deadlock are consecutive, in cycle order.
+  const deadlocks: TxnDeadlock[] = [];
+  result.execution.txn_results[0].rows.forEach(row => {
+    let deadlock = deadlocks[deadlocks.length - 1];
+    if (deadlock?.deadlockID !== row.deadlock_id) {
+      deadlock = {
+        deadlockID: row.deadlock_id,
+        detectedAt: moment.utc(row.detected),
+        members: [],
+      };
+      deadlocks.push(deadlock);
+    }
+    deadlock.members.push({
+      txnID: row.txn_id,
+      txnFingerprintID: FixFingerprintHexValue(row.txn_fingerprint_id),
+      aborted: row.aborted,
+      waitingOnTxnID: row.waiting_on_txn_id ?? "",
+      lockKey: row.lock_key ?? "",
+      waitTimeMs: row.wait_duration
+        ? moment.duration(row.wait_duration).asMilliseconds()
+        : 0,
+    });
+  });
+  return deadlocks;
+}
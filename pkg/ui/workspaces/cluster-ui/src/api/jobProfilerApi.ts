@@ -4,14 +4,16 @@
 // included in the /LICENSE file.
 
 import { cockroach } from "@cockroachlabs/crdb-protobuf-client";
+import { SWRConfiguration } from "swr";
 
-import { propsToQueryString } from "../util";
+import { propsToQueryString, useSwrWithClusterId } from "../util";
 
 import { fetchData } from "./fetchData";
 import {
   SqlExecutionRequest,
   executeInternalSql,
   LONG_TIMEOUT,
+  txnResultIsEmpty,
 } from "./sqlApi";
 
 const JOB_PROFILER_PATH = "_status/job_profiler_execution_details";
@@ -95,3 +97,67 @@ export function collectExecutionDetails(
     return res.execution.txn_results[0].rows[0];
   });
 }
+
+// JobProgressEstimate is the latest estimate of the remaining work of a
+// BACKUP or RESTORE job, as reported in the job's execution details.
+export type JobProgressEstimate = {
+  total_bytes: number;
+  completed_bytes: number;
+  remaining_bytes: number;
+  bytes_per_second: number;
+  // eta_nanos is zero if the job has not made enough progress to produce an
+  // estimate.
+  eta_nanos: number;
+  bottleneck: string;
+  per_node_throughput: {
+    sql_instance_id: number;
+    completed_bytes: number;
+    bytes_per_second: number;
+  }[];
+  updated_at: string;
+};
+
+type JobProgressEstimateRow = {
+  progress_estimate: JobProgressEstimate | null;
+};
+
+export function getJobProgressEstimate(
+  jobID: Long,
+): Promise<JobProgressEstimate | null> {
+  const req: SqlExecutionRequest = {
+    execute: true,
+    statements: [
+      {
+        sql: `SELECT crdb_internal.job_execution_details($1::INT)->'progress_estimate' AS progress_estimate`,
+        arguments: [jobID.toString()],
+      },
+    ],
+  };
+
+  return executeInternalSql<JobProgressEstimateRow>(req).then(res => {
+    if (res.error) {
+      throw res.error;
+    }
+
+    if (txnResultIsEmpty(res.execution?.txn_results[0])) {
+      return null;
+    }
+
+    return res.execution.txn_results[0].rows[0].progress_estimate;
+  });
+}
+
+export function useJobProgressEstimate(
+  jobID: Long,
+  opts: SWRConfiguration = {},
+) {
+  return useSwrWithClusterId<JobProgressEstimate | null>(
+    { name: "jobProgressEstimate", jobID },
+    () => getJobProgressEstimate(jobID),
+    {
+      revalidateOnFocus: false,
+      revalidateOnReconnect: false,
+      ...opts,
+    },
+  );
+}
@@ -13,6 +13,7 @@ import {
 import { maybeError } from "../util";
 
 import { getTxnInsightsContentionDetailsApi } from "./contentionApi";
+import { getTxnDeadlocksApi } from "./deadlocksApi";
 import {
   executeInternalSql,
   isMaxSizeError,
@@ -44,6 +45,7 @@ export type TxnInsightDetailsRequest = {
 export type TxnInsightDetailsReqErrs = {
   txnDetailsErr: Error | null;
   contentionErr: Error | null;
+  deadlocksErr: Error | null;
   statementsErr: Error | null;
 };
 
@@ -72,6 +74,7 @@ export async function getTxnInsightDetailsApi(
   const errors: TxnInsightDetailsReqErrs = {
     txnDetailsErr: null,
     contentionErr: null,
+    deadlocksErr: null,
     statementsErr: null,
   };
 
@@ -156,6 +159,22 @@ export async function getTxnInsightDetailsApi(
     errors.contentionErr = maybeError(e);
   }
 
+  // A transaction that took part in a deadlock either waited on the other
+  // transactions of the cycle, which surfaces as high contention, or was
+  // aborted to break the cycle, which surfaces as a retry error.
+  try {
+    if (
+      !req.excludeContention &&
+      (highContention || isRetrySerializableFailure)
+    ) {
+      txnInsightDetails.deadlocks = await getTxnDeadlocksApi(
+        req.txnExecutionID,
+      );
+    }
+  } catch (e) {
+    errors.deadlocksErr = maybeError(e);
+  }
+
   return {
     maxSizeReached: maxSizeReached,
     results: {
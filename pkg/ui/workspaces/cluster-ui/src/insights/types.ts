@@ -89,6 +89,29 @@ export type ContentionDetails = {
   contentionType: ContentionTypeKey;
 };
 
+// A deadlock detected by the transaction wait queues which involved a
+// transaction, as recorded in crdb_internal.transaction_deadlocks.
+// A transaction of a deadlock cycle.
+export type TxnDeadlockMember = {
+  txnID: string;
+  txnFingerprintID: string;
+  // Whether the transaction was aborted to break the cycle.
+  aborted: boolean;
+  // The transaction it waited on, if known.
+  waitingOnTxnID: string;
+  // The key of the lock it was blocked on, if known.
+  lockKey: string;
+  waitTimeMs: number;
+};
+
+export type TxnDeadlock = {
+  deadlockID: string;
+  detectedAt: Moment;
+  // The transactions of the cycle, in order: each one waited on the next one,
+  // and the last one on the first one.
+  members: TxnDeadlockMember[];
+};
+
 // The return type of getTxnInsightsContentionDetailsApi.
 export type TxnContentionInsightDetails = {
   transactionExecutionID: string;
@@ -101,10 +124,11 @@ export type TxnContentionInsightDetails = {
 
 export type TxnInsightDetails = {
   // Querying from virtual tables is expensive.
-  // This data is segmented into 3 parts so that we can
+  // This data is segmented into parts so that we can
   // selective fetch missing info on the details page.
   txnDetails?: TxnInsightEvent;
   blockingContentionDetails?: ContentionDetails[];
+  deadlocks?: TxnDeadlock[];
   statements?: StmtInsightEvent[];
 };
 
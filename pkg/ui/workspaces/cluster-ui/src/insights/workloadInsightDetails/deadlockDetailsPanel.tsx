@@ -0,0 +1,128 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+import { Heading } from "@cockroachlabs/ui-components";
+import { Col, Row } from "antd";
+import classNames from "classnames/bind";
+import "antd/lib/col/style";
+import React from "react";
+
+import styles from "../../statementDetails/statementDetails.module.scss";
+import { SummaryCard, SummaryCardItem } from "../../summaryCard";
+import { Timestamp } from "../../timestamp";
+import {
+  DATE_WITH_SECONDS_AND_MILLISECONDS_FORMAT_24_TZ,
+  Duration,
+} from "../../util/format";
+import { TxnDeadlock, TxnDeadlockMember } from "../types";
+import { TransactionDetailsLink } from "../workloadInsights/util";
+import "antd/lib/row/style";
+
+const cx = classNames.bind(styles);
+
+type Props = {
+  deadlocks: TxnDeadlock[];
+};
+
+const DeadlockDetailsPanelLabels = {
+  SECTION_HEADER: "Deadlocks",
+  DEADLOCK_HEADER: "Deadlock",
+  DETECTED_AT: "Detected At",
+  TRANSACTION_COUNT: "Transactions In Cycle",
+  TRANSACTION_HEADER: "Transaction",
+  ABORTED_TRANSACTION_HEADER: "Aborted Transaction",
+  TRANSACTION_EXEC_ID: "Transaction Execution",
+  TRANSACTION_FINGERPRINT: "Transaction Fingerprint",
+  WAITING_ON: "Waiting On Transaction Execution",
+  LOCK_KEY: "Lock Key",
+  WAIT_TIME: "Wait Time",
+  UNAVAILABLE: "Unavailable",
+};
+
+const fingerprintLink = (fingerprintID: string) =>
+  fingerprintID
+    ? TransactionDetailsLink(fingerprintID)
+    : DeadlockDetailsPanelLabels.UNAVAILABLE;
+
+const MemberCard: React.FC<{ member: TxnDeadlockMember }> = ({ member }) => (
+  <SummaryCard className={cx("summary-card")}>
+    <Heading type="h5">
+      {member.aborted
+        ? DeadlockDetailsPanelLabels.ABORTED_TRANSACTION_HEADER
+        : DeadlockDetailsPanelLabels.TRANSACTION_HEADER}
+    </Heading>
+    <SummaryCardItem
+      label={DeadlockDetailsPanelLabels.TRANSACTION_EXEC_ID}
+      value={member.txnID}
+    />
+    <SummaryCardItem
+      label={DeadlockDetailsPanelLabels.TRANSACTION_FINGERPRINT}
+      value={fingerprintLink(member.txnFingerprintID)}
+    />
+    <SummaryCardItem
+      label={DeadlockDetailsPanelLabels.WAITING_ON}
+      value={member.waitingOnTxnID || DeadlockDetailsPanelLabels.UNAVAILABLE}
+    />
+    <SummaryCardItem
+      label={DeadlockDetailsPanelLabels.LOCK_KEY}
+      value={member.lockKey || DeadlockDetailsPanelLabels.UNAVAILABLE}
+    />
+    <SummaryCardItem
+      label={DeadlockDetailsPanelLabels.WAIT_TIME}
+      value={
+        member.waitingOnTxnID
+          ? Duration(member.waitTimeMs * 1e6)
+          : DeadlockDetailsPanelLabels.UNAVAILABLE
+      }
+    />
+  </SummaryCard>
+);
+
+export const DeadlockDetailsPanel: React.FC<Props> = ({ deadlocks }) => {
+  return (
+    <section
+      className={cx("section", "section--container", "margin-bottom-large")}
+    >
+      <Row gutter={24}>
+        <Col>
+          <Heading type="h5">
+            {DeadlockDetailsPanelLabels.SECTION_HEADER}
+          </Heading>
+          {deadlocks.map(deadlock => (
+            <Row gutter={24} key={deadlock.deadlockID}>
+              <Col className="gutter-row" span={12}>
+                <SummaryCard className={cx("summary-card")}>
+                  <Heading type="h5">
+                    {DeadlockDetailsPanelLabels.DEADLOCK_HEADER}
+                  </Heading>
+                  <SummaryCardItem
+                    label={DeadlockDetailsPanelLabels.DETECTED_AT}
+                    value={
+                      <Timestamp
+                        time={deadlock.detectedAt}
+                        format={
+                          DATE_WITH_SECONDS_AND_MILLISECONDS_FORMAT_24_TZ
+                        }
+                      />
+                    }
+                  />
+                  <SummaryCardItem
+                    label={DeadlockDetailsPanelLabels.TRANSACTION_COUNT}
+                    value={deadlock.members.length}
+                  />
+                </SummaryCard>
+              </Col>
+              {deadlock.members.map(member => (
+                <Col className="gutter-row" span={12} key={member.txnID}>
+                  <MemberCard member={member} />
+                </Col>
+              ))}
+            </Row>
+          ))}
+        </Col>
+      </Row>
+    </section>
+  );
+};
@@ -153,6 +153,7 @@ export const TransactionInsightDetails: React.FC<
               statements={insightDetails.statements}
               txnDetails={insightDetails.txnDetails}
               contentionDetails={insightDetails.blockingContentionDetails}
+              deadlocks={insightDetails.deadlocks}
               setTimeScale={setTimeScale}
               hasAdminRole={hasAdminRole}
               maxApiSizeReached={maxSizeApiReached}
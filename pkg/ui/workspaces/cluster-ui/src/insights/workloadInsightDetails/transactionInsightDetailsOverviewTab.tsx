@@ -37,11 +37,13 @@ import {
   InsightExecEnum,
   InsightNameEnum,
   StmtInsightEvent,
+  TxnDeadlock,
   TxnInsightEvent,
 } from "../types";
 import { getTxnInsightRecommendations } from "../utils";
 import { TransactionDetailsLink } from "../workloadInsights/util";
 
+import { DeadlockDetailsPanel } from "./deadlockDetailsPanel";
 import { FailedInsightDetailsPanel } from "./failedInsightDetailsPanel";
 import { WaitTimeDetailsTable } from "./insightDetailsTables";
 
@@ -52,6 +54,7 @@ type Props = {
   txnDetails: TxnInsightEvent | null;
   statements: StmtInsightEvent[] | null;
   contentionDetails?: ContentionDetails[];
+  deadlocks?: TxnDeadlock[];
   setTimeScale: (ts: TimeScale) => void;
   hasAdminRole: boolean;
   errors: TxnInsightDetailsReqErrs | null;
@@ -62,6 +65,7 @@ type Props = {
 export const TransactionInsightDetailsOverviewTab: React.FC<Props> = ({
   errors,
   contentionDetails,
+  deadlocks,
   txnDetails,
   statements,
   setTimeScale,
@@ -238,6 +242,8 @@ the maximum number of statements was reached in the console.`;
       {serializationConflict && (
         <FailedInsightDetailsPanel conflictDetails={serializationConflict} />
       )}
+      {errors?.deadlocksErr && InsightsError(errors.deadlocksErr.message)}
+      {deadlocks?.length > 0 && <DeadlockDetailsPanel deadlocks={deadlocks} />}
       {hasContentionInsights && (
         <Loading
           loading={!maxRequestsReached && contentionDetails == null}
@@ -7,6 +7,7 @@ import { Col, Row } from "antd";
 import classNames from "classnames/bind";
 import React from "react";
 
+import { useJobProgressEstimate } from "src/api/jobProfilerApi";
 import { JobResponse } from "src/api/jobsApi";
 import { EmptyTable } from "src/empty";
 import jobStyles from "src/jobs/jobs.module.scss";
@@ -15,13 +16,15 @@ import { SummaryCard, SummaryCardItem } from "src/summaryCard";
 import summaryCardStyles from "src/summaryCard/summaryCard.module.scss";
 import { Text, TextTypes } from "src/text";
 import {
+  Bytes,
   DATE_WITH_SECONDS_FORMAT,
   DATE_WITH_SECONDS_FORMAT_24_TZ,
+  Duration,
   TimestampToMoment,
 } from "src/util";
 
 import { Timestamp } from "../../timestamp";
-import { HighwaterTimestamp, JobStatusCell } from "../util";
+import { HighwaterTimestamp, JobStatusCell, isRunning } from "../util";
 
 type JobMessage = JobResponse["messages"][number];
 const cardCx = classNames.bind(summaryCardStyles);
@@ -101,6 +104,7 @@ export default function OverviewTabContent({
             }
           />
         </SummaryCard>
+        {estimatesProgress(job) && <ProgressEstimateCard job={job} />}
       </Col>
       <Col className="gutter-row" span={16}>
         <Text textType={TextTypes.Heading5} className={jobCx("details-header")}>
@@ -119,6 +123,70 @@ export default function OverviewTabContent({
   );
 }
 
+// estimatesProgress returns whether the job persists an estimate of its
+// remaining work that can be displayed.
+function estimatesProgress(job: JobResponse): boolean {
+  return (
+    (job.type === "BACKUP" || job.type === "RESTORE") && isRunning(job.status)
+  );
+}
+
+const bottleneckLabels: Record<string, string> = {
+  download: "Download",
+  ingestion: "Ingestion",
+  admission_control: "Admission Control",
+  export: "Export",
+};
+
+function ProgressEstimateCard({
+  job,
+}: {
+  job: JobResponse;
+}): React.ReactElement {
+  const { data: estimate } = useJobProgressEstimate(job.id, {
+    refreshInterval: 10 * 1000,
+    keepPreviousData: true,
+  });
+  if (!estimate) {
+    return null;
+  }
+
+  return (
+    <>
+      <Text textType={TextTypes.Heading5} className={jobCx("details-header")}>
+        Progress Estimate
+      </Text>
+      <SummaryCard className={cardCx("summary-card")}>
+        <SummaryCardItem
+          label="Remaining"
+          value={`${Bytes(estimate.remaining_bytes)} of ${Bytes(
+            estimate.total_bytes,
+          )}`}
+        />
+        <SummaryCardItem
+          label="Estimated Time Remaining"
+          value={estimate.eta_nanos > 0 ? Duration(estimate.eta_nanos) : "-"}
+        />
+        <SummaryCardItem
+          label="Throughput"
+          value={`${Bytes(estimate.bytes_per_second)}/s`}
+        />
+        <SummaryCardItem
+          label="Bottleneck"
+          value={bottleneckLabels[estimate.bottleneck] ?? "-"}
+        />
+        {estimate.per_node_throughput?.map(n => (
+          <SummaryCardItem
+            key={n.sql_instance_id}
+            label={`n${n.sql_instance_id} Throughput`}
+            value={`${Bytes(n.bytes_per_second)}/s`}
+          />
+        ))}
+      </SummaryCard>
+    </>
+  );
+}
+
 const messageColumns = [
   {
     name: "timestamp",
@@ -55,6 +55,7 @@ const transactionInsightDetailsSlice = createSlice({
         errors: {
           txnDetailsErr: action.payload.err,
           contentionErr: action.payload.err,
+          deadlocksErr: action.payload.err,
           statementsErr: action.payload.err,
         },
         lastUpdated: null,
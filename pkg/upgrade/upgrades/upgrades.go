@@ -101,6 +101,22 @@ var upgrades = []upgradebase.Upgrade{
 		upgrade.RestoreActionNotRequired("cluster restore does not restore this job"),
 	),
 
+	upgrade.NewTenantUpgrade(
+		"create backup_catalog table",
+		clusterversion.V25_2_BackupCatalogTable.Version(),
+		upgrade.NoPrecondition,
+		createBackupCatalogTable,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
+	),
+
+	upgrade.NewTenantUpgrade(
+		"create transaction_deadlocks table",
+		clusterversion.V25_2_TransactionDeadlocksTable.Version(),
+		upgrade.NoPrecondition,
+		createTransactionDeadlocksTable,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
+	),
+
 	// Note: when starting a new release version, the first upgrade (for
 	// Vxy_zStart) must be a newFirstUpgrade. Keep this comment at the bottom.
 }
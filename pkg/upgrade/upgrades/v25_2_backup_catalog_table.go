@@ -0,0 +1,22 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package upgrades
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/upgrade"
+)
+
+// createBackupCatalogTable creates the backup_catalog system table.
+func createBackupCatalogTable(
+	ctx context.Context, cv clusterversion.ClusterVersion, d upgrade.TenantDeps,
+) error {
+	return createSystemTable(ctx, d.DB, d.Settings, d.Codec, systemschema.BackupCatalogTable, tree.LocalityLevelTable)
+}